@@ -2,12 +2,14 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	"github.com/sirupsen/logrus"
 	"github.com/survivorbat/qq.maarten.dev/server"
 	_ "github.com/survivorbat/qq.maarten.dev/server/routes" // Import for swaggo
+	"github.com/survivorbat/qq.maarten.dev/server/services"
 	"github.com/toorop/gin-logrus"
 	"github.com/uptrace/opentelemetry-go-extra/otellogrus"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
@@ -19,6 +21,8 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
 	"log"
 	"os"
+	"strconv"
+	"time"
 )
 
 const ServiceName = "Quizness-Server"
@@ -43,11 +47,16 @@ func main() {
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:  []string{os.Getenv("CORS_ALLOW_ORIGIN")},
 		AllowMethods:  []string{"GET", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:  []string{"Authorization"},
-		ExposeHeaders: []string{"Content-Length", "Token"},
+		AllowHeaders:  []string{"Authorization", "If-Match"},
+		ExposeHeaders: []string{"Content-Length", "Token", "ETag", "Retry-After", "Warning", "Content-Disposition"},
 	}))
 
-	instance, err := server.NewServer(os.Getenv("DB_CONNECTION_STRING"), os.Getenv("JWT_SECRET"), os.Getenv("AUTH_CLIENT_ID"), os.Getenv("AUTH_CLIENT_SECRET"), os.Getenv("AUTH_REDIRECT_URL"))
+	options, err := serverOptions()
+	if err != nil {
+		log.Fatalln(err.Error())
+	}
+
+	instance, err := server.NewServer(os.Getenv("DB_CONNECTION_STRING"), os.Getenv("JWT_SECRET"), os.Getenv("AUTH_CLIENT_ID"), os.Getenv("AUTH_CLIENT_SECRET"), os.Getenv("AUTH_REDIRECT_URL"), options...)
 	if err != nil {
 		log.Fatalln(err.Error())
 	}
@@ -59,6 +68,96 @@ func main() {
 	log.Fatalln(router.Run("0.0.0.0:8000"))
 }
 
+// serverOptions reads the optional settings of the server from the environment, settings that are not
+// set keep their defaults
+func serverOptions() ([]server.Option, error) {
+	var result []server.Option
+
+	rateLimits := server.DefaultRateLimitConfig()
+	for name, target := range map[string]any{
+		"RATE_LIMIT_IP_RATE":       &rateLimits.IPRate,
+		"RATE_LIMIT_IP_BURST":      &rateLimits.IPBurst,
+		"RATE_LIMIT_GAME_RATE":     &rateLimits.GameRate,
+		"RATE_LIMIT_GAME_BURST":    &rateLimits.GameBurst,
+		"RATE_LIMIT_CODE_ATTEMPTS": &rateLimits.CodeAttempts,
+		"RATE_LIMIT_CODE_LOCKOUT":  &rateLimits.CodeLockout,
+	} {
+		if err := parseEnv(name, target); err != nil {
+			return nil, err
+		}
+	}
+
+	result = append(result, server.WithRateLimits(rateLimits))
+
+	if os.Getenv("JOIN_CODE_LENGTH") != "" || os.Getenv("JOIN_CODE_NUMERIC") != "" {
+		length, numeric := 6, false
+		if err := parseEnv("JOIN_CODE_LENGTH", &length); err != nil {
+			return nil, err
+		}
+
+		if err := parseEnv("JOIN_CODE_NUMERIC", &numeric); err != nil {
+			return nil, err
+		}
+
+		result = append(result, server.WithJoinCodes(length, numeric))
+	}
+
+	if path := os.Getenv("NICKNAME_BLOCKLIST_FILE"); path != "" {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, server.WithNicknameBlocklist(services.ParseWordList(string(contents))))
+	}
+
+	if path := os.Getenv("NICKNAME_ALLOWLIST_FILE"); path != "" {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, server.WithNicknameAllowlist(services.ParseWordList(string(contents))))
+	}
+
+	if directory := os.Getenv("NICKNAME_WORDLISTS_DIR"); directory != "" {
+		lists, err := services.LoadWordLists(os.DirFS(directory))
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, server.WithNicknameWordLists(lists, os.Getenv("NICKNAME_DEFAULT_LOCALE")))
+	}
+
+	return result, nil
+}
+
+// parseEnv parses the environment variable into the target if it's set, the target keeps its value otherwise
+func parseEnv(name string, target any) error {
+	value := os.Getenv(name)
+	if value == "" {
+		return nil
+	}
+
+	var err error
+	switch target := target.(type) {
+	case *int:
+		*target, err = strconv.Atoi(value)
+	case *float64:
+		*target, err = strconv.ParseFloat(value, 64)
+	case *bool:
+		*target, err = strconv.ParseBool(value)
+	case *time.Duration:
+		*target, err = time.ParseDuration(value)
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+
+	return nil
+}
+
 func configureTracing(url string) (func(), error) {
 	exp, err := jaeger.New(jaeger.WithCollectorEndpoint(jaeger.WithEndpoint(url)))
 	if err != nil {
@@ -17,10 +17,11 @@ type CreatorAction string
 
 const NextQuestionAction CreatorAction = "next"
 const FinishGameAction CreatorAction = "finish"
+const RenamePlayerAction CreatorAction = "rename"
 
 func (c CreatorAction) IsValid() bool {
 	switch c {
-	case FinishGameAction, NextQuestionAction:
+	case FinishGameAction, NextQuestionAction, RenamePlayerAction:
 		return true
 	default:
 		return false
@@ -2,6 +2,7 @@ package coordinator
 
 import (
 	"github.com/google/uuid"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
 	"time"
 )
 
@@ -16,6 +17,15 @@ const PlayerAnsweredType BroadcastType = "answered"
 // StateType is used to broadcast the current participants and the creator
 const StateType BroadcastType = "state"
 
+// TeamLeaderboardType is used to broadcast the team scores after every question
+const TeamLeaderboardType BroadcastType = "teamLeaderboard"
+
+// RevokedType is sent to spectators whose presenter token was revoked, right before they are disconnected
+const RevokedType BroadcastType = "revoked"
+
+// PresentationType is only sent to spectators and contains everything a big screen needs to show
+const PresentationType BroadcastType = "presentation"
+
 type BroadcastMessage struct {
 	Type BroadcastType `json:"type"`
 
@@ -24,11 +34,19 @@ type BroadcastMessage struct {
 
 	// StateType type
 	StateContent *stateContent `json:"stateContent,omitempty"`
+
+	// TeamLeaderboardType
+	TeamLeaderboardContent []*domain.TeamScore `json:"teamLeaderboardContent,omitempty"`
+
+	// PresentationType
+	PresentationContent *presentationContent `json:"presentationContent,omitempty"`
 }
 
 type stateContent struct {
 	Creator         *participant   `json:"creator"`
+	Hosts           []*participant `json:"hosts"`
 	Players         []*participant `json:"players"`
+	Teams           []*team        `json:"teams"`
 	CurrentQuestion uuid.UUID      `json:"currentQuestion"`
 	CurrentDeadline time.Time      `json:"currentDeadline"`
 }
@@ -38,8 +56,43 @@ type participant struct {
 	Nickname        string    `json:"nickname"`
 	Color           string    `json:"color"`
 	BackgroundColor string    `json:"backgroundColor"`
+
+	// Avatar is either a built-in avatar or the participant's ID, both can be fetched from /api/v1/avatars/{avatar}
+	Avatar string `json:"avatar"`
+
+	// TeamID is only set for players in games with teams
+	TeamID *uuid.UUID `json:"teamID,omitempty"`
+}
+
+type team struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
 }
 
 type playerAnsweredContent struct {
 	PlayerID uuid.UUID `json:"playerID"`
 }
+
+type presentationContent struct {
+	// Question is nil until the first question is asked
+	Question        *presentedQuestion `json:"question"`
+	CurrentDeadline time.Time          `json:"currentDeadline"`
+
+	// Distribution contains the amount of answers per option of the current question
+	Distribution map[uuid.UUID]uint `json:"distribution"`
+
+	// Leaderboard and TeamLeaderboard leave out the current question until its deadline passed
+	Leaderboard     []*domain.PlayerScore `json:"leaderboard"`
+	TeamLeaderboard []*domain.TeamScore   `json:"teamLeaderboard,omitempty"`
+}
+
+type presentedQuestion struct {
+	ID          uuid.UUID                `json:"id"`
+	Title       string                   `json:"title"`
+	Description string                   `json:"description"`
+	Category    string                   `json:"category"`
+	Options     []*domain.QuestionOption `json:"options"`
+
+	// AnswerID is only revealed once the deadline passed
+	AnswerID *uuid.UUID `json:"answerID,omitempty"`
+}
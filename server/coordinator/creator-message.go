@@ -1,7 +1,17 @@
 package coordinator
 
+import (
+	"encoding/json"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+)
+
 type CreatorMessage struct {
-	Action CreatorAction `json:"action"`
+	Action  CreatorAction   `json:"action"`
+	Content json.RawMessage `json:"content,omitempty"`
+
+	// Optional
+	Rename *inputs.Rename `json:"-"`
 }
 
 func (c *CreatorMessage) IsValid() bool {
@@ -9,5 +19,26 @@ func (c *CreatorMessage) IsValid() bool {
 		return false
 	}
 
+	switch c.Action {
+	case RenamePlayerAction:
+		if err := validate.Struct(c.Rename); err != nil {
+			logrus.WithError(err).Error("Failed to validate")
+			return false
+		}
+	}
+
 	return true
 }
+
+// Parse reads the content of actions that have one, other actions are left alone
+func (c *CreatorMessage) Parse() error {
+	switch c.Action {
+	case RenamePlayerAction:
+		if err := json.Unmarshal(c.Content, &c.Rename); err != nil {
+			logrus.WithError(err).Error("Failed to parse")
+			return err
+		}
+	}
+
+	return nil
+}
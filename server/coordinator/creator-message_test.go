@@ -27,3 +27,36 @@ func TestCreatorMessage_IsValid_ReturnsInvalidOnBadType(t *testing.T) {
 	// Assert
 	assert.False(t, result)
 }
+
+func TestCreatorMessage_Parse_ReadsRename(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	message := &CreatorMessage{
+		Action:  RenamePlayerAction,
+		Content: []byte(`{"playerID":"ffcdf7eb-0eee-411f-9b3f-2401315cc9e6","nickname":"Nice Name"}`),
+	}
+
+	// Act
+	err := message.Parse()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, message.IsValid())
+	assert.Equal(t, "Nice Name", message.Rename.Nickname)
+}
+
+func TestCreatorMessage_IsValid_ReturnsInvalidOnMissingPlayer(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	message := &CreatorMessage{
+		Action:  RenamePlayerAction,
+		Content: []byte(`{"nickname":"Nice Name"}`),
+	}
+	_ = message.Parse()
+
+	// Act
+	result := message.IsValid()
+
+	// Assert
+	assert.False(t, result)
+}
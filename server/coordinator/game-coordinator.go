@@ -5,7 +5,9 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/survivorbat/go-tsyncmap"
 	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
 	"github.com/survivorbat/qq.maarten.dev/server/services"
+	"time"
 )
 
 // Compile-time interface checks
@@ -17,8 +19,16 @@ type GameCoordinator interface {
 	SubscribePlayer(gameID uuid.UUID, player *domain.Player, callback BroadcastCallback)
 	SubscribeCreator(gameID uuid.UUID, creator *domain.Creator, callback BroadcastCallback)
 
+	// SubscribeSpectator registers a read-only connection, spectators receive every broadcast and presentations
+	SubscribeSpectator(gameID uuid.UUID, tokenID uuid.UUID, spectatorID uuid.UUID, callback BroadcastCallback)
+
 	UnsubscribePlayer(gameID uuid.UUID, player *domain.Player)
-	UnsubscribeCreator(gameID uuid.UUID)
+	UnsubscribeCreator(gameID uuid.UUID, creator *domain.Creator)
+	UnsubscribeSpectator(gameID uuid.UUID, spectatorID uuid.UUID)
+
+	// DisconnectSpectators sends RevokedType to the spectators that connected with the presenter token
+	// and unsubscribes them
+	DisconnectSpectators(gameID uuid.UUID, tokenID uuid.UUID)
 
 	HandlePlayerMessage(game uuid.UUID, player uuid.UUID, message *PlayerMessage)
 	HandleCreatorMessage(game uuid.UUID, message *CreatorMessage)
@@ -30,16 +40,28 @@ type creatorInfo struct {
 	creator  *domain.Creator
 }
 
+// spectatorInfo is a container for the presenter token a display connected with and its callback
+type spectatorInfo struct {
+	callback BroadcastCallback
+	tokenID  uuid.UUID
+}
+
 // LocalGameCoordinator coordinates a running game
 type LocalGameCoordinator struct {
 	// GameService is used to manipulate games
 	GameService services.GameService
 
-	// creators is a mapping of game ids with creatorInfo
-	creators tsyncmap.Map[uuid.UUID, creatorInfo]
+	// PlayerService is used to rename players
+	PlayerService services.PlayerService
+
+	// creators is a list of games with the connected owner and co-hosts, keyed by their creator id
+	creators tsyncmap.Map[uuid.UUID, *tsyncmap.Map[uuid.UUID, creatorInfo]]
 
 	// clients is a list of games with connected players and callbacks
 	clients tsyncmap.Map[uuid.UUID, *tsyncmap.Map[*domain.Player, BroadcastCallback]]
+
+	// spectators is a list of games with connected displays, keyed by a connection id
+	spectators tsyncmap.Map[uuid.UUID, *tsyncmap.Map[uuid.UUID, spectatorInfo]]
 }
 
 func (c *LocalGameCoordinator) SubscribePlayer(gameID uuid.UUID, player *domain.Player, callback BroadcastCallback) {
@@ -49,10 +71,50 @@ func (c *LocalGameCoordinator) SubscribePlayer(gameID uuid.UUID, player *domain.
 }
 
 func (c *LocalGameCoordinator) SubscribeCreator(gameID uuid.UUID, creator *domain.Creator, callback BroadcastCallback) {
-	c.creators.Store(gameID, creatorInfo{creator: creator, callback: callback})
+	value, _ := c.creators.LoadOrStore(gameID, &tsyncmap.Map[uuid.UUID, creatorInfo]{})
+	value.Store(creator.ID, creatorInfo{creator: creator, callback: callback})
 	c.broadcastState(gameID)
 }
 
+func (c *LocalGameCoordinator) SubscribeSpectator(gameID uuid.UUID, tokenID uuid.UUID, spectatorID uuid.UUID, callback BroadcastCallback) {
+	value, _ := c.spectators.LoadOrStore(gameID, &tsyncmap.Map[uuid.UUID, spectatorInfo]{})
+	value.Store(spectatorID, spectatorInfo{callback: callback, tokenID: tokenID})
+
+	game, err := c.GameService.GetByID(gameID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get game")
+		return
+	}
+
+	c.broadcastPresentation(game)
+}
+
+func (c *LocalGameCoordinator) UnsubscribeSpectator(gameID uuid.UUID, spectatorID uuid.UUID) {
+	value, ok := c.spectators.Load(gameID)
+	if ok {
+		value.Delete(spectatorID)
+	}
+}
+
+func (c *LocalGameCoordinator) DisconnectSpectators(gameID uuid.UUID, tokenID uuid.UUID) {
+	value, ok := c.spectators.Load(gameID)
+	if !ok {
+		return
+	}
+
+	value.Range(func(spectatorID uuid.UUID, spectator spectatorInfo) bool {
+		if spectator.tokenID != tokenID {
+			return true
+		}
+
+		if _, ok := value.LoadAndDelete(spectatorID); ok {
+			spectator.callback(&BroadcastMessage{Type: RevokedType})
+		}
+
+		return true
+	})
+}
+
 func (c *LocalGameCoordinator) UnsubscribePlayer(gameID uuid.UUID, player *domain.Player) {
 	value, ok := c.clients.Load(gameID)
 	if ok {
@@ -62,8 +124,12 @@ func (c *LocalGameCoordinator) UnsubscribePlayer(gameID uuid.UUID, player *domai
 	c.broadcastState(gameID)
 }
 
-func (c *LocalGameCoordinator) UnsubscribeCreator(gameID uuid.UUID) {
-	c.creators.Delete(gameID)
+func (c *LocalGameCoordinator) UnsubscribeCreator(gameID uuid.UUID, creator *domain.Creator) {
+	value, ok := c.creators.Load(gameID)
+	if ok {
+		value.Delete(creator.ID)
+	}
+
 	c.broadcastState(gameID)
 }
 
@@ -81,6 +147,10 @@ func (c *LocalGameCoordinator) HandleCreatorMessage(gameID uuid.UUID, message *C
 			return
 		}
 
+		// Send the final scores before the players disconnect
+		c.broadcastTeamLeaderboard(game)
+		c.broadcastPresentation(game)
+
 		broadcast := &BroadcastMessage{
 			Type: FinishGameType,
 		}
@@ -95,9 +165,47 @@ func (c *LocalGameCoordinator) HandleCreatorMessage(gameID uuid.UUID, message *C
 
 		// Broadcast the new state
 		c.broadcastState(game.ID)
+		c.broadcastTeamLeaderboard(game)
+		c.broadcastPresentation(game)
+		c.scheduleReveal(game)
+
+	case RenamePlayerAction:
+		c.renamePlayer(game, message.Rename)
 	}
 }
 
+// renamePlayer changes the nickname of a player and updates the connected player as well
+func (c *LocalGameCoordinator) renamePlayer(game *domain.Game, rename *inputs.Rename) {
+	var player *domain.Player
+	for _, gamePlayer := range game.Players {
+		if gamePlayer.ID == rename.PlayerID {
+			player = gamePlayer
+			break
+		}
+	}
+
+	if player == nil {
+		logrus.Errorf("Player %s is not in game %s", rename.PlayerID, game.ID)
+		return
+	}
+
+	if err := c.PlayerService.Rename(player, rename.Nickname, game.Locale); err != nil {
+		logrus.WithError(err).Error("Failed to rename player")
+		return
+	}
+
+	if clients, ok := c.clients.Load(game.ID); ok {
+		clients.Range(func(connected *domain.Player, _ BroadcastCallback) bool {
+			if connected.ID == player.ID {
+				connected.Nickname = player.Nickname
+			}
+			return true
+		})
+	}
+
+	c.broadcastState(game.ID)
+}
+
 func (c *LocalGameCoordinator) HandlePlayerMessage(gameID uuid.UUID, player uuid.UUID, message *PlayerMessage) {
 	game, err := c.GameService.GetByID(gameID)
 	if err != nil {
@@ -118,6 +226,7 @@ func (c *LocalGameCoordinator) HandlePlayerMessage(gameID uuid.UUID, player uuid
 		}
 
 		c.broadcast(gameID, broadcast)
+		c.broadcastPresentation(game)
 	}
 }
 
@@ -131,20 +240,36 @@ func (c *LocalGameCoordinator) broadcastState(gameID uuid.UUID) {
 	message := &BroadcastMessage{
 		Type: StateType,
 		StateContent: &stateContent{
+			Hosts:           []*participant{},
 			Players:         []*participant{},
+			Teams:           []*team{},
 			CurrentQuestion: game.CurrentQuestion,
 			CurrentDeadline: game.CurrentDeadline,
 		},
 	}
 
-	creator, ok := c.creators.Load(gameID)
+	for _, gameTeam := range game.Teams {
+		message.StateContent.Teams = append(message.StateContent.Teams, &team{ID: gameTeam.ID, Name: gameTeam.Name})
+	}
+
+	hosts, ok := c.creators.Load(gameID)
 	if ok {
-		message.StateContent.Creator = &participant{
-			ID:              creator.creator.ID,
-			Nickname:        creator.creator.Nickname,
-			Color:           creator.creator.Color,
-			BackgroundColor: creator.creator.BackgroundColor,
-		}
+		hosts.Range(func(_ uuid.UUID, info creatorInfo) bool {
+			host := &participant{
+				ID:              info.creator.ID,
+				Nickname:        info.creator.Nickname,
+				Color:           info.creator.Color,
+				BackgroundColor: info.creator.BackgroundColor,
+				Avatar:          info.creator.GetAvatar(),
+			}
+			message.StateContent.Hosts = append(message.StateContent.Hosts, host)
+
+			// Prefer the owner of the quiz, otherwise any of the co-hosts
+			if message.StateContent.Creator == nil || (game.Quiz != nil && game.Quiz.CreatorID == host.ID) {
+				message.StateContent.Creator = host
+			}
+			return true
+		})
 	}
 
 	result, ok := c.clients.Load(gameID)
@@ -155,6 +280,8 @@ func (c *LocalGameCoordinator) broadcastState(gameID uuid.UUID) {
 				Nickname:        player.Nickname,
 				Color:           player.Color,
 				BackgroundColor: player.BackgroundColor,
+				Avatar:          player.GetAvatar(),
+				TeamID:          player.TeamID,
 			})
 			return true
 		})
@@ -163,11 +290,115 @@ func (c *LocalGameCoordinator) broadcastState(gameID uuid.UUID) {
 	c.broadcast(gameID, message)
 }
 
-// broadcast sends a message to the creator and
+// broadcastTeamLeaderboard sends the team scores to everyone, if the game has teams
+func (c *LocalGameCoordinator) broadcastTeamLeaderboard(game *domain.Game) {
+	if !game.HasTeams() {
+		return
+	}
+
+	c.broadcast(game.ID, &BroadcastMessage{
+		Type:                   TeamLeaderboardType,
+		TeamLeaderboardContent: game.TeamLeaderboard(),
+	})
+}
+
+// scheduleReveal sends the presentation again once the deadline passed, so spectators can show the answer
+func (c *LocalGameCoordinator) scheduleReveal(game *domain.Game) {
+	if !c.hasSpectators(game.ID) {
+		return
+	}
+
+	questionID := game.CurrentQuestion
+	time.AfterFunc(time.Until(game.CurrentDeadline), func() {
+		current, err := c.GameService.GetByID(game.ID)
+		if err != nil {
+			logrus.WithError(err).Error("Failed to get game")
+			return
+		}
+
+		// The host may have moved on already
+		if current.CurrentQuestion == questionID {
+			c.broadcastPresentation(current)
+		}
+	})
+}
+
+// broadcastPresentation sends the question, answers and leaderboards to the spectators
+func (c *LocalGameCoordinator) broadcastPresentation(game *domain.Game) {
+	if !c.hasSpectators(game.ID) {
+		return
+	}
+
+	revealed := !game.CurrentDeadline.IsZero() && time.Now().After(game.CurrentDeadline)
+
+	// Leave out the answers to the current question, the scores would give away the answer
+	scored := game
+	if !revealed {
+		withoutCurrent := *game
+		withoutCurrent.Answers = nil
+		for _, answer := range game.Answers {
+			if answer.QuestionID != game.CurrentQuestion {
+				withoutCurrent.Answers = append(withoutCurrent.Answers, answer)
+			}
+		}
+		scored = &withoutCurrent
+	}
+
+	content := &presentationContent{
+		CurrentDeadline: game.CurrentDeadline,
+		Distribution:    game.AnswerDistribution(game.CurrentQuestion),
+		Leaderboard:     scored.Leaderboard(),
+	}
+
+	if game.HasTeams() {
+		content.TeamLeaderboard = scored.TeamLeaderboard()
+	}
+
+	question, _ := game.GetCurrentQuestion()
+	if multipleChoice, ok := question.(*domain.MultipleChoiceQuestion); ok {
+		content.Question = &presentedQuestion{
+			ID:          multipleChoice.ID,
+			Title:       multipleChoice.Title,
+			Description: multipleChoice.Description,
+			Category:    multipleChoice.Category,
+			Options:     game.Options(multipleChoice, uuid.Nil),
+		}
+
+		if revealed {
+			content.Question.AnswerID = &multipleChoice.AnswerID
+		}
+	}
+
+	message := &BroadcastMessage{Type: PresentationType, PresentationContent: content}
+
+	spectators, _ := c.spectators.Load(game.ID)
+	spectators.Range(func(_ uuid.UUID, spectator spectatorInfo) bool {
+		spectator.callback(message)
+		return true
+	})
+}
+
+func (c *LocalGameCoordinator) hasSpectators(gameID uuid.UUID) bool {
+	spectators, ok := c.spectators.Load(gameID)
+	if !ok {
+		return false
+	}
+
+	var result bool
+	spectators.Range(func(uuid.UUID, spectatorInfo) bool {
+		result = true
+		return false
+	})
+
+	return result
+}
+
+// broadcast sends a message to the players, creators and spectators
 func (c *LocalGameCoordinator) broadcast(game uuid.UUID, message *BroadcastMessage) {
 	var (
-		playerCount      int
-		creatorAvailable bool
+		playerCount    int
+		creatorCount   int
+		spectatorCount int
 	)
 
 	result, ok := c.clients.Load(game)
@@ -179,11 +410,23 @@ func (c *LocalGameCoordinator) broadcast(game uuid.UUID, message *BroadcastMessa
 		})
 	}
 
-	creator, ok := c.creators.Load(game)
+	hosts, ok := c.creators.Load(game)
+	if ok {
+		hosts.Range(func(_ uuid.UUID, info creatorInfo) bool {
+			info.callback(message)
+			creatorCount++
+			return true
+		})
+	}
+
+	spectators, ok := c.spectators.Load(game)
 	if ok {
-		creator.callback(message)
-		creatorAvailable = true
+		spectators.Range(func(_ uuid.UUID, spectator spectatorInfo) bool {
+			spectator.callback(message)
+			spectatorCount++
+			return true
+		})
 	}
 
-	logrus.Infof("Broadcast to %d players and creator (%t): %#v", playerCount, creatorAvailable, message)
+	logrus.Infof("Broadcast to %d players, %d creators and %d spectators: %#v", playerCount, creatorCount, spectatorCount, message)
 }
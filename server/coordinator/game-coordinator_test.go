@@ -10,8 +10,13 @@ import (
 )
 
 type callbackCollection struct {
-	creatorCalledWith []*BroadcastMessage
-	playerCalledWith  []*BroadcastMessage
+	creatorCalledWith   []*BroadcastMessage
+	playerCalledWith    []*BroadcastMessage
+	spectatorCalledWith []*BroadcastMessage
+}
+
+func (c *callbackCollection) spectator(msg *BroadcastMessage) {
+	c.spectatorCalledWith = append(c.spectatorCalledWith, msg)
 }
 
 func (c *callbackCollection) player(msg *BroadcastMessage) {
@@ -173,6 +178,44 @@ func TestLocalGameCoordinator_SubscribeCreator_AddsClient(t *testing.T) {
 	}
 }
 
+func TestLocalGameCoordinator_SubscribeCreator_AddsCoHost(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	ownerID := uuid.MustParse("f8f9cf51-31d7-4a6b-a1fc-63f5e750e16a")
+	gameID := uuid.MustParse("2389b70a-74df-439c-8d5f-cf4f3f9471bd")
+
+	game := &domain.Game{
+		BaseObject: domain.BaseObject{ID: gameID},
+		Quiz:       &domain.Quiz{CreatorID: ownerID},
+	}
+
+	coordinator := &LocalGameCoordinator{
+		GameService: &MockGameService{
+			getByIDReturns: game,
+		},
+	}
+	ownerCallbacks := new(callbackCollection)
+	coHostCallbacks := new(callbackCollection)
+
+	owner := &domain.Creator{BaseObject: domain.BaseObject{ID: ownerID}, Nickname: "Owner"}
+	coHost := &domain.Creator{BaseObject: domain.BaseObject{ID: uuid.MustParse("a6a8a5c0-1d4b-4d43-9e3e-4bd0b5d6b2f1")}, Nickname: "Co-host"}
+
+	// Act
+	coordinator.SubscribeCreator(gameID, coHost, coHostCallbacks.creator)
+	coordinator.SubscribeCreator(gameID, owner, ownerCallbacks.creator)
+
+	// Assert
+	if assert.Len(t, coHostCallbacks.creatorCalledWith, 2) {
+		state := coHostCallbacks.creatorCalledWith[1].StateContent
+		assert.Equal(t, owner.ID, state.Creator.ID)
+		assert.Len(t, state.Hosts, 2)
+	}
+
+	if assert.Len(t, ownerCallbacks.creatorCalledWith, 1) {
+		assert.Equal(t, owner.ID, ownerCallbacks.creatorCalledWith[0].StateContent.Creator.ID)
+	}
+}
+
 func TestLocalGameCoordinator_UnsubscribeCreator_RemovesClient(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -205,10 +248,11 @@ func TestLocalGameCoordinator_UnsubscribeCreator_RemovesClient(t *testing.T) {
 	coordinator.SubscribePlayer(gameID, player, callbacks.player)
 
 	// Act
-	coordinator.UnsubscribeCreator(gameID)
+	coordinator.UnsubscribeCreator(gameID, creator)
 
 	// Assert
-	_, ok := coordinator.creators.Load(gameID)
+	hosts, _ := coordinator.creators.Load(gameID)
+	_, ok := hosts.Load(creatorID)
 	assert.False(t, ok)
 
 	if assert.Len(t, callbacks.playerCalledWith, 2) {
@@ -389,6 +433,34 @@ func TestLocalGameCoordinator_HandleCreatorMessage_NextLaunchesBroadcast(t *test
 	}
 }
 
+func TestLocalGameCoordinator_HandleCreatorMessage_OnlyBroadcastsToOwnGame(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	gameID := uuid.MustParse("2389b70a-74df-439c-8d5f-cf4f3f9471bd")
+	otherGameID := uuid.MustParse("b7f0e0a6-1c5e-4b0b-9d3e-4f6f3c2a1e8d")
+	questionID := uuid.MustParse("67ec56fa-d082-4fcd-b373-885801e7a910")
+
+	game := &domain.Game{
+		BaseObject: domain.BaseObject{ID: gameID},
+	}
+
+	gameService := &MockGameService{getByIDReturns: game, nextSetsCurrentQuestion: questionID}
+	coordinator := &LocalGameCoordinator{GameService: gameService}
+	callbacks := new(callbackCollection)
+	otherCallbacks := new(callbackCollection)
+
+	// Both games are of the same quiz, running at the same time
+	coordinator.SubscribePlayer(gameID, &domain.Player{BaseObject: domain.BaseObject{ID: uuid.MustParse("ffcdf7eb-0eee-411f-9b3f-2401315cc9e6")}}, callbacks.player)
+	coordinator.SubscribePlayer(otherGameID, &domain.Player{BaseObject: domain.BaseObject{ID: uuid.MustParse("0d2b5c9e-6f3a-4a8e-8b1d-7e9c4f2a6b3c")}}, otherCallbacks.player)
+
+	// Act
+	coordinator.HandleCreatorMessage(gameID, &CreatorMessage{Action: NextQuestionAction})
+
+	// Assert
+	assert.Len(t, callbacks.playerCalledWith, 2)
+	assert.Len(t, otherCallbacks.playerCalledWith, 1)
+}
+
 func TestLocalGameCoordinator_HandleCreatorMessage_DoesNothingOnGameNotFound(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -533,3 +605,231 @@ func TestLocalGameCoordinator_HandleCreatorMessage_DoesNothingOnFinishFailure(t
 	assert.Len(t, callbacks.playerCalledWith, 1)
 	assert.Len(t, callbacks.creatorCalledWith, 2)
 }
+
+func TestLocalGameCoordinator_HandleCreatorMessage_RenameUpdatesConnectedPlayer(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	gameID := uuid.MustParse("2389b70a-74df-439c-8d5f-cf4f3f9471bd")
+	playerID := uuid.MustParse("ffcdf7eb-0eee-411f-9b3f-2401315cc9e6")
+
+	connected := &domain.Player{BaseObject: domain.BaseObject{ID: playerID}, Nickname: "Rude Name"}
+
+	game := &domain.Game{
+		BaseObject: domain.BaseObject{ID: gameID},
+		Players:    []*domain.Player{{BaseObject: domain.BaseObject{ID: playerID}, Nickname: "Rude Name", GameID: gameID}},
+	}
+
+	playerService := &MockPlayerService{}
+	coordinator := &LocalGameCoordinator{GameService: &MockGameService{getByIDReturns: game}, PlayerService: playerService}
+	callbacks := new(callbackCollection)
+
+	coordinator.SubscribePlayer(gameID, connected, callbacks.player)
+
+	message := &CreatorMessage{
+		Action: RenamePlayerAction,
+		Rename: &inputs.Rename{PlayerID: playerID, Nickname: "Nice Name"},
+	}
+
+	// Act
+	coordinator.HandleCreatorMessage(gameID, message)
+
+	// Assert
+	assert.Equal(t, game.Players[0], playerService.renameCalledWithPlayer)
+	assert.Equal(t, "Nice Name", connected.Nickname)
+
+	if assert.Len(t, callbacks.playerCalledWith, 2) {
+		assert.Equal(t, "Nice Name", callbacks.playerCalledWith[1].StateContent.Players[0].Nickname)
+	}
+}
+
+func TestLocalGameCoordinator_HandleCreatorMessage_RenameIgnoresUnknownPlayer(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	gameID := uuid.MustParse("2389b70a-74df-439c-8d5f-cf4f3f9471bd")
+	game := &domain.Game{BaseObject: domain.BaseObject{ID: gameID}}
+
+	playerService := &MockPlayerService{}
+	coordinator := &LocalGameCoordinator{GameService: &MockGameService{getByIDReturns: game}, PlayerService: playerService}
+
+	message := &CreatorMessage{
+		Action: RenamePlayerAction,
+		Rename: &inputs.Rename{PlayerID: uuid.MustParse("ffcdf7eb-0eee-411f-9b3f-2401315cc9e6")},
+	}
+
+	// Act
+	coordinator.HandleCreatorMessage(gameID, message)
+
+	// Assert
+	assert.Nil(t, playerService.renameCalledWithPlayer)
+}
+
+func TestLocalGameCoordinator_HandleCreatorMessage_NextBroadcastsTeamLeaderboard(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	gameID := uuid.MustParse("2389b70a-74df-439c-8d5f-cf4f3f9471bd")
+	questionID := uuid.MustParse("67ec56fa-d082-4fcd-b373-885801e7a910")
+	teamID := uuid.MustParse("3e0ec4b9-4d8b-44d4-9fa2-d4e7d1fbfc0b")
+
+	player := &domain.Player{
+		BaseObject: domain.BaseObject{ID: uuid.MustParse("ffcdf7eb-0eee-411f-9b3f-2401315cc9e6")},
+		TeamID:     &teamID,
+	}
+
+	game := &domain.Game{
+		BaseObject:  domain.BaseObject{ID: gameID},
+		Quiz:        &domain.Quiz{},
+		Players:     []*domain.Player{player},
+		Teams:       []*domain.Team{{BaseObject: domain.BaseObject{ID: teamID}, Name: "Marketing"}},
+		TeamScoring: domain.TeamScoringSum,
+	}
+
+	gameService := &MockGameService{getByIDReturns: game, nextSetsCurrentQuestion: questionID}
+	coordinator := &LocalGameCoordinator{GameService: gameService}
+	callbacks := new(callbackCollection)
+
+	coordinator.SubscribePlayer(gameID, player, callbacks.player)
+
+	message := &CreatorMessage{
+		Action: NextQuestionAction,
+	}
+
+	// Act
+	coordinator.HandleCreatorMessage(gameID, message)
+
+	// Assert
+	if assert.Len(t, callbacks.playerCalledWith, 3) {
+		assert.Equal(t, "Marketing", callbacks.playerCalledWith[1].StateContent.Teams[0].Name)
+		assert.Equal(t, &teamID, callbacks.playerCalledWith[1].StateContent.Players[0].TeamID)
+
+		assert.Equal(t, TeamLeaderboardType, callbacks.playerCalledWith[2].Type)
+		assert.Equal(t, uint(1), callbacks.playerCalledWith[2].TeamLeaderboardContent[0].Players)
+	}
+}
+
+// presentationGame returns a game with a current question that one of the two players answered correctly
+func presentationGame(deadline time.Time) *domain.Game {
+	questionID := uuid.MustParse("67ec56fa-d082-4fcd-b373-885801e7a910")
+	correct := uuid.MustParse("0f04a1a3-2a2f-4c84-9a7c-4d2d39b6f0e5")
+	playerID := uuid.MustParse("ffcdf7eb-0eee-411f-9b3f-2401315cc9e6")
+
+	return &domain.Game{
+		BaseObject: domain.BaseObject{ID: uuid.MustParse("2389b70a-74df-439c-8d5f-cf4f3f9471bd")},
+		Quiz: &domain.Quiz{MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{
+			{
+				BaseQuestion: domain.BaseQuestion{BaseObject: domain.BaseObject{ID: questionID}, Title: "What is 5+5?"},
+				AnswerID:     correct,
+				Options:      []*domain.QuestionOption{{BaseObject: domain.BaseObject{ID: correct}, TextOption: "10"}},
+			},
+		}},
+		CurrentQuestion: questionID,
+		CurrentDeadline: deadline,
+		Players: []*domain.Player{
+			{BaseObject: domain.BaseObject{ID: playerID}},
+			{BaseObject: domain.BaseObject{ID: uuid.MustParse("0bd6d8c5-1f8a-4b8e-9f42-8e4d8c6f5e3a")}},
+		},
+		Answers: domain.GameAnswers{{PlayerID: playerID, QuestionID: questionID, OptionID: correct}},
+	}
+}
+
+func TestLocalGameCoordinator_SubscribeSpectator_SendsPresentation(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		deadline      time.Time
+		expectedScore uint
+		revealed      bool
+	}{
+		"question open": {
+			deadline:      time.Now().Add(time.Hour),
+			expectedScore: 0,
+			revealed:      false,
+		},
+		"deadline passed": {
+			deadline:      time.Now().Add(-time.Second),
+			expectedScore: 1,
+			revealed:      true,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			game := presentationGame(testData.deadline)
+			coordinator := &LocalGameCoordinator{GameService: &MockGameService{getByIDReturns: game}}
+			callbacks := new(callbackCollection)
+
+			// Act
+			coordinator.SubscribeSpectator(game.ID, uuid.New(), uuid.New(), callbacks.spectator)
+
+			// Assert
+			if !assert.Len(t, callbacks.spectatorCalledWith, 1) {
+				return
+			}
+
+			message := callbacks.spectatorCalledWith[0]
+			assert.Equal(t, PresentationType, message.Type)
+
+			content := message.PresentationContent
+			assert.Equal(t, "What is 5+5?", content.Question.Title)
+			assert.Equal(t, testData.revealed, content.Question.AnswerID != nil)
+			assert.Equal(t, uint(1), content.Distribution[game.Quiz.MultipleChoiceQuestions[0].AnswerID])
+			assert.Equal(t, testData.expectedScore, content.Leaderboard[0].Score)
+
+			// The game itself is not changed
+			assert.Len(t, game.Answers, 1)
+		})
+	}
+}
+
+func TestLocalGameCoordinator_HandlePlayerMessage_BroadcastsToSpectators(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	game := presentationGame(time.Now().Add(time.Hour))
+	coordinator := &LocalGameCoordinator{GameService: &MockGameService{getByIDReturns: game}}
+	callbacks := new(callbackCollection)
+
+	spectatorID := uuid.New()
+	coordinator.SubscribeSpectator(game.ID, uuid.New(), spectatorID, callbacks.spectator)
+
+	message := &PlayerMessage{Action: AnswerAction, Answer: &inputs.Answer{}}
+
+	// Act
+	coordinator.HandlePlayerMessage(game.ID, game.Players[1].ID, message)
+	coordinator.UnsubscribeSpectator(game.ID, spectatorID)
+	coordinator.HandlePlayerMessage(game.ID, game.Players[1].ID, message)
+
+	// Assert
+	if assert.Len(t, callbacks.spectatorCalledWith, 3) {
+		assert.Equal(t, PlayerAnsweredType, callbacks.spectatorCalledWith[1].Type)
+		assert.Equal(t, PresentationType, callbacks.spectatorCalledWith[2].Type)
+	}
+}
+
+func TestLocalGameCoordinator_DisconnectSpectators_OnlyDisconnectsToken(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	game := presentationGame(time.Now().Add(time.Hour))
+	coordinator := &LocalGameCoordinator{GameService: &MockGameService{getByIDReturns: game}}
+	revokedCallbacks := new(callbackCollection)
+	otherCallbacks := new(callbackCollection)
+
+	tokenID := uuid.New()
+	coordinator.SubscribeSpectator(game.ID, tokenID, uuid.New(), revokedCallbacks.spectator)
+	coordinator.SubscribeSpectator(game.ID, uuid.New(), uuid.New(), otherCallbacks.spectator)
+
+	message := &PlayerMessage{Action: AnswerAction, Answer: &inputs.Answer{}}
+
+	// Act
+	coordinator.DisconnectSpectators(game.ID, tokenID)
+	coordinator.HandlePlayerMessage(game.ID, game.Players[1].ID, message)
+
+	// Assert
+	if assert.Len(t, revokedCallbacks.spectatorCalledWith, 3) {
+		assert.Equal(t, RevokedType, revokedCallbacks.spectatorCalledWith[2].Type)
+	}
+
+	if assert.Len(t, otherCallbacks.spectatorCalledWith, 3) {
+		assert.Equal(t, PlayerAnsweredType, otherCallbacks.spectatorCalledWith[1].Type)
+	}
+}
@@ -0,0 +1,239 @@
+package coordinator
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/go-tsyncmap"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/services"
+	"sync"
+	"time"
+)
+
+// Compile-time interface checks
+var _ GameScheduler = new(TimerGameScheduler)
+
+const (
+	// maxStartAttempts is how often the scheduler tries to start a game, after that the host has to start it
+	maxStartAttempts = 5
+
+	// defaultRetryDelay is used if the scheduler has no RetryDelay
+	defaultRetryDelay = 30 * time.Second
+)
+
+type GameScheduler interface {
+	// Schedule starts or finishes the game at its scheduled time, replacing an earlier schedule of the game
+	Schedule(game *domain.Game)
+
+	// Cancel stops the game from starting or finishing by itself
+	Cancel(gameID uuid.UUID)
+
+	// Restore schedules all pending games, so schedules survive a restart
+	Restore() error
+}
+
+// TimerGameScheduler keeps a timer per scheduled game in memory
+type TimerGameScheduler struct {
+	// GameService is used to fetch and start games
+	GameService services.GameService
+
+	// Coordinator finishes games, so connected players and hosts are told about it
+	Coordinator GameCoordinator
+
+	// RetryDelay is how long the scheduler waits before starting a game again after it failed to start,
+	// like when the quiz has too many games in progress. It doubles with every attempt.
+	RetryDelay time.Duration
+
+	// timers contains the pending timer of every scheduled game
+	timers tsyncmap.Map[uuid.UUID, *time.Timer]
+
+	// lock makes sure a game has one pending timer at most
+	lock sync.Mutex
+}
+
+func (t *TimerGameScheduler) Schedule(game *domain.Game) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.cancel(game.ID)
+	t.scheduleGame(game)
+}
+
+func (t *TimerGameScheduler) Cancel(gameID uuid.UUID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.cancel(gameID)
+}
+
+func (t *TimerGameScheduler) Restore() error {
+	games, err := t.GameService.GetScheduled()
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get scheduled games")
+		return err
+	}
+
+	for _, game := range games {
+		t.Schedule(game)
+	}
+
+	logrus.Infof("Restored %d scheduled games", len(games))
+	return nil
+}
+
+// cancel stops and forgets the pending timer of the game, the lock must be held
+func (t *TimerGameScheduler) cancel(gameID uuid.UUID) {
+	if timer, ok := t.timers.LoadAndDelete(gameID); ok {
+		timer.Stop()
+	}
+}
+
+// scheduleGame schedules the start or the finish of the game, the lock must be held
+func (t *TimerGameScheduler) scheduleGame(game *domain.Game) {
+	if startsAt, ok := game.StartsAt(); ok {
+		t.schedule(game.ID, startsAt, t.start)
+		return
+	}
+
+	if finishesAt, ok := game.FinishesAt(); ok && game.IsInProgress() {
+		t.schedule(game.ID, finishesAt, t.finish)
+	}
+}
+
+// schedule runs the action at the given time, right away if it has already passed. The lock must be held,
+// which the timer waits for so the action always gets the stored timer.
+func (t *TimerGameScheduler) schedule(gameID uuid.UUID, at time.Time, action func(gameID uuid.UUID, timer *time.Timer)) {
+	var timer *time.Timer
+	timer = time.AfterFunc(time.Until(at), func() {
+		t.lock.Lock()
+		fired := timer
+		t.lock.Unlock()
+
+		action(gameID, fired)
+	})
+
+	t.timers.Store(gameID, timer)
+}
+
+// release forgets the timer that fired. It returns false if the game was scheduled again or cancelled
+// since, in which case the timer should leave the game alone.
+func (t *TimerGameScheduler) release(gameID uuid.UUID, timer *time.Timer) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.timers.Map.CompareAndDelete(gameID, timer)
+}
+
+// reschedule schedules the game again after its timer fired, unless it was scheduled in the meantime
+func (t *TimerGameScheduler) reschedule(game *domain.Game) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, ok := t.timers.Load(game.ID); !ok {
+		t.scheduleGame(game)
+	}
+}
+
+// start starts the game if nothing changed since it was scheduled, and schedules the finish
+func (t *TimerGameScheduler) start(gameID uuid.UUID, timer *time.Timer) {
+	t.startAttempt(gameID, timer, 1)
+}
+
+// startAttempt is the start of a game that has been tried attempt-1 times before
+func (t *TimerGameScheduler) startAttempt(gameID uuid.UUID, timer *time.Timer, attempt int) {
+	if !t.release(gameID, timer) {
+		return
+	}
+
+	game, err := t.GameService.GetByID(gameID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get game")
+		t.retryStart(gameID, attempt)
+		return
+	}
+
+	// The host may have started it already or moved it to another time
+	startsAt, ok := game.StartsAt()
+	if !ok || time.Now().Before(startsAt) {
+		t.reschedule(game)
+		return
+	}
+
+	err = t.GameService.Start(game)
+	if errors.Is(err, services.ErrTooManyGamesInProgress) {
+		logrus.Warnf("Scheduled game %s was not started, quiz %s has too many games in progress", game.ID, game.QuizID)
+		t.retryStart(gameID, attempt)
+		return
+	}
+
+	if err != nil {
+		logrus.WithError(err).Error("Failed to start scheduled game")
+		t.retryStart(gameID, attempt)
+		return
+	}
+
+	t.reschedule(game)
+}
+
+// retryStart tries to start the game again after a delay that doubles with every attempt, unless the
+// game was scheduled again in the meantime
+func (t *TimerGameScheduler) retryStart(gameID uuid.UUID, attempt int) {
+	if attempt >= maxStartAttempts {
+		logrus.Errorf("Gave up starting scheduled game %s after %d attempts, the host has to start it", gameID, attempt)
+		return
+	}
+
+	delay := t.RetryDelay
+	if delay == 0 {
+		delay = defaultRetryDelay
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, ok := t.timers.Load(gameID); ok {
+		return
+	}
+
+	t.schedule(gameID, time.Now().Add(delay<<(attempt-1)), func(gameID uuid.UUID, timer *time.Timer) {
+		t.startAttempt(gameID, timer, attempt+1)
+	})
+}
+
+// finish finishes the game once the current question's deadline passed
+func (t *TimerGameScheduler) finish(gameID uuid.UUID, timer *time.Timer) {
+	if !t.release(gameID, timer) {
+		return
+	}
+
+	game, err := t.GameService.GetByID(gameID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get game")
+		return
+	}
+
+	finishesAt, ok := game.FinishesAt()
+	if !ok || !game.IsInProgress() {
+		return
+	}
+
+	if time.Now().Before(finishesAt) {
+		t.reschedule(game)
+		return
+	}
+
+	// Players get to answer the question they're at
+	if time.Now().Before(game.CurrentDeadline) {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+
+		if _, ok := t.timers.Load(gameID); !ok {
+			t.schedule(gameID, game.CurrentDeadline, t.finish)
+		}
+
+		return
+	}
+
+	t.Coordinator.HandleCreatorMessage(gameID, &CreatorMessage{Action: FinishGameAction})
+}
@@ -0,0 +1,220 @@
+package coordinator
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"testing"
+	"time"
+)
+
+// waitForCall returns the game sent on the channel, or nil if nothing was sent in time
+func waitForCall(calls chan *domain.Game, timeout time.Duration) *domain.Game {
+	select {
+	case game := <-calls:
+		return game
+	case <-time.After(timeout):
+		return nil
+	}
+}
+
+func TestTimerGameScheduler_Schedule_StartsGameAtScheduledStart(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	game := &domain.Game{
+		BaseObject:     domain.BaseObject{ID: uuid.MustParse("f7422157-bc0c-4998-834a-0aeb7a800dc7")},
+		ScheduledStart: time.Now().Add(50 * time.Millisecond),
+		Quiz:           &domain.Quiz{},
+	}
+
+	gameService := &MockGameService{getByIDReturns: game, startCalls: make(chan *domain.Game, 1)}
+	scheduler := &TimerGameScheduler{GameService: gameService}
+
+	// Act
+	scheduler.Schedule(game)
+
+	// Assert
+	started := waitForCall(gameService.startCalls, time.Second)
+	if assert.NotNil(t, started) {
+		assert.Equal(t, game.ID, started.ID)
+		assert.False(t, time.Now().Before(game.ScheduledStart))
+	}
+}
+
+func TestTimerGameScheduler_Schedule_DoesNotStartOnGameInProgress(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	game := &domain.Game{
+		BaseObject:     domain.BaseObject{ID: uuid.MustParse("f7422157-bc0c-4998-834a-0aeb7a800dc7")},
+		ScheduledStart: time.Now(),
+		Quiz:           &domain.Quiz{Games: []*domain.Game{{StartTime: time.Now()}}},
+	}
+
+	gameService := &MockGameService{getByIDReturns: game, startCalls: make(chan *domain.Game, 1)}
+	scheduler := &TimerGameScheduler{GameService: gameService}
+
+	// Act
+	scheduler.Schedule(game)
+
+	// Assert
+	assert.Nil(t, waitForCall(gameService.startCalls, 100*time.Millisecond))
+}
+
+func TestTimerGameScheduler_Schedule_RetriesFailedStart(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	game := &domain.Game{
+		BaseObject:     domain.BaseObject{ID: uuid.MustParse("f7422157-bc0c-4998-834a-0aeb7a800dc7")},
+		ScheduledStart: time.Now(),
+		Quiz:           &domain.Quiz{},
+	}
+
+	gameService := &MockGameService{getByIDReturns: game, startCalls: make(chan *domain.Game, 1)}
+	gameService.startFailures.Store(2)
+
+	scheduler := &TimerGameScheduler{GameService: gameService, RetryDelay: 10 * time.Millisecond}
+
+	// Act
+	scheduler.Schedule(game)
+
+	// Assert
+	assert.NotNil(t, waitForCall(gameService.startCalls, time.Second))
+	assert.Equal(t, int32(3), gameService.startAttempts.Load())
+}
+
+func TestTimerGameScheduler_Schedule_GivesUpOnStartAfterMaxAttempts(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	game := &domain.Game{
+		BaseObject:     domain.BaseObject{ID: uuid.MustParse("f7422157-bc0c-4998-834a-0aeb7a800dc7")},
+		ScheduledStart: time.Now(),
+		Quiz:           &domain.Quiz{Games: []*domain.Game{{StartTime: time.Now()}}},
+	}
+
+	gameService := &MockGameService{getByIDReturns: game, startCalls: make(chan *domain.Game, 1)}
+	scheduler := &TimerGameScheduler{GameService: gameService, RetryDelay: time.Millisecond}
+
+	// Act
+	scheduler.Schedule(game)
+
+	// Assert
+	assert.Nil(t, waitForCall(gameService.startCalls, 200*time.Millisecond))
+	assert.Equal(t, int32(maxStartAttempts), gameService.startAttempts.Load())
+}
+
+func TestTimerGameScheduler_Cancel_PreventsStart(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	game := &domain.Game{
+		BaseObject:     domain.BaseObject{ID: uuid.MustParse("f7422157-bc0c-4998-834a-0aeb7a800dc7")},
+		ScheduledStart: time.Now().Add(50 * time.Millisecond),
+		Quiz:           &domain.Quiz{},
+	}
+
+	gameService := &MockGameService{getByIDReturns: game, startCalls: make(chan *domain.Game, 1)}
+	scheduler := &TimerGameScheduler{GameService: gameService}
+	scheduler.Schedule(game)
+
+	// Act
+	scheduler.Cancel(game.ID)
+
+	// Assert
+	assert.Nil(t, waitForCall(gameService.startCalls, 200*time.Millisecond))
+}
+
+func TestTimerGameScheduler_Restore_FinishesGamesThroughCoordinator(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	game := &domain.Game{
+		BaseObject:      domain.BaseObject{ID: uuid.MustParse("f7422157-bc0c-4998-834a-0aeb7a800dc7")},
+		StartTime:       time.Now().Add(-time.Hour),
+		ScheduledFinish: time.Now().Add(-time.Minute),
+		Quiz:            &domain.Quiz{},
+	}
+
+	gameService := &MockGameService{
+		getByIDReturns:      game,
+		getScheduledReturns: []*domain.Game{game},
+		finishCalls:         make(chan *domain.Game, 1),
+	}
+
+	scheduler := &TimerGameScheduler{GameService: gameService, Coordinator: &LocalGameCoordinator{GameService: gameService}}
+
+	// Act
+	err := scheduler.Restore()
+
+	// Assert
+	assert.NoError(t, err)
+
+	finished := waitForCall(gameService.finishCalls, time.Second)
+	if assert.NotNil(t, finished) {
+		assert.Equal(t, game.ID, finished.ID)
+	}
+}
+
+func TestTimerGameScheduler_Schedule_WaitsForCurrentDeadline(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	game := &domain.Game{
+		BaseObject:      domain.BaseObject{ID: uuid.MustParse("f7422157-bc0c-4998-834a-0aeb7a800dc7")},
+		StartTime:       time.Now().Add(-time.Hour),
+		ScheduledFinish: time.Now().Add(-time.Minute),
+		CurrentDeadline: time.Now().Add(100 * time.Millisecond),
+		Quiz:            &domain.Quiz{},
+	}
+
+	gameService := &MockGameService{getByIDReturns: game, finishCalls: make(chan *domain.Game, 1)}
+	scheduler := &TimerGameScheduler{GameService: gameService, Coordinator: &LocalGameCoordinator{GameService: gameService}}
+
+	// Act
+	scheduler.Schedule(game)
+
+	// Assert
+	finished := waitForCall(gameService.finishCalls, time.Second)
+	if assert.NotNil(t, finished) {
+		assert.False(t, time.Now().Before(game.CurrentDeadline))
+	}
+}
+
+func TestTimerGameScheduler_Actions_IgnoreReplacedTimers(t *testing.T) {
+	t.Parallel()
+	tests := map[string]func(scheduler *TimerGameScheduler) func(uuid.UUID, *time.Timer){
+		"start": func(scheduler *TimerGameScheduler) func(uuid.UUID, *time.Timer) {
+			return scheduler.start
+		},
+		"finish": func(scheduler *TimerGameScheduler) func(uuid.UUID, *time.Timer) {
+			return scheduler.finish
+		},
+	}
+
+	for name, action := range tests {
+		action := action
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			game := &domain.Game{
+				BaseObject:     domain.BaseObject{ID: uuid.MustParse("f7422157-bc0c-4998-834a-0aeb7a800dc7")},
+				ScheduledStart: time.Now().Add(time.Hour),
+				Quiz:           &domain.Quiz{},
+			}
+
+			gameService := &MockGameService{getByIDReturns: game, startCalls: make(chan *domain.Game, 1)}
+			scheduler := &TimerGameScheduler{GameService: gameService}
+			scheduler.Schedule(game)
+			defer scheduler.Cancel(game.ID)
+
+			// The timer of an earlier schedule that fired right before the game was scheduled again
+			replaced := time.NewTimer(time.Hour)
+			defer replaced.Stop()
+
+			// Act
+			action(scheduler)(game.ID, replaced)
+
+			// Assert
+			current, ok := scheduler.timers.Load(game.ID)
+			assert.True(t, ok)
+			assert.NotSame(t, replaced, current)
+			assert.Nil(t, waitForCall(gameService.startCalls, 50*time.Millisecond))
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package coordinator
 
 import (
+	"errors"
 	"github.com/google/uuid"
 	"github.com/survivorbat/qq.maarten.dev/server/domain"
 	"github.com/survivorbat/qq.maarten.dev/server/services"
+	"sync/atomic"
+	"time"
 )
 
 type MockGameService struct {
@@ -24,6 +27,36 @@ type MockGameService struct {
 
 	finishCalledWith *domain.Game
 	finishReturns    error
+
+	// startCalls and finishCalls receive the games of calls made by timers, if set
+	startCalls  chan *domain.Game
+	finishCalls chan *domain.Game
+
+	// startFailures is the amount of calls to Start that fail before it works
+	startFailures atomic.Int32
+	startAttempts atomic.Int32
+
+	getScheduledReturns []*domain.Game
+}
+
+func (m *MockGameService) Start(game *domain.Game) error {
+	m.startAttempts.Add(1)
+
+	if !game.Quiz.CanStart(game) {
+		return services.ErrTooManyGamesInProgress
+	}
+
+	if m.startFailures.Add(-1) >= 0 {
+		return errors.New("database is unavailable")
+	}
+
+	game.StartTime = time.Now()
+	m.startCalls <- game
+	return nil
+}
+
+func (m *MockGameService) GetScheduled() ([]*domain.Game, error) {
+	return m.getScheduledReturns, nil
 }
 
 func (m *MockGameService) GetByID(uuid.UUID) (*domain.Game, error) {
@@ -38,6 +71,9 @@ func (m *MockGameService) Next(game *domain.Game) error {
 
 func (m *MockGameService) Finish(game *domain.Game) error {
 	m.finishCalledWith = game
+	if m.finishCalls != nil {
+		m.finishCalls <- game
+	}
 	return m.finishReturns
 }
 
@@ -48,3 +84,20 @@ func (m *MockGameService) AnswerQuestion(game *domain.Game, questionID uuid.UUID
 	m.answerQuestionCalledWithOption = optionID
 	return m.answerQuestionReturns
 }
+
+type MockPlayerService struct {
+	services.PlayerService
+
+	renameCalledWithPlayer   *domain.Player
+	renameCalledWithNickname string
+	renameReturns            error
+}
+
+func (m *MockPlayerService) Rename(player *domain.Player, nickname string, _ ...string) error {
+	m.renameCalledWithPlayer = player
+	m.renameCalledWithNickname = nickname
+	if m.renameReturns == nil {
+		player.Nickname = nickname
+	}
+	return m.renameReturns
+}
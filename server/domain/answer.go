@@ -17,12 +17,12 @@ func (g GameAnswers) Contains(questionID uuid.UUID, playerID uuid.UUID) bool {
 type GameAnswer struct {
 	BaseObject
 
-	PlayerID uuid.UUID `json:"playerID"  example:"00000000-0000-0000-0000-000000000000"`
+	PlayerID uuid.UUID `json:"playerID"  example:"00000000-0000-0000-0000-000000000000" gorm:"uniqueIndex:idx_answer,priority:3"`
 	Player   *Player   `json:"player" gorm:"foreignKey:PlayerID"`
 
-	GameID uuid.UUID `json:"gameID"  example:"00000000-0000-0000-0000-000000000000"`
+	GameID uuid.UUID `json:"gameID"  example:"00000000-0000-0000-0000-000000000000" gorm:"uniqueIndex:idx_answer,priority:1"`
 	Game   *Game     `json:"game" gorm:"foreignKey:GameID"`
 
-	QuestionID uuid.UUID `json:"questionID"  example:"00000000-0000-0000-0000-000000000000"`
+	QuestionID uuid.UUID `json:"questionID"  example:"00000000-0000-0000-0000-000000000000" gorm:"uniqueIndex:idx_answer,priority:2"`
 	OptionID   uuid.UUID `json:"optionID"  example:"00000000-0000-0000-0000-000000000000"`
 }
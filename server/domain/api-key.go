@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/google/uuid"
+	"time"
+)
+
+type APIKeyScope string
+
+const (
+	ScopeQuizzesRead  APIKeyScope = "quizzes:read"
+	ScopeQuizzesWrite APIKeyScope = "quizzes:write"
+	ScopeGamesControl APIKeyScope = "games:control"
+)
+
+// APIKeyPrefix is prepended to every key so the guard can tell it apart from a JWT
+const APIKeyPrefix = "qq_"
+
+// APIKey allows creators to authenticate scripts without going through the OAuth flow
+type APIKey struct {
+	BaseObject
+
+	Name     string        `json:"name" example:"CMS upload"`                             // desc: Can be anything
+	Hint     string        `json:"hint" example:"qq_3f9a"`                                // desc: The first few characters of the key, to recognise it
+	Scopes   []APIKeyScope `json:"scopes" gorm:"serializer:json" example:"quizzes:write"` // desc: An empty list allows everything
+	Hash     string        `json:"-" gorm:"unique"`                                       // desc: Never store or expose the key itself
+	LastUsed time.Time     `json:"lastUsed"`                                              // desc: The last time this key was used to authenticate
+
+	CreatorID uuid.UUID `json:"creatorID" example:"00000000-0000-0000-0000-000000000000"`
+	Creator   *Creator  `json:"-" gorm:"foreignKey:CreatorID"`
+}
+
+// HashAPIKey returns the value that is stored in place of the key
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// HasScope returns whether this key may be used for the given scope, keys without
+// scopes are not restricted
+func (a *APIKey) HasScope(scope APIKeyScope) bool {
+	if len(a.Scopes) == 0 {
+		return true
+	}
+
+	for _, keyScope := range a.Scopes {
+		if keyScope == scope {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestHashAPIKey_ReturnsSha256(t *testing.T) {
+	t.Parallel()
+	// Act
+	result := HashAPIKey("qq_abc")
+
+	// Assert
+	assert.Equal(t, "e019cf307265aff7b7cad59b1f2ba17f5aa2c82f32df83b8845e48b71f53ad8b", result)
+}
+
+func TestAPIKey_HasScope_ReturnsExpectedValue(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		scopes   []APIKeyScope
+		scope    APIKeyScope
+		expected bool
+	}{
+		"no scopes": {
+			scope:    ScopeGamesControl,
+			expected: true,
+		},
+		"matching scope": {
+			scopes:   []APIKeyScope{ScopeQuizzesRead, ScopeGamesControl},
+			scope:    ScopeGamesControl,
+			expected: true,
+		},
+		"other scope": {
+			scopes: []APIKeyScope{ScopeQuizzesRead},
+			scope:  ScopeQuizzesWrite,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			key := &APIKey{Scopes: testData.scopes}
+
+			// Act
+			result := key.HasScope(testData.scope)
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
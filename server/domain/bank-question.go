@@ -0,0 +1,116 @@
+package domain
+
+import "github.com/google/uuid"
+
+// BankQuestion lives in a question bank independent of quizzes, so it can be reused across them.
+// Quizzes either take a copy or link to it, linked questions follow every change to it.
+type BankQuestion struct {
+	BaseObject
+
+	CreatorID uuid.UUID `json:"creatorID" example:"00000000-0000-0000-0000-000000000000"`
+	Creator   *Creator  `json:"-" gorm:"foreignKey:CreatorID;constraint:OnDelete:CASCADE"`
+
+	OrganizationID *uuid.UUID    `json:"organizationID,omitempty" example:"00000000-0000-0000-0000-000000000000"` // desc: Bank questions in an organization are shared with its members
+	Organization   *Organization `json:"-" gorm:"foreignKey:OrganizationID;constraint:OnDelete:SET NULL"`
+
+	Title             string `json:"title" example:"What is 5+5?"`
+	Description       string `json:"description" example:"We want to test your math skills for no apparent reason"`
+	DurationInSeconds uint   `json:"durationInSeconds" example:"30"`
+	Category          string `json:"category" example:"Geography"`
+
+	AnswerID uuid.UUID     `json:"answerID" example:"00000000-0000-0000-0000-000000000000"`
+	Options  []*BankOption `json:"options" gorm:"serializer:json"`
+
+	Tags []*BankQuestionTag `json:"tags,omitempty" gorm:"foreignKey:BankQuestionID;constraint:OnDelete:CASCADE"`
+}
+
+// BankOption is an option of a bank question, quizzes get their own copies
+type BankOption struct {
+	ID         uuid.UUID `json:"id" example:"00000000-0000-0000-0000-000000000000"`
+	TextOption string    `json:"textOption" example:"Haarlem"`
+}
+
+// BankQuestionTag helps finding questions in the bank
+type BankQuestionTag struct {
+	BankQuestionID uuid.UUID `json:"-" gorm:"primaryKey"`
+	Name           string    `json:"name" gorm:"primaryKey" example:"history"`
+}
+
+// BankQuestionStats shows how often a bank question is used
+type BankQuestionStats struct {
+	Quizzes        int64 `json:"quizzes" example:"4"`         // desc: The amount of quizzes that contain the question
+	LinkedQuizzes  int64 `json:"linkedQuizzes" example:"2"`   // desc: The amount of quizzes that follow changes to the question
+	Answers        int64 `json:"answers" example:"120"`       // desc: The amount of times players answered the question
+	CorrectAnswers int64 `json:"correctAnswers" example:"87"` // desc: The amount of those answers that were correct
+}
+
+// NewBankQuestionTags turns the names into tags the same way quiz tags are made
+func NewBankQuestionTags(names []string) []*BankQuestionTag {
+	quizTags := NewQuizTags(names)
+
+	result := make([]*BankQuestionTag, len(quizTags))
+	for index, tag := range quizTags {
+		result[index] = &BankQuestionTag{Name: tag.Name}
+	}
+
+	return result
+}
+
+// CanUse returns whether the creator may see the question and add it to their quizzes
+func (b *BankQuestion) CanUse(creatorID uuid.UUID) bool {
+	if b.CreatorID == creatorID {
+		return true
+	}
+
+	if b.Organization == nil {
+		return false
+	}
+
+	_, ok := b.Organization.RoleOf(creatorID)
+	return ok
+}
+
+// CanEdit returns whether the creator may change the question, which affects every linked quiz
+func (b *BankQuestion) CanEdit(creatorID uuid.UUID) bool {
+	return b.CreatorID == creatorID || (b.Organization != nil && b.Organization.IsAdmin(creatorID))
+}
+
+// NewQuestion returns a quiz question with the contents of the bank question, a linked question
+// follows later changes to the bank question
+func (b *BankQuestion) NewQuestion(linked bool) *MultipleChoiceQuestion {
+	result := &MultipleChoiceQuestion{
+		BaseQuestion:   BaseQuestion{BaseObject: BaseObject{ID: uuid.New()}},
+		BankQuestionID: &b.ID,
+		Linked:         linked,
+	}
+
+	b.ApplyTo(result)
+
+	return result
+}
+
+// ApplyTo overwrites the contents of the quiz question, options keep their IDs by position so
+// existing answers keep pointing at the same option
+func (b *BankQuestion) ApplyTo(question *MultipleChoiceQuestion) {
+	question.Title = b.Title
+	question.Description = b.Description
+	question.DurationInSeconds = b.DurationInSeconds
+	question.Category = b.Category
+
+	options := make([]*QuestionOption, len(b.Options))
+	for index, bankOption := range b.Options {
+		option := &QuestionOption{BaseObject: BaseObject{ID: uuid.New()}, MultipleChoiceQuestionID: &question.ID}
+		if index < len(question.Options) {
+			option.ID = question.Options[index].ID
+		}
+
+		option.TextOption = bankOption.TextOption
+		options[index] = option
+
+		if bankOption.ID == b.AnswerID {
+			question.AnswerID = option.ID
+		}
+	}
+
+	question.Options = options
+}
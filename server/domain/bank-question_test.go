@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBankQuestion_CanUse_ReturnsExpectedValue(t *testing.T) {
+	t.Parallel()
+	creatorID := uuid.MustParse("5a1a9c43-5b54-4e27-8a39-6d7ab0f0c3a1")
+	memberID := uuid.MustParse("f8b2ff4b-55d3-4b8f-9e21-2b6ad86b7d48")
+
+	tests := map[string]struct {
+		question *BankQuestion
+		expected bool
+	}{
+		"owner": {
+			question: &BankQuestion{CreatorID: creatorID},
+			expected: true,
+		},
+		"stranger": {
+			question: &BankQuestion{CreatorID: memberID},
+		},
+		"member of the organization": {
+			question: &BankQuestion{
+				CreatorID:    memberID,
+				Organization: &Organization{Members: []*Member{{CreatorID: creatorID, Role: OrganizationMember}}},
+			},
+			expected: true,
+		},
+		"not a member of the organization": {
+			question: &BankQuestion{
+				CreatorID:    memberID,
+				Organization: &Organization{Members: []*Member{{CreatorID: memberID, Role: OrganizationAdmin}}},
+			},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result := testData.question.CanUse(creatorID)
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestBankQuestion_CanEdit_ReturnsExpectedValue(t *testing.T) {
+	t.Parallel()
+	creatorID := uuid.MustParse("5a1a9c43-5b54-4e27-8a39-6d7ab0f0c3a1")
+	otherID := uuid.MustParse("f8b2ff4b-55d3-4b8f-9e21-2b6ad86b7d48")
+
+	tests := map[string]struct {
+		question *BankQuestion
+		expected bool
+	}{
+		"owner": {
+			question: &BankQuestion{CreatorID: creatorID},
+			expected: true,
+		},
+		"member of the organization": {
+			question: &BankQuestion{
+				CreatorID:    otherID,
+				Organization: &Organization{Members: []*Member{{CreatorID: creatorID, Role: OrganizationMember}}},
+			},
+		},
+		"admin of the organization": {
+			question: &BankQuestion{
+				CreatorID:    otherID,
+				Organization: &Organization{Members: []*Member{{CreatorID: creatorID, Role: OrganizationAdmin}}},
+			},
+			expected: true,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result := testData.question.CanEdit(creatorID)
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestBankQuestion_NewQuestion_CopiesContents(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	answerID := uuid.MustParse("0e3b1d1c-4a36-4f1e-a2c5-0f7d8d2b11c2")
+	bank := &BankQuestion{
+		BaseObject:        BaseObject{ID: uuid.MustParse("c6d0d2c4-8b5f-4f3a-9f1d-6f5c3e2b1a09")},
+		Title:             "Capital of France?",
+		Description:       "Easy one",
+		DurationInSeconds: 20,
+		Category:          "Geography",
+		AnswerID:          answerID,
+		Options:           []*BankOption{{ID: uuid.New(), TextOption: "Lyon"}, {ID: answerID, TextOption: "Paris"}},
+	}
+
+	// Act
+	result := bank.NewQuestion(true)
+
+	// Assert
+	assert.NotEqual(t, uuid.Nil, result.ID)
+	assert.Equal(t, bank.ID, *result.BankQuestionID)
+	assert.True(t, result.Linked)
+	assert.Equal(t, "Capital of France?", result.Title)
+	assert.Equal(t, "Easy one", result.Description)
+	assert.Equal(t, uint(20), result.DurationInSeconds)
+	assert.Equal(t, "Geography", result.Category)
+
+	if assert.Len(t, result.Options, 2) {
+		assert.Equal(t, "Paris", result.Options[1].TextOption)
+		assert.Equal(t, result.Options[1].ID, result.AnswerID)
+		assert.NotEqual(t, answerID, result.AnswerID)
+	}
+}
+
+func TestBankQuestion_ApplyTo_KeepsOptionIDsByPosition(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	answerID := uuid.MustParse("0e3b1d1c-4a36-4f1e-a2c5-0f7d8d2b11c2")
+	bank := &BankQuestion{
+		Title:    "Changed",
+		AnswerID: answerID,
+		Options:  []*BankOption{{ID: answerID, TextOption: "a"}, {ID: uuid.New(), TextOption: "b"}, {ID: uuid.New(), TextOption: "c"}},
+	}
+
+	firstID := uuid.MustParse("7bd0f6e4-3b0b-46b1-9d3b-b1b2f8c3e4d5")
+	secondID := uuid.MustParse("9a8f0c1b-2e3d-4c5b-8a7f-6e5d4c3b2a10")
+	question := &MultipleChoiceQuestion{
+		BaseQuestion: BaseQuestion{Title: "Original"},
+		Options:      []*QuestionOption{{BaseObject: BaseObject{ID: firstID}}, {BaseObject: BaseObject{ID: secondID}}},
+		AnswerID:     secondID,
+	}
+
+	// Act
+	bank.ApplyTo(question)
+
+	// Assert
+	assert.Equal(t, "Changed", question.Title)
+	assert.Equal(t, firstID, question.AnswerID)
+
+	if assert.Len(t, question.Options, 3) {
+		assert.Equal(t, firstID, question.Options[0].ID)
+		assert.Equal(t, secondID, question.Options[1].ID)
+		assert.NotEqual(t, uuid.Nil, question.Options[2].ID)
+		assert.Equal(t, "c", question.Options[2].TextOption)
+	}
+}
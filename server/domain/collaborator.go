@@ -0,0 +1,67 @@
+package domain
+
+import "github.com/google/uuid"
+
+type Role string
+
+const (
+	RoleOwner  Role = "owner"
+	RoleEditor Role = "editor"
+	RoleHost   Role = "host"
+	RoleViewer Role = "viewer"
+)
+
+type Permission string
+
+const (
+	PermissionViewQuiz            Permission = "quiz:view"
+	PermissionEditQuiz            Permission = "quiz:edit"
+	PermissionDeleteQuiz          Permission = "quiz:delete"
+	PermissionManageCollaborators Permission = "quiz:collaborators"
+	PermissionHostGames           Permission = "games:host"
+)
+
+// rolePermissions is the single source of truth for what every role is allowed to do
+var rolePermissions = map[Role][]Permission{
+	RoleOwner:  {PermissionViewQuiz, PermissionEditQuiz, PermissionDeleteQuiz, PermissionManageCollaborators, PermissionHostGames},
+	RoleEditor: {PermissionViewQuiz, PermissionEditQuiz, PermissionHostGames},
+	RoleHost:   {PermissionViewQuiz, PermissionHostGames},
+	RoleViewer: {PermissionViewQuiz},
+}
+
+// roleRanks orders the roles from least to most privileged
+var roleRanks = map[Role]int{
+	RoleViewer: 1,
+	RoleHost:   2,
+	RoleEditor: 3,
+	RoleOwner:  4,
+}
+
+// Outranks returns whether this role has more privileges than the other role
+func (r Role) Outranks(other Role) bool {
+	return roleRanks[r] > roleRanks[other]
+}
+
+// Can returns whether this role has been granted the permission
+func (r Role) Can(permission Permission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Collaborator gives another creator access to a quiz and its games
+type Collaborator struct {
+	BaseObject
+
+	QuizID uuid.UUID `json:"quizID" gorm:"uniqueIndex:idx_collaborator" example:"00000000-0000-0000-0000-000000000000"`
+	Quiz   *Quiz     `json:"-" gorm:"foreignKey:QuizID"`
+
+	CreatorID uuid.UUID `json:"creatorID" gorm:"uniqueIndex:idx_collaborator" example:"00000000-0000-0000-0000-000000000000"`
+	Creator   *Creator  `json:"creator,omitempty" gorm:"foreignKey:CreatorID"`
+
+	Role Role `json:"role" example:"editor"` // desc: One of editor, host or viewer
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRole_Can_ReturnsExpectedValue(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		role       Role
+		permission Permission
+		expected   bool
+	}{
+		"owner manages collaborators": {
+			role:       RoleOwner,
+			permission: PermissionManageCollaborators,
+			expected:   true,
+		},
+		"editor edits": {
+			role:       RoleEditor,
+			permission: PermissionEditQuiz,
+			expected:   true,
+		},
+		"editor does not delete": {
+			role:       RoleEditor,
+			permission: PermissionDeleteQuiz,
+		},
+		"host hosts": {
+			role:       RoleHost,
+			permission: PermissionHostGames,
+			expected:   true,
+		},
+		"host does not edit": {
+			role:       RoleHost,
+			permission: PermissionEditQuiz,
+		},
+		"viewer views": {
+			role:       RoleViewer,
+			permission: PermissionViewQuiz,
+			expected:   true,
+		},
+		"viewer does not host": {
+			role:       RoleViewer,
+			permission: PermissionHostGames,
+		},
+		"unknown role": {
+			role:       "admin",
+			permission: PermissionViewQuiz,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result := testData.role.Can(testData.permission)
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package domain
 
 import (
-	"fmt"
 	"github.com/AvraamMavridis/randomcolor"
 )
 
@@ -12,18 +11,13 @@ type Creator struct {
 	Color           string `json:"color" example:"#220022"`                          // desc: Randomly assigned color
 	BackgroundColor string `json:"backgroundColor" example:"#220022"`                // desc: Randomly assigned color
 	Nickname        string `json:"nickname" gorm:"unique" example:"Adorable Beaver"` // desc: Randomly assigned nickname, to avoid naughty words
+	Avatar          string `json:"avatar" example:"moon"`                            // desc: Chosen built-in avatar, empty if a generated one is used
 
 	// Never expose this
 	AuthID string `json:"-" gorm:"unique"`
 
-	Quizzes []*Quiz `json:"-" gorm:"foreignKey:CreatorID;constraint:OnDelete:CASCADE"`
-}
-
-// GenerateNickname overwrites the creator's nickname using a random prefix and suffix
-func (c *Creator) GenerateNickname() {
-	prefix := namePrefixes[randomGenerator.Intn(len(namePrefixes))]
-	suffix := nameSuffixes[randomGenerator.Intn(len(nameSuffixes))]
-	c.Nickname = fmt.Sprintf("%s %s", prefix, suffix)
+	Quizzes []*Quiz   `json:"-" gorm:"foreignKey:CreatorID;constraint:OnDelete:CASCADE"`
+	APIKeys []*APIKey `json:"-" gorm:"foreignKey:CreatorID;constraint:OnDelete:CASCADE"`
 }
 
 // GenerateColors overwrites the creator's colors
@@ -31,3 +25,12 @@ func (c *Creator) GenerateColors() {
 	c.Color = randomcolor.GetRandomColorInHex()
 	c.BackgroundColor = randomcolor.GetRandomColorInHex()
 }
+
+// GetAvatar returns the chosen built-in avatar, or the ID for a generated one
+func (c *Creator) GetAvatar() string {
+	if c.Avatar != "" {
+		return c.Avatar
+	}
+
+	return c.ID.String()
+}
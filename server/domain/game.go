@@ -4,7 +4,7 @@ import (
 	"errors"
 	"github.com/google/uuid"
 	"math/rand"
-	"strings"
+	"sort"
 	"time"
 )
 
@@ -15,8 +15,12 @@ type Game struct {
 	QuizID uuid.UUID `json:"quizID" example:"00000000-0000-0000-0000-000000000000"`
 	Quiz   *Quiz     `json:"-" gorm:"foreignKey:QuizID"`
 
-	Code        string `json:"code" example:"KO384B"` // desc: The 'join' code for new players
-	PlayerLimit uint   `json:"playerLimit"`           // desc: The max amount of players that may join this game
+	QuizRevisionID *uuid.UUID    `json:"quizRevisionID,omitempty" example:"00000000-0000-0000-0000-000000000000"` // desc: The revision of the quiz this game was started with
+	QuizRevision   *QuizRevision `json:"-" gorm:"foreignKey:QuizRevisionID"`
+
+	Code        string `json:"code" example:"KP384B" gorm:"uniqueIndex:idx_game_code,where:code <> ''"` // desc: The 'join' code for new players, freed once the game finishes
+	PlayerLimit uint   `json:"playerLimit"`                                                             // desc: The max amount of players that may join this game
+	Locale      string `json:"locale" example:"nl"`                                                     // desc: Language of generated nicknames, the players' browser language is used if empty
 
 	CurrentQuestion uuid.UUID `json:"currentQuestion" example:"00000000-0000-0000-0000-000000000000"` // desc: The current question
 	CurrentDeadline time.Time `json:"currentDeadline"`                                                // desc: Past this deadline, no answers may be submitted
@@ -24,6 +28,25 @@ type Game struct {
 	Players Players     `json:"players" gorm:"foreignKey:GameID;constraint:OnDelete:CASCADE"`
 	Answers GameAnswers `json:"answers" gorm:"foreignKey:GameID;constraint:OnDelete:CASCADE"`
 
+	Teams       []*Team     `json:"teams" gorm:"foreignKey:GameID;constraint:OnDelete:CASCADE"` // desc: Players play in teams if there are any
+	TeamScoring TeamScoring `json:"teamScoring" example:"sum"`                                  // desc: How the scores of a team's players are combined
+
+	DrawnQuestions []*GameQuestion `json:"drawnQuestions,omitempty" gorm:"foreignKey:GameID;constraint:OnDelete:CASCADE"` // desc: The questions this game got from the quiz's pools, empty if the quiz has none
+
+	PresenterTokens []*PresenterToken `json:"-" gorm:"foreignKey:GameID;constraint:OnDelete:CASCADE"`
+
+	Mode      GameMode  `json:"mode" example:"live" gorm:"default:live"` // desc: Whether the host moves the game on, or every player goes at their own pace
+	OpenTime  time.Time `json:"openTime"`                                // desc: Self-paced only, players may join and answer from this time
+	CloseTime time.Time `json:"closeTime"`                               // desc: Self-paced only, the game finishes at this time
+
+	ShuffleQuestions        bool  `json:"shuffleQuestions"`        // desc: Whether questions are asked in a random order
+	ShuffleOptions          bool  `json:"shuffleOptions"`          // desc: Whether the options of every question are shown in a random order
+	ShuffleOptionsPerPlayer bool  `json:"shuffleOptionsPerPlayer"` // desc: Whether every player sees the options in a different random order
+	Seed                    int64 `json:"seed" example:"42"`       // desc: Determines the random orders, the same seed results in the same orders
+
+	ScheduledStart  time.Time `json:"scheduledStart"`  // desc: Optional, the game starts by itself at this time
+	ScheduledFinish time.Time `json:"scheduledFinish"` // desc: Optional, the game finishes by itself at this time
+
 	StartTime  time.Time `json:"startTime"`  // desc: The time that this game started
 	FinishTime time.Time `json:"finishTime"` // desc: The time that this game ended
 }
@@ -34,7 +57,7 @@ func (g *Game) GetCurrentQuestion() (Question, bool) {
 		return nil, false
 	}
 
-	for _, question := range g.Quiz.MultipleChoiceQuestions {
+	for _, question := range g.Questions() {
 		if question.ID == g.CurrentQuestion {
 			return question, true
 		}
@@ -48,11 +71,15 @@ func (g *Game) IsInProgress() bool {
 }
 
 func (g *Game) IsOpenForPlayers() bool {
+	if g.IsSelfPaced() {
+		return g.IsInProgress() && g.IsWindowOpen()
+	}
+
 	return !g.StartTime.IsZero() && g.CurrentQuestion == uuid.Nil && g.FinishTime.IsZero()
 }
 
 // Start starts the game and sets the code
-func (g *Game) Start() error {
+func (g *Game) Start(code string) error {
 	if !g.StartTime.IsZero() {
 		return errors.New("game has already started")
 	}
@@ -61,13 +88,26 @@ func (g *Game) Start() error {
 		return errors.New("no questions defined")
 	}
 
-	g.StartTime = time.Now()
+	if g.IsSelfPaced() && !time.Now().Before(g.CloseTime) {
+		return errors.New("game has already closed")
+	}
 
-	code := make([]string, 6)
-	for i := range code {
-		code[i] = codeChars[rand.Intn(len(codeChars))]
+	if code == "" {
+		return errors.New("no code provided")
 	}
-	g.Code = strings.Join(code, "")
+
+	// Hosts may pick a seed to replay the order of an earlier game
+	if g.Seed == 0 {
+		g.Seed = rand.Int63()
+	}
+
+	g.drawQuestions()
+	if len(g.Questions()) == 0 {
+		return errors.New("no questions drawn")
+	}
+
+	g.StartTime = time.Now()
+	g.Code = code
 
 	return nil
 }
@@ -77,6 +117,10 @@ func (g *Game) Next() error {
 		return errors.New("game is not in progress")
 	}
 
+	if g.IsSelfPaced() {
+		return errors.New("players of a self-paced game move on by themselves")
+	}
+
 	if len(g.Players) < 2 {
 		return errors.New("can only start with 2 or more players")
 	}
@@ -85,7 +129,7 @@ func (g *Game) Next() error {
 		return errors.New("deadline has not passed")
 	}
 
-	nextQuestion, ok := g.Quiz.GetNextQuestion(g.CurrentQuestion)
+	nextQuestion, ok := g.NextQuestion(g.CurrentQuestion)
 
 	if !ok {
 		return errors.New("no more questions")
@@ -97,7 +141,7 @@ func (g *Game) Next() error {
 	return nil
 }
 
-// Finish ends the game
+// Finish ends the game and frees up its code
 func (g *Game) Finish() error {
 	if g.StartTime.IsZero() {
 		return errors.New("game has not started")
@@ -112,15 +156,33 @@ func (g *Game) Finish() error {
 	}
 
 	g.FinishTime = time.Now()
+	g.Code = ""
 	return nil
 }
 
+// AnswerQuestion validates the answer against the game's current question, or the player's own
+// current question in self-paced games
 func (g *Game) AnswerQuestion(player uuid.UUID, question uuid.UUID, optionID uuid.UUID) (*GameAnswer, error) {
-	if g.CurrentQuestion != question {
+	if !g.IsInProgress() {
+		return nil, errors.New("game is not in progress")
+	}
+
+	currentQuestion, currentDeadline := g.CurrentQuestion, g.CurrentDeadline
+
+	if g.IsSelfPaced() {
+		progress, ok := g.Players.Get(player)
+		if !ok {
+			return nil, errors.New("player not in game")
+		}
+
+		currentQuestion, currentDeadline = progress.CurrentQuestion, progress.CurrentDeadline
+	}
+
+	if currentQuestion != question {
 		return nil, errors.New("not the current question")
 	}
 
-	if time.Now().After(g.CurrentDeadline) {
+	if time.Now().After(currentDeadline) {
 		return nil, errors.New("deadline passed")
 	}
 
@@ -143,3 +205,54 @@ func (g *Game) AnswerQuestion(player uuid.UUID, question uuid.UUID, optionID uui
 
 	return answer, nil
 }
+
+// PlayerScores returns the amount of correct answers per player
+func (g *Game) PlayerScores() map[uuid.UUID]uint {
+	answers := map[uuid.UUID]uuid.UUID{}
+	for _, question := range g.Quiz.MultipleChoiceQuestions {
+		answers[question.ID] = question.AnswerID
+	}
+
+	result := map[uuid.UUID]uint{}
+	for _, answer := range g.Answers {
+		if correct, ok := answers[answer.QuestionID]; ok && correct == answer.OptionID {
+			result[answer.PlayerID]++
+		}
+	}
+
+	return result
+}
+
+// PlayerScore is a player's position on the leaderboard
+type PlayerScore struct {
+	Player *Player `json:"player"`
+	Score  uint    `json:"score" example:"4"` // desc: The amount of correct answers
+}
+
+// Leaderboard returns the players ordered by their score, highest first
+func (g *Game) Leaderboard() []*PlayerScore {
+	scores := g.PlayerScores()
+
+	result := make([]*PlayerScore, 0, len(g.Players))
+	for _, player := range g.Players {
+		result = append(result, &PlayerScore{Player: player, Score: scores[player.ID]})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Score > result[j].Score
+	})
+
+	return result
+}
+
+// AnswerDistribution returns how many players picked each option of the question
+func (g *Game) AnswerDistribution(questionID uuid.UUID) map[uuid.UUID]uint {
+	result := map[uuid.UUID]uint{}
+	for _, answer := range g.Answers {
+		if answer.QuestionID == questionID {
+			result[answer.OptionID]++
+		}
+	}
+
+	return result
+}
@@ -145,12 +145,25 @@ func TestGame_Start_SetsStartTimeAndCode(t *testing.T) {
 	game := Game{Quiz: &Quiz{MultipleChoiceQuestions: []*MultipleChoiceQuestion{{}, {}}}}
 
 	// Act
-	err := game.Start()
+	err := game.Start("ABC234")
 
 	// Assert
 	assert.NoError(t, err)
 	assert.False(t, game.StartTime.IsZero())
-	assert.Len(t, game.Code, 6)
+	assert.Equal(t, "ABC234", game.Code)
+}
+
+func TestGame_Start_ErrorsOnNoCode(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	game := Game{Quiz: &Quiz{MultipleChoiceQuestions: []*MultipleChoiceQuestion{{}, {}}}}
+
+	// Act
+	err := game.Start("")
+
+	// Assert
+	assert.ErrorContains(t, err, "no code")
+	assert.True(t, game.StartTime.IsZero())
 }
 
 func TestGame_Start_ErrorsOnAlreadyStarted(t *testing.T) {
@@ -158,10 +171,10 @@ func TestGame_Start_ErrorsOnAlreadyStarted(t *testing.T) {
 	// Arrange
 	game := Game{Quiz: &Quiz{MultipleChoiceQuestions: []*MultipleChoiceQuestion{{}, {}}}}
 
-	_ = game.Start()
+	_ = game.Start("ABC234")
 
 	// Act
-	err := game.Start()
+	err := game.Start("DEF567")
 
 	// Assert
 	assert.ErrorContains(t, err, "game has already started")
@@ -175,7 +188,7 @@ func TestGame_Start_ErrorsOnNoQuestions(t *testing.T) {
 	}
 
 	// Act
-	err := game.Start()
+	err := game.Start("ABC234")
 
 	// Assert
 	assert.ErrorContains(t, err, "no questions")
@@ -184,7 +197,7 @@ func TestGame_Start_ErrorsOnNoQuestions(t *testing.T) {
 func TestGame_Finish_SetsFinishTime(t *testing.T) {
 	t.Parallel()
 	// Arrange
-	game := Game{StartTime: time.Now(), CurrentDeadline: time.Now().Add(5 * -time.Hour)}
+	game := Game{StartTime: time.Now(), Code: "ABC234", CurrentDeadline: time.Now().Add(5 * -time.Hour)}
 
 	// Act
 	err := game.Finish()
@@ -192,6 +205,7 @@ func TestGame_Finish_SetsFinishTime(t *testing.T) {
 	// Assert
 	assert.NoError(t, err)
 	assert.False(t, game.FinishTime.IsZero())
+	assert.Empty(t, game.Code)
 }
 
 func TestGame_Finish_ErrorsOnNotStarted(t *testing.T) {
@@ -236,6 +250,29 @@ func TestGame_Finish_ErrorsOnDeadlineNotPassed(t *testing.T) {
 	assert.ErrorContains(t, err, "deadline has not passed")
 }
 
+func TestGame_AnswerQuestion_ReturnsErrorOnNotInProgress(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	questionID := uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8")
+	playerID := uuid.MustParse("a28ca8c6-63d9-45a2-b990-9b41e306f156")
+	optionID := uuid.MustParse("c7ff1cdf-72d3-4ea9-ae48-e1c1d61f8bc8")
+
+	game := &Game{
+		StartTime:       time.Now().Add(-time.Hour),
+		FinishTime:      time.Now(),
+		CurrentQuestion: questionID,
+		CurrentDeadline: time.Now().Add(5 * time.Hour),
+		Players:         []*Player{{BaseObject: BaseObject{ID: playerID}}},
+	}
+
+	// Act
+	answer, err := game.AnswerQuestion(playerID, questionID, optionID)
+
+	// Assert
+	assert.Nil(t, answer)
+	assert.ErrorContains(t, err, "game is not in progress")
+}
+
 func TestGame_AnswerQuestion_ReturnsErrorOnWrongQuestion(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -243,7 +280,7 @@ func TestGame_AnswerQuestion_ReturnsErrorOnWrongQuestion(t *testing.T) {
 	playerID := uuid.MustParse("a28ca8c6-63d9-45a2-b990-9b41e306f156")
 	optionID := uuid.MustParse("c7ff1cdf-72d3-4ea9-ae48-e1c1d61f8bc8")
 
-	game := &Game{CurrentQuestion: uuid.MustParse("f6d2fa67-c12d-4096-958b-18206fbf3538")}
+	game := &Game{StartTime: time.Now(), CurrentQuestion: uuid.MustParse("f6d2fa67-c12d-4096-958b-18206fbf3538")}
 
 	// Act
 	answer, err := game.AnswerQuestion(playerID, questionID, optionID)
@@ -260,7 +297,7 @@ func TestGame_AnswerQuestion_ReturnsErrorOnDeadlinePassed(t *testing.T) {
 	playerID := uuid.MustParse("a28ca8c6-63d9-45a2-b990-9b41e306f156")
 	optionID := uuid.MustParse("c7ff1cdf-72d3-4ea9-ae48-e1c1d61f8bc8")
 
-	game := &Game{CurrentQuestion: questionID, CurrentDeadline: time.Now()}
+	game := &Game{StartTime: time.Now(), CurrentQuestion: questionID, CurrentDeadline: time.Now()}
 
 	// Act
 	answer, err := game.AnswerQuestion(playerID, questionID, optionID)
@@ -277,7 +314,7 @@ func TestGame_AnswerQuestion_ReturnsErrorOnPlayerNotInGame(t *testing.T) {
 	playerID := uuid.MustParse("a28ca8c6-63d9-45a2-b990-9b41e306f156")
 	optionID := uuid.MustParse("c7ff1cdf-72d3-4ea9-ae48-e1c1d61f8bc8")
 
-	game := &Game{CurrentQuestion: questionID, CurrentDeadline: time.Now().Add(5 * time.Hour)}
+	game := &Game{StartTime: time.Now(), CurrentQuestion: questionID, CurrentDeadline: time.Now().Add(5 * time.Hour)}
 
 	// Act
 	answer, err := game.AnswerQuestion(playerID, questionID, optionID)
@@ -296,6 +333,7 @@ func TestGame_AnswerQuestion_ReturnsErrorOnAlreadyAnswered(t *testing.T) {
 
 	game := &Game{
 		BaseObject:      BaseObject{ID: uuid.MustParse("bd787fed-8a2e-40d3-abf6-6b90fa89f862")},
+		StartTime:       time.Now(),
 		CurrentQuestion: questionID,
 		CurrentDeadline: time.Now().Add(5 * time.Hour),
 		Answers:         []*GameAnswer{{PlayerID: playerID, QuestionID: questionID}},
@@ -319,6 +357,7 @@ func TestGame_AnswerQuestion_ReturnsAnswer(t *testing.T) {
 
 	game := &Game{
 		BaseObject:      BaseObject{ID: uuid.MustParse("bd787fed-8a2e-40d3-abf6-6b90fa89f862")},
+		StartTime:       time.Now(),
 		CurrentQuestion: questionID,
 		CurrentDeadline: time.Now().Add(5 * time.Hour),
 		Players:         []*Player{{BaseObject: BaseObject{ID: playerID}}},
@@ -427,3 +466,58 @@ func TestGame_Next_SetsNextQuestionOnNil(t *testing.T) {
 	assert.Equal(t, uuid.MustParse("32acdba2-3472-4489-82a2-426c22ff529c"), game.CurrentQuestion)
 	assert.False(t, game.CurrentDeadline.IsZero())
 }
+
+func TestGame_Leaderboard_ReturnsPlayersByScore(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	question := uuid.MustParse("c9dd02c7-5a4c-4a5e-9a10-8ed9e9e5e9a8")
+	correct := uuid.MustParse("0f04a1a3-2a2f-4c84-9a7c-4d2d39b6f0e5")
+	wrong := uuid.MustParse("5b8c9a0e-2d8f-4b6c-8e7a-6f3d2c1b0a9e")
+
+	players := []*Player{
+		{BaseObject: BaseObject{ID: uuid.MustParse("a1b2c3d4-0000-4000-8000-000000000001")}},
+		{BaseObject: BaseObject{ID: uuid.MustParse("a1b2c3d4-0000-4000-8000-000000000002")}},
+	}
+
+	game := &Game{
+		Quiz: &Quiz{MultipleChoiceQuestions: []*MultipleChoiceQuestion{
+			{BaseQuestion: BaseQuestion{BaseObject: BaseObject{ID: question}}, AnswerID: correct},
+		}},
+		Players: players,
+		Answers: GameAnswers{
+			{PlayerID: players[0].ID, QuestionID: question, OptionID: wrong},
+			{PlayerID: players[1].ID, QuestionID: question, OptionID: correct},
+		},
+	}
+
+	// Act
+	result := game.Leaderboard()
+
+	// Assert
+	if assert.Len(t, result, 2) {
+		assert.Equal(t, &PlayerScore{Player: players[1], Score: 1}, result[0])
+		assert.Equal(t, &PlayerScore{Player: players[0], Score: 0}, result[1])
+	}
+}
+
+func TestGame_AnswerDistribution_CountsAnswersPerOption(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	question := uuid.MustParse("c9dd02c7-5a4c-4a5e-9a10-8ed9e9e5e9a8")
+	other := uuid.MustParse("29ac8a2b-5dc5-4bd1-8a8c-5fd1a3a0f4b1")
+	optionA := uuid.MustParse("0f04a1a3-2a2f-4c84-9a7c-4d2d39b6f0e5")
+	optionB := uuid.MustParse("5b8c9a0e-2d8f-4b6c-8e7a-6f3d2c1b0a9e")
+
+	game := &Game{Answers: GameAnswers{
+		{QuestionID: question, OptionID: optionA},
+		{QuestionID: question, OptionID: optionA},
+		{QuestionID: question, OptionID: optionB},
+		{QuestionID: other, OptionID: optionB},
+	}}
+
+	// Act
+	result := game.AnswerDistribution(question)
+
+	// Assert
+	assert.Equal(t, map[uuid.UUID]uint{optionA: 2, optionB: 1}, result)
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type OrganizationRole string
+
+const (
+	OrganizationAdmin  OrganizationRole = "admin"
+	OrganizationMember OrganizationRole = "member"
+)
+
+// QuizRole returns the role a member of this organization has on quizzes that belong to it
+func (o OrganizationRole) QuizRole() Role {
+	if o == OrganizationAdmin {
+		return RoleOwner
+	}
+
+	return RoleHost
+}
+
+// Organization allows a group of creators to share a library of quizzes
+type Organization struct {
+	BaseObject
+
+	Name string `json:"name" example:"Math department"` // desc: Can be anything
+
+	Members     []*Member     `json:"members,omitempty" gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE"`
+	Invitations []*Invitation `json:"-" gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE"`
+	Quizzes     []*Quiz       `json:"-" gorm:"foreignKey:OrganizationID;constraint:OnDelete:SET NULL"`
+}
+
+// RoleOf returns the role the creator has in this organization
+func (o *Organization) RoleOf(creatorID uuid.UUID) (OrganizationRole, bool) {
+	for _, member := range o.Members {
+		if member.CreatorID == creatorID {
+			return member.Role, true
+		}
+	}
+
+	return "", false
+}
+
+// IsAdmin returns whether the creator may manage this organization
+func (o *Organization) IsAdmin(creatorID uuid.UUID) bool {
+	role, ok := o.RoleOf(creatorID)
+	return ok && role == OrganizationAdmin
+}
+
+// CountAdmins is used to prevent an organization from ending up without admins
+func (o *Organization) CountAdmins() int {
+	var result int
+	for _, member := range o.Members {
+		if member.Role == OrganizationAdmin {
+			result++
+		}
+	}
+
+	return result
+}
+
+// Member is a creator that belongs to an organization
+type Member struct {
+	BaseObject
+
+	OrganizationID uuid.UUID     `json:"organizationID" gorm:"uniqueIndex:idx_member" example:"00000000-0000-0000-0000-000000000000"`
+	Organization   *Organization `json:"-" gorm:"foreignKey:OrganizationID"`
+
+	CreatorID uuid.UUID `json:"creatorID" gorm:"uniqueIndex:idx_member" example:"00000000-0000-0000-0000-000000000000"`
+	Creator   *Creator  `json:"creator,omitempty" gorm:"foreignKey:CreatorID"`
+
+	Role OrganizationRole `json:"role" example:"member"` // desc: Either admin or member
+}
+
+// Invitation is sent by an admin and has to be accepted by the creator before they become a member
+type Invitation struct {
+	BaseObject
+
+	OrganizationID uuid.UUID     `json:"organizationID" gorm:"uniqueIndex:idx_invitation" example:"00000000-0000-0000-0000-000000000000"`
+	Organization   *Organization `json:"organization,omitempty" gorm:"foreignKey:OrganizationID"`
+
+	CreatorID uuid.UUID `json:"creatorID" gorm:"uniqueIndex:idx_invitation" example:"00000000-0000-0000-0000-000000000000"` // desc: The invited creator
+	Creator   *Creator  `json:"-" gorm:"foreignKey:CreatorID"`
+
+	InvitedByID uuid.UUID `json:"invitedByID" example:"00000000-0000-0000-0000-000000000000"`
+	InvitedBy   *Creator  `json:"-" gorm:"foreignKey:InvitedByID"`
+
+	Role OrganizationRole `json:"role" example:"member"` // desc: The role the creator gets after accepting
+
+	ExpiresAt time.Time `json:"expiresAt"` // desc: Past this moment the invitation can no longer be accepted
+}
+
+// IsExpired returns whether this invitation can no longer be accepted
+func (i *Invitation) IsExpired() bool {
+	return time.Now().After(i.ExpiresAt)
+}
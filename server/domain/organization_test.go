@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestOrganization_IsAdmin_ReturnsExpectedValue(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	adminID := uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58")
+	memberID := uuid.MustParse("e3274bf0-b154-4d37-a6fd-878d530025f9")
+
+	organization := &Organization{
+		Members: []*Member{
+			{CreatorID: adminID, Role: OrganizationAdmin},
+			{CreatorID: memberID, Role: OrganizationMember},
+		},
+	}
+
+	// Act
+	adminResult := organization.IsAdmin(adminID)
+	memberResult := organization.IsAdmin(memberID)
+	strangerResult := organization.IsAdmin(uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b"))
+
+	// Assert
+	assert.True(t, adminResult)
+	assert.False(t, memberResult)
+	assert.False(t, strangerResult)
+}
+
+func TestOrganization_CountAdmins_ReturnsAdmins(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	organization := &Organization{
+		Members: []*Member{
+			{Role: OrganizationAdmin},
+			{Role: OrganizationMember},
+			{Role: OrganizationAdmin},
+		},
+	}
+
+	// Act
+	result := organization.CountAdmins()
+
+	// Assert
+	assert.Equal(t, 2, result)
+}
+
+func TestInvitation_IsExpired_ReturnsExpectedValue(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		expiresAt time.Time
+		expected  bool
+	}{
+		"expired": {
+			expiresAt: time.Now().Add(-time.Minute),
+			expected:  true,
+		},
+		"valid": {
+			expiresAt: time.Now().Add(time.Hour),
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			invitation := &Invitation{ExpiresAt: testData.expiresAt}
+
+			// Act
+			result := invitation.IsExpired()
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
@@ -1,9 +1,9 @@
 package domain
 
 import (
-	"fmt"
 	"github.com/AvraamMavridis/randomcolor"
 	"github.com/google/uuid"
+	"time"
 )
 
 type Players []*Player
@@ -18,23 +18,34 @@ func (p Players) Contains(playerID uuid.UUID) bool {
 	return false
 }
 
+func (p Players) Get(playerID uuid.UUID) (*Player, bool) {
+	for _, player := range p {
+		if player.ID == playerID {
+			return player, true
+		}
+	}
+
+	return nil, false
+}
+
 // Player is a simple anonymous user that joins a game, also gets a random nickname assigned
 type Player struct {
 	BaseObject
 
-	Color           string `json:"color" example:"#220022"`            // desc: Randomly assigned color
-	BackgroundColor string `json:"backgroundColor" example:"#220022"`  // desc: Randomly assigned color
-	Nickname        string `json:"nickname" example:"Adorable Beaver"` // desc: Randomly assigned nickname, to avoid naughty words
+	Color           string `json:"color" example:"#220022"`                                                                                                              // desc: Randomly assigned color
+	BackgroundColor string `json:"backgroundColor" example:"#220022"`                                                                                                    // desc: Randomly assigned color
+	Nickname        string `json:"nickname" example:"Adorable Beaver" gorm:"uniqueIndex:idx_player_nickname,expression:LOWER(nickname),priority:2,where:nickname <> ''"` // desc: Randomly assigned nickname, to avoid naughty words
+	Avatar          string `json:"avatar" example:"moon"`                                                                                                                // desc: Chosen built-in avatar, empty if a generated one is used
 
-	GameID uuid.UUID `json:"gameID" example:"00000000-0000-0000-0000-000000000000"` // desc: The game this player belongs to
+	GameID uuid.UUID `json:"gameID" example:"00000000-0000-0000-0000-000000000000" gorm:"uniqueIndex:idx_player_nickname,priority:1"` // desc: The game this player belongs to
 	Game   *Game     `json:"-" gorm:"foreignKey:GameID"`
-}
 
-// GenerateNickname overwrites the player's nickname using a random prefix and suffix
-func (c *Player) GenerateNickname() {
-	prefix := namePrefixes[randomGenerator.Intn(len(namePrefixes))]
-	suffix := nameSuffixes[randomGenerator.Intn(len(nameSuffixes))]
-	c.Nickname = fmt.Sprintf("%s %s", prefix, suffix)
+	TeamID *uuid.UUID `json:"teamID,omitempty" example:"00000000-0000-0000-0000-000000000000"` // desc: The team this player plays in, if the game has teams
+	Team   *Team      `json:"-" gorm:"foreignKey:TeamID;constraint:OnDelete:SET NULL"`
+
+	CurrentQuestion uuid.UUID `json:"currentQuestion" example:"00000000-0000-0000-0000-000000000000"` // desc: Self-paced only, the question this player is at
+	CurrentDeadline time.Time `json:"currentDeadline"`                                                // desc: Self-paced only, past this deadline the player may not answer
+	FinishTime      time.Time `json:"finishTime"`                                                     // desc: Self-paced only, the time this player went through all questions
 }
 
 // GenerateColors overwrites the player's colors
@@ -42,3 +53,12 @@ func (c *Player) GenerateColors() {
 	c.Color = randomcolor.GetRandomColorInHex()
 	c.BackgroundColor = randomcolor.GetRandomColorInHex()
 }
+
+// GetAvatar returns the chosen built-in avatar, or the ID for a generated one
+func (c *Player) GetAvatar() string {
+	if c.Avatar != "" {
+		return c.Avatar
+	}
+
+	return c.ID.String()
+}
@@ -3,7 +3,6 @@ package domain
 import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
-	"strings"
 	"testing"
 )
 
@@ -39,29 +38,44 @@ func TestPlayers_Contains_ReturnsFalseOnNotContains(t *testing.T) {
 	assert.False(t, result)
 }
 
-func TestPlayer_GenerateNickname_SetsARandomName(t *testing.T) {
+func TestPlayer_GenerateColors_SetsARandomColor(t *testing.T) {
 	t.Parallel()
 	// Arrange
 	player := &Player{}
 
 	// Act
-	player.GenerateNickname()
+	player.GenerateColors()
 
 	// Assert
-	split := strings.Split(player.Nickname, " ")
-	assert.Contains(t, namePrefixes, split[0])
-	assert.Contains(t, nameSuffixes, split[1])
+	assert.Len(t, player.Color, 7)
+	assert.Len(t, player.BackgroundColor, 7)
 }
 
-func TestPlayer_GenerateColors_SetsARandomColor(t *testing.T) {
+func TestPlayer_GetAvatar_ReturnsExpectedAvatar(t *testing.T) {
 	t.Parallel()
-	// Arrange
-	player := &Player{}
+	tests := map[string]struct {
+		player   *Player
+		expected string
+	}{
+		"chosen": {
+			player:   &Player{BaseObject: BaseObject{ID: uuid.MustParse("20ac1eb2-5424-4aca-9018-1eee6a2a5510")}, Avatar: "moon"},
+			expected: "moon",
+		},
+		"generated": {
+			player:   &Player{BaseObject: BaseObject{ID: uuid.MustParse("20ac1eb2-5424-4aca-9018-1eee6a2a5510")}},
+			expected: "20ac1eb2-5424-4aca-9018-1eee6a2a5510",
+		},
+	}
 
-	// Act
-	player.GenerateColors()
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result := testData.player.GetAvatar()
 
-	// Assert
-	assert.Len(t, player.Color, 7)
-	assert.Len(t, player.BackgroundColor, 7)
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
 }
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+// PresenterTokenPrefix is prepended to every presenter token so it can't be mistaken for an API key
+const PresenterTokenPrefix = "qqp_"
+
+// PresenterToken gives a display read-only access to a single game, without the host's powers
+type PresenterToken struct {
+	BaseObject
+
+	Name string `json:"name" example:"Big screen room 2"` // desc: Can be anything
+	Hint string `json:"hint" example:"qqp_3f9a"`          // desc: The first few characters of the token, to recognise it
+	Hash string `json:"-" gorm:"unique"`                  // desc: Never store or expose the token itself
+
+	GameID uuid.UUID `json:"gameID" example:"00000000-0000-0000-0000-000000000000"`
+	Game   *Game     `json:"-" gorm:"foreignKey:GameID"`
+
+	CreatorID uuid.UUID `json:"creatorID" example:"00000000-0000-0000-0000-000000000000"` // desc: The host that issued the token
+	Creator   *Creator  `json:"-" gorm:"foreignKey:CreatorID"`
+}
+
+// HashPresenterToken returns the value that is stored in place of the token, it uses the same hash as API keys
+func HashPresenterToken(token string) string {
+	return HashAPIKey(token)
+}
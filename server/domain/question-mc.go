@@ -9,6 +9,10 @@ type MultipleChoiceQuestion struct {
 	AnswerID uuid.UUID `json:"answerID" example:"00000000-0000-0000-0000-000000000000"`
 
 	Options []*QuestionOption `json:"options" gorm:"foreignKey:MultipleChoiceQuestionID;constraint:OnDelete:CASCADE"`
+
+	BankQuestionID *uuid.UUID    `json:"bankQuestionID,omitempty" example:"00000000-0000-0000-0000-000000000000"` // desc: The bank question this question came from, if any
+	BankQuestion   *BankQuestion `json:"-" gorm:"foreignKey:BankQuestionID;constraint:OnDelete:SET NULL"`
+	Linked         bool          `json:"linked,omitempty" example:"false"` // desc: Linked questions follow changes to their bank question
 }
 
 func (m MultipleChoiceQuestion) GetType() QuestionType {
@@ -10,6 +10,10 @@ type QuestionOption struct {
 
 	TextOption string `json:"textOption" example:"Haarlem"` // desc: A textual option for this question
 
+	// Position keeps the options in the order they were saved in, the answer of linked questions
+	// depends on it
+	Position uint `json:"-"`
+
 	// Expand for more option types
 	// ImageOption
 	// ...
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"math/rand"
+	"sort"
+)
+
+// QuestionPool makes every game draw a random selection of the quiz's questions
+type QuestionPool struct {
+	BaseObject
+
+	QuizID uuid.UUID `json:"quizID" example:"00000000-0000-0000-0000-000000000000"`
+	Quiz   *Quiz     `json:"-" gorm:"foreignKey:QuizID"`
+
+	Category  string `json:"category" example:"Geography"` // desc: Draws from the questions in this category, if empty it draws from the questions that no other pool draws from
+	DrawCount uint   `json:"drawCount" example:"5"`        // desc: The amount of questions every game gets from this pool
+}
+
+// GameQuestion is a question that was drawn for a game when it started
+type GameQuestion struct {
+	GameID     uuid.UUID `json:"-" gorm:"primaryKey"`
+	QuestionID uuid.UUID `json:"questionID" gorm:"primaryKey" example:"00000000-0000-0000-0000-000000000000"`
+}
+
+// HasQuestionPools returns whether games only get a selection of the questions
+func (q *Quiz) HasQuestionPools() bool {
+	return len(q.QuestionPools) > 0
+}
+
+// DrawQuestions picks the questions of every pool at random, pools that have fewer questions
+// than they should draw give all of them
+func (q *Quiz) DrawQuestions(random *rand.Rand) []*MultipleChoiceQuestion {
+	categories := map[string]bool{}
+	for _, pool := range q.QuestionPools {
+		if pool.Category != "" {
+			categories[pool.Category] = true
+		}
+	}
+
+	var result []*MultipleChoiceQuestion
+	for _, pool := range q.QuestionPools {
+		var candidates []*MultipleChoiceQuestion
+		for _, question := range q.MultipleChoiceQuestions {
+			if question.Category == pool.Category || (pool.Category == "" && !categories[question.Category]) {
+				candidates = append(candidates, question)
+			}
+		}
+
+		// The database doesn't guarantee an order, the same seed should draw the same questions
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].Order < candidates[j].Order
+		})
+
+		random.Shuffle(len(candidates), func(i, j int) {
+			candidates[i], candidates[j] = candidates[j], candidates[i]
+		})
+
+		if uint(len(candidates)) > pool.DrawCount {
+			candidates = candidates[:pool.DrawCount]
+		}
+
+		result = append(result, candidates...)
+	}
+
+	return result
+}
+
+// drawQuestions freezes the questions of the game, so changes to the pools don't affect games
+// that are already running
+func (g *Game) drawQuestions() {
+	g.DrawnQuestions = nil
+	if !g.Quiz.HasQuestionPools() {
+		return
+	}
+
+	for _, question := range g.Quiz.DrawQuestions(rand.New(rand.NewSource(g.Seed))) {
+		g.DrawnQuestions = append(g.DrawnQuestions, &GameQuestion{GameID: g.ID, QuestionID: question.ID})
+	}
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func poolQuiz() *Quiz {
+	quiz := &Quiz{}
+	for order := uint(0); order < 12; order++ {
+		category := "Geography"
+		if order%3 == 0 {
+			category = "Math"
+		}
+
+		quiz.MultipleChoiceQuestions = append(quiz.MultipleChoiceQuestions, &MultipleChoiceQuestion{
+			BaseQuestion: BaseQuestion{BaseObject: BaseObject{ID: uuid.New()}, Order: order, Category: category},
+		})
+	}
+
+	return quiz
+}
+
+func TestQuiz_DrawQuestions_DrawsFromEveryPool(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quiz := poolQuiz()
+	quiz.QuestionPools = []*QuestionPool{{Category: "Math", DrawCount: 2}, {DrawCount: 3}}
+
+	// Act
+	result := quiz.DrawQuestions(rand.New(rand.NewSource(42)))
+
+	// Assert
+	categories := map[string]int{}
+	for _, question := range result {
+		categories[question.Category]++
+	}
+
+	assert.Equal(t, map[string]int{"Math": 2, "Geography": 3}, categories)
+}
+
+func TestQuiz_DrawQuestions_DrawsAllIfPoolIsTooSmall(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quiz := poolQuiz()
+	quiz.QuestionPools = []*QuestionPool{{Category: "Math", DrawCount: 10}}
+
+	// Act
+	result := quiz.DrawQuestions(rand.New(rand.NewSource(42)))
+
+	// Assert
+	assert.Len(t, result, 4)
+}
+
+func TestGame_Start_FreezesDrawnQuestions(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quiz := poolQuiz()
+	quiz.QuestionPools = []*QuestionPool{{DrawCount: 5}}
+
+	game := &Game{Seed: 42, Quiz: quiz}
+	replay := &Game{Seed: 42, Quiz: quiz}
+
+	// Act
+	err := game.Start("ABC123")
+	_ = replay.Start("ABC123")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, game.DrawnQuestions, 5)
+	assert.Equal(t, game.DrawnQuestions, replay.DrawnQuestions)
+
+	var drawnIDs, questionIDs []uuid.UUID
+	for _, drawn := range game.DrawnQuestions {
+		drawnIDs = append(drawnIDs, drawn.QuestionID)
+	}
+	for _, question := range game.Questions() {
+		questionIDs = append(questionIDs, question.ID)
+	}
+
+	assert.ElementsMatch(t, drawnIDs, questionIDs)
+}
+
+func TestGame_GetCurrentQuestion_IgnoresQuestionsThatWereNotDrawn(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quiz := poolQuiz()
+
+	game := &Game{
+		Quiz:            quiz,
+		DrawnQuestions:  []*GameQuestion{{QuestionID: quiz.MultipleChoiceQuestions[1].ID}},
+		CurrentQuestion: quiz.MultipleChoiceQuestions[0].ID,
+	}
+
+	// Act
+	_, ok := game.GetCurrentQuestion()
+	next, nextOk := game.NextQuestion(uuid.Nil)
+
+	// Assert
+	assert.False(t, ok)
+	assert.True(t, nextOk)
+	assert.Equal(t, quiz.MultipleChoiceQuestions[1].ID, next.GetBaseQuestion().ID)
+}
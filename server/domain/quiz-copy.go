@@ -0,0 +1,68 @@
+package domain
+
+import "github.com/google/uuid"
+
+// Copy returns a new quiz for the creator with the contents of this one, it is neither public nor
+// a template until the creator decides so
+func (q *Quiz) Copy(creatorID uuid.UUID) *Quiz {
+	return &Quiz{
+		Name:                    q.Name,
+		Description:             q.Description,
+		CreatorID:               creatorID,
+		MaxConcurrentGames:      q.MaxConcurrentGames,
+		Tags:                    copyTags(q.Tags),
+		Language:                q.Language,
+		MultipleChoiceQuestions: copyQuestions(q.MultipleChoiceQuestions),
+		QuestionPools:           copyPools(q.QuestionPools),
+	}
+}
+
+// Fork copies the quiz like Copy does, but keeps a reference to this quiz as its source
+func (q *Quiz) Fork(creatorID uuid.UUID) *Quiz {
+	result := q.Copy(creatorID)
+	result.ForkedFromID = &q.ID
+
+	return result
+}
+
+// copyQuestions copies the contents of the questions, they get new IDs when they're saved and the
+// options get new ones right away so the answer can point at them
+func copyQuestions(questions []*MultipleChoiceQuestion) []*MultipleChoiceQuestion {
+	result := make([]*MultipleChoiceQuestion, len(questions))
+
+	for index, question := range questions {
+		copied := &MultipleChoiceQuestion{
+			BaseQuestion: BaseQuestion{
+				Title:             question.Title,
+				Description:       question.Description,
+				DurationInSeconds: question.DurationInSeconds,
+				Category:          question.Category,
+				Order:             question.Order,
+			},
+			BankQuestionID: question.BankQuestionID,
+			Linked:         question.Linked,
+		}
+
+		for _, option := range question.Options {
+			copiedOption := &QuestionOption{BaseObject: BaseObject{ID: uuid.New()}, TextOption: option.TextOption}
+			copied.Options = append(copied.Options, copiedOption)
+
+			if option.ID == question.AnswerID {
+				copied.AnswerID = copiedOption.ID
+			}
+		}
+
+		result[index] = copied
+	}
+
+	return result
+}
+
+func copyPools(pools []*QuestionPool) []*QuestionPool {
+	result := make([]*QuestionPool, len(pools))
+	for index, pool := range pools {
+		result[index] = &QuestionPool{Category: pool.Category, DrawCount: pool.DrawCount}
+	}
+
+	return result
+}
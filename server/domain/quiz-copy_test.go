@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestQuiz_Copy_GivesEverythingNewIDs(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	answerID := uuid.MustParse("c7ff1cdf-72d3-4ea9-ae48-e1c1d61f8bc8")
+	creatorID := uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58")
+	organizationID := uuid.MustParse("bd787fed-8a2e-40d3-abf6-6b90fa89f862")
+
+	quiz := &Quiz{
+		BaseObject:     BaseObject{ID: uuid.MustParse("788f12a9-51e8-4c87-9b0c-06bcc9f0691b")},
+		Name:           "Quiz",
+		Public:         true,
+		Template:       true,
+		OrganizationID: &organizationID,
+		MultipleChoiceQuestions: []*MultipleChoiceQuestion{
+			{
+				BaseQuestion: BaseQuestion{BaseObject: BaseObject{ID: uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8")}, Title: "What is 2+2", Category: "Math"},
+				AnswerID:     answerID,
+				Options: []*QuestionOption{
+					{BaseObject: BaseObject{ID: uuid.MustParse("a28ca8c6-63d9-45a2-b990-9b41e306f156")}, TextOption: "3"},
+					{BaseObject: BaseObject{ID: answerID}, TextOption: "4"},
+				},
+			},
+		},
+		QuestionPools: []*QuestionPool{{BaseObject: BaseObject{ID: uuid.MustParse("f6d2fa67-c12d-4096-958b-18206fbf3538")}, Category: "Math", DrawCount: 1}},
+	}
+
+	// Act
+	result := quiz.Copy(creatorID)
+
+	// Assert
+	assert.Equal(t, uuid.Nil, result.ID)
+	assert.Equal(t, "Quiz", result.Name)
+	assert.Equal(t, creatorID, result.CreatorID)
+	assert.Nil(t, result.OrganizationID)
+	assert.Nil(t, result.ForkedFromID)
+	assert.False(t, result.Public)
+	assert.False(t, result.Template)
+
+	if assert.Len(t, result.MultipleChoiceQuestions, 1) {
+		question := result.MultipleChoiceQuestions[0]
+		assert.Equal(t, uuid.Nil, question.ID)
+		assert.Equal(t, "What is 2+2", question.Title)
+		assert.NotEqual(t, answerID, question.AnswerID)
+		assert.Equal(t, question.Options[1].ID, question.AnswerID)
+		assert.Equal(t, "4", question.Options[1].TextOption)
+	}
+
+	if assert.Len(t, result.QuestionPools, 1) {
+		assert.Equal(t, uuid.Nil, result.QuestionPools[0].ID)
+		assert.Equal(t, uint(1), result.QuestionPools[0].DrawCount)
+	}
+}
+
+func TestQuiz_Fork_RefersToSource(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quiz := &Quiz{BaseObject: BaseObject{ID: uuid.MustParse("788f12a9-51e8-4c87-9b0c-06bcc9f0691b")}, Name: "Quiz"}
+
+	// Act
+	result := quiz.Fork(uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"))
+
+	// Assert
+	if assert.NotNil(t, result.ForkedFromID) {
+		assert.Equal(t, quiz.ID, *result.ForkedFromID)
+	}
+}
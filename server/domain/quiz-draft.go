@@ -0,0 +1,23 @@
+package domain
+
+import "github.com/google/uuid"
+
+// QuizDraft holds changes to a quiz that are not published yet, the content doesn't have to be
+// valid until it's published. A quiz has at most one draft.
+type QuizDraft struct {
+	BaseObject
+
+	QuizID uuid.UUID `json:"quizID" gorm:"uniqueIndex" example:"00000000-0000-0000-0000-000000000000"`
+	Quiz   *Quiz     `json:"-" gorm:"foreignKey:QuizID"`
+
+	UpdatedByID uuid.UUID `json:"updatedByID" example:"00000000-0000-0000-0000-000000000000"` // desc: The creator that saved the draft last
+	Content     []byte    `json:"-"`                                                          // desc: The quiz as it was sent, in JSON
+
+	// Version continues from the version of the quiz and goes up with every save, edits of single
+	// questions have to send it in If-Match
+	Version uint `json:"version" example:"3"`
+
+	// QuizVersion is the version of the quiz the draft was started from, it can't be published once
+	// the quiz has moved on
+	QuizVersion uint `json:"quizVersion" example:"2"`
+}
@@ -0,0 +1,217 @@
+package domain
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"sort"
+)
+
+const (
+	maxQuestions = 100
+	minOptions   = 2
+	maxOptions   = 4
+)
+
+// Question returns the multiple choice question of this quiz with the ID
+func (q *Quiz) Question(id uuid.UUID) (*MultipleChoiceQuestion, bool) {
+	for _, question := range q.MultipleChoiceQuestions {
+		if question.ID == id {
+			return question, true
+		}
+	}
+
+	return nil, false
+}
+
+// AddQuestion inserts the question at its order, the questions from that point on move down
+func (q *Quiz) AddQuestion(question *MultipleChoiceQuestion) error {
+	if len(q.MultipleChoiceQuestions) >= maxQuestions {
+		return errors.New("quiz has too many questions")
+	}
+
+	if question.ID == uuid.Nil {
+		question.ID = uuid.New()
+	}
+
+	question.QuizID = q.ID
+	q.MultipleChoiceQuestions = insertQuestion(q.sortedQuestions(), question, question.Order)
+	q.renumberQuestions()
+
+	return nil
+}
+
+// MoveQuestion gives the question a new position, the other questions shift to make room
+func (q *Quiz) MoveQuestion(id uuid.UUID, order uint) error {
+	question, ok := q.Question(id)
+	if !ok {
+		return errors.New("question not found")
+	}
+
+	var others []*MultipleChoiceQuestion
+	for _, other := range q.sortedQuestions() {
+		if other.ID != id {
+			others = append(others, other)
+		}
+	}
+
+	q.MultipleChoiceQuestions = insertQuestion(others, question, order)
+	q.renumberQuestions()
+
+	return nil
+}
+
+// RemoveQuestion deletes the question from the quiz, a quiz always keeps at least one question
+func (q *Quiz) RemoveQuestion(id uuid.UUID) error {
+	if _, ok := q.Question(id); !ok {
+		return errors.New("question not found")
+	}
+
+	if len(q.MultipleChoiceQuestions) == 1 {
+		return errors.New("quiz must have at least one question")
+	}
+
+	var result []*MultipleChoiceQuestion
+	for _, question := range q.sortedQuestions() {
+		if question.ID != id {
+			result = append(result, question)
+		}
+	}
+
+	q.MultipleChoiceQuestions = result
+	q.renumberQuestions()
+
+	return nil
+}
+
+// ReorderQuestions puts the questions in the order of the IDs, every question has to be in it once
+func (q *Quiz) ReorderQuestions(ids []uuid.UUID) error {
+	if len(ids) != len(q.MultipleChoiceQuestions) {
+		return errors.New("every question must be ordered")
+	}
+
+	seen := map[uuid.UUID]bool{}
+	result := make([]*MultipleChoiceQuestion, len(ids))
+
+	for index, id := range ids {
+		question, ok := q.Question(id)
+		if !ok || seen[id] {
+			return errors.New("every question must be ordered once")
+		}
+
+		seen[id] = true
+		result[index] = question
+	}
+
+	q.MultipleChoiceQuestions = result
+	q.renumberQuestions()
+
+	return nil
+}
+
+// sortedQuestions returns the questions by their current order
+func (q *Quiz) sortedQuestions() []*MultipleChoiceQuestion {
+	result := make([]*MultipleChoiceQuestion, len(q.MultipleChoiceQuestions))
+	copy(result, q.MultipleChoiceQuestions)
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Order < result[j].Order
+	})
+
+	return result
+}
+
+// renumberQuestions makes the order of the questions count up from 0 without gaps
+func (q *Quiz) renumberQuestions() {
+	for index, question := range q.MultipleChoiceQuestions {
+		question.Order = uint(index)
+	}
+}
+
+// insertQuestion puts the question at the position, positions past the end append it
+func insertQuestion(questions []*MultipleChoiceQuestion, question *MultipleChoiceQuestion, position uint) []*MultipleChoiceQuestion {
+	if position > uint(len(questions)) {
+		position = uint(len(questions))
+	}
+
+	result := make([]*MultipleChoiceQuestion, 0, len(questions)+1)
+	result = append(result, questions[:position]...)
+	result = append(result, question)
+
+	return append(result, questions[position:]...)
+}
+
+// Option returns the option of this question with the ID
+func (m *MultipleChoiceQuestion) Option(id uuid.UUID) (*QuestionOption, bool) {
+	for _, option := range m.Options {
+		if option.ID == id {
+			return option, true
+		}
+	}
+
+	return nil, false
+}
+
+// AddOption adds the option to the end of the question, if it's the answer it replaces the current one
+func (m *MultipleChoiceQuestion) AddOption(option *QuestionOption, answer bool) error {
+	if len(m.Options) >= maxOptions {
+		return errors.New("question has too many options")
+	}
+
+	if option.ID == uuid.Nil {
+		option.ID = uuid.New()
+	}
+
+	option.MultipleChoiceQuestionID = &m.ID
+	m.Options = append(m.Options, option)
+
+	if answer {
+		m.AnswerID = option.ID
+	}
+
+	return nil
+}
+
+// UpdateOption changes the text of the option, a question can't be left without an answer
+func (m *MultipleChoiceQuestion) UpdateOption(id uuid.UUID, text string, answer bool) error {
+	option, ok := m.Option(id)
+	if !ok {
+		return errors.New("option not found")
+	}
+
+	if !answer && m.AnswerID == id {
+		return errors.New("question must have an answer")
+	}
+
+	option.TextOption = text
+	if answer {
+		m.AnswerID = id
+	}
+
+	return nil
+}
+
+// RemoveOption deletes the option, the answer can't be removed and a question keeps at least two options
+func (m *MultipleChoiceQuestion) RemoveOption(id uuid.UUID) error {
+	if _, ok := m.Option(id); !ok {
+		return errors.New("option not found")
+	}
+
+	if m.AnswerID == id {
+		return errors.New("question must have an answer")
+	}
+
+	if len(m.Options) <= minOptions {
+		return errors.New("question must have at least two options")
+	}
+
+	var result []*QuestionOption
+	for _, option := range m.Options {
+		if option.ID != id {
+			result = append(result, option)
+		}
+	}
+
+	m.Options = result
+
+	return nil
+}
@@ -0,0 +1,245 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func editableQuiz() *Quiz {
+	return &Quiz{
+		MultipleChoiceQuestions: []*MultipleChoiceQuestion{
+			{BaseQuestion: BaseQuestion{BaseObject: BaseObject{ID: uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8")}, Title: "c", Order: 2}},
+			{BaseQuestion: BaseQuestion{BaseObject: BaseObject{ID: uuid.MustParse("f6d2fa67-c12d-4096-958b-18206fbf3538")}, Title: "a", Order: 0}},
+			{BaseQuestion: BaseQuestion{BaseObject: BaseObject{ID: uuid.MustParse("0e3a6de0-6a3e-4b8e-a2f0-6b0bd2ad6d11")}, Title: "b", Order: 1}},
+		},
+	}
+}
+
+func questionTitles(quiz *Quiz) []string {
+	var result []string
+	for _, question := range quiz.MultipleChoiceQuestions {
+		result = append(result, question.Title)
+	}
+
+	return result
+}
+
+func questionOrders(quiz *Quiz) []uint {
+	var result []uint
+	for _, question := range quiz.MultipleChoiceQuestions {
+		result = append(result, question.Order)
+	}
+
+	return result
+}
+
+func TestQuiz_AddQuestion_InsertsAtOrder(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		order    uint
+		expected []string
+	}{
+		"start": {
+			order:    0,
+			expected: []string{"new", "a", "b", "c"},
+		},
+		"middle": {
+			order:    2,
+			expected: []string{"a", "b", "new", "c"},
+		},
+		"past the end": {
+			order:    20,
+			expected: []string{"a", "b", "c", "new"},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			quiz := editableQuiz()
+			question := &MultipleChoiceQuestion{BaseQuestion: BaseQuestion{Title: "new", Order: testData.order}}
+
+			// Act
+			err := quiz.AddQuestion(question)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.NotEqual(t, uuid.Nil, question.ID)
+			assert.Equal(t, testData.expected, questionTitles(quiz))
+			assert.Equal(t, []uint{0, 1, 2, 3}, questionOrders(quiz))
+		})
+	}
+}
+
+func TestQuiz_MoveQuestion_RenumbersQuestions(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quiz := editableQuiz()
+
+	// Act
+	err := quiz.MoveQuestion(uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"), 0)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c", "a", "b"}, questionTitles(quiz))
+	assert.Equal(t, []uint{0, 1, 2}, questionOrders(quiz))
+}
+
+func TestQuiz_RemoveQuestion_RenumbersQuestions(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quiz := editableQuiz()
+
+	// Act
+	err := quiz.RemoveQuestion(uuid.MustParse("f6d2fa67-c12d-4096-958b-18206fbf3538"))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b", "c"}, questionTitles(quiz))
+	assert.Equal(t, []uint{0, 1}, questionOrders(quiz))
+}
+
+func TestQuiz_RemoveQuestion_KeepsLastQuestion(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quiz := editableQuiz()
+	quiz.MultipleChoiceQuestions = quiz.MultipleChoiceQuestions[:1]
+
+	// Act
+	err := quiz.RemoveQuestion(uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"))
+
+	// Assert
+	assert.ErrorContains(t, err, "at least one question")
+	assert.Len(t, quiz.MultipleChoiceQuestions, 1)
+}
+
+func TestQuiz_ReorderQuestions_ReturnsErrorOnIncompleteOrder(t *testing.T) {
+	t.Parallel()
+	tests := map[string][]uuid.UUID{
+		"missing": {
+			uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"),
+			uuid.MustParse("f6d2fa67-c12d-4096-958b-18206fbf3538"),
+		},
+		"duplicate": {
+			uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"),
+			uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"),
+			uuid.MustParse("f6d2fa67-c12d-4096-958b-18206fbf3538"),
+		},
+		"unknown": {
+			uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"),
+			uuid.MustParse("f6d2fa67-c12d-4096-958b-18206fbf3538"),
+			uuid.MustParse("a28ca8c6-63d9-45a2-b990-9b41e306f156"),
+		},
+	}
+
+	for name, ids := range tests {
+		ids := ids
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			quiz := editableQuiz()
+
+			// Act
+			err := quiz.ReorderQuestions(ids)
+
+			// Assert
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestQuiz_ReorderQuestions_OrdersQuestions(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quiz := editableQuiz()
+
+	// Act
+	err := quiz.ReorderQuestions([]uuid.UUID{
+		uuid.MustParse("0e3a6de0-6a3e-4b8e-a2f0-6b0bd2ad6d11"),
+		uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"),
+		uuid.MustParse("f6d2fa67-c12d-4096-958b-18206fbf3538"),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b", "c", "a"}, questionTitles(quiz))
+	assert.Equal(t, []uint{0, 1, 2}, questionOrders(quiz))
+}
+
+func TestMultipleChoiceQuestion_Options_ReturnsErrorOnInvalidChange(t *testing.T) {
+	t.Parallel()
+	answerID := uuid.MustParse("c7ff1cdf-72d3-4ea9-ae48-e1c1d61f8bc8")
+	otherID := uuid.MustParse("bd787fed-8a2e-40d3-abf6-6b90fa89f862")
+
+	question := func(options int) *MultipleChoiceQuestion {
+		result := &MultipleChoiceQuestion{AnswerID: answerID, Options: []*QuestionOption{{BaseObject: BaseObject{ID: answerID}}, {BaseObject: BaseObject{ID: otherID}}}}
+		for len(result.Options) < options {
+			result.Options = append(result.Options, &QuestionOption{BaseObject: BaseObject{ID: uuid.New()}})
+		}
+
+		return result
+	}
+
+	tests := map[string]struct {
+		change   func(question *MultipleChoiceQuestion) error
+		expected string
+	}{
+		"add to full question": {
+			change:   func(question *MultipleChoiceQuestion) error { return question.AddOption(&QuestionOption{}, false) },
+			expected: "too many options",
+		},
+		"unmark answer": {
+			change:   func(question *MultipleChoiceQuestion) error { return question.UpdateOption(answerID, "abc", false) },
+			expected: "must have an answer",
+		},
+		"remove answer": {
+			change:   func(question *MultipleChoiceQuestion) error { return question.RemoveOption(answerID) },
+			expected: "must have an answer",
+		},
+		"remove below minimum": {
+			change: func(question *MultipleChoiceQuestion) error {
+				question.Options = question.Options[:2]
+				return question.RemoveOption(otherID)
+			},
+			expected: "at least two options",
+		},
+		"unknown option": {
+			change:   func(question *MultipleChoiceQuestion) error { return question.RemoveOption(uuid.New()) },
+			expected: "option not found",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			mcQuestion := question(4)
+
+			// Act
+			err := testData.change(mcQuestion)
+
+			// Assert
+			assert.ErrorContains(t, err, testData.expected)
+		})
+	}
+}
+
+func TestMultipleChoiceQuestion_UpdateOption_MovesAnswer(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	answerID := uuid.MustParse("c7ff1cdf-72d3-4ea9-ae48-e1c1d61f8bc8")
+	otherID := uuid.MustParse("bd787fed-8a2e-40d3-abf6-6b90fa89f862")
+	question := &MultipleChoiceQuestion{AnswerID: answerID, Options: []*QuestionOption{{BaseObject: BaseObject{ID: answerID}}, {BaseObject: BaseObject{ID: otherID}}}}
+
+	// Act
+	err := question.UpdateOption(otherID, "Rome", true)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, otherID, question.AnswerID)
+	assert.Equal(t, "Rome", question.Options[1].TextOption)
+}
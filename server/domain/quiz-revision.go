@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"reflect"
+)
+
+// QuizRevision is an immutable copy of a quiz, every time a quiz is saved a new revision is made
+// so games keep pointing at the questions they were played with
+type QuizRevision struct {
+	BaseObject
+
+	QuizID uuid.UUID `json:"quizID" gorm:"uniqueIndex:idx_quiz_revision" example:"00000000-0000-0000-0000-000000000000"`
+	Quiz   *Quiz     `json:"-" gorm:"foreignKey:QuizID"`
+
+	Number   uint          `json:"number" gorm:"uniqueIndex:idx_quiz_revision" example:"3"` // desc: Counts up from 1 for every quiz
+	Snapshot *QuizSnapshot `json:"snapshot" gorm:"serializer:json"`                         // desc: The contents of the quiz at the time
+}
+
+// QuizSnapshot contains the parts of a quiz that are versioned
+type QuizSnapshot struct {
+	Name                    string                    `json:"name"`
+	Description             string                    `json:"description"`
+	MultipleChoiceQuestions []*MultipleChoiceQuestion `json:"multipleChoiceQuestions"`
+	QuestionPools           []*QuestionPool           `json:"questionPools,omitempty"`
+}
+
+// Snapshot copies the versioned parts of the quiz, question and option IDs stay the same so
+// answers keep pointing at them
+func (q *Quiz) Snapshot() *QuizSnapshot {
+	return &QuizSnapshot{
+		Name:                    q.Name,
+		Description:             q.Description,
+		MultipleChoiceQuestions: q.MultipleChoiceQuestions,
+		QuestionPools:           q.QuestionPools,
+	}
+}
+
+// Apply replaces the versioned parts of the quiz with the ones of the revision
+func (r *QuizRevision) Apply(quiz *Quiz) {
+	quiz.Name = r.Snapshot.Name
+	quiz.Description = r.Snapshot.Description
+	quiz.MultipleChoiceQuestions = r.Snapshot.MultipleChoiceQuestions
+	quiz.QuestionPools = r.Snapshot.QuestionPools
+}
+
+// Restore returns an update of the quiz with the contents of the revision, the questions, options
+// and pools get new IDs since the ones of the revision belong to it
+func (r *QuizRevision) Restore(quiz *Quiz) *Quiz {
+	return &Quiz{
+		BaseObject:              BaseObject{ID: quiz.ID},
+		Name:                    r.Snapshot.Name,
+		Description:             r.Snapshot.Description,
+		CreatorID:               quiz.CreatorID,
+		OrganizationID:          quiz.OrganizationID,
+		MaxConcurrentGames:      quiz.MaxConcurrentGames,
+		Public:                  quiz.Public,
+		Template:                quiz.Template,
+		ForkedFromID:            quiz.ForkedFromID,
+		Tags:                    copyTags(quiz.Tags),
+		Language:                quiz.Language,
+		MultipleChoiceQuestions: copyQuestions(r.Snapshot.MultipleChoiceQuestions),
+		QuestionPools:           copyPools(r.Snapshot.QuestionPools),
+	}
+}
+
+// RevisionDiff lists what changed between two revisions, questions are matched by their ID
+type RevisionDiff struct {
+	From uint `json:"from" example:"1"`
+	To   uint `json:"to" example:"2"`
+
+	Fields           []string        `json:"fields" example:"name"`                   // desc: The quiz fields that changed, like the name or the pools
+	AddedQuestions   []string        `json:"addedQuestions" example:"What is 5+5?"`   // desc: Titles of the questions that are new
+	RemovedQuestions []string        `json:"removedQuestions" example:"What is 4+4?"` // desc: Titles of the questions that are gone
+	ChangedQuestions []*QuestionDiff `json:"changedQuestions"`                        // desc: The questions that are in both, but differ
+}
+
+// QuestionDiff lists the fields of a question that changed between two revisions
+type QuestionDiff struct {
+	Title  string   `json:"title" example:"What is 5+5?"` // desc: The title in the later revision
+	Fields []string `json:"fields" example:"options"`
+}
+
+// Diff compares the revision to a later one
+func (r *QuizRevision) Diff(other *QuizRevision) *RevisionDiff {
+	result := &RevisionDiff{
+		From:             r.Number,
+		To:               other.Number,
+		Fields:           []string{},
+		AddedQuestions:   []string{},
+		RemovedQuestions: []string{},
+		ChangedQuestions: []*QuestionDiff{},
+	}
+
+	if r.Snapshot.Name != other.Snapshot.Name {
+		result.Fields = append(result.Fields, "name")
+	}
+
+	if r.Snapshot.Description != other.Snapshot.Description {
+		result.Fields = append(result.Fields, "description")
+	}
+
+	if !reflect.DeepEqual(poolSettings(r.Snapshot.QuestionPools), poolSettings(other.Snapshot.QuestionPools)) {
+		result.Fields = append(result.Fields, "questionPools")
+	}
+
+	before := map[uuid.UUID]*MultipleChoiceQuestion{}
+	for _, question := range r.Snapshot.MultipleChoiceQuestions {
+		before[question.ID] = question
+	}
+
+	after := map[uuid.UUID]bool{}
+	for _, question := range other.Snapshot.MultipleChoiceQuestions {
+		after[question.ID] = true
+
+		previous, ok := before[question.ID]
+		if !ok {
+			result.AddedQuestions = append(result.AddedQuestions, question.Title)
+			continue
+		}
+
+		if fields := diffQuestions(previous, question); len(fields) > 0 {
+			result.ChangedQuestions = append(result.ChangedQuestions, &QuestionDiff{Title: question.Title, Fields: fields})
+		}
+	}
+
+	for _, question := range r.Snapshot.MultipleChoiceQuestions {
+		if !after[question.ID] {
+			result.RemovedQuestions = append(result.RemovedQuestions, question.Title)
+		}
+	}
+
+	return result
+}
+
+// diffQuestions compares the contents of two questions
+func diffQuestions(before *MultipleChoiceQuestion, after *MultipleChoiceQuestion) []string {
+	var result []string
+
+	if before.Title != after.Title {
+		result = append(result, "title")
+	}
+
+	if before.Description != after.Description {
+		result = append(result, "description")
+	}
+
+	if before.DurationInSeconds != after.DurationInSeconds {
+		result = append(result, "durationInSeconds")
+	}
+
+	if before.Category != after.Category {
+		result = append(result, "category")
+	}
+
+	if before.Order != after.Order {
+		result = append(result, "order")
+	}
+
+	beforeOptions, beforeAnswer := optionTexts(before)
+	afterOptions, afterAnswer := optionTexts(after)
+
+	if !reflect.DeepEqual(beforeOptions, afterOptions) {
+		result = append(result, "options")
+	}
+
+	if beforeAnswer != afterAnswer {
+		result = append(result, "answer")
+	}
+
+	return result
+}
+
+// optionTexts returns the text of every option and the text of the answer
+func optionTexts(question *MultipleChoiceQuestion) ([]string, string) {
+	var result []string
+	var answer string
+
+	for _, option := range question.Options {
+		result = append(result, option.TextOption)
+
+		if option.ID == question.AnswerID {
+			answer = option.TextOption
+		}
+	}
+
+	return result, answer
+}
+
+// poolSettings leaves out the IDs of pools, so only actual changes are compared
+func poolSettings(pools []*QuestionPool) map[string]uint {
+	result := map[string]uint{}
+	for _, pool := range pools {
+		result[pool.Category] = pool.DrawCount
+	}
+
+	return result
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func revisionQuestion(title string, answer string, options ...string) *MultipleChoiceQuestion {
+	result := &MultipleChoiceQuestion{BaseQuestion: BaseQuestion{BaseObject: BaseObject{ID: uuid.New()}, Title: title, DurationInSeconds: 20}}
+	for _, text := range options {
+		option := &QuestionOption{BaseObject: BaseObject{ID: uuid.New()}, TextOption: text}
+		result.Options = append(result.Options, option)
+
+		if text == answer {
+			result.AnswerID = option.ID
+		}
+	}
+
+	return result
+}
+
+func TestQuizRevision_Apply_ReplacesQuestions(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	revision := &QuizRevision{Snapshot: &QuizSnapshot{
+		Name:                    "Old",
+		MultipleChoiceQuestions: []*MultipleChoiceQuestion{revisionQuestion("What is 2+2?", "4", "3", "4")},
+	}}
+	quiz := &Quiz{
+		Name:                    "New",
+		MaxConcurrentGames:      3,
+		MultipleChoiceQuestions: []*MultipleChoiceQuestion{revisionQuestion("What is 3+3?", "6", "5", "6")},
+	}
+
+	// Act
+	revision.Apply(quiz)
+
+	// Assert
+	assert.Equal(t, "Old", quiz.Name)
+	assert.Equal(t, uint(3), quiz.MaxConcurrentGames)
+	assert.Equal(t, revision.Snapshot.MultipleChoiceQuestions, quiz.MultipleChoiceQuestions)
+}
+
+func TestQuizRevision_Restore_ReturnsCopyWithNewIDs(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	question := revisionQuestion("What is 2+2?", "4", "3", "4")
+	revision := &QuizRevision{Snapshot: &QuizSnapshot{
+		Name:                    "Old",
+		MultipleChoiceQuestions: []*MultipleChoiceQuestion{question},
+		QuestionPools:           []*QuestionPool{{BaseObject: BaseObject{ID: uuid.New()}, DrawCount: 1}},
+	}}
+	quiz := &Quiz{
+		BaseObject: BaseObject{ID: uuid.MustParse("788f12a9-51e8-4c87-9b0c-06bcc9f0691b")},
+		Name:       "New",
+		CreatorID:  uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"),
+	}
+
+	// Act
+	result := revision.Restore(quiz)
+
+	// Assert
+	assert.Equal(t, quiz.ID, result.ID)
+	assert.Equal(t, quiz.CreatorID, result.CreatorID)
+	assert.Equal(t, "Old", result.Name)
+
+	if assert.Len(t, result.MultipleChoiceQuestions, 1) {
+		restored := result.MultipleChoiceQuestions[0]
+		assert.Equal(t, uuid.Nil, restored.ID)
+		assert.Equal(t, question.Title, restored.Title)
+		assert.NotEqual(t, question.AnswerID, restored.AnswerID)
+		assert.Equal(t, restored.Options[1].ID, restored.AnswerID)
+	}
+
+	if assert.Len(t, result.QuestionPools, 1) {
+		assert.Equal(t, uuid.Nil, result.QuestionPools[0].ID)
+		assert.Equal(t, uint(1), result.QuestionPools[0].DrawCount)
+	}
+}
+
+func TestQuizRevision_Diff_ReturnsChanges(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	kept := revisionQuestion("What is 2+2?", "4", "3", "4")
+	original := revisionQuestion("What is 3+3?", "6", "5", "6")
+
+	from := &QuizRevision{Number: 1, Snapshot: &QuizSnapshot{
+		Name: "Quiz",
+		MultipleChoiceQuestions: []*MultipleChoiceQuestion{
+			kept,
+			original,
+			revisionQuestion("What is 4+4?", "8", "7", "8"),
+		},
+	}}
+
+	changed := revisionQuestion("What is 3 + 3?", "5", "5", "6")
+	changed.ID = original.ID
+	changed.DurationInSeconds = 30
+
+	to := &QuizRevision{Number: 2, Snapshot: &QuizSnapshot{
+		Name:        "Quiz",
+		Description: "Now with a description",
+		MultipleChoiceQuestions: []*MultipleChoiceQuestion{
+			kept,
+			changed,
+			revisionQuestion("What is 5+5?", "10", "9", "10"),
+		},
+	}}
+
+	// Act
+	result := from.Diff(to)
+
+	// Assert
+	expected := &RevisionDiff{
+		From:             1,
+		To:               2,
+		Fields:           []string{"description"},
+		AddedQuestions:   []string{"What is 5+5?"},
+		RemovedQuestions: []string{"What is 4+4?"},
+		ChangedQuestions: []*QuestionDiff{{Title: "What is 3 + 3?", Fields: []string{"title", "durationInSeconds", "answer"}}},
+	}
+
+	assert.Equal(t, expected, result)
+}
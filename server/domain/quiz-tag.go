@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"strings"
+)
+
+// QuizTag is a free-form label that helps others find a public quiz
+type QuizTag struct {
+	QuizID uuid.UUID `json:"-" gorm:"primaryKey"`
+	Name   string    `json:"name" gorm:"primaryKey" example:"history"`
+}
+
+// NewQuizTags turns the names into tags, names are compared case-insensitively so duplicates are
+// left out
+func NewQuizTags(names []string) []*QuizTag {
+	seen := map[string]bool{}
+
+	var result []*QuizTag
+	for _, name := range names {
+		name = NormalizeTag(name)
+		if name == "" || seen[name] {
+			continue
+		}
+
+		seen[name] = true
+		result = append(result, &QuizTag{Name: name})
+	}
+
+	return result
+}
+
+// NormalizeTag returns the tag the way it is stored
+func NormalizeTag(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// TagNames returns the names of the quiz's tags
+func (q *Quiz) TagNames() []string {
+	result := make([]string, len(q.Tags))
+	for index, tag := range q.Tags {
+		result[index] = tag.Name
+	}
+
+	return result
+}
+
+// CountGamesPlayed returns the amount of games that were started with this quiz
+func (q *Quiz) CountGamesPlayed() uint {
+	var result uint
+	for _, game := range q.Games {
+		if !game.StartTime.IsZero() {
+			result++
+		}
+	}
+
+	return result
+}
+
+func copyTags(tags []*QuizTag) []*QuizTag {
+	result := make([]*QuizTag, len(tags))
+	for index, tag := range tags {
+		result[index] = &QuizTag{Name: tag.Name}
+	}
+
+	return result
+}
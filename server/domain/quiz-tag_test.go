@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewQuizTags_NormalizesNames(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	names := []string{"History", " history ", "", "Kids"}
+
+	// Act
+	result := NewQuizTags(names)
+
+	// Assert
+	assert.Equal(t, []*QuizTag{{Name: "history"}, {Name: "kids"}}, result)
+}
+
+func TestQuiz_CountGamesPlayed_CountsStartedGames(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quiz := &Quiz{Games: []*Game{
+		{},
+		{StartTime: time.Now()},
+		{StartTime: time.Now(), FinishTime: time.Now()},
+	}}
+
+	// Act
+	result := quiz.CountGamesPlayed()
+
+	// Assert
+	assert.Equal(t, uint(2), result)
+}
@@ -15,50 +15,93 @@ type Quiz struct {
 	Creator   *Creator  `json:"-" gorm:"foreignKey:CreatorID"`
 
 	MultipleChoiceQuestions []*MultipleChoiceQuestion `json:"multipleChoiceQuestions,omitempty" gorm:"foreignKey:QuizID;constraint:OnDelete:CASCADE"`
+	QuestionPools           []*QuestionPool           `json:"questionPools,omitempty" gorm:"foreignKey:QuizID;constraint:OnDelete:CASCADE"` // desc: If any, every game draws its questions from these pools
 
-	Games []*Game `json:"games" gorm:"foreignKey:QuizID;constraint:OnDelete:CASCADE"`
-}
+	Games              []*Game `json:"games" gorm:"foreignKey:QuizID;constraint:OnDelete:CASCADE"`
+	MaxConcurrentGames uint    `json:"maxConcurrentGames" example:"1" gorm:"default:1"` // desc: The amount of live games that may be in progress at the same time
 
-func (q *Quiz) CountQuestions() int {
-	return len(q.MultipleChoiceQuestions)
-}
+	Version uint `json:"version" example:"4" gorm:"default:1"` // desc: Goes up with every change, send it along as If-Match when changing single questions
 
-// GetQuestion retrieves a question based on the Order of a question in the list, will return
-// uuid.Nil if not found
-func (q *Quiz) GetQuestion(order uint) (Question, bool) {
-	for _, question := range q.MultipleChoiceQuestions {
-		if question.Order == order {
-			return question, true
-		}
-	}
+	Public   bool `json:"public" example:"false"`   // desc: Anyone can find and fork public quizzes
+	Template bool `json:"template" example:"false"` // desc: Templates show up in the template gallery
+
+	Tags     []*QuizTag `json:"tags,omitempty" gorm:"foreignKey:QuizID;constraint:OnDelete:CASCADE"` // desc: Help others find the quiz in the library
+	Language string     `json:"language,omitempty" example:"en"`                                     // desc: The language the quiz is written in
 
-	return nil, false
+	ForkedFromID *uuid.UUID `json:"forkedFromID,omitempty" example:"00000000-0000-0000-0000-000000000000"` // desc: The quiz this one was forked from, if any
+	ForkedFrom   *Quiz      `json:"-" gorm:"foreignKey:ForkedFromID;constraint:OnDelete:SET NULL"`
+
+	Revisions []*QuizRevision `json:"-" gorm:"foreignKey:QuizID;constraint:OnDelete:CASCADE"`
+	Draft     *QuizDraft      `json:"-" gorm:"foreignKey:QuizID;constraint:OnDelete:CASCADE"`
+
+	Collaborators []*Collaborator `json:"collaborators,omitempty" gorm:"foreignKey:QuizID;constraint:OnDelete:CASCADE"`
+
+	OrganizationID *uuid.UUID    `json:"organizationID,omitempty" example:"00000000-0000-0000-0000-000000000000"` // desc: Quizzes in an organization are shared with its members
+	Organization   *Organization `json:"-" gorm:"foreignKey:OrganizationID"`
 }
 
-func (q *Quiz) GetNextQuestion(current uuid.UUID) (Question, bool) {
-	// If no current is found, take the first one
-	if current == uuid.Nil {
-		return q.GetQuestion(0)
+// RoleOf returns the role the creator has on this quiz, the creator of the quiz is always the owner.
+// If the creator is both a collaborator and a member of the quiz's organization, the strongest role wins.
+func (q *Quiz) RoleOf(creatorID uuid.UUID) (Role, bool) {
+	if q.CreatorID == creatorID {
+		return RoleOwner, true
 	}
 
-	var currentQuestion uint
-	for _, question := range q.MultipleChoiceQuestions {
-		if question.ID == current {
-			currentQuestion = question.Order
+	var result Role
+	for _, collaborator := range q.Collaborators {
+		if collaborator.CreatorID == creatorID {
+			result = collaborator.Role
 			break
 		}
 	}
 
-	return q.GetQuestion(currentQuestion + 1)
+	if q.Organization != nil {
+		if orgRole, ok := q.Organization.RoleOf(creatorID); ok && orgRole.QuizRole().Outranks(result) {
+			result = orgRole.QuizRole()
+		}
+	}
+
+	return result, result != ""
 }
 
-// HasGameInProgress can be used to verify whether a new game can be started
-func (q *Quiz) HasGameInProgress() bool {
+// Allows returns whether the creator may perform actions that require the permission
+func (q *Quiz) Allows(creatorID uuid.UUID, permission Permission) bool {
+	role, ok := q.RoleOf(creatorID)
+	if !ok {
+		return false
+	}
+
+	return role.Can(permission)
+}
+
+func (q *Quiz) CountQuestions() int {
+	return len(q.MultipleChoiceQuestions)
+}
+
+// CountGamesInProgress returns the amount of live games in progress, self-paced games run alongside
+// other games and are not counted
+func (q *Quiz) CountGamesInProgress() uint {
+	var result uint
 	for _, game := range q.Games {
-		if game.IsInProgress() {
-			return true
+		if game.IsInProgress() && !game.IsSelfPaced() {
+			result++
 		}
 	}
 
-	return false
+	return result
+}
+
+// CanStart returns whether the game may start without exceeding the max amount of concurrent
+// live games, self-paced games may always start
+func (q *Quiz) CanStart(game *Game) bool {
+	if game.IsSelfPaced() {
+		return true
+	}
+
+	limit := q.MaxConcurrentGames
+	if limit == 0 {
+		limit = 1
+	}
+
+	return q.CountGamesInProgress() < limit
 }
@@ -7,93 +7,59 @@ import (
 	"time"
 )
 
-func TestQuiz_HasGameInProgress_ReturnsTrueOnInProgress(t *testing.T) {
+func TestQuiz_CountGamesInProgress_ReturnsExpectedValue(t *testing.T) {
 	t.Parallel()
 	// Arrange
-	quiz := &Quiz{Games: []*Game{{}, {StartTime: time.Now()}}}
+	quiz := &Quiz{Games: []*Game{
+		{},
+		{StartTime: time.Now()},
+		{StartTime: time.Now()},
+		{StartTime: time.Now(), FinishTime: time.Now()},
+	}}
 
 	// Act
-	result := quiz.HasGameInProgress()
+	result := quiz.CountGamesInProgress()
 
 	// Assert
-	assert.True(t, result)
+	assert.Equal(t, uint(2), result)
 }
 
-func TestQuiz_HasGameInProgress_ReturnsFalseOnNotInProgress(t *testing.T) {
-	t.Parallel()
-	// Arrange
-	quiz := &Quiz{Games: []*Game{{}, {}}}
-
-	// Act
-	result := quiz.HasGameInProgress()
-
-	// Assert
-	assert.False(t, result)
-}
-
-func TestQuiz_GetQuestion_ReturnsExpectedValue(t *testing.T) {
+func TestQuiz_CanStart_ReturnsExpectedValue(t *testing.T) {
 	t.Parallel()
 	tests := map[string]struct {
 		quiz     *Quiz
-		index    uint
-		expected Question
+		game     *Game
+		expected bool
 	}{
-		"none": {
-			quiz:     &Quiz{},
-			expected: nil,
-			index:    15,
+		"no games in progress": {
+			quiz:     &Quiz{Games: []*Game{{}}, MaxConcurrentGames: 1},
+			game:     &Game{},
+			expected: true,
 		},
-		"first": {
-			quiz: &Quiz{MultipleChoiceQuestions: []*MultipleChoiceQuestion{
-				{
-					BaseQuestion: BaseQuestion{
-						BaseObject: BaseObject{ID: uuid.MustParse("c5803f9a-a584-409a-9c06-7e66a37a959f")},
-						Title:      "First question!",
-						Order:      0,
-					},
-				},
-			}},
-			expected: MultipleChoiceQuestion{
-				BaseQuestion: BaseQuestion{
-					BaseObject: BaseObject{ID: uuid.MustParse("c5803f9a-a584-409a-9c06-7e66a37a959f")},
-					Title:      "First question!",
-					Order:      0,
-				},
-			},
-			index: 0,
+		"one at a time": {
+			quiz:     &Quiz{Games: []*Game{{StartTime: time.Now()}}, MaxConcurrentGames: 1},
+			game:     &Game{},
+			expected: false,
 		},
-		"second": {
-			quiz: &Quiz{MultipleChoiceQuestions: []*MultipleChoiceQuestion{
-				{
-					BaseQuestion: BaseQuestion{
-						BaseObject: BaseObject{ID: uuid.MustParse("41dbef92-9bdb-4714-8dd8-a6163ad382a7")},
-						Title:      "Second question!",
-						Order:      1,
-					},
-				},
-				{
-					BaseQuestion: BaseQuestion{
-						BaseObject: BaseObject{ID: uuid.MustParse("8e60fff6-94c6-4e8b-8b36-6c949bcaf15d")},
-						Title:      "First question!",
-						Order:      0,
-					},
-				},
-				{
-					BaseQuestion: BaseQuestion{
-						BaseObject: BaseObject{ID: uuid.MustParse("b582db7d-80bb-4441-a449-f1af7c1a6e94")},
-						Title:      "Third question!",
-						Order:      2,
-					},
-				},
-			}},
-			expected: MultipleChoiceQuestion{
-				BaseQuestion: BaseQuestion{
-					BaseObject: BaseObject{ID: uuid.MustParse("41dbef92-9bdb-4714-8dd8-a6163ad382a7")},
-					Title:      "Second question!",
-					Order:      1,
-				},
-			},
-			index: 1,
+		"unset limit allows one": {
+			quiz:     &Quiz{Games: []*Game{{StartTime: time.Now()}}},
+			game:     &Game{},
+			expected: false,
+		},
+		"below limit": {
+			quiz:     &Quiz{Games: []*Game{{StartTime: time.Now()}, {StartTime: time.Now()}}, MaxConcurrentGames: 3},
+			game:     &Game{},
+			expected: true,
+		},
+		"at limit": {
+			quiz:     &Quiz{Games: []*Game{{StartTime: time.Now()}, {StartTime: time.Now()}}, MaxConcurrentGames: 2},
+			game:     &Game{},
+			expected: false,
+		},
+		"self-paced": {
+			quiz:     &Quiz{Games: []*Game{{StartTime: time.Now()}}, MaxConcurrentGames: 1},
+			game:     &Game{Mode: GameModeSelfPaced},
+			expected: true,
 		},
 	}
 
@@ -102,121 +68,99 @@ func TestQuiz_GetQuestion_ReturnsExpectedValue(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 			// Act
-			result, ok := testData.quiz.GetQuestion(testData.index)
+			result := testData.quiz.CanStart(testData.game)
 
 			// Assert
-			assert.Equal(t, testData.expected != nil, ok)
-
-			if ok {
-				assert.Equal(t, testData.expected.GetBaseQuestion().Title, result.GetBaseQuestion().Title)
-			}
+			assert.Equal(t, testData.expected, result)
 		})
 	}
 }
 
-func TestQuiz_GetNextQuestion_ReturnsExpectedValue(t *testing.T) {
+func TestQuiz_CountQuestions_ReturnsExpectedCount(t *testing.T) {
 	t.Parallel()
-	tests := map[string]struct {
-		quiz            *Quiz
-		currentQuestion uuid.UUID
-		expected        Question
-	}{
-		"no current, no next": {
-			quiz:            &Quiz{},
-			expected:        nil,
-			currentQuestion: uuid.Nil,
-		},
-		"no current, next": {
-			quiz: &Quiz{
-				MultipleChoiceQuestions: []*MultipleChoiceQuestion{
-					{
-						BaseQuestion: BaseQuestion{
-							BaseObject: BaseObject{ID: uuid.MustParse("c5803f9a-a584-409a-9c06-7e66a37a959f")},
-							Title:      "First question!",
-							Order:      0,
-						},
-					},
-				},
-			},
-			expected: MultipleChoiceQuestion{
-				BaseQuestion: BaseQuestion{
-					BaseObject: BaseObject{ID: uuid.MustParse("c5803f9a-a584-409a-9c06-7e66a37a959f")},
-					Title:      "First question!",
-					Order:      0,
-				},
-			},
-			currentQuestion: uuid.Nil,
-		},
-		"current, no next": {
-			quiz: &Quiz{
-				MultipleChoiceQuestions: []*MultipleChoiceQuestion{
-					{
-						BaseQuestion: BaseQuestion{
-							BaseObject: BaseObject{ID: uuid.MustParse("c5803f9a-a584-409a-9c06-7e66a37a959f")},
-							Title:      "First question!",
-							Order:      0,
-						},
-					},
-				},
-			},
-			expected:        nil,
-			currentQuestion: uuid.MustParse("c5803f9a-a584-409a-9c06-7e66a37a959f"),
+	// Arrange
+	quiz := &Quiz{MultipleChoiceQuestions: []*MultipleChoiceQuestion{{}, {}}}
+
+	// Act
+	result := quiz.CountQuestions()
+
+	// Assert
+	assert.Equal(t, 2, result)
+}
+
+func TestQuiz_RoleOf_ReturnsExpectedRole(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	ownerID := uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58")
+	hostID := uuid.MustParse("e3274bf0-b154-4d37-a6fd-878d530025f9")
+	strangerID := uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b")
+	memberID := uuid.MustParse("5e0a4b44-73f4-4b8a-9f0e-0bd1b04a8b5e")
+	adminID := uuid.MustParse("c0a3b7b8-5d67-4a63-b1d4-8e1ad1c3b8f2")
+	viewerID := uuid.MustParse("9a7e07d4-31c6-4a4f-8a54-0c5ab9b2a7d1")
+
+	quiz := &Quiz{
+		CreatorID: ownerID,
+		Collaborators: []*Collaborator{
+			{CreatorID: hostID, Role: RoleHost},
+			{CreatorID: viewerID, Role: RoleViewer},
+			{CreatorID: adminID, Role: RoleEditor},
 		},
-		"current and next": {
-			quiz: &Quiz{
-				MultipleChoiceQuestions: []*MultipleChoiceQuestion{
-					{
-						BaseQuestion: BaseQuestion{
-							BaseObject: BaseObject{ID: uuid.MustParse("c5803f9a-a584-409a-9c06-7e66a37a959f")},
-							Title:      "First question!",
-							Order:      1,
-						},
-					},
-					{
-						BaseQuestion: BaseQuestion{
-							BaseObject: BaseObject{ID: uuid.MustParse("dfd2294f-a2bf-45bf-9522-982b2fd056a6")},
-							Title:      "Second question!",
-							Order:      0,
-						},
-					},
-				},
-			},
-			expected: MultipleChoiceQuestion{
-				BaseQuestion: BaseQuestion{
-					BaseObject: BaseObject{ID: uuid.MustParse("c5803f9a-a584-409a-9c06-7e66a37a959f")},
-					Title:      "First question!",
-					Order:      1,
-				},
+		Organization: &Organization{
+			Members: []*Member{
+				{CreatorID: memberID, Role: OrganizationMember},
+				{CreatorID: adminID, Role: OrganizationAdmin},
+				{CreatorID: viewerID, Role: OrganizationMember},
 			},
-			currentQuestion: uuid.MustParse("dfd2294f-a2bf-45bf-9522-982b2fd056a6"),
 		},
 	}
 
+	tests := map[string]struct {
+		creator      uuid.UUID
+		expected     Role
+		expectedFind bool
+	}{
+		"owner":                  {creator: ownerID, expected: RoleOwner, expectedFind: true},
+		"host":                   {creator: hostID, expected: RoleHost, expectedFind: true},
+		"stranger":               {creator: strangerID},
+		"org member":             {creator: memberID, expected: RoleHost, expectedFind: true},
+		"org admin outranks":     {creator: adminID, expected: RoleOwner, expectedFind: true},
+		"org member upgrades to": {creator: viewerID, expected: RoleHost, expectedFind: true},
+	}
+
 	for name, testData := range tests {
 		testData := testData
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 			// Act
-			result, ok := testData.quiz.GetNextQuestion(testData.currentQuestion)
+			result, ok := quiz.RoleOf(testData.creator)
 
 			// Assert
-			assert.Equal(t, testData.expected != nil, ok)
-
-			if ok {
-				assert.Equal(t, testData.expected.GetBaseQuestion().Title, result.GetBaseQuestion().Title)
-			}
+			assert.Equal(t, testData.expectedFind, ok)
+			assert.Equal(t, testData.expected, result)
 		})
 	}
 }
 
-func TestQuiz_CountQuestions_ReturnsExpectedCount(t *testing.T) {
+func TestQuiz_Allows_ReturnsExpectedValue(t *testing.T) {
 	t.Parallel()
 	// Arrange
-	quiz := &Quiz{MultipleChoiceQuestions: []*MultipleChoiceQuestion{{}, {}}}
+	ownerID := uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58")
+	viewerID := uuid.MustParse("e3274bf0-b154-4d37-a6fd-878d530025f9")
+
+	quiz := &Quiz{
+		CreatorID:     ownerID,
+		Collaborators: []*Collaborator{{CreatorID: viewerID, Role: RoleViewer}},
+	}
 
 	// Act
-	result := quiz.CountQuestions()
+	ownerResult := quiz.Allows(ownerID, PermissionDeleteQuiz)
+	viewerViewResult := quiz.Allows(viewerID, PermissionViewQuiz)
+	viewerEditResult := quiz.Allows(viewerID, PermissionEditQuiz)
+	strangerResult := quiz.Allows(uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b"), PermissionViewQuiz)
 
 	// Assert
-	assert.Equal(t, 2, result)
+	assert.True(t, ownerResult)
+	assert.True(t, viewerViewResult)
+	assert.False(t, viewerEditResult)
+	assert.False(t, strangerResult)
 }
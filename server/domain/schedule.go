@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// StartsAt returns when a game that has not started yet is scheduled to start
+func (g *Game) StartsAt() (time.Time, bool) {
+	if !g.StartTime.IsZero() || g.ScheduledStart.IsZero() {
+		return time.Time{}, false
+	}
+
+	return g.ScheduledStart, true
+}
+
+// FinishesAt returns when a game that has not finished yet is scheduled to finish, self-paced games
+// always finish at their close time
+func (g *Game) FinishesAt() (time.Time, bool) {
+	if !g.FinishTime.IsZero() {
+		return time.Time{}, false
+	}
+
+	if g.IsSelfPaced() {
+		return g.CloseTime, true
+	}
+
+	return g.ScheduledFinish, !g.ScheduledFinish.IsZero()
+}
+
+// ExpectedDuration is the sum of the time players get for every question
+func (q *Quiz) ExpectedDuration() time.Duration {
+	var result time.Duration
+	for _, question := range q.MultipleChoiceQuestions {
+		result += time.Duration(question.DurationInSeconds) * time.Second
+	}
+
+	return result
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGame_StartsAt_ReturnsExpectedValue(t *testing.T) {
+	t.Parallel()
+	scheduledStart := time.Now().Add(time.Hour)
+
+	tests := map[string]struct {
+		game       *Game
+		expected   time.Time
+		expectedOk bool
+	}{
+		"not scheduled": {
+			game: &Game{},
+		},
+		"scheduled": {
+			game:       &Game{ScheduledStart: scheduledStart},
+			expected:   scheduledStart,
+			expectedOk: true,
+		},
+		"already started": {
+			game: &Game{ScheduledStart: scheduledStart, StartTime: time.Now()},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, ok := testData.game.StartsAt()
+
+			// Assert
+			assert.Equal(t, testData.expectedOk, ok)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestGame_FinishesAt_ReturnsExpectedValue(t *testing.T) {
+	t.Parallel()
+	finish := time.Now().Add(time.Hour)
+
+	tests := map[string]struct {
+		game       *Game
+		expected   time.Time
+		expectedOk bool
+	}{
+		"not scheduled": {
+			game: &Game{},
+		},
+		"scheduled": {
+			game:       &Game{ScheduledFinish: finish},
+			expected:   finish,
+			expectedOk: true,
+		},
+		"self-paced": {
+			game:       &Game{Mode: GameModeSelfPaced, CloseTime: finish},
+			expected:   finish,
+			expectedOk: true,
+		},
+		"already finished": {
+			game: &Game{ScheduledFinish: finish, FinishTime: time.Now()},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, ok := testData.game.FinishesAt()
+
+			// Assert
+			assert.Equal(t, testData.expectedOk, ok)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestQuiz_ExpectedDuration_SumsQuestionDurations(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quiz := &Quiz{MultipleChoiceQuestions: []*MultipleChoiceQuestion{
+		{BaseQuestion: BaseQuestion{DurationInSeconds: 15}},
+		{BaseQuestion: BaseQuestion{DurationInSeconds: 45}},
+	}}
+
+	// Act
+	result := quiz.ExpectedDuration()
+
+	// Assert
+	assert.Equal(t, time.Minute, result)
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+// GameMode determines who decides when a game moves on to the next question
+type GameMode string
+
+const (
+	// GameModeLive lets the host move all players to the next question at the same time
+	GameModeLive GameMode = "live"
+
+	// GameModeSelfPaced lets every player go through the questions on their own, between the open and close time
+	GameModeSelfPaced GameMode = "self-paced"
+)
+
+// IsSelfPaced returns whether players go through the questions on their own
+func (g *Game) IsSelfPaced() bool {
+	return g.Mode == GameModeSelfPaced
+}
+
+// IsWindowOpen returns whether the current time is between the open and close time of a self-paced game
+func (g *Game) IsWindowOpen() bool {
+	now := time.Now()
+	return !now.Before(g.OpenTime) && now.Before(g.CloseTime)
+}
+
+// NextForPlayer moves the player of a self-paced game to their next question, the returned question
+// is nil once the player went through all of them
+func (g *Game) NextForPlayer(player *Player) (Question, error) {
+	if !g.IsSelfPaced() {
+		return nil, errors.New("game is not self-paced")
+	}
+
+	if !g.IsInProgress() || !g.IsWindowOpen() {
+		return nil, errors.New("game is not open")
+	}
+
+	if !g.Players.Contains(player.ID) {
+		return nil, errors.New("player not in game")
+	}
+
+	if !player.FinishTime.IsZero() {
+		return nil, errors.New("player has already finished")
+	}
+
+	// Players that answered may move on right away, others have to wait for their timer
+	if player.CurrentQuestion != uuid.Nil && time.Now().Before(player.CurrentDeadline) && !g.Answers.Contains(player.CurrentQuestion, player.ID) {
+		return nil, errors.New("deadline has not passed")
+	}
+
+	nextQuestion, ok := g.NextQuestion(player.CurrentQuestion)
+	if !ok {
+		player.FinishTime = time.Now()
+		return nil, nil
+	}
+
+	// Nobody gets to answer after the game closes, even if their timer says otherwise
+	deadline := time.Now().Add(time.Duration(nextQuestion.GetBaseQuestion().DurationInSeconds) * time.Second)
+	if deadline.After(g.CloseTime) {
+		deadline = g.CloseTime
+	}
+
+	player.CurrentQuestion = nextQuestion.GetBaseQuestion().ID
+	player.CurrentDeadline = deadline
+
+	return nextQuestion, nil
+}
@@ -0,0 +1,244 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func selfPacedGame(player *Player) *Game {
+	return &Game{
+		Mode:      GameModeSelfPaced,
+		StartTime: time.Now().Add(-time.Hour),
+		OpenTime:  time.Now().Add(-time.Hour),
+		CloseTime: time.Now().Add(time.Hour),
+		Players:   []*Player{player},
+		Quiz: &Quiz{
+			MultipleChoiceQuestions: []*MultipleChoiceQuestion{
+				{BaseQuestion: BaseQuestion{BaseObject: BaseObject{ID: uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8")}, Order: 0, DurationInSeconds: 20}},
+				{BaseQuestion: BaseQuestion{BaseObject: BaseObject{ID: uuid.MustParse("f6d2fa67-c12d-4096-958b-18206fbf3538")}, Order: 1, DurationInSeconds: 20}},
+			},
+		},
+	}
+}
+
+func TestGame_IsOpenForPlayers_ReturnsWhetherSelfPacedWindowIsOpen(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		openTime  time.Time
+		closeTime time.Time
+		expected  bool
+	}{
+		"not open yet": {
+			openTime:  time.Now().Add(time.Hour),
+			closeTime: time.Now().Add(2 * time.Hour),
+			expected:  false,
+		},
+		"open": {
+			openTime:  time.Now().Add(-time.Hour),
+			closeTime: time.Now().Add(time.Hour),
+			expected:  true,
+		},
+		"closed": {
+			openTime:  time.Now().Add(-2 * time.Hour),
+			closeTime: time.Now().Add(-time.Hour),
+			expected:  false,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			game := &Game{Mode: GameModeSelfPaced, StartTime: time.Now(), OpenTime: testData.openTime, CloseTime: testData.closeTime}
+
+			// Act
+			result := game.IsOpenForPlayers()
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestGame_Next_ReturnsErrorOnSelfPaced(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	game := selfPacedGame(&Player{})
+
+	// Act
+	err := game.Next()
+
+	// Assert
+	assert.ErrorContains(t, err, "players of a self-paced game move on by themselves")
+}
+
+func TestGame_NextForPlayer_ReturnsErrorOnInvalidState(t *testing.T) {
+	t.Parallel()
+	playerID := uuid.MustParse("a28ca8c6-63d9-45a2-b990-9b41e306f156")
+
+	tests := map[string]struct {
+		game     func(player *Player) *Game
+		player   *Player
+		expected string
+	}{
+		"live game": {
+			game: func(player *Player) *Game {
+				game := selfPacedGame(player)
+				game.Mode = GameModeLive
+				return game
+			},
+			player:   &Player{BaseObject: BaseObject{ID: playerID}},
+			expected: "game is not self-paced",
+		},
+		"closed": {
+			game: func(player *Player) *Game {
+				game := selfPacedGame(player)
+				game.CloseTime = time.Now().Add(-time.Minute)
+				return game
+			},
+			player:   &Player{BaseObject: BaseObject{ID: playerID}},
+			expected: "game is not open",
+		},
+		"not in game": {
+			game: func(*Player) *Game {
+				return selfPacedGame(&Player{})
+			},
+			player:   &Player{BaseObject: BaseObject{ID: playerID}},
+			expected: "player not in game",
+		},
+		"finished": {
+			game:     selfPacedGame,
+			player:   &Player{BaseObject: BaseObject{ID: playerID}, FinishTime: time.Now()},
+			expected: "player has already finished",
+		},
+		"unanswered and deadline not passed": {
+			game: selfPacedGame,
+			player: &Player{
+				BaseObject:      BaseObject{ID: playerID},
+				CurrentQuestion: uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"),
+				CurrentDeadline: time.Now().Add(time.Minute),
+			},
+			expected: "deadline has not passed",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			game := testData.game(testData.player)
+
+			// Act
+			question, err := game.NextForPlayer(testData.player)
+
+			// Assert
+			assert.Nil(t, question)
+			assert.ErrorContains(t, err, testData.expected)
+		})
+	}
+}
+
+func TestGame_NextForPlayer_MovesOnAfterAnswering(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	player := &Player{
+		BaseObject:      BaseObject{ID: uuid.MustParse("a28ca8c6-63d9-45a2-b990-9b41e306f156")},
+		CurrentQuestion: uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"),
+		CurrentDeadline: time.Now().Add(time.Minute),
+	}
+
+	game := selfPacedGame(player)
+	game.Answers = []*GameAnswer{{PlayerID: player.ID, QuestionID: player.CurrentQuestion}}
+
+	// Act
+	question, err := game.NextForPlayer(player)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, uuid.MustParse("f6d2fa67-c12d-4096-958b-18206fbf3538"), question.GetBaseQuestion().ID)
+	assert.Equal(t, question.GetBaseQuestion().ID, player.CurrentQuestion)
+	assert.WithinDuration(t, time.Now().Add(20*time.Second), player.CurrentDeadline, time.Second)
+}
+
+func TestGame_NextForPlayer_LimitsDeadlineToCloseTime(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	player := &Player{BaseObject: BaseObject{ID: uuid.MustParse("a28ca8c6-63d9-45a2-b990-9b41e306f156")}}
+
+	game := selfPacedGame(player)
+	game.CloseTime = time.Now().Add(5 * time.Second)
+
+	// Act
+	question, err := game.NextForPlayer(player)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"), question.GetBaseQuestion().ID)
+	assert.Equal(t, game.CloseTime, player.CurrentDeadline)
+}
+
+func TestGame_NextForPlayer_FinishesAfterLastQuestion(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	player := &Player{
+		BaseObject:      BaseObject{ID: uuid.MustParse("a28ca8c6-63d9-45a2-b990-9b41e306f156")},
+		CurrentQuestion: uuid.MustParse("f6d2fa67-c12d-4096-958b-18206fbf3538"),
+		CurrentDeadline: time.Now().Add(-time.Second),
+	}
+
+	game := selfPacedGame(player)
+
+	// Act
+	question, err := game.NextForPlayer(player)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, question)
+	assert.False(t, player.FinishTime.IsZero())
+}
+
+func TestGame_AnswerQuestion_UsesPlayerProgressOnSelfPaced(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	questionID := uuid.MustParse("f6d2fa67-c12d-4096-958b-18206fbf3538")
+	optionID := uuid.MustParse("c7ff1cdf-72d3-4ea9-ae48-e1c1d61f8bc8")
+
+	player := &Player{
+		BaseObject:      BaseObject{ID: uuid.MustParse("a28ca8c6-63d9-45a2-b990-9b41e306f156")},
+		CurrentQuestion: questionID,
+		CurrentDeadline: time.Now().Add(time.Minute),
+	}
+	other := &Player{
+		BaseObject:      BaseObject{ID: uuid.MustParse("bd787fed-8a2e-40d3-abf6-6b90fa89f862")},
+		CurrentQuestion: uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"),
+		CurrentDeadline: time.Now().Add(time.Minute),
+	}
+
+	game := selfPacedGame(player)
+	game.Players = append(game.Players, other)
+
+	// Act
+	answer, err := game.AnswerQuestion(player.ID, questionID, optionID)
+	_, otherErr := game.AnswerQuestion(other.ID, questionID, optionID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, questionID, answer.QuestionID)
+	assert.ErrorContains(t, otherErr, "not the current question")
+}
+
+func TestQuiz_CountGamesInProgress_IgnoresSelfPacedGames(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quiz := &Quiz{Games: []*Game{selfPacedGame(&Player{})}}
+
+	// Act
+	result := quiz.CountGamesInProgress()
+
+	// Assert
+	assert.Zero(t, result)
+}
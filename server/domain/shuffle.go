@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"encoding/binary"
+	"github.com/google/uuid"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+)
+
+// Questions returns the questions in the order they're asked in this game, the same seed always
+// results in the same order. Games of quizzes with pools only get the questions they drew.
+func (g *Game) Questions() []*MultipleChoiceQuestion {
+	drawn := map[uuid.UUID]bool{}
+	for _, question := range g.DrawnQuestions {
+		drawn[question.QuestionID] = true
+	}
+
+	result := make([]*MultipleChoiceQuestion, 0, len(g.Quiz.MultipleChoiceQuestions))
+	for _, question := range g.Quiz.MultipleChoiceQuestions {
+		if len(drawn) == 0 || drawn[question.ID] {
+			result = append(result, question)
+		}
+	}
+
+	// The database doesn't guarantee an order, so start from the quiz's order
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Order < result[j].Order
+	})
+
+	if !g.ShuffleQuestions {
+		return result
+	}
+
+	random := rand.New(rand.NewSource(g.shuffleSeed(uuid.Nil, uuid.Nil)))
+	random.Shuffle(len(result), func(i, j int) {
+		result[i], result[j] = result[j], result[i]
+	})
+
+	return result
+}
+
+// NextQuestion returns the question after the current one in this game's order, the first
+// question if there is no current question
+func (g *Game) NextQuestion(current uuid.UUID) (Question, bool) {
+	questions := g.Questions()
+
+	next := 0
+	if current != uuid.Nil {
+		next = len(questions)
+		for index, question := range questions {
+			if question.ID == current {
+				next = index + 1
+				break
+			}
+		}
+	}
+
+	if next >= len(questions) {
+		return nil, false
+	}
+
+	return questions[next], true
+}
+
+// Options returns the options of the question in the order the player sees them, uuid.Nil returns
+// the order that is shared by all players, like on the presenter's screen
+func (g *Game) Options(question *MultipleChoiceQuestion, playerID uuid.UUID) []*QuestionOption {
+	if !g.ShuffleOptions && !g.ShuffleOptionsPerPlayer {
+		return question.Options
+	}
+
+	result := make([]*QuestionOption, len(question.Options))
+	copy(result, question.Options)
+
+	if !g.ShuffleOptionsPerPlayer {
+		playerID = uuid.Nil
+	}
+
+	random := rand.New(rand.NewSource(g.shuffleSeed(question.ID, playerID)))
+	random.Shuffle(len(result), func(i, j int) {
+		result[i], result[j] = result[j], result[i]
+	})
+
+	return result
+}
+
+// shuffleSeed derives a seed from the game's seed, so every question and player gets their
+// own order that can be reproduced later on
+func (g *Game) shuffleSeed(questionID uuid.UUID, playerID uuid.UUID) int64 {
+	hash := fnv.New64a()
+	_ = binary.Write(hash, binary.BigEndian, g.Seed)
+	_, _ = hash.Write(questionID[:])
+	_, _ = hash.Write(playerID[:])
+
+	return int64(hash.Sum64())
+}
@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func shuffleGame(seed int64) *Game {
+	quiz := &Quiz{}
+	for order := uint(0); order < 10; order++ {
+		question := &MultipleChoiceQuestion{BaseQuestion: BaseQuestion{BaseObject: BaseObject{ID: uuid.New()}, Order: order}}
+		for i := 0; i < 6; i++ {
+			question.Options = append(question.Options, &QuestionOption{BaseObject: BaseObject{ID: uuid.New()}})
+		}
+
+		quiz.MultipleChoiceQuestions = append(quiz.MultipleChoiceQuestions, question)
+	}
+
+	return &Game{Seed: seed, Quiz: quiz}
+}
+
+func TestGame_Questions_ReturnsQuizOrderWithoutShuffling(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	game := shuffleGame(42)
+	quiz := game.Quiz
+	quiz.MultipleChoiceQuestions[0], quiz.MultipleChoiceQuestions[3] = quiz.MultipleChoiceQuestions[3], quiz.MultipleChoiceQuestions[0]
+
+	// Act
+	result := game.Questions()
+
+	// Assert
+	for index, question := range result {
+		assert.Equal(t, uint(index), question.Order)
+	}
+}
+
+func TestGame_Questions_ReturnsSameOrderForSameSeed(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	game := shuffleGame(42)
+	game.ShuffleQuestions = true
+
+	replay := &Game{Seed: 42, ShuffleQuestions: true, Quiz: game.Quiz}
+	other := &Game{Seed: 43, ShuffleQuestions: true, Quiz: game.Quiz}
+
+	// Act
+	result := game.Questions()
+
+	// Assert
+	assert.Equal(t, result, replay.Questions())
+	assert.NotEqual(t, result, other.Questions())
+	assert.NotEqual(t, game.Quiz.MultipleChoiceQuestions, result)
+	assert.ElementsMatch(t, game.Quiz.MultipleChoiceQuestions, result)
+}
+
+func TestGame_NextQuestion_FollowsShuffledOrder(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	game := shuffleGame(42)
+	game.ShuffleQuestions = true
+
+	var result []*MultipleChoiceQuestion
+
+	// Act
+	current := uuid.Nil
+	for {
+		question, ok := game.NextQuestion(current)
+		if !ok {
+			break
+		}
+
+		result = append(result, question.(*MultipleChoiceQuestion))
+		current = question.GetBaseQuestion().ID
+	}
+
+	// Assert
+	assert.Equal(t, game.Questions(), result)
+}
+
+func TestGame_Options_ReturnsExpectedOrder(t *testing.T) {
+	t.Parallel()
+	playerID := uuid.MustParse("a28ca8c6-63d9-45a2-b990-9b41e306f156")
+	otherPlayerID := uuid.MustParse("bd787fed-8a2e-40d3-abf6-6b90fa89f862")
+
+	tests := map[string]struct {
+		shuffleOptions bool
+		perPlayer      bool
+
+		expectedShuffled   bool
+		expectedSameOthers bool
+	}{
+		"no shuffling": {
+			expectedShuffled:   false,
+			expectedSameOthers: true,
+		},
+		"shuffled for everyone": {
+			shuffleOptions:     true,
+			expectedShuffled:   true,
+			expectedSameOthers: true,
+		},
+		"shuffled per player": {
+			shuffleOptions:     true,
+			perPlayer:          true,
+			expectedShuffled:   true,
+			expectedSameOthers: false,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			game := shuffleGame(42)
+			game.ShuffleOptions = testData.shuffleOptions
+			game.ShuffleOptionsPerPlayer = testData.perPlayer
+
+			question := game.Quiz.MultipleChoiceQuestions[0]
+
+			// Act
+			result := game.Options(question, playerID)
+
+			// Assert
+			assert.ElementsMatch(t, question.Options, result)
+			assert.Equal(t, testData.expectedShuffled, !assert.ObjectsAreEqual(question.Options, result))
+			assert.Equal(t, testData.expectedSameOthers, assert.ObjectsAreEqual(game.Options(question, otherPlayerID), result))
+			assert.Equal(t, result, game.Options(question, playerID))
+		})
+	}
+}
+
+func TestGame_Start_PicksSeed(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	game := shuffleGame(0)
+	replay := shuffleGame(42)
+
+	// Act
+	err := game.Start("ABC123")
+	replayErr := replay.Start("ABC123")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, replayErr)
+	assert.NotZero(t, game.Seed)
+	assert.Equal(t, int64(42), replay.Seed)
+}
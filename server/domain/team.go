@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"sort"
+)
+
+// TeamScoring determines how the scores of a team's players are combined into a team score
+type TeamScoring string
+
+const (
+	TeamScoringSum     TeamScoring = "sum"
+	TeamScoringAverage TeamScoring = "average"
+	TeamScoringBest    TeamScoring = "best"
+)
+
+// Aggregate combines the scores of a team's players, teams without players score 0
+func (t TeamScoring) Aggregate(scores []uint) float64 {
+	if len(scores) == 0 {
+		return 0
+	}
+
+	var sum, best uint
+	for _, score := range scores {
+		sum += score
+		if score > best {
+			best = score
+		}
+	}
+
+	switch t {
+	case TeamScoringAverage:
+		return float64(sum) / float64(len(scores))
+	case TeamScoringBest:
+		return float64(best)
+	default:
+		return float64(sum)
+	}
+}
+
+// Team groups players in a game, players are assigned to the smallest team if they don't pick one
+type Team struct {
+	BaseObject
+
+	Name string `json:"name" example:"Marketing"` // desc: Unique within the game
+
+	GameID uuid.UUID `json:"gameID" example:"00000000-0000-0000-0000-000000000000"`
+	Game   *Game     `json:"-" gorm:"foreignKey:GameID"`
+}
+
+// TeamScore is a team's position on the leaderboard
+type TeamScore struct {
+	Team    *Team   `json:"team"`
+	Score   float64 `json:"score" example:"4.5"` // desc: The team's players' scores, combined using the game's team scoring
+	Players uint    `json:"players" example:"4"` // desc: The amount of players in the team
+}
+
+// HasTeams returns whether players in this game play in teams
+func (g *Game) HasTeams() bool {
+	return len(g.Teams) > 0
+}
+
+// AssignTeam puts the player in the chosen team, or the team with the fewest players if none was chosen
+func (g *Game) AssignTeam(player *Player, teamID *uuid.UUID) error {
+	if !g.HasTeams() {
+		if teamID != nil {
+			return errors.New("game has no teams")
+		}
+
+		return nil
+	}
+
+	if teamID != nil {
+		for _, team := range g.Teams {
+			if team.ID == *teamID {
+				player.TeamID = &team.ID
+				return nil
+			}
+		}
+
+		return errors.New("team is not in this game")
+	}
+
+	sizes := g.teamSizes()
+
+	smallest := g.Teams[0]
+	for _, team := range g.Teams[1:] {
+		if sizes[team.ID] < sizes[smallest.ID] {
+			smallest = team
+		}
+	}
+
+	player.TeamID = &smallest.ID
+	return nil
+}
+
+// TeamLeaderboard returns the teams ordered by their score, highest first
+func (g *Game) TeamLeaderboard() []*TeamScore {
+	playerScores := g.PlayerScores()
+
+	teamScores := map[uuid.UUID][]uint{}
+	for _, player := range g.Players {
+		if player.TeamID != nil {
+			teamScores[*player.TeamID] = append(teamScores[*player.TeamID], playerScores[player.ID])
+		}
+	}
+
+	result := make([]*TeamScore, 0, len(g.Teams))
+	for _, team := range g.Teams {
+		result = append(result, &TeamScore{
+			Team:    team,
+			Score:   g.TeamScoring.Aggregate(teamScores[team.ID]),
+			Players: uint(len(teamScores[team.ID])),
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Score > result[j].Score
+	})
+
+	return result
+}
+
+func (g *Game) teamSizes() map[uuid.UUID]int {
+	result := map[uuid.UUID]int{}
+	for _, player := range g.Players {
+		if player.TeamID != nil {
+			result[*player.TeamID]++
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTeamScoring_Aggregate_ReturnsExpectedScore(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		scoring  TeamScoring
+		scores   []uint
+		expected float64
+	}{
+		"sum": {
+			scoring:  TeamScoringSum,
+			scores:   []uint{1, 4, 2},
+			expected: 7,
+		},
+		"average": {
+			scoring:  TeamScoringAverage,
+			scores:   []uint{1, 4, 2, 0},
+			expected: 1.75,
+		},
+		"best": {
+			scoring:  TeamScoringBest,
+			scores:   []uint{1, 4, 2},
+			expected: 4,
+		},
+		"empty team": {
+			scoring:  TeamScoringAverage,
+			expected: 0,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result := testData.scoring.Aggregate(testData.scores)
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestGame_AssignTeam_PicksSmallestTeam(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	marketing := uuid.MustParse("3e0ec4b9-4d8b-44d4-9fa2-d4e7d1fbfc0b")
+	sales := uuid.MustParse("c37bbf7c-5d2c-4a55-8c2e-4bb1a78f8f7e")
+
+	game := &Game{
+		Teams: []*Team{
+			{BaseObject: BaseObject{ID: marketing}},
+			{BaseObject: BaseObject{ID: sales}},
+		},
+		Players: []*Player{{TeamID: &marketing}},
+	}
+
+	player := &Player{}
+
+	// Act
+	err := game.AssignTeam(player, nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, &sales, player.TeamID)
+}
+
+func TestGame_AssignTeam_UsesChosenTeam(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	marketing := uuid.MustParse("3e0ec4b9-4d8b-44d4-9fa2-d4e7d1fbfc0b")
+
+	game := &Game{Teams: []*Team{{BaseObject: BaseObject{ID: marketing}}}}
+	player := &Player{}
+
+	// Act
+	err := game.AssignTeam(player, &marketing)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, &marketing, player.TeamID)
+}
+
+func TestGame_AssignTeam_ReturnsErrorOnUnknownTeam(t *testing.T) {
+	t.Parallel()
+	unknown := uuid.MustParse("c37bbf7c-5d2c-4a55-8c2e-4bb1a78f8f7e")
+
+	tests := map[string]*Game{
+		"no teams":   {},
+		"other team": {Teams: []*Team{{BaseObject: BaseObject{ID: uuid.MustParse("3e0ec4b9-4d8b-44d4-9fa2-d4e7d1fbfc0b")}}}},
+	}
+
+	for name, game := range tests {
+		game := game
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			player := &Player{}
+
+			// Act
+			err := game.AssignTeam(player, &unknown)
+
+			// Assert
+			assert.Error(t, err)
+			assert.Nil(t, player.TeamID)
+		})
+	}
+}
+
+func TestGame_TeamLeaderboard_ReturnsTeamsByScore(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	marketing := uuid.MustParse("3e0ec4b9-4d8b-44d4-9fa2-d4e7d1fbfc0b")
+	sales := uuid.MustParse("c37bbf7c-5d2c-4a55-8c2e-4bb1a78f8f7e")
+	question := uuid.MustParse("c9dd02c7-5a4c-4a5e-9a10-8ed9e9e5e9a8")
+	correct := uuid.MustParse("0f04a1a3-2a2f-4c84-9a7c-4d2d39b6f0e5")
+	wrong := uuid.MustParse("5b8c9a0e-2d8f-4b6c-8e7a-6f3d2c1b0a9e")
+
+	players := []*Player{
+		{BaseObject: BaseObject{ID: uuid.MustParse("a1b2c3d4-0000-4000-8000-000000000001")}, TeamID: &marketing},
+		{BaseObject: BaseObject{ID: uuid.MustParse("a1b2c3d4-0000-4000-8000-000000000002")}, TeamID: &marketing},
+		{BaseObject: BaseObject{ID: uuid.MustParse("a1b2c3d4-0000-4000-8000-000000000003")}, TeamID: &sales},
+	}
+
+	game := &Game{
+		Quiz: &Quiz{MultipleChoiceQuestions: []*MultipleChoiceQuestion{
+			{BaseQuestion: BaseQuestion{BaseObject: BaseObject{ID: question}}, AnswerID: correct},
+		}},
+		Teams:       []*Team{{BaseObject: BaseObject{ID: marketing}}, {BaseObject: BaseObject{ID: sales}}},
+		TeamScoring: TeamScoringAverage,
+		Players:     players,
+		Answers: GameAnswers{
+			{PlayerID: players[0].ID, QuestionID: question, OptionID: correct},
+			{PlayerID: players[1].ID, QuestionID: question, OptionID: wrong},
+			{PlayerID: players[2].ID, QuestionID: question, OptionID: correct},
+		},
+	}
+
+	// Act
+	result := game.TeamLeaderboard()
+
+	// Assert
+	if assert.Len(t, result, 2) {
+		assert.Equal(t, sales, result[0].Team.ID)
+		assert.Equal(t, float64(1), result[0].Score)
+		assert.Equal(t, uint(1), result[0].Players)
+
+		assert.Equal(t, marketing, result[1].Team.ID)
+		assert.Equal(t, 0.5, result[1].Score)
+		assert.Equal(t, uint(2), result[1].Players)
+	}
+}
@@ -0,0 +1,182 @@
+package formats
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"strings"
+)
+
+// maxAikenOptions is the amount of options a question may have, Aiken allows more
+const maxAikenOptions = 4
+
+// Aiken is Moodle's simple format for multiple choice questions: a line with the question, a line
+// per option starting with a letter and a line with the letter of the answer. It has no titles,
+// categories or durations.
+//
+//	What is the capital of France?
+//	A. Lyon
+//	B. Paris
+//	ANSWER: B
+type Aiken struct{}
+
+// aikenQuestion collects the lines of a question while it's read
+type aikenQuestion struct {
+	line    int
+	text    string
+	letters []string
+	options []string
+}
+
+func (a *Aiken) ContentType() string {
+	return "text/plain; charset=utf-8"
+}
+
+func (a *Aiken) Parse(content []byte) ([]*Question, []*Problem) {
+	var result []*Question
+	var problems []*Problem
+
+	var current *aikenQuestion
+
+	// After a problem the rest of the question is skipped, up to its ANSWER line or an empty line
+	var skipping bool
+
+	number := 1
+	scanner := newLineScanner(content)
+	for ; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "":
+			skipping = false
+			continue
+		case skipping:
+			skipping = !strings.HasPrefix(strings.ToUpper(line), "ANSWER:")
+			continue
+		case current == nil:
+			current = &aikenQuestion{line: number, text: line}
+		case strings.HasPrefix(strings.ToUpper(line), "ANSWER:"):
+			question, problem := current.finish(strings.TrimSpace(line[len("ANSWER:"):]))
+			if problem != nil {
+				problems = append(problems, problem)
+			} else {
+				result = append(result, question)
+			}
+
+			current = nil
+		default:
+			letter, option, ok := aikenOption(line)
+			if !ok {
+				problems = append(problems, &Problem{Line: number, Reason: fmt.Sprintf("expected an option or ANSWER, got %q", line), Skipped: true})
+				current = nil
+				skipping = true
+				continue
+			}
+
+			current.letters = append(current.letters, letter)
+			current.options = append(current.options, option)
+		}
+	}
+
+	// The question the scanner stopped in is left out as well
+	if current != nil {
+		number = current.line
+	}
+
+	if problem := scanProblem(scanner, number); problem != nil {
+		return result, append(problems, problem)
+	}
+
+	if current != nil {
+		problems = append(problems, &Problem{Line: current.line, Reason: "question has no ANSWER line", Skipped: true})
+	}
+
+	return result, problems
+}
+
+// aikenOption splits a line like "B. Paris" or "B) Paris" into its letter and text
+func aikenOption(line string) (string, string, bool) {
+	if len(line) < 3 || line[0] < 'A' || line[0] > 'Z' || (line[1] != '.' && line[1] != ')') || line[2] != ' ' {
+		return "", "", false
+	}
+
+	return line[:1], strings.TrimSpace(line[3:]), true
+}
+
+func (a *aikenQuestion) finish(answer string) (*Question, *Problem) {
+	if len(a.options) < 2 {
+		return nil, &Problem{Line: a.line, Reason: "question needs at least 2 options", Skipped: true}
+	}
+
+	if len(a.options) > maxAikenOptions {
+		return nil, &Problem{Line: a.line, Reason: fmt.Sprintf("question has %d options, at most %d are supported", len(a.options), maxAikenOptions), Skipped: true}
+	}
+
+	title, description := titleAndDescription(a.text)
+	question := &inputs.MultipleChoiceQuestion{Title: title, Description: description}
+
+	found := false
+	for index, option := range a.options {
+		correct := a.letters[index] == strings.ToUpper(answer)
+		found = found || correct
+
+		question.Options = append(question.Options, &inputs.QuestionOption{TextOption: option, Answer: correct})
+	}
+
+	if !found {
+		return nil, &Problem{Line: a.line, Reason: fmt.Sprintf("answer %q is not one of the options", answer), Skipped: true}
+	}
+
+	return &Question{Line: a.line, Question: question}, nil
+}
+
+func (a *Aiken) Write(questions []*domain.MultipleChoiceQuestion) ([]byte, []*Problem) {
+	var problems []*Problem
+	var buffer bytes.Buffer
+
+	hasCategories := false
+	hasDurations := false
+	for index, question := range sortedQuestions(questions) {
+		if index > 0 {
+			buffer.WriteString("\n")
+		}
+
+		text, titleLost := questionText(question)
+		if titleLost {
+			problems = append(problems, &Problem{Question: question.Title, Reason: "Aiken has no titles, only the description was written"})
+		}
+
+		hasCategories = hasCategories || question.Category != ""
+		hasDurations = hasDurations || question.DurationInSeconds > 0
+
+		buffer.WriteString(singleLine(text) + "\n")
+
+		answer := ""
+		for optionIndex, option := range question.Options {
+			letter := string(rune('A' + optionIndex))
+			buffer.WriteString(fmt.Sprintf("%s. %s\n", letter, singleLine(option.TextOption)))
+
+			if option.ID == question.AnswerID {
+				answer = letter
+			}
+		}
+
+		buffer.WriteString("ANSWER: " + answer + "\n")
+	}
+
+	if hasCategories {
+		problems = append(problems, &Problem{Reason: "Aiken has no categories, they were left out"})
+	}
+
+	if hasDurations {
+		problems = append(problems, &Problem{Reason: "Aiken has no durations, they were left out"})
+	}
+
+	return buffer.Bytes(), problems
+}
+
+// singleLine puts text on one line, since Aiken uses line breaks to separate the parts of a question
+func singleLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
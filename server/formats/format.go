@@ -0,0 +1,126 @@
+// Package formats reads and writes the text formats other quiz tools use for their questions
+package formats
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Compile-time interface checks
+var _ Format = new(GIFT)
+var _ Format = new(Aiken)
+var _ Format = new(QTI)
+
+// maxTitleLength is the longest title a question may have, longer question texts get a shortened title
+const maxTitleLength = 30
+
+// maxLineLength is the longest line of a text document that is read, as long as the largest document
+// that may be imported
+const maxLineLength = 1 << 20
+
+// Format reads and writes questions in a document format. Parts of a document that can't be represented
+// are reported as problems instead of being left out without notice.
+type Format interface {
+	ContentType() string
+	Parse(content []byte) ([]*Question, []*Problem)
+	Write(questions []*domain.MultipleChoiceQuestion) ([]byte, []*Problem)
+}
+
+// Question is a question read from a document, it still has to be validated
+type Question struct {
+	Line     int                            `json:"line,omitempty"` // The line the question starts on
+	File     string                         `json:"file,omitempty"` // The file of a package the question is in
+	Question *inputs.MultipleChoiceQuestion `json:"question"`
+}
+
+// Problem is a part of a document that could not be read or written
+type Problem struct {
+	Line     int    `json:"line,omitempty" example:"12"`               // desc: The line in the imported document
+	File     string `json:"file,omitempty" example:"items/item-1.xml"` // desc: The file in the imported package
+	Question string `json:"question,omitempty" example:"Capital city"` // desc: The title of the exported question
+	Reason   string `json:"reason" example:"numeric questions are not supported"`
+	Skipped  bool   `json:"skipped" example:"true"` // desc: Whether the whole question was left out, otherwise only the part in the reason was
+}
+
+// ByName returns the format with the given name
+func ByName(name string) (Format, bool) {
+	switch strings.ToLower(name) {
+	case "gift":
+		return new(GIFT), true
+	case "aiken":
+		return new(Aiken), true
+	case "qti":
+		return new(QTI), true
+	}
+
+	return nil, false
+}
+
+// newLineScanner reads a text document line by line
+func newLineScanner(content []byte) *bufio.Scanner {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineLength)
+
+	return scanner
+}
+
+// scanProblem reports everything from the line on as skipped if the scanner stopped before the end of
+// the document
+func scanProblem(scanner *bufio.Scanner, line int) *Problem {
+	if scanner.Err() == nil {
+		return nil
+	}
+
+	return &Problem{Line: line, Reason: fmt.Sprintf("a line is longer than %d bytes, everything from here on was left out", maxLineLength), Skipped: true}
+}
+
+// titleAndDescription splits a question text into a title and a description, texts that fit in a title
+// don't need a description
+func titleAndDescription(text string) (string, string) {
+	if utf8.RuneCountInString(text) <= maxTitleLength {
+		return text, ""
+	}
+
+	return shortTitle(text), text
+}
+
+// shortTitle cuts the text at the last word that fits in a title
+func shortTitle(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+
+	runes := []rune(text)
+	if len(runes) <= maxTitleLength {
+		return text
+	}
+
+	cut := string(runes[:maxTitleLength-1])
+	if space := strings.LastIndex(cut, " "); space > 0 {
+		cut = cut[:space]
+	}
+
+	return cut + "…"
+}
+
+// questionText returns the text a format without titles should show, and whether the title is lost
+func questionText(question *domain.MultipleChoiceQuestion) (string, bool) {
+	if question.Description == "" {
+		return question.Title, false
+	}
+
+	return question.Description, question.Title != shortTitle(question.Description)
+}
+
+// sortedQuestions returns the questions in the order they're asked
+func sortedQuestions(questions []*domain.MultipleChoiceQuestion) []*domain.MultipleChoiceQuestion {
+	result := make([]*domain.MultipleChoiceQuestion, len(questions))
+	copy(result, questions)
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Order < result[j].Order })
+
+	return result
+}
@@ -0,0 +1,233 @@
+package formats
+
+import (
+	"encoding/json"
+	"flag"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// update rewrites the golden files with the current output, run go test ./formats -update after
+// changing a format on purpose and review the diff
+var update = flag.Bool("update", false, "update the golden files")
+
+// assertGolden compares the result with the golden file
+func assertGolden(t *testing.T, name string, result []byte) {
+	t.Helper()
+
+	golden := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(golden, result, 0o644); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	assert.Equal(t, string(expected), string(result))
+}
+
+// exportQuestions has a question for every way a question can be written
+func exportQuestions() []*domain.MultipleChoiceQuestion {
+	question := func(order uint, title string, description string, category string, answer int, options ...string) *domain.MultipleChoiceQuestion {
+		result := &domain.MultipleChoiceQuestion{BaseQuestion: domain.BaseQuestion{Title: title, Description: description, Category: category, Order: order, DurationInSeconds: 20}}
+
+		for index, text := range options {
+			option := &domain.QuestionOption{BaseObject: domain.BaseObject{ID: uuid.New()}, TextOption: text}
+			result.Options = append(result.Options, option)
+
+			if index == answer {
+				result.AnswerID = option.ID
+			}
+		}
+
+		return result
+	}
+
+	return []*domain.MultipleChoiceQuestion{
+		question(2, "Sahara", "The Sahara is in Asia.", "Geography", 1, "True", "False"),
+		question(0, "Capital of France", "", "Geography", 1, "Lyon", "Paris", "Marseille"),
+		question(1, "Longest river", "Which of these rivers is the longest river in the world?", "Geography", 0, "The Nile", "The Amazon"),
+		question(3, "Which river flows through…", "Which river flows through the city of Cairo?", "Geography", 0, "The Nile", "The Danube"),
+		question(4, "Escapes", "Is 1 = 1 or {maybe} 1 ~ 2?\nThink: carefully", "Math", 0, "yes #1", "no"),
+	}
+}
+
+func TestFormat_Parse_MatchesGoldenFiles(t *testing.T) {
+	t.Parallel()
+	tests := map[string]Format{
+		"moodle.gift":  new(GIFT),
+		"moodle.aiken": new(Aiken),
+	}
+
+	for name, format := range tests {
+		name, format := name, format
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			content, err := os.ReadFile(filepath.Join("testdata", name))
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			// Act
+			questions, problems := format.Parse(content)
+
+			// Assert
+			result, _ := json.MarshalIndent(map[string]any{"questions": questions, "problems": problems}, "", "  ")
+			assertGolden(t, name+".golden.json", append(result, '\n'))
+		})
+	}
+}
+
+func TestFormat_Parse_ReportsLinesTooLongToRead(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		format  Format
+		content string
+	}{
+		"toolong.gift": {
+			format:  new(GIFT),
+			content: "What is 2+2? {=4 ~3}\n\nWhat is 3+3? {=6 ~5}\n" + strings.Repeat("x", maxLineLength+1) + "\n\nWhat is 4+4? {=8 ~7}\n",
+		},
+		"toolong.aiken": {
+			format:  new(Aiken),
+			content: "What is 2+2?\nA. 4\nB. 3\nANSWER: A\n\nWhat is 3+3?\n" + strings.Repeat("x", maxLineLength+1) + "\nANSWER: A\n",
+		},
+	}
+
+	for name, testData := range tests {
+		name, testData := name, testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			questions, problems := testData.format.Parse([]byte(testData.content))
+
+			// Assert
+			result, _ := json.MarshalIndent(map[string]any{"questions": questions, "problems": problems}, "", "  ")
+			assertGolden(t, name+".golden.json", append(result, '\n'))
+		})
+	}
+}
+
+func TestFormat_Parse_ReadsLongLines(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		format  Format
+		content string
+	}{
+		"gift": {
+			format:  new(GIFT),
+			content: "::Long::" + strings.Repeat("x", 100000) + " {=4 ~3}\n",
+		},
+		"aiken": {
+			format:  new(Aiken),
+			content: strings.Repeat("x", 100000) + "\nA. 4\nB. 3\nANSWER: A\n",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			questions, problems := testData.format.Parse([]byte(testData.content))
+
+			// Assert
+			assert.Empty(t, problems)
+			assert.Len(t, questions, 1)
+		})
+	}
+}
+
+func TestFormat_Write_MatchesGoldenFiles(t *testing.T) {
+	t.Parallel()
+	tests := map[string]Format{
+		"export.gift":  new(GIFT),
+		"export.aiken": new(Aiken),
+	}
+
+	for name, format := range tests {
+		name, format := name, format
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, problems := format.Write(exportQuestions())
+
+			// Assert
+			assertGolden(t, name, result)
+
+			if problems == nil {
+				problems = []*Problem{}
+			}
+
+			problemsResult, _ := json.MarshalIndent(problems, "", "  ")
+			assertGolden(t, name+".problems.json", append(problemsResult, '\n'))
+		})
+	}
+}
+
+func TestGIFT_Parse_ReadsWhatWasWritten(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	format := new(GIFT)
+	questions := sortedQuestions(exportQuestions())
+
+	content, _ := format.Write(questions)
+
+	// Act
+	result, problems := format.Parse(content)
+
+	// Assert
+	assert.Empty(t, problems)
+
+	if assert.Len(t, result, len(questions)) {
+		for index, question := range questions {
+			parsed := result[index].Question
+			assert.Equal(t, question.Title, parsed.Title)
+			assert.Equal(t, question.Description, parsed.Description)
+			assert.Equal(t, question.Category, parsed.Category)
+
+			if assert.Len(t, parsed.Options, len(question.Options)) {
+				for optionIndex, option := range question.Options {
+					assert.Equal(t, option.TextOption, parsed.Options[optionIndex].TextOption)
+					assert.Equal(t, option.ID == question.AnswerID, parsed.Options[optionIndex].Answer)
+				}
+			}
+		}
+	}
+}
+
+func TestByName_ReturnsFormat(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		expected Format
+		ok       bool
+	}{
+		"gift":  {expected: new(GIFT), ok: true},
+		"AIKEN": {expected: new(Aiken), ok: true},
+		"QTI":   {expected: new(QTI), ok: true},
+		"xml":   {},
+	}
+
+	for name, testData := range tests {
+		name, testData := name, testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, ok := ByName(name)
+
+			// Assert
+			assert.Equal(t, testData.ok, ok)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
@@ -0,0 +1,408 @@
+package formats
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"regexp"
+	"strings"
+)
+
+// maxGIFTOptions is the amount of options a question may have
+const maxGIFTOptions = 4
+
+// giftSpecial are the characters that have to be escaped with a backslash in GIFT
+const giftSpecial = `~=#{}:\`
+
+// giftWeight matches the percentage some answers start with, like ~%50%
+var giftWeight = regexp.MustCompile(`^%(-?[0-9.]+)%`)
+
+// giftTrueFalse are the answers of a true/false question
+var giftTrueFalse = map[string]bool{"T": true, "TRUE": true, "F": false, "FALSE": false}
+
+// GIFT is Moodle's text format, questions are separated by empty lines and have their answers
+// between braces. Multiple choice and true/false questions are supported, true/false questions
+// become multiple choice questions with the options True and False. GIFT has no durations.
+//
+//	$CATEGORY: Geography
+//
+//	::Capital of France::What is the capital of France? {
+//		=Paris
+//		~Lyon
+//	}
+type GIFT struct{}
+
+// giftBlock is a question and the line it starts on
+type giftBlock struct {
+	line int
+	text string
+}
+
+func (g *GIFT) ContentType() string {
+	return "text/plain; charset=utf-8"
+}
+
+func (g *GIFT) Parse(content []byte) ([]*Question, []*Problem) {
+	var result []*Question
+	var problems []*Problem
+
+	blocks, problem := giftBlocks(content)
+
+	category := ""
+	for _, block := range blocks {
+		if strings.HasPrefix(block.text, "$CATEGORY:") {
+			path := strings.TrimSpace(block.text[len("$CATEGORY:"):])
+			category = path[strings.LastIndex(path, "/")+1:]
+			continue
+		}
+
+		question, blockProblems := parseGIFTQuestion(block)
+		problems = append(problems, blockProblems...)
+
+		if question != nil {
+			question.Question.Category = category
+			result = append(result, question)
+		}
+	}
+
+	if problem != nil {
+		problems = append(problems, problem)
+	}
+
+	return result, problems
+}
+
+// giftBlocks splits the document at empty lines, comments are left out and categories get their own block.
+// If the document could not be read to the end, that's returned as a problem.
+func giftBlocks(content []byte) ([]*giftBlock, *Problem) {
+	var result []*giftBlock
+	var current *giftBlock
+
+	number := 1
+	scanner := newLineScanner(content)
+	for ; scanner.Scan(); number++ {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "//"):
+			continue
+		case trimmed == "":
+			current = nil
+		case strings.HasPrefix(trimmed, "$CATEGORY:"):
+			result = append(result, &giftBlock{line: number, text: trimmed})
+			current = nil
+		case current == nil:
+			current = &giftBlock{line: number, text: line}
+			result = append(result, current)
+		default:
+			current.text += "\n" + line
+		}
+	}
+
+	// The block the scanner stopped in is incomplete, so it's left out as well
+	if current != nil {
+		number = current.line
+	}
+
+	if problem := scanProblem(scanner, number); problem != nil {
+		if current != nil {
+			result = result[:len(result)-1]
+		}
+
+		return result, problem
+	}
+
+	return result, nil
+}
+
+func parseGIFTQuestion(block *giftBlock) (*Question, []*Problem) {
+	var problems []*Problem
+	skip := func(reason string) (*Question, []*Problem) {
+		return nil, append(problems, &Problem{Line: block.line, Reason: reason, Skipped: true})
+	}
+
+	text := strings.TrimSpace(block.text)
+
+	name := ""
+	if strings.HasPrefix(text, "::") {
+		end := indexUnescaped(text[2:], "::")
+		if end < 0 {
+			return skip("the title is not closed with ::")
+		}
+
+		name = unescapeGIFT(strings.TrimSpace(text[2 : 2+end]))
+		text = strings.TrimSpace(text[2+end+2:])
+	}
+
+	if strings.HasPrefix(text, "[") {
+		if end := strings.Index(text, "]"); end > 0 {
+			if markup := text[1:end]; markup == "html" || markup == "markdown" {
+				problems = append(problems, &Problem{Line: block.line, Reason: markup + " formatting is not supported, the text was kept as is"})
+			}
+
+			text = text[end+1:]
+		}
+	}
+
+	start := indexUnescaped(text, "{")
+	if start < 0 {
+		return skip("descriptions without answers are not supported")
+	}
+
+	length := indexUnescaped(text[start:], "}")
+	if length < 0 {
+		return skip("the answers are not closed with }")
+	}
+
+	answers := strings.TrimSpace(text[start+1 : start+length])
+
+	// Answers in the middle of the text are the missing word format, the blank shows where they go
+	questionText := strings.TrimSpace(text[:start])
+	if after := strings.TrimSpace(text[start+length+1:]); after != "" {
+		questionText += " _____ " + after
+	}
+
+	questionText = unescapeGIFT(questionText)
+
+	options, optionProblems, skipped := parseGIFTAnswers(answers)
+	for _, reason := range optionProblems {
+		problems = append(problems, &Problem{Line: block.line, Reason: reason, Skipped: skipped})
+	}
+
+	if skipped {
+		return nil, problems
+	}
+
+	title, description := titleAndDescription(questionText)
+	if name != "" {
+		title, description = name, questionText
+	}
+
+	question := &inputs.MultipleChoiceQuestion{Title: title, Description: description, Options: options}
+	return &Question{Line: block.line, Question: question}, problems
+}
+
+// parseGIFTAnswers reads the part between the braces, it returns the reasons that parts of it were left
+// out and whether that means the question can't be imported
+func parseGIFTAnswers(answers string) ([]*inputs.QuestionOption, []string, bool) {
+	if answers == "" {
+		return nil, []string{"essay questions are not supported"}, true
+	}
+
+	if strings.HasPrefix(answers, "#") {
+		return nil, []string{"numeric questions are not supported"}, true
+	}
+
+	var reasons []string
+
+	// Feedback on true/false questions follows a #
+	value, feedback, _ := strings.Cut(answers, "#")
+	if correct, ok := giftTrueFalse[strings.ToUpper(strings.TrimSpace(value))]; ok {
+		if feedback != "" {
+			reasons = append(reasons, "feedback is not supported and was left out")
+		}
+
+		return []*inputs.QuestionOption{{TextOption: "True", Answer: correct}, {TextOption: "False", Answer: !correct}}, reasons, false
+	}
+
+	var options []*inputs.QuestionOption
+	var correct, wrong int
+	var hasFeedback bool
+
+	for _, token := range splitGIFTAnswers(answers) {
+		text := token[1:]
+
+		if feedbackIndex := indexUnescaped(text, "#"); feedbackIndex >= 0 {
+			hasFeedback = true
+			text = text[:feedbackIndex]
+		}
+
+		if indexUnescaped(text, "->") >= 0 {
+			return nil, []string{"matching questions are not supported"}, true
+		}
+
+		if weight := giftWeight.FindString(text); weight != "" {
+			return nil, []string{"answers with a weight like " + weight + " are not supported"}, true
+		}
+
+		isCorrect := token[0] == '='
+		if isCorrect {
+			correct++
+		} else {
+			wrong++
+		}
+
+		options = append(options, &inputs.QuestionOption{TextOption: unescapeGIFT(strings.TrimSpace(text)), Answer: isCorrect})
+	}
+
+	switch {
+	case len(options) == 0:
+		return nil, []string{"answers have to start with = or ~"}, true
+	case wrong == 0:
+		return nil, []string{"short answer questions are not supported"}, true
+	case correct != 1:
+		return nil, []string{fmt.Sprintf("questions need exactly 1 correct answer, found %d", correct)}, true
+	case len(options) > maxGIFTOptions:
+		return nil, []string{fmt.Sprintf("question has %d options, at most %d are supported", len(options), maxGIFTOptions)}, true
+	}
+
+	if hasFeedback {
+		reasons = append(reasons, "feedback is not supported and was left out")
+	}
+
+	return options, reasons, false
+}
+
+// splitGIFTAnswers splits the answers at every unescaped = and ~, each part starts with one of them
+func splitGIFTAnswers(answers string) []string {
+	var result []string
+
+	start := -1
+	escaped := false
+	for index, character := range answers {
+		switch {
+		case escaped:
+			escaped = false
+		case character == '\\':
+			escaped = true
+		case character == '=' || character == '~':
+			if start >= 0 {
+				result = append(result, answers[start:index])
+			}
+
+			start = index
+		}
+	}
+
+	if start >= 0 {
+		result = append(result, answers[start:])
+	}
+
+	return result
+}
+
+// indexUnescaped returns the index of the first occurrence of substring that is not escaped
+func indexUnescaped(text string, substring string) int {
+	escaped := false
+	for index, character := range text {
+		switch {
+		case escaped:
+			escaped = false
+		case character == '\\':
+			escaped = true
+		case strings.HasPrefix(text[index:], substring):
+			return index
+		}
+	}
+
+	return -1
+}
+
+func unescapeGIFT(text string) string {
+	var builder strings.Builder
+
+	escaped := false
+	for _, character := range text {
+		switch {
+		case escaped && character == 'n':
+			builder.WriteRune('\n')
+			escaped = false
+		case escaped:
+			if !strings.ContainsRune(giftSpecial, character) {
+				builder.WriteRune('\\')
+			}
+
+			builder.WriteRune(character)
+			escaped = false
+		case character == '\\':
+			escaped = true
+		default:
+			builder.WriteRune(character)
+		}
+	}
+
+	return builder.String()
+}
+
+func escapeGIFT(text string) string {
+	var builder strings.Builder
+
+	for _, character := range text {
+		switch {
+		case character == '\n':
+			builder.WriteString(`\n`)
+		case strings.ContainsRune(giftSpecial, character):
+			builder.WriteRune('\\')
+			builder.WriteRune(character)
+		default:
+			builder.WriteRune(character)
+		}
+	}
+
+	return builder.String()
+}
+
+func (g *GIFT) Write(questions []*domain.MultipleChoiceQuestion) ([]byte, []*Problem) {
+	var problems []*Problem
+	var buffer bytes.Buffer
+
+	hasDurations := false
+	category := ""
+	for index, question := range sortedQuestions(questions) {
+		if index > 0 {
+			buffer.WriteString("\n")
+		}
+
+		hasDurations = hasDurations || question.DurationInSeconds > 0
+
+		if question.Category != category {
+			category = question.Category
+			buffer.WriteString("$CATEGORY: " + category + "\n\n")
+		}
+
+		// Titles that can be derived from the text are left out, so the text is all that's left
+		if text, titleLost := questionText(question); titleLost {
+			buffer.WriteString("::" + escapeGIFT(question.Title) + "::" + escapeGIFT(text))
+		} else {
+			buffer.WriteString(escapeGIFT(text))
+		}
+
+		if value, ok := trueFalseAnswer(question); ok {
+			buffer.WriteString(fmt.Sprintf(" {%s}\n", value))
+			continue
+		}
+
+		buffer.WriteString(" {\n")
+		for _, option := range question.Options {
+			prefix := "~"
+			if option.ID == question.AnswerID {
+				prefix = "="
+			}
+
+			buffer.WriteString("\t" + prefix + escapeGIFT(option.TextOption) + "\n")
+		}
+
+		buffer.WriteString("}\n")
+	}
+
+	if hasDurations {
+		problems = append(problems, &Problem{Reason: "GIFT has no durations, they were left out"})
+	}
+
+	return buffer.Bytes(), problems
+}
+
+// trueFalseAnswer returns T or F for questions with just the options True and False
+func trueFalseAnswer(question *domain.MultipleChoiceQuestion) (string, bool) {
+	if len(question.Options) != 2 || question.Options[0].TextOption != "True" || question.Options[1].TextOption != "False" {
+		return "", false
+	}
+
+	if question.Options[0].ID == question.AnswerID {
+		return "T", true
+	}
+
+	return "F", true
+}
@@ -0,0 +1,582 @@
+package formats
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// qtiManifestFile is the file at the root of every content package that lists its resources
+const qtiManifestFile = "imsmanifest.xml"
+
+// maxQTIOptions is the amount of choices an item may have
+const maxQTIOptions = 4
+
+// maxQTIFileSize is the largest file that's read from a package, it keeps small packages from
+// unpacking into something huge
+const maxQTIFileSize = 1 << 20
+
+// maxQTIItems is the amount of items that are read from a package, as many as a quiz can have
+const maxQTIItems = 100
+
+// maxQTIPackageSize is the amount of bytes that are read from a package in total, since a manifest can
+// refer to the same file again and again
+const maxQTIPackageSize = 10 << 20
+
+// Resource types of a QTI 2.1 content package
+const (
+	qtiItemType = "imsqti_item_xmlv2p1"
+	qtiTestType = "imsqti_test_xmlv2p1"
+)
+
+// Namespaces of the documents in a package
+const (
+	qtiContentPackageNamespace = "http://www.imsglobal.org/xsd/imscp_v1p1"
+	qtiItemNamespace           = "http://www.imsglobal.org/xsd/imsqti_v2p1"
+	qtiMatchCorrectTemplate    = "http://www.imsglobal.org/question/qti_v2p1/rptemplates/match_correct"
+)
+
+// qtiInteractions names the interactions that are not supported after the question type they're used for
+var qtiInteractions = map[string]string{
+	"textEntryInteraction":    "text entry",
+	"extendedTextInteraction": "essay",
+	"matchInteraction":        "matching",
+	"associateInteraction":    "matching",
+	"gapMatchInteraction":     "matching",
+	"orderInteraction":        "ordering",
+	"inlineChoiceInteraction": "inline choice",
+	"hotspotInteraction":      "hotspot",
+	"sliderInteraction":       "slider",
+	"uploadInteraction":       "upload",
+}
+
+// qtiWhitespace matches the whitespace that's collapsed into a single space
+var qtiWhitespace = regexp.MustCompile(`\s+`)
+
+// qtiTextElements are the elements of an item's body that only hold text, others are formatting
+var qtiTextElements = map[string]bool{"itemBody": true, "p": true, "div": true, "span": true, "br": true, "prompt": true, "simpleChoice": true}
+
+// QTI reads and writes IMS QTI 2.1 content packages: a zip with a manifest and an XML file per item.
+// Items with a single choice interaction and one correct choice are supported. The items of a package
+// are read in the order of the manifest, tests in the package are left out. QTI items have no categories
+// or durations, and since questions have no media yet, images are left out.
+type QTI struct{}
+
+// qtiManifest is the imsmanifest.xml of a package
+type qtiManifest struct {
+	XMLName       xml.Name       `xml:"manifest"`
+	Namespace     string         `xml:"xmlns,attr,omitempty"`
+	Identifier    string         `xml:"identifier,attr"`
+	Schema        string         `xml:"metadata>schema"`
+	SchemaVersion string         `xml:"metadata>schemaversion"`
+	Organizations struct{}       `xml:"organizations"`
+	Resources     []*qtiResource `xml:"resources>resource"`
+}
+
+type qtiResource struct {
+	Identifier string     `xml:"identifier,attr"`
+	Type       string     `xml:"type,attr"`
+	Href       string     `xml:"href,attr"`
+	Files      []*qtiFile `xml:"file"`
+}
+
+type qtiFile struct {
+	Href string `xml:"href,attr"`
+}
+
+// qtiItem is an assessment item as it's written, items are read token by token since their body
+// may contain any markup
+type qtiItem struct {
+	XMLName             xml.Name `xml:"assessmentItem"`
+	Namespace           string   `xml:"xmlns,attr"`
+	Identifier          string   `xml:"identifier,attr"`
+	Title               string   `xml:"title,attr"`
+	Adaptive            bool     `xml:"adaptive,attr"`
+	TimeDependent       bool     `xml:"timeDependent,attr"`
+	ResponseDeclaration struct {
+		Identifier      string `xml:"identifier,attr"`
+		Cardinality     string `xml:"cardinality,attr"`
+		BaseType        string `xml:"baseType,attr"`
+		CorrectResponse string `xml:"correctResponse>value"`
+	} `xml:"responseDeclaration"`
+	OutcomeDeclaration struct {
+		Identifier  string `xml:"identifier,attr"`
+		Cardinality string `xml:"cardinality,attr"`
+		BaseType    string `xml:"baseType,attr"`
+	} `xml:"outcomeDeclaration"`
+	ChoiceInteraction struct {
+		ResponseIdentifier string       `xml:"responseIdentifier,attr"`
+		Shuffle            bool         `xml:"shuffle,attr"`
+		MaxChoices         int          `xml:"maxChoices,attr"`
+		Prompt             *qtiPrompt   `xml:"prompt"`
+		Choices            []*qtiChoice `xml:"simpleChoice"`
+	} `xml:"itemBody>choiceInteraction"`
+	ResponseProcessing struct {
+		Template string `xml:"template,attr"`
+	} `xml:"responseProcessing"`
+}
+
+// qtiPrompt holds markup, so its line breaks can be written as elements
+type qtiPrompt struct {
+	Content string `xml:",innerxml"`
+}
+
+type qtiChoice struct {
+	Identifier string `xml:"identifier,attr"`
+	Text       string `xml:",chardata"`
+}
+
+// qtiItemReader collects the parts of an item while its tokens are read
+type qtiItemReader struct {
+	file         string
+	title        string
+	elements     []string
+	body         strings.Builder
+	prompt       strings.Builder
+	interactions []xml.StartElement
+	choices      []*qtiChoice
+	responses    map[string][]string
+	response     string
+	images       bool
+	shuffle      bool
+	formatting   bool
+	feedback     bool
+}
+
+func (q *QTI) ContentType() string {
+	return "application/zip"
+}
+
+func (q *QTI) Parse(content []byte) ([]*Question, []*Problem) {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, []*Problem{{Reason: "the package is not a zip file", Skipped: true}}
+	}
+
+	files := map[string]*zip.File{}
+	for _, file := range archive.File {
+		files[path.Clean(file.Name)] = file
+	}
+
+	manifestContent, problem := readQTIFile(files, qtiManifestFile)
+	if problem != nil {
+		return nil, []*Problem{problem}
+	}
+
+	var manifest qtiManifest
+	if err := xml.Unmarshal(manifestContent, &manifest); err != nil {
+		return nil, []*Problem{{File: qtiManifestFile, Reason: "the manifest is not valid XML", Skipped: true}}
+	}
+
+	var result []*Question
+	var problems []*Problem
+
+	items := 0
+	size := len(manifestContent)
+
+	for _, resource := range manifest.Resources {
+		switch {
+		case resource.Type == qtiItemType:
+			if items == maxQTIItems {
+				return result, append(problems, &Problem{File: resource.Href, Reason: fmt.Sprintf("the package has more than %d items, the rest was left out", maxQTIItems), Skipped: true})
+			}
+
+			items++
+
+			itemContent, problem := readQTIFile(files, resource.Href)
+			if problem != nil {
+				problems = append(problems, problem)
+				continue
+			}
+
+			size += len(itemContent)
+			if size > maxQTIPackageSize {
+				return result, append(problems, &Problem{File: resource.Href, Reason: fmt.Sprintf("the package unpacks to more than %d bytes, the rest was left out", maxQTIPackageSize), Skipped: true})
+			}
+
+			question, itemProblems := parseQTIItem(path.Clean(resource.Href), itemContent)
+			problems = append(problems, itemProblems...)
+
+			if question != nil {
+				result = append(result, question)
+			}
+		case resource.Type == qtiTestType:
+			problems = append(problems, &Problem{File: resource.Href, Reason: "tests are not supported, the items of the package were imported in the order of the manifest"})
+		case strings.HasPrefix(resource.Type, "imsqti_"):
+			problems = append(problems, &Problem{File: resource.Href, Reason: fmt.Sprintf("resources of type %s are not supported, only QTI 2.1 items are", resource.Type), Skipped: true})
+		}
+	}
+
+	return result, problems
+}
+
+// readQTIFile reads a file from the package, files that are missing or too large are a problem
+func readQTIFile(files map[string]*zip.File, name string) ([]byte, *Problem) {
+	file, ok := files[path.Clean(name)]
+	if !ok {
+		return nil, &Problem{File: name, Reason: "the file is missing from the package", Skipped: true}
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return nil, &Problem{File: name, Reason: "the file could not be read", Skipped: true}
+	}
+
+	defer reader.Close()
+
+	content, err := io.ReadAll(io.LimitReader(reader, maxQTIFileSize+1))
+	if err != nil {
+		return nil, &Problem{File: name, Reason: "the file could not be read", Skipped: true}
+	}
+
+	if len(content) > maxQTIFileSize {
+		return nil, &Problem{File: name, Reason: fmt.Sprintf("the file is larger than %d bytes", maxQTIFileSize), Skipped: true}
+	}
+
+	return content, nil
+}
+
+// parseQTIItem reads an assessment item, if it can't become a question only the reason why is returned
+func parseQTIItem(file string, content []byte) (*Question, []*Problem) {
+	reader := &qtiItemReader{file: file, responses: map[string][]string{}}
+
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, []*Problem{reader.skip("the item is not valid XML")}
+		}
+
+		if !reader.read(token) {
+			return nil, []*Problem{reader.skip("the file is not an assessment item")}
+		}
+	}
+
+	question, problem := reader.finish()
+	if problem != nil {
+		return nil, []*Problem{problem}
+	}
+
+	var problems []*Problem
+	if reader.images {
+		problems = append(problems, &Problem{File: file, Reason: "images are not supported and were left out"})
+	}
+
+	if reader.shuffle {
+		problems = append(problems, &Problem{File: file, Reason: "shuffled choices are not supported, they keep their order"})
+	}
+
+	if reader.formatting {
+		problems = append(problems, &Problem{File: file, Reason: "formatting is not supported, only the text was kept"})
+	}
+
+	if reader.feedback {
+		problems = append(problems, &Problem{File: file, Reason: "feedback is not supported and was left out"})
+	}
+
+	return question, problems
+}
+
+// read handles a token of the item, it returns false if the document is not an assessment item
+func (r *qtiItemReader) read(token xml.Token) bool {
+	switch token := token.(type) {
+	case xml.StartElement:
+		name := token.Name.Local
+		if len(r.elements) == 0 && name != "assessmentItem" {
+			return false
+		}
+
+		r.elements = append(r.elements, name)
+		r.start(token)
+	case xml.EndElement:
+		if name := token.Name.Local; name == "p" || name == "div" {
+			r.text().WriteString("\n")
+		}
+
+		r.elements = r.elements[:len(r.elements)-1]
+	case xml.CharData:
+		// Like in HTML, line breaks only come from elements
+		r.write(qtiWhitespace.ReplaceAllString(string(token), " "))
+	}
+
+	return true
+}
+
+// start handles the start of an element, the elements it's in are already on the stack
+func (r *qtiItemReader) start(element xml.StartElement) {
+	name := element.Name.Local
+
+	switch {
+	case name == "assessmentItem":
+		r.title = qtiAttribute(element, "title")
+	case name == "responseDeclaration":
+		r.response = qtiAttribute(element, "identifier")
+	case name == "value" && r.within("correctResponse"):
+		r.responses[r.response] = append(r.responses[r.response], "")
+	case name == "modalFeedback" || name == "feedbackInline" || name == "feedbackBlock":
+		r.feedback = true
+	case !r.within("itemBody") || r.within("feedbackInline") || r.within("feedbackBlock"):
+		return
+	case strings.HasSuffix(name, "Interaction"):
+		r.interactions = append(r.interactions, element)
+		r.shuffle = r.shuffle || qtiAttribute(element, "shuffle") == "true"
+	case name == "simpleChoice":
+		r.choices = append(r.choices, &qtiChoice{Identifier: qtiAttribute(element, "identifier")})
+	case name == "img" || name == "object":
+		r.images = true
+	case name == "p" || name == "div" || name == "br":
+		r.text().WriteString("\n")
+	case !qtiTextElements[name]:
+		r.formatting = true
+	}
+}
+
+// write adds text to the part of the item it's in
+func (r *qtiItemReader) write(text string) {
+	if r.within("correctResponse") && r.within("value") {
+		values := r.responses[r.response]
+		values[len(values)-1] += text
+		return
+	}
+
+	if !r.within("itemBody") || r.within("feedbackInline") || r.within("feedbackBlock") {
+		return
+	}
+
+	if r.within("simpleChoice") {
+		r.choices[len(r.choices)-1].Text += text
+		return
+	}
+
+	r.text().WriteString(text)
+}
+
+// text returns where the text of the item's body goes, text in interactions other than the prompt is left out
+func (r *qtiItemReader) text() *strings.Builder {
+	if r.within("prompt") {
+		return &r.prompt
+	}
+
+	if len(r.interactions) > 0 && r.within(r.interactions[len(r.interactions)-1].Name.Local) {
+		return new(strings.Builder)
+	}
+
+	return &r.body
+}
+
+// within returns true if the current element is or is inside an element with the given name
+func (r *qtiItemReader) within(name string) bool {
+	for _, element := range r.elements {
+		if element == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *qtiItemReader) skip(reason string) *Problem {
+	return &Problem{File: r.file, Reason: reason, Skipped: true}
+}
+
+// finish turns the collected parts into a question
+func (r *qtiItemReader) finish() (*Question, *Problem) {
+	if len(r.interactions) == 0 {
+		return nil, r.skip("items without an interaction are not supported")
+	}
+
+	if len(r.interactions) > 1 {
+		return nil, r.skip("items with more than one interaction are not supported")
+	}
+
+	interaction := r.interactions[0]
+	if name := interaction.Name.Local; name != "choiceInteraction" {
+		if questionType, ok := qtiInteractions[name]; ok {
+			return nil, r.skip(questionType + " questions are not supported")
+		}
+
+		return nil, r.skip(name + " is not supported")
+	}
+
+	if maxChoices := qtiAttribute(interaction, "maxChoices"); maxChoices != "" && maxChoices != "1" {
+		return nil, r.skip("questions with more than one answer are not supported")
+	}
+
+	answers := r.responses[qtiAttribute(interaction, "responseIdentifier")]
+	if len(answers) != 1 {
+		return nil, r.skip(fmt.Sprintf("questions need exactly 1 correct answer, found %d", len(answers)))
+	}
+
+	if len(r.choices) < 2 {
+		return nil, r.skip("question needs at least 2 options")
+	}
+
+	if len(r.choices) > maxQTIOptions {
+		return nil, r.skip(fmt.Sprintf("question has %d options, at most %d are supported", len(r.choices), maxQTIOptions))
+	}
+
+	text := strings.TrimSpace(qtiText(r.body.String()) + "\n" + qtiText(r.prompt.String()))
+	if text == "" {
+		return nil, r.skip("question has no text")
+	}
+
+	question := &inputs.MultipleChoiceQuestion{}
+	question.Title, question.Description = titleAndDescription(text)
+
+	if title := qtiText(r.title); title != "" && title != text {
+		question.Title, question.Description = shortTitle(title), text
+	}
+
+	found := false
+	for _, choice := range r.choices {
+		option := qtiText(choice.Text)
+		if option == "" {
+			return nil, r.skip(fmt.Sprintf("choice %q has no text", choice.Identifier))
+		}
+
+		correct := choice.Identifier == strings.TrimSpace(answers[0])
+		found = found || correct
+
+		question.Options = append(question.Options, &inputs.QuestionOption{TextOption: option, Answer: correct})
+	}
+
+	if !found {
+		return nil, r.skip(fmt.Sprintf("answer %q is not one of the options", strings.TrimSpace(answers[0])))
+	}
+
+	return &Question{File: r.file, Question: question}, nil
+}
+
+// qtiAttribute returns the value of the element's attribute with the given name
+func qtiAttribute(element xml.StartElement, name string) string {
+	for _, attribute := range element.Attr {
+		if attribute.Name.Local == name {
+			return attribute.Value
+		}
+	}
+
+	return ""
+}
+
+// qtiText collapses the whitespace of every line and leaves out empty lines, which come from indentation
+func qtiText(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// qtiLines escapes text for an item's body, with a line break element for every line break
+func qtiLines(text string) string {
+	lines := strings.Split(text, "\n")
+	for index, line := range lines {
+		var buffer bytes.Buffer
+
+		// Writing to a buffer doesn't fail
+		_ = xml.EscapeText(&buffer, []byte(line))
+		lines[index] = buffer.String()
+	}
+
+	return strings.Join(lines, "<br/>")
+}
+
+func (q *QTI) Write(questions []*domain.MultipleChoiceQuestion) ([]byte, []*Problem) {
+	var problems []*Problem
+	var buffer bytes.Buffer
+
+	archive := zip.NewWriter(&buffer)
+
+	manifest := &qtiManifest{
+		Namespace:     qtiContentPackageNamespace,
+		Identifier:    "manifest",
+		Schema:        "QTIv2.1 Package",
+		SchemaVersion: "1.0.0",
+	}
+
+	hasCategories := false
+	hasDurations := false
+	for index, question := range sortedQuestions(questions) {
+		identifier := fmt.Sprintf("item-%d", index+1)
+		href := "items/" + identifier + ".xml"
+
+		hasCategories = hasCategories || question.Category != ""
+		hasDurations = hasDurations || question.DurationInSeconds > 0
+
+		manifest.Resources = append(manifest.Resources, &qtiResource{Identifier: identifier, Type: qtiItemType, Href: href, Files: []*qtiFile{{Href: href}}})
+		writeQTIFile(archive, href, newQTIItem(identifier, question))
+	}
+
+	writeQTIFile(archive, qtiManifestFile, manifest)
+
+	// Writing to a buffer doesn't fail
+	_ = archive.Close()
+
+	if hasCategories {
+		problems = append(problems, &Problem{Reason: "QTI items have no categories, they were left out"})
+	}
+
+	if hasDurations {
+		problems = append(problems, &Problem{Reason: "QTI items have no durations, they were left out"})
+	}
+
+	return buffer.Bytes(), problems
+}
+
+// newQTIItem turns a question into an item with a choice interaction, the description is the prompt if
+// there is one
+func newQTIItem(identifier string, question *domain.MultipleChoiceQuestion) *qtiItem {
+	item := &qtiItem{Namespace: qtiItemNamespace, Identifier: identifier, Title: question.Title}
+
+	item.ResponseDeclaration.Identifier = "RESPONSE"
+	item.ResponseDeclaration.Cardinality = "single"
+	item.ResponseDeclaration.BaseType = "identifier"
+
+	item.OutcomeDeclaration.Identifier = "SCORE"
+	item.OutcomeDeclaration.Cardinality = "single"
+	item.OutcomeDeclaration.BaseType = "float"
+
+	item.ChoiceInteraction.ResponseIdentifier = "RESPONSE"
+	item.ChoiceInteraction.MaxChoices = 1
+	prompt, _ := questionText(question)
+	item.ChoiceInteraction.Prompt = &qtiPrompt{Content: qtiLines(prompt)}
+
+	for index, option := range question.Options {
+		choice := &qtiChoice{Identifier: fmt.Sprintf("choice-%d", index+1), Text: option.TextOption}
+		item.ChoiceInteraction.Choices = append(item.ChoiceInteraction.Choices, choice)
+
+		if option.ID == question.AnswerID {
+			item.ResponseDeclaration.CorrectResponse = choice.Identifier
+		}
+	}
+
+	item.ResponseProcessing.Template = qtiMatchCorrectTemplate
+
+	return item
+}
+
+// writeQTIFile adds an XML document to the package
+func writeQTIFile(archive *zip.Writer, name string, document any) {
+	content, _ := xml.MarshalIndent(document, "", "  ")
+
+	// Writing to a buffer doesn't fail
+	writer, _ := archive.Create(name)
+	_, _ = writer.Write([]byte(xml.Header))
+	_, _ = writer.Write(content)
+	_, _ = writer.Write([]byte("\n"))
+}
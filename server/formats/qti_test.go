@@ -0,0 +1,232 @@
+package formats
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// zipDirectory packs a directory of testdata the way an LMS would export it
+func zipDirectory(t *testing.T, directory string) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+
+	root := filepath.Join("testdata", directory)
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		name, _ := filepath.Rel(root, path)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		writer, err := archive.Create(filepath.ToSlash(name))
+		if err != nil {
+			return err
+		}
+
+		_, err = writer.Write(content)
+		return err
+	})
+
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if err := archive.Close(); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	return buffer.Bytes()
+}
+
+// unzip returns the files in a package by their name
+func unzip(t *testing.T, content []byte) map[string][]byte {
+	t.Helper()
+
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	result := map[string][]byte{}
+	for _, file := range archive.File {
+		reader, _ := file.Open()
+		result[file.Name], _ = io.ReadAll(reader)
+		_ = reader.Close()
+	}
+
+	return result
+}
+
+func TestQTI_Parse_MatchesGoldenFile(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	content := zipDirectory(t, "lms.qti")
+
+	// Act
+	questions, problems := new(QTI).Parse(content)
+
+	// Assert
+	result, _ := json.MarshalIndent(map[string]any{"questions": questions, "problems": problems}, "", "  ")
+	assertGolden(t, "lms.qti.golden.json", append(result, '\n'))
+}
+
+func TestQTI_Parse_ReturnsProblemOnInvalidPackage(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		content  []byte
+		expected *Problem
+	}{
+		"not a zip": {
+			content:  []byte("<manifest/>"),
+			expected: &Problem{Reason: "the package is not a zip file", Skipped: true},
+		},
+		"no manifest": {
+			content:  zipDirectory(t, "lms.qti/items"),
+			expected: &Problem{File: "imsmanifest.xml", Reason: "the file is missing from the package", Skipped: true},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			questions, problems := new(QTI).Parse(testData.content)
+
+			// Assert
+			assert.Empty(t, questions)
+			assert.Equal(t, []*Problem{testData.expected}, problems)
+		})
+	}
+}
+
+// repeatedItemPackage returns a package whose manifest lists the same item the given amount of times
+func repeatedItemPackage(t *testing.T, item []byte, count int) []byte {
+	t.Helper()
+
+	manifest := &qtiManifest{Namespace: qtiContentPackageNamespace}
+	for index := 0; index < count; index++ {
+		manifest.Resources = append(manifest.Resources, &qtiResource{Identifier: fmt.Sprintf("item-%d", index), Type: qtiItemType, Href: "item.xml"})
+	}
+
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	writeQTIFile(archive, qtiManifestFile, manifest)
+
+	writer, _ := archive.Create("item.xml")
+	_, _ = writer.Write(item)
+
+	if err := archive.Close(); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	return buffer.Bytes()
+}
+
+func TestQTI_Parse_StopsAtLimits(t *testing.T) {
+	t.Parallel()
+	item := unzip(t, zipDirectory(t, "lms.qti"))["items/capital.xml"]
+	padded := append(bytes.Repeat([]byte(" "), maxQTIFileSize-len(item)), item...)
+
+	tests := map[string]struct {
+		content   []byte
+		questions int
+		expected  *Problem
+	}{
+		"too many items": {
+			content:   repeatedItemPackage(t, item, maxQTIItems+5),
+			questions: maxQTIItems,
+			expected:  &Problem{File: "item.xml", Reason: fmt.Sprintf("the package has more than %d items, the rest was left out", maxQTIItems), Skipped: true},
+		},
+		"unpacks too large": {
+			content: repeatedItemPackage(t, padded, 20),
+			// The manifest counts towards the size as well
+			questions: maxQTIPackageSize/maxQTIFileSize - 1,
+			expected:  &Problem{File: "item.xml", Reason: fmt.Sprintf("the package unpacks to more than %d bytes, the rest was left out", maxQTIPackageSize), Skipped: true},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			questions, problems := new(QTI).Parse(testData.content)
+
+			// Assert
+			assert.Len(t, questions, testData.questions)
+			if assert.NotEmpty(t, problems) {
+				assert.Equal(t, testData.expected, problems[len(problems)-1])
+			}
+		})
+	}
+}
+
+func TestQTI_Write_MatchesGoldenFiles(t *testing.T) {
+	t.Parallel()
+	// Act
+	result, problems := new(QTI).Write(exportQuestions())
+
+	// Assert
+	files := unzip(t, result)
+
+	names := make([]string, 0, len(files))
+	for name, content := range files {
+		names = append(names, name)
+		assertGolden(t, filepath.Join("export.qti", filepath.FromSlash(name)), content)
+	}
+
+	sort.Strings(names)
+	assert.Equal(t, []string{"imsmanifest.xml", "items/item-1.xml", "items/item-2.xml", "items/item-3.xml", "items/item-4.xml", "items/item-5.xml"}, names)
+
+	expected := []*Problem{
+		{Reason: "QTI items have no categories, they were left out"},
+		{Reason: "QTI items have no durations, they were left out"},
+	}
+	assert.Equal(t, expected, problems)
+}
+
+func TestQTI_Parse_ReadsWhatWasWritten(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	format := new(QTI)
+	questions := sortedQuestions(exportQuestions())
+
+	content, _ := format.Write(questions)
+
+	// Act
+	result, problems := format.Parse(content)
+
+	// Assert
+	assert.Empty(t, problems)
+
+	if assert.Len(t, result, len(questions)) {
+		for index, question := range questions {
+			parsed := result[index].Question
+			assert.Equal(t, question.Title, parsed.Title)
+			assert.Equal(t, question.Description, parsed.Description)
+
+			if assert.Len(t, parsed.Options, len(question.Options)) {
+				for optionIndex, option := range question.Options {
+					assert.Equal(t, option.TextOption, parsed.Options[optionIndex].TextOption)
+					assert.Equal(t, option.ID == question.AnswerID, parsed.Options[optionIndex].Answer)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package inputs
+
+import "github.com/survivorbat/qq.maarten.dev/server/domain"
+
+type APIKey struct {
+	Name   string   `json:"name" binding:"required,min=3,max=30" example:"CMS upload"`
+	Scopes []string `json:"scopes" binding:"omitempty,dive,oneof=quizzes:read quizzes:write games:control" example:"quizzes:write"` // desc: Leave empty to allow everything
+}
+
+func (a APIKey) ToDomain() *domain.APIKey {
+	scopes := make([]domain.APIKeyScope, len(a.Scopes))
+	for index, scope := range a.Scopes {
+		scopes[index] = domain.APIKeyScope(scope)
+	}
+
+	return &domain.APIKey{
+		Name:   a.Name,
+		Scopes: scopes,
+	}
+}
@@ -0,0 +1,5 @@
+package inputs
+
+type Avatar struct {
+	Avatar string `json:"avatar" binding:"max=30" example:"moon"` // desc: One of the built-in avatars, leave empty to use a generated one
+}
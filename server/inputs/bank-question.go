@@ -0,0 +1,60 @@
+package inputs
+
+import (
+	"github.com/google/uuid"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+)
+
+type BankQuestion struct {
+	Title             string            `json:"title" binding:"required,min=3,max=30" example:"What is the best city?"`
+	Description       string            `json:"description" example:"Subjective, but whatever ;)"`
+	DurationInSeconds uint              `json:"durationInSeconds" binding:"required,min=5,max=60" example:"15"`
+	Category          string            `json:"category" binding:"required,min=3" example:"Geography"`
+	Options           []*QuestionOption `json:"options" binding:"required,min=2,max=4"`
+	Tags              []string          `json:"tags" binding:"omitempty,max=10,dive,min=2,max=30" example:"capitals"` // desc: Optional, helps finding the question in the bank
+	OrganizationID    *uuid.UUID        `json:"organizationID" example:"00000000-0000-0000-0000-000000000000"`        // desc: Optional, shares the question with the organization
+}
+
+func (b BankQuestion) IsValid() (bool, any, string, string, string, string) {
+	if !hasOneAnswer(b.Options) {
+		return true, nil, "Options", "Options", "hasOneAnswer", "must have exactly one answer"
+	}
+
+	return false, "", "", "", "", ""
+}
+
+func (b BankQuestion) ToDomain() *domain.BankQuestion {
+	result := &domain.BankQuestion{
+		Title:             b.Title,
+		Description:       b.Description,
+		DurationInSeconds: b.DurationInSeconds,
+		Category:          b.Category,
+		OrganizationID:    b.OrganizationID,
+		Options:           make([]*domain.BankOption, len(b.Options)),
+		Tags:              domain.NewBankQuestionTags(b.Tags),
+	}
+
+	for index, option := range b.Options {
+		result.Options[index] = &domain.BankOption{ID: NewUuid(), TextOption: option.TextOption}
+
+		if option.Answer {
+			result.AnswerID = result.Options[index].ID
+		}
+	}
+
+	return result
+}
+
+// BankQuestionSearch is read from the query string
+type BankQuestionSearch struct {
+	Query    string `form:"q" binding:"omitempty,max=100" example:"capital"`
+	Category string `form:"category" binding:"omitempty,max=30" example:"Geography"`
+	Tag      string `form:"tag" binding:"omitempty,max=30" example:"capitals"`
+}
+
+// BankReference adds a bank question to a quiz
+type BankReference struct {
+	BankQuestionID uuid.UUID `json:"bankQuestionID" binding:"required" example:"00000000-0000-0000-0000-000000000000"`
+	Linked         bool      `json:"linked" example:"true"` // desc: Optional, a linked question follows changes to the bank question, otherwise it's a copy
+	Order          uint      `json:"order" example:"0"`     // desc: Determines the order of this question in the quiz
+}
@@ -0,0 +1,76 @@
+package inputs
+
+import "github.com/survivorbat/qq.maarten.dev/server/domain"
+
+// BundleVersion is the version of the bundle format, it changes whenever existing bundles can no
+// longer be read the same way
+const BundleVersion = 1
+
+// QuizBundle is a portable document of a quiz that can be kept in version control, the order of
+// the questions is the order in which they're asked
+type QuizBundle struct {
+	Version            uint                  `json:"version" yaml:"version" binding:"required,eq=1" example:"1"` // desc: Version of the bundle format
+	Name               string                `json:"name" yaml:"name" example:"My awesome quiz"`
+	Description        string                `json:"description,omitempty" yaml:"description,omitempty" example:"This is going to be amazing"`
+	Language           string                `json:"language,omitempty" yaml:"language,omitempty" example:"en"`
+	Tags               []string              `json:"tags,omitempty" yaml:"tags,omitempty" example:"history"`
+	Public             bool                  `json:"public,omitempty" yaml:"public,omitempty" example:"false"`
+	Template           bool                  `json:"template,omitempty" yaml:"template,omitempty" example:"false"`
+	MaxConcurrentGames uint                  `json:"maxConcurrentGames,omitempty" yaml:"maxConcurrentGames,omitempty" example:"1"`
+	QuestionPools      []*BundleQuestionPool `json:"questionPools,omitempty" yaml:"questionPools,omitempty"`
+	Questions          []*BundleQuestion     `json:"questions" yaml:"questions" binding:"dive"`
+}
+
+type BundleQuestionPool struct {
+	Category  string `json:"category,omitempty" yaml:"category,omitempty" example:"Geography"`
+	DrawCount uint   `json:"drawCount" yaml:"drawCount" example:"5"`
+}
+
+type BundleQuestion struct {
+	Type              domain.QuestionType `json:"type" yaml:"type" binding:"omitempty,oneof=mc" example:"mc"` // desc: Optional, the type of question, defaults to mc
+	Title             string              `json:"title" yaml:"title" example:"What is the best city?"`
+	Description       string              `json:"description,omitempty" yaml:"description,omitempty" example:"Subjective, but whatever ;)"`
+	DurationInSeconds uint                `json:"durationInSeconds" yaml:"durationInSeconds" example:"15"`
+	Category          string              `json:"category" yaml:"category" example:"Geography"`
+	Options           []*BundleOption     `json:"options" yaml:"options"`
+}
+
+type BundleOption struct {
+	TextOption string `json:"textOption" yaml:"textOption" example:"Rome"`
+	Answer     bool   `json:"answer,omitempty" yaml:"answer,omitempty" example:"true"` // desc: Marks this option as the answer
+}
+
+// ToQuiz turns the bundle into the input used to create quizzes, so it's validated by the same rules
+func (q QuizBundle) ToQuiz() *Quiz {
+	result := &Quiz{
+		Name:               q.Name,
+		Description:        q.Description,
+		Language:           q.Language,
+		Tags:               q.Tags,
+		Public:             q.Public,
+		Template:           q.Template,
+		MaxConcurrentGames: q.MaxConcurrentGames,
+	}
+
+	for _, pool := range q.QuestionPools {
+		result.QuestionPools = append(result.QuestionPools, &QuestionPool{Category: pool.Category, DrawCount: pool.DrawCount})
+	}
+
+	for index, question := range q.Questions {
+		mcQuestion := &MultipleChoiceQuestion{
+			Title:             question.Title,
+			Description:       question.Description,
+			DurationInSeconds: question.DurationInSeconds,
+			Category:          question.Category,
+			Order:             uint(index),
+		}
+
+		for _, option := range question.Options {
+			mcQuestion.Options = append(mcQuestion.Options, &QuestionOption{TextOption: option.TextOption, Answer: option.Answer})
+		}
+
+		result.MultipleChoiceQuestions = append(result.MultipleChoiceQuestions, mcQuestion)
+	}
+
+	return result
+}
@@ -0,0 +1,19 @@
+package inputs
+
+import (
+	"github.com/google/uuid"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+)
+
+type Collaborator struct {
+	CreatorID uuid.UUID `json:"creatorID" binding:"required" example:"00000000-0000-0000-0000-000000000000"`
+	Role      string    `json:"role" binding:"required,oneof=editor host viewer" example:"host"` // desc: Ownership can not be shared
+}
+
+func (c Collaborator) ToDomain(quizID uuid.UUID) *domain.Collaborator {
+	return &domain.Collaborator{
+		QuizID:    quizID,
+		CreatorID: c.CreatorID,
+		Role:      domain.Role(c.Role),
+	}
+}
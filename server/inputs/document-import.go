@@ -0,0 +1,17 @@
+package inputs
+
+// DocumentImport is read from the query string of an import of another tool's document. The formats
+// don't have durations and not all of them have categories, so those are the same for every question.
+type DocumentImport struct {
+	Name              string `form:"name" binding:"required,min=3,max=30" example:"My awesome quiz"`
+	Category          string `form:"category,default=General" binding:"min=3" example:"Geography"`     // desc: Optional, used for questions without a category, defaults to General
+	DurationInSeconds uint   `form:"durationInSeconds,default=20" binding:"min=5,max=60" example:"20"` // desc: Optional, the duration of every question, defaults to 20
+	SkipUnsupported   bool   `form:"skipUnsupported" example:"true"`                                   // desc: Optional, imports the other questions if some are not supported
+	DryRun            bool   `form:"dryRun" example:"true"`                                            // desc: Optional, only returns a preview and the problems without creating the quiz
+}
+
+// TextImport is read from the query string of a GIFT or Aiken import
+type TextImport struct {
+	DocumentImport
+	Format string `form:"format" binding:"required,oneof=gift aiken" example:"gift"`
+}
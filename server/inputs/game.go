@@ -1,5 +1,93 @@
 package inputs
 
+import (
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"time"
+)
+
 type Game struct {
-	PlayerLimit uint `json:"playerLimit" example:"25" binding:"required,min=2,max=25"` // desc: The max amount of players that may join this game
+	PlayerLimit uint   `json:"playerLimit" example:"25" binding:"required,min=2,max=25"`   // desc: The max amount of players that may join this game
+	Locale      string `json:"locale" example:"nl" binding:"omitempty,bcp47_language_tag"` // desc: Optional, language of generated nicknames
+
+	Teams       []string `json:"teams" example:"Marketing,Sales" binding:"omitempty,min=2,max=10,unique,dive,min=1,max=30"` // desc: Optional, players are divided over these teams
+	TeamScoring string   `json:"teamScoring" example:"average" binding:"omitempty,oneof=sum average best"`                  // desc: How the scores of a team's players are combined, defaults to sum
+
+	Mode      string    `json:"mode" example:"self-paced" binding:"omitempty,oneof=live self-paced"` // desc: Optional, defaults to live
+	OpenTime  time.Time `json:"openTime" binding:"required_if=Mode self-paced"`                      // desc: Self-paced only, players may join and answer from this time
+	CloseTime time.Time `json:"closeTime" binding:"required_if=Mode self-paced"`                     // desc: Self-paced only, the game finishes at this time
+
+	ScheduledStart  time.Time `json:"scheduledStart"`  // desc: Optional, the game starts by itself at this time
+	ScheduledFinish time.Time `json:"scheduledFinish"` // desc: Optional and live only, the game finishes by itself at this time
+
+	ShuffleQuestions        bool  `json:"shuffleQuestions"`        // desc: Optional, asks the questions in a random order
+	ShuffleOptions          bool  `json:"shuffleOptions"`          // desc: Optional, shows the options of every question in a random order
+	ShuffleOptionsPerPlayer bool  `json:"shuffleOptionsPerPlayer"` // desc: Optional, gives every player their own random order of options
+	Seed                    int64 `json:"seed" example:"42"`       // desc: Optional, reuse the seed of an earlier game to get the same orders, picked at random otherwise
+}
+
+func (g Game) IsValid() (bool, any, string, string, string, string) {
+	if g.Mode == string(domain.GameModeSelfPaced) && !g.CloseTime.After(g.OpenTime) {
+		return true, nil, "CloseTime", "closeTime", "isAfterOpenTime", "must be after the open time"
+	}
+
+	if !g.ScheduledStart.IsZero() && !g.ScheduledStart.After(time.Now()) {
+		return true, nil, "ScheduledStart", "scheduledStart", "isInFuture", "must be in the future"
+	}
+
+	if !g.ScheduledFinish.IsZero() && !g.isValidScheduledFinish() {
+		return true, nil, "ScheduledFinish", "scheduledFinish", "isValidScheduledFinish", "must be after the scheduled start and not be used in self-paced games"
+	}
+
+	return false, "", "", "", "", ""
+}
+
+// isValidScheduledFinish verifies that the finish is after the start, self-paced games use their close time instead
+func (g Game) isValidScheduledFinish() bool {
+	if g.Mode == string(domain.GameModeSelfPaced) {
+		return false
+	}
+
+	start := time.Now()
+	if !g.ScheduledStart.IsZero() {
+		start = g.ScheduledStart
+	}
+
+	return g.ScheduledFinish.After(start)
+}
+
+func (g *Game) ToDomain() *domain.Game {
+	result := &domain.Game{
+		PlayerLimit: g.PlayerLimit,
+		Locale:      g.Locale,
+		Mode:        domain.GameModeLive,
+
+		ScheduledStart:  g.ScheduledStart,
+		ScheduledFinish: g.ScheduledFinish,
+
+		ShuffleQuestions:        g.ShuffleQuestions,
+		ShuffleOptions:          g.ShuffleOptions,
+		ShuffleOptionsPerPlayer: g.ShuffleOptionsPerPlayer,
+		Seed:                    g.Seed,
+	}
+
+	if g.Mode == string(domain.GameModeSelfPaced) {
+		result.Mode = domain.GameModeSelfPaced
+		result.OpenTime = g.OpenTime
+		result.CloseTime = g.CloseTime
+	}
+
+	if len(g.Teams) == 0 {
+		return result
+	}
+
+	for _, name := range g.Teams {
+		result.Teams = append(result.Teams, &domain.Team{Name: name})
+	}
+
+	result.TeamScoring = domain.TeamScoringSum
+	if g.TeamScoring != "" {
+		result.TeamScoring = domain.TeamScoring(g.TeamScoring)
+	}
+
+	return result
 }
@@ -0,0 +1,59 @@
+package inputs
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGame_IsValid_ReturnsExpectedValue(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		input        Game
+		expectedTag  string
+		expectedFail bool
+	}{
+		"live": {
+			input: Game{},
+		},
+		"self-paced": {
+			input: Game{Mode: "self-paced", OpenTime: time.Now(), CloseTime: time.Now().Add(time.Hour)},
+		},
+		"self-paced closes before opening": {
+			input:        Game{Mode: "self-paced", OpenTime: time.Now(), CloseTime: time.Now().Add(-time.Hour)},
+			expectedTag:  "isAfterOpenTime",
+			expectedFail: true,
+		},
+		"scheduled": {
+			input: Game{ScheduledStart: time.Now().Add(time.Hour), ScheduledFinish: time.Now().Add(2 * time.Hour)},
+		},
+		"scheduled in the past": {
+			input:        Game{ScheduledStart: time.Now().Add(-time.Hour)},
+			expectedTag:  "isInFuture",
+			expectedFail: true,
+		},
+		"finishes before start": {
+			input:        Game{ScheduledStart: time.Now().Add(2 * time.Hour), ScheduledFinish: time.Now().Add(time.Hour)},
+			expectedTag:  "isValidScheduledFinish",
+			expectedFail: true,
+		},
+		"finish on self-paced": {
+			input:        Game{Mode: "self-paced", OpenTime: time.Now(), CloseTime: time.Now().Add(time.Hour), ScheduledFinish: time.Now().Add(time.Hour)},
+			expectedTag:  "isValidScheduledFinish",
+			expectedFail: true,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			failed, _, _, _, tag, _ := testData.input.IsValid()
+
+			// Assert
+			assert.Equal(t, testData.expectedFail, failed)
+			assert.Equal(t, testData.expectedTag, tag)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package inputs
+
+// LibrarySearch is read from the query string
+type LibrarySearch struct {
+	Query    string `form:"q" binding:"omitempty,max=100" example:"capitals"`
+	Tag      string `form:"tag" binding:"omitempty,max=30" example:"history"`
+	Language string `form:"language" binding:"omitempty,bcp47_language_tag" example:"en"`
+	Sort     string `form:"sort" binding:"omitempty,oneof=popular newest name" example:"popular"` // desc: Optional, popular (default) sorts by the amount of games played
+
+	Page     int `form:"page" binding:"omitempty,min=1" example:"1"`
+	PageSize int `form:"pageSize" binding:"omitempty,min=1,max=100" example:"20"`
+}
+
+// defaultPageSize is used if no page size is given
+const defaultPageSize = 20
+
+// GetPage returns the page, starting at 1
+func (l LibrarySearch) GetPage() int {
+	if l.Page == 0 {
+		return 1
+	}
+
+	return l.Page
+}
+
+func (l LibrarySearch) GetPageSize() int {
+	if l.PageSize == 0 {
+		return defaultPageSize
+	}
+
+	return l.PageSize
+}
@@ -0,0 +1,45 @@
+package inputs
+
+import (
+	"github.com/google/uuid"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"time"
+)
+
+type Organization struct {
+	Name string `json:"name" binding:"required,min=3,max=50" example:"Math department"`
+}
+
+func (o Organization) ToDomain() *domain.Organization {
+	return &domain.Organization{Name: o.Name}
+}
+
+type Invitation struct {
+	CreatorID uuid.UUID `json:"creatorID" binding:"required" example:"00000000-0000-0000-0000-000000000000"`
+	Role      string    `json:"role" binding:"required,oneof=admin member" example:"member"`
+}
+
+// InvitationDuration determines how long an invitation can be accepted
+const InvitationDuration = 7 * 24 * time.Hour
+
+func (i Invitation) ToDomain(organizationID uuid.UUID, invitedByID uuid.UUID) *domain.Invitation {
+	return &domain.Invitation{
+		OrganizationID: organizationID,
+		CreatorID:      i.CreatorID,
+		InvitedByID:    invitedByID,
+		Role:           domain.OrganizationRole(i.Role),
+		ExpiresAt:      time.Now().Add(InvitationDuration),
+	}
+}
+
+type Member struct {
+	Role string `json:"role" binding:"required,oneof=admin member" example:"admin"`
+}
+
+func (m Member) ToDomain(organizationID uuid.UUID, creatorID uuid.UUID) *domain.Member {
+	return &domain.Member{
+		OrganizationID: organizationID,
+		CreatorID:      creatorID,
+		Role:           domain.OrganizationRole(m.Role),
+	}
+}
@@ -0,0 +1,22 @@
+package inputs
+
+import (
+	"github.com/google/uuid"
+	"strings"
+)
+
+type Player struct {
+	Nickname string `json:"nickname" binding:"omitempty,min=2,max=20" example:"Quiz Wizard"` // desc: Optional, a random nickname is generated if left empty
+	Avatar   string `json:"avatar" binding:"max=30" example:"moon"`                          // desc: Optional, one of the built-in avatars, a generated one is used if left empty
+
+	TeamID *uuid.UUID `json:"teamID" example:"00000000-0000-0000-0000-000000000000"` // desc: Optional, players are put in the smallest team if left empty
+}
+
+func (p Player) GetNickname() string {
+	return strings.TrimSpace(p.Nickname)
+}
+
+type Rename struct {
+	PlayerID uuid.UUID `json:"playerID" binding:"required" example:"00000000-0000-0000-0000-000000000000"`
+	Nickname string    `json:"nickname" binding:"omitempty,min=2,max=20" example:"Quiz Wizard"` // desc: Leave empty to generate a random nickname
+}
@@ -0,0 +1,18 @@
+package inputs
+
+import (
+	"github.com/google/uuid"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+)
+
+type PresenterToken struct {
+	Name string `json:"name" binding:"required,min=3,max=30" example:"Big screen room 2"`
+}
+
+func (p PresenterToken) ToDomain(gameID uuid.UUID, creatorID uuid.UUID) *domain.PresenterToken {
+	return &domain.PresenterToken{
+		Name:      p.Name,
+		GameID:    gameID,
+		CreatorID: creatorID,
+	}
+}
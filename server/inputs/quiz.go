@@ -6,24 +6,29 @@ import (
 )
 
 type QuestionOption struct {
-	TextOption string `json:"textOption" example:"Rome"` // desc: Only one value must be filled in
-	Answer     bool   `json:"answer" example:"true"`     // desc: Marks this option as the answer, should only be used once
+	ID         uuid.UUID `json:"id" example:"00000000-0000-0000-0000-000000000000"` // desc: Optional, keeps the ID of an option of the quiz
+	TextOption string    `json:"textOption" example:"Rome"`                         // desc: Only one value must be filled in
+	Answer     bool      `json:"answer" example:"true"`                             // desc: Marks this option as the answer, should only be used once
 }
 
 type MultipleChoiceQuestion struct {
-	Title             string `json:"title" binding:"required,min=3,max=30" example:"What is the best city?"`
-	Description       string `json:"description" example:"Subjective, but whatever ;)"`
-	DurationInSeconds uint   `json:"durationInSeconds" binding:"required,min=5,max=60" example:"15"`
-	Category          string `json:"category" binding:"required,min=3" example:"Geography"`
-	Order             uint   `json:"order" example:"0"` // desc: Determines the order of this question in the quiz
+	ID                uuid.UUID  `json:"id" example:"00000000-0000-0000-0000-000000000000"`                       // desc: Optional, keeps the ID of a question of the quiz
+	BankQuestionID    *uuid.UUID `json:"bankQuestionID,omitempty" example:"00000000-0000-0000-0000-000000000000"` // desc: Optional, the bank question this question came from
+	Linked            bool       `json:"linked,omitempty" example:"false"`                                        // desc: Optional, linked questions follow changes to their bank question
+	Title             string     `json:"title" binding:"required,min=3,max=30" example:"What is the best city?"`
+	Description       string     `json:"description" example:"Subjective, but whatever ;)"`
+	DurationInSeconds uint       `json:"durationInSeconds" binding:"required,min=5,max=60" example:"15"`
+	Category          string     `json:"category" binding:"required,min=3" example:"Geography"`
+	Order             uint       `json:"order" example:"0"` // desc: Determines the order of this question in the quiz
 
 	Options []*QuestionOption `json:"options" binding:"required,dive,min=2,max=4"`
 }
 
-func (m MultipleChoiceQuestion) hasOneAnswer() bool {
+// hasOneAnswer verifies that exactly one of the options is marked as the answer
+func hasOneAnswer(options []*QuestionOption) bool {
 	var foundAnswer bool
 
-	for _, option := range m.Options {
+	for _, option := range options {
 		if option.Answer {
 			// Only one answer allowed
 			if foundAnswer {
@@ -38,17 +43,77 @@ func (m MultipleChoiceQuestion) hasOneAnswer() bool {
 }
 
 func (m MultipleChoiceQuestion) IsValid() (bool, any, string, string, string, string) {
-	if !m.hasOneAnswer() {
+	if !hasOneAnswer(m.Options) {
 		return true, nil, "Options", "Options", "hasOneAnswer", "must have exactly one answer"
 	}
 
 	return false, "", "", "", "", ""
 }
 
+func (m MultipleChoiceQuestion) ToDomain() *domain.MultipleChoiceQuestion {
+	result := &domain.MultipleChoiceQuestion{
+		BaseQuestion: domain.BaseQuestion{
+			BaseObject:        domain.BaseObject{ID: m.ID},
+			Title:             m.Title,
+			Description:       m.Description,
+			DurationInSeconds: m.DurationInSeconds,
+			Category:          m.Category,
+			Order:             m.Order,
+		},
+		BankQuestionID: m.BankQuestionID,
+		Linked:         m.Linked,
+		Options:        make([]*domain.QuestionOption, len(m.Options)),
+	}
+
+	for index, mcOption := range m.Options {
+		optionID := mcOption.ID
+		if optionID == uuid.Nil {
+			optionID = NewUuid()
+		}
+
+		result.Options[index] = &domain.QuestionOption{
+			BaseObject: domain.BaseObject{ID: optionID},
+			TextOption: mcOption.TextOption,
+		}
+
+		if mcOption.Answer {
+			result.AnswerID = result.Options[index].ID
+		}
+	}
+
+	return result
+}
+
+// QuestionUpdate changes a single question without touching its options
+type QuestionUpdate struct {
+	Title             string `json:"title" binding:"required,min=3,max=30" example:"What is the best city?"`
+	Description       string `json:"description" example:"Subjective, but whatever ;)"`
+	DurationInSeconds uint   `json:"durationInSeconds" binding:"required,min=5,max=60" example:"15"`
+	Category          string `json:"category" binding:"required,min=3" example:"Geography"`
+	Order             *uint  `json:"order" example:"0"` // desc: Optional, moves the question to this position, the other questions shift along
+}
+
+// QuestionOrder lists every question of a quiz in the order they should be asked
+type QuestionOrder struct {
+	QuestionIDs []uuid.UUID `json:"questionIDs" binding:"required,min=1,max=100"`
+}
+
+type QuestionPool struct {
+	Category  string `json:"category" binding:"omitempty,min=3" example:"Geography"` // desc: Optional, draws from all questions that are not in another pool's category if empty
+	DrawCount uint   `json:"drawCount" binding:"required,min=1,max=100" example:"5"`
+}
+
 type Quiz struct {
 	Name                    string                    `json:"name" binding:"required,min=3,max=30" example:"My awesome quiz"`
 	Description             string                    `json:"description" binding:"omitempty,max=250" example:"This is going to be amazing"`
-	MultipleChoiceQuestions []*MultipleChoiceQuestion `json:"multipleChoiceQuestions" binding:"required,dive,max=20"`
+	MultipleChoiceQuestions []*MultipleChoiceQuestion `json:"multipleChoiceQuestions" binding:"required,max=100,dive"`
+	QuestionPools           []*QuestionPool           `json:"questionPools" binding:"omitempty,max=20,dive"`                       // desc: Optional, every game draws its questions from these pools
+	OrganizationID          *uuid.UUID                `json:"organizationID" example:"00000000-0000-0000-0000-000000000000"`       // desc: Optional, shares the quiz with the organization
+	MaxConcurrentGames      uint                      `json:"maxConcurrentGames" binding:"omitempty,max=20" example:"3"`           // desc: Optional, the amount of live games that may run at the same time, defaults to 1
+	Public                  bool                      `json:"public" example:"false"`                                              // desc: Optional, lets anyone find and fork the quiz
+	Template                bool                      `json:"template" example:"false"`                                            // desc: Optional, shows the quiz in the template gallery
+	Tags                    []string                  `json:"tags" binding:"omitempty,max=10,dive,min=2,max=30" example:"history"` // desc: Optional, helps others find the quiz in the library
+	Language                string                    `json:"language" binding:"omitempty,bcp47_language_tag" example:"en"`        // desc: Optional, the language the quiz is written in
 }
 
 func (q Quiz) IsValid() (bool, any, string, string, string, string) {
@@ -60,6 +125,10 @@ func (q Quiz) IsValid() (bool, any, string, string, string, string) {
 		return true, nil, "Questions", "questions", "hasAnyQuestions", "No questions"
 	}
 
+	if !q.HasValidPools() {
+		return true, nil, "QuestionPools", "questionPools", "hasValidPools", "Categories must be unique and have enough questions to draw from"
+	}
+
 	return false, "", "", "", "", ""
 }
 
@@ -68,6 +137,41 @@ func (q Quiz) hasAnyQuestions() bool {
 	return len(q.MultipleChoiceQuestions) > 0
 }
 
+// HasValidPools verifies that no category is used twice and that every pool can draw all its questions
+func (q Quiz) HasValidPools() bool {
+	categories := map[string]uint{}
+	for _, question := range q.MultipleChoiceQuestions {
+		categories[question.Category]++
+	}
+
+	seen := map[string]bool{}
+	remaining := uint(len(q.MultipleChoiceQuestions))
+	for _, pool := range q.QuestionPools {
+		if seen[pool.Category] {
+			return false
+		}
+
+		seen[pool.Category] = true
+
+		if pool.Category != "" {
+			if categories[pool.Category] < pool.DrawCount {
+				return false
+			}
+
+			remaining -= categories[pool.Category]
+		}
+	}
+
+	// The pool without a category draws from what the other pools leave alone
+	for _, pool := range q.QuestionPools {
+		if pool.Category == "" && remaining < pool.DrawCount {
+			return false
+		}
+	}
+
+	return true
+}
+
 // hasValidOrder verifies whether the questions are ordered correctly
 func (q Quiz) hasValidOrder() bool {
 	var count uint
@@ -89,34 +193,78 @@ var NewUuid = uuid.New
 func (q Quiz) ToDomain() *domain.Quiz {
 	mcQuestions := make([]*domain.MultipleChoiceQuestion, len(q.MultipleChoiceQuestions))
 	for index, mcQuestion := range q.MultipleChoiceQuestions {
-		result := &domain.MultipleChoiceQuestion{
-			BaseQuestion: domain.BaseQuestion{
-				Title:             mcQuestion.Title,
-				Description:       mcQuestion.Description,
-				DurationInSeconds: mcQuestion.DurationInSeconds,
-				Category:          mcQuestion.Category,
-				Order:             mcQuestion.Order,
-			},
-			Options: make([]*domain.QuestionOption, len(mcQuestion.Options)),
-		}
-
-		for index, mcOption := range mcQuestion.Options {
-			result.Options[index] = &domain.QuestionOption{
-				BaseObject: domain.BaseObject{ID: NewUuid()},
-				TextOption: mcOption.TextOption,
-			}
+		mcQuestions[index] = mcQuestion.ToDomain()
+	}
 
-			if mcOption.Answer {
-				result.AnswerID = result.Options[index].ID
-			}
-		}
+	var pools []*domain.QuestionPool
+	for _, pool := range q.QuestionPools {
+		pools = append(pools, &domain.QuestionPool{Category: pool.Category, DrawCount: pool.DrawCount})
+	}
 
-		mcQuestions[index] = result
+	maxConcurrentGames := q.MaxConcurrentGames
+	if maxConcurrentGames == 0 {
+		maxConcurrentGames = 1
 	}
 
 	return &domain.Quiz{
 		Name:                    q.Name,
 		Description:             q.Description,
 		MultipleChoiceQuestions: mcQuestions,
+		OrganizationID:          q.OrganizationID,
+		MaxConcurrentGames:      maxConcurrentGames,
+		QuestionPools:           pools,
+		Public:                  q.Public,
+		Template:                q.Template,
+		Tags:                    domain.NewQuizTags(q.Tags),
+		Language:                q.Language,
+	}
+}
+
+// NewQuiz turns the quiz back into the input it could have been made with, including the IDs of its
+// questions and options so they can be edited as a draft
+func NewQuiz(quiz *domain.Quiz) *Quiz {
+	result := &Quiz{
+		Name:               quiz.Name,
+		Description:        quiz.Description,
+		OrganizationID:     quiz.OrganizationID,
+		MaxConcurrentGames: quiz.MaxConcurrentGames,
+		Public:             quiz.Public,
+		Template:           quiz.Template,
+		Tags:               quiz.TagNames(),
+		Language:           quiz.Language,
+	}
+
+	for _, pool := range quiz.QuestionPools {
+		result.QuestionPools = append(result.QuestionPools, &QuestionPool{Category: pool.Category, DrawCount: pool.DrawCount})
+	}
+
+	for _, question := range quiz.MultipleChoiceQuestions {
+		result.MultipleChoiceQuestions = append(result.MultipleChoiceQuestions, NewMultipleChoiceQuestion(question))
+	}
+
+	return result
+}
+
+// NewMultipleChoiceQuestion turns the question back into input, keeping the IDs of the question and its options
+func NewMultipleChoiceQuestion(question *domain.MultipleChoiceQuestion) *MultipleChoiceQuestion {
+	result := &MultipleChoiceQuestion{
+		ID:                question.ID,
+		BankQuestionID:    question.BankQuestionID,
+		Linked:            question.Linked,
+		Title:             question.Title,
+		Description:       question.Description,
+		DurationInSeconds: question.DurationInSeconds,
+		Category:          question.Category,
+		Order:             question.Order,
+	}
+
+	for _, option := range question.Options {
+		result.Options = append(result.Options, &QuestionOption{
+			ID:         option.ID,
+			TextOption: option.TextOption,
+			Answer:     option.ID == question.AnswerID,
+		})
 	}
+
+	return result
 }
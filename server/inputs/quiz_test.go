@@ -5,7 +5,7 @@ import (
 	"testing"
 )
 
-func TestMultipleChoiceQuestion_HasOneAnswer_ReturnsExpectedValue(t *testing.T) {
+func TestHasOneAnswer_ReturnsExpectedValue(t *testing.T) {
 	t.Parallel()
 	tests := map[string]struct {
 		input    *MultipleChoiceQuestion
@@ -34,7 +34,7 @@ func TestMultipleChoiceQuestion_HasOneAnswer_ReturnsExpectedValue(t *testing.T)
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 			// Act
-			result := testData.input.hasOneAnswer()
+			result := hasOneAnswer(testData.input.Options)
 
 			// Assert
 			assert.Equal(t, testData.expected, result)
@@ -99,3 +99,57 @@ func TestQuiz_HasValidOrder_ReturnsExpectedResult(t *testing.T) {
 		})
 	}
 }
+
+func TestQuiz_HasValidPools_ReturnsExpectedResult(t *testing.T) {
+	t.Parallel()
+	questions := []*MultipleChoiceQuestion{
+		{Category: "Geography"},
+		{Category: "Geography"},
+		{Category: "Math"},
+		{Category: "History"},
+	}
+
+	tests := map[string]struct {
+		pools    []*QuestionPool
+		expected bool
+	}{
+		"no pools": {
+			expected: true,
+		},
+		"pools by category": {
+			pools:    []*QuestionPool{{Category: "Geography", DrawCount: 2}, {Category: "Math", DrawCount: 1}},
+			expected: true,
+		},
+		"pool for the rest": {
+			pools:    []*QuestionPool{{Category: "Geography", DrawCount: 1}, {DrawCount: 2}},
+			expected: true,
+		},
+		"duplicate category": {
+			pools:    []*QuestionPool{{Category: "Geography", DrawCount: 1}, {Category: "Geography", DrawCount: 1}},
+			expected: false,
+		},
+		"too few questions in category": {
+			pools:    []*QuestionPool{{Category: "Math", DrawCount: 2}},
+			expected: false,
+		},
+		"too few questions for the rest": {
+			pools:    []*QuestionPool{{Category: "Geography", DrawCount: 1}, {DrawCount: 3}},
+			expected: false,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			quiz := &Quiz{MultipleChoiceQuestions: questions, QuestionPools: testData.pools}
+
+			// Act
+			result := quiz.HasValidPools()
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package inputs
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Columns of a question spreadsheet, the header row may name them in any order and case
+const (
+	ColumnTitle         = "title"
+	ColumnDescription   = "description"
+	ColumnCategory      = "category"
+	ColumnDuration      = "duration"
+	ColumnCorrectOption = "correct option"
+)
+
+// maxSheetOptions is the amount of option columns, option 1 up to option 4
+const maxSheetOptions = 4
+
+// columnAliases lets the header use a few other common names for the columns
+var columnAliases = map[string]string{
+	"question":            ColumnTitle,
+	"duration in seconds": ColumnDuration,
+	"seconds":             ColumnDuration,
+	"answer":              ColumnCorrectOption,
+	"correct":             ColumnCorrectOption,
+	"correct answer":      ColumnCorrectOption,
+}
+
+// SheetImport is read from the query string of a spreadsheet import
+type SheetImport struct {
+	Name   string `form:"name" binding:"required,min=3,max=30" example:"My awesome quiz"`
+	DryRun bool   `form:"dryRun" example:"true"` // desc: Optional, only returns a preview and the errors without creating the quiz
+}
+
+// SheetError is a cell that could not be turned into part of a question
+type SheetError struct {
+	Column string
+	Rule   string
+	Param  string
+}
+
+// SheetRow is a question read from a row of a spreadsheet
+type SheetRow struct {
+	Number   int // The number of the row in the spreadsheet, the header is row 1
+	Question *MultipleChoiceQuestion
+	Errors   []*SheetError
+}
+
+// ReadQuestionSheet maps the rows below the header onto questions, rows without any values are
+// skipped. If the header misses columns, it's returned with errors and no questions are read.
+// The question's order is left to the caller, since invalid rows are left out.
+func ReadQuestionSheet(rows [][]string) (*SheetRow, []*SheetRow) {
+	header := &SheetRow{Number: 1}
+	if len(rows) == 0 {
+		header.Errors = append(header.Errors, &SheetError{Column: ColumnTitle, Rule: "required"})
+		return header, nil
+	}
+
+	columns := map[string]int{}
+	for index, name := range rows[0] {
+		name = normalizeColumn(name)
+		if alias, ok := columnAliases[name]; ok {
+			name = alias
+		}
+
+		if _, ok := columns[name]; !ok && name != "" {
+			columns[name] = index
+		}
+	}
+
+	for _, required := range []string{ColumnTitle, ColumnCategory, ColumnDuration, ColumnCorrectOption, optionColumn(1), optionColumn(2)} {
+		if _, ok := columns[required]; !ok {
+			header.Errors = append(header.Errors, &SheetError{Column: required, Rule: "required"})
+		}
+	}
+
+	if len(header.Errors) > 0 {
+		return header, nil
+	}
+
+	var result []*SheetRow
+	for index, row := range rows[1:] {
+		if isEmptyRow(row) {
+			continue
+		}
+
+		result = append(result, readQuestionRow(index+2, row, columns))
+	}
+
+	return header, result
+}
+
+func readQuestionRow(number int, row []string, columns map[string]int) *SheetRow {
+	cell := func(column string) string {
+		index, ok := columns[column]
+		if !ok || index >= len(row) {
+			return ""
+		}
+
+		return strings.TrimSpace(row[index])
+	}
+
+	result := &SheetRow{
+		Number: number,
+		Question: &MultipleChoiceQuestion{
+			Title:       cell(ColumnTitle),
+			Description: cell(ColumnDescription),
+			Category:    cell(ColumnCategory),
+		},
+	}
+
+	if duration := cell(ColumnDuration); duration != "" {
+		// Spreadsheets store every number as a float
+		seconds, err := strconv.ParseFloat(duration, 64)
+		if err != nil || seconds < 0 || seconds != float64(uint(seconds)) {
+			result.Errors = append(result.Errors, &SheetError{Column: ColumnDuration, Rule: "numeric"})
+		} else {
+			result.Question.DurationInSeconds = uint(seconds)
+		}
+	}
+
+	// Empty option columns are skipped, but the correct option still refers to the column's number
+	numbered := map[int]*QuestionOption{}
+	for number := 1; number <= maxSheetOptions; number++ {
+		if text := cell(optionColumn(number)); text != "" {
+			numbered[number] = &QuestionOption{TextOption: text}
+			result.Question.Options = append(result.Question.Options, numbered[number])
+		}
+	}
+
+	if len(result.Question.Options) < 2 {
+		result.Errors = append(result.Errors, &SheetError{Column: optionColumn(2), Rule: "required"})
+	}
+
+	correct := cell(ColumnCorrectOption)
+	answer := correctOption(correct, numbered, result.Question.Options)
+	if answer == nil {
+		result.Errors = append(result.Errors, &SheetError{Column: ColumnCorrectOption, Rule: "correctOption", Param: correct})
+	} else {
+		answer.Answer = true
+	}
+
+	return result
+}
+
+// correctOption finds the option by the number of its column or, if it's not a number, by its text
+func correctOption(value string, numbered map[int]*QuestionOption, options []*QuestionOption) *QuestionOption {
+	if number, err := strconv.ParseFloat(value, 64); err == nil {
+		if number != float64(int(number)) {
+			return nil
+		}
+
+		return numbered[int(number)]
+	}
+
+	for _, option := range options {
+		if value != "" && strings.EqualFold(option.TextOption, value) {
+			return option
+		}
+	}
+
+	return nil
+}
+
+func optionColumn(number int) string {
+	return "option " + strconv.Itoa(number)
+}
+
+// normalizeColumn lowercases the header and turns underscores and repeated spaces into single spaces
+func normalizeColumn(header string) string {
+	header = strings.ReplaceAll(strings.ToLower(header), "_", " ")
+
+	// Option1 is as common as Option 1
+	if strings.HasPrefix(header, "option") && len(header) == len("option")+1 {
+		header = "option " + header[len("option"):]
+	}
+
+	return strings.Join(strings.Fields(header), " ")
+}
+
+func isEmptyRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,123 @@
+package inputs
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestReadQuestionSheet_ReturnsMissingColumns(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	rows := [][]string{{"Question", "Category", "Option1", "Answer"}}
+
+	// Act
+	header, questions := ReadQuestionSheet(rows)
+
+	// Assert
+	expected := []*SheetError{
+		{Column: ColumnDuration, Rule: "required"},
+		{Column: "option 2", Rule: "required"},
+	}
+	assert.Equal(t, expected, header.Errors)
+	assert.Nil(t, questions)
+}
+
+func TestReadQuestionSheet_ReadsQuestions(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	rows := [][]string{
+		{"Title", "Description", "Category", "Duration_In_Seconds", "Option 1", "Option 2", "Option 3", "Option 4", "Correct Option"},
+		{"Capital of France", "", "Geography", "15", "Lyon", "Paris", "", "", "2"},
+		{"", "", "", "", "", "", "", "", ""},
+		{"Capital of Spain", "Easy", "Geography", "20.0", "Madrid", "", "Barcelona", "", "3"},
+		{"Capital of Italy", "", "Geography", "20", "Rome", "Milan", "", "", "rome"},
+	}
+
+	// Act
+	header, questions := ReadQuestionSheet(rows)
+
+	// Assert
+	assert.Empty(t, header.Errors)
+
+	expected := []*SheetRow{
+		{
+			Number: 2,
+			Question: &MultipleChoiceQuestion{
+				Title:             "Capital of France",
+				Category:          "Geography",
+				DurationInSeconds: 15,
+				Options:           []*QuestionOption{{TextOption: "Lyon"}, {TextOption: "Paris", Answer: true}},
+			},
+		},
+		{
+			Number: 4,
+			Question: &MultipleChoiceQuestion{
+				Title:             "Capital of Spain",
+				Description:       "Easy",
+				Category:          "Geography",
+				DurationInSeconds: 20,
+				Options:           []*QuestionOption{{TextOption: "Madrid"}, {TextOption: "Barcelona", Answer: true}},
+			},
+		},
+		{
+			Number: 5,
+			Question: &MultipleChoiceQuestion{
+				Title:             "Capital of Italy",
+				Category:          "Geography",
+				DurationInSeconds: 20,
+				Options:           []*QuestionOption{{TextOption: "Rome", Answer: true}, {TextOption: "Milan"}},
+			},
+		},
+	}
+	assert.Equal(t, expected, questions)
+}
+
+func TestReadQuestionSheet_ReturnsCellErrors(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		row      []string
+		expected []*SheetError
+	}{
+		"text duration": {
+			row:      []string{"Capital of France", "Geography", "long", "Paris", "Lyon", "1"},
+			expected: []*SheetError{{Column: ColumnDuration, Rule: "numeric"}},
+		},
+		"negative duration": {
+			row:      []string{"Capital of France", "Geography", "-5", "Paris", "Lyon", "1"},
+			expected: []*SheetError{{Column: ColumnDuration, Rule: "numeric"}},
+		},
+		"one option": {
+			row:      []string{"Capital of France", "Geography", "15", "Paris", "", "1"},
+			expected: []*SheetError{{Column: "option 2", Rule: "required"}},
+		},
+		"correct option is empty": {
+			row:      []string{"Capital of France", "Geography", "15", "Paris", "Lyon", "3"},
+			expected: []*SheetError{{Column: ColumnCorrectOption, Rule: "correctOption", Param: "3"}},
+		},
+		"unknown correct option": {
+			row:      []string{"Capital of France", "Geography", "15", "Paris", "Lyon", "Marseille"},
+			expected: []*SheetError{{Column: ColumnCorrectOption, Rule: "correctOption", Param: "Marseille"}},
+		},
+		"no correct option": {
+			row:      []string{"Capital of France", "Geography", "15", "Paris", "Lyon", ""},
+			expected: []*SheetError{{Column: ColumnCorrectOption, Rule: "correctOption"}},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			rows := [][]string{{"title", "category", "duration", "option 1", "option 2", "correct option"}, testData.row}
+
+			// Act
+			_, questions := ReadQuestionSheet(rows)
+
+			// Assert
+			if assert.Len(t, questions, 1) {
+				assert.Equal(t, testData.expected, questions[0].Errors)
+			}
+		})
+	}
+}
@@ -1,9 +1,11 @@
 package server
 
 import (
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/survivorbat/qq.maarten.dev/server/coordinator"
 	"github.com/survivorbat/qq.maarten.dev/server/domain"
@@ -18,17 +20,19 @@ import (
 	"golang.org/x/oauth2/google"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"strings"
+	"time"
 )
 
 var databaseOpen = postgres.Open
 
-func NewServer(connectionString string, jwtSecret string, oAuthID string, oAuthSecret string, authRedirectUrl string) (*Server, error) {
+func NewServer(connectionString string, jwtSecret string, oAuthID string, oAuthSecret string, authRedirectUrl string, options ...Option) (*Server, error) {
 	db, err := gorm.Open(databaseOpen(connectionString))
 	if err != nil {
 		return nil, err
 	}
 
-	return &Server{
+	result := &Server{
 		database:  db,
 		jwtSecret: jwtSecret,
 		oAuthConfig: &oauth2.Config{
@@ -38,7 +42,13 @@ func NewServer(connectionString string, jwtSecret string, oAuthID string, oAuthS
 			Scopes:       []string{"openid"},
 			Endpoint:     google.Endpoint,
 		},
-	}, nil
+	}
+
+	for _, option := range options {
+		option(result)
+	}
+
+	return result, nil
 }
 
 type Server struct {
@@ -57,9 +67,72 @@ type Server struct {
 	playerHandler         *routes.PlayerHandler
 	publicGameHandler     *routes.PublicGameHandler
 	gameConnectionHandler *routes.GameConnectionHandler
+	apiKeyHandler         *routes.APIKeyHandler
+	collaboratorHandler   *routes.CollaboratorHandler
+	organizationHandler   *routes.OrganizationHandler
+	rateLimitHandler      *routes.RateLimitHandler
+	avatarHandler         *routes.AvatarHandler
+	presenterTokenHandler *routes.PresenterTokenHandler
+	quizRevisionHandler   *routes.QuizRevisionHandler
+	questionHandler       *routes.QuestionHandler
+	libraryHandler        *routes.LibraryHandler
+	bankQuestionHandler   *routes.BankQuestionHandler
+
+	// scheduler starts and finishes games at their scheduled time
+	scheduler coordinator.GameScheduler
+
+	// rateLimits falls back to DefaultRateLimitConfig if not set
+	rateLimits *RateLimitConfig
+
+	// joinCodes falls back to 6 alphanumeric characters if not set
+	joinCodes services.JoinCodeGenerator
+
+	// nicknameBlocklist falls back to services.DefaultBlocklist if not set
+	nicknameBlocklist []string
+
+	// nicknameAllowlist falls back to services.DefaultAllowlist if not set
+	nicknameAllowlist []string
+
+	// nicknames falls back to services.DefaultWordLists if not set
+	nicknames services.NicknameGenerator
 }
 
 func (s *Server) Configure(router *gin.Engine) error {
+	if s.joinCodes != nil {
+		if err := binding.Validator.ValidateStruct(s.joinCodes); err != nil {
+			logrus.WithError(err).Error("Invalid join codes")
+			return err
+		}
+	}
+
+	if generator, ok := s.nicknames.(*services.WordListNicknameGenerator); ok {
+		if err := binding.Validator.ValidateStruct(generator); err != nil {
+			logrus.WithError(err).Error("Invalid nickname word lists")
+			return err
+		}
+
+		defaultLocale := generator.DefaultLocale
+		if defaultLocale == "" {
+			defaultLocale = services.DefaultLocale
+		}
+
+		if _, ok := generator.Lists[defaultLocale]; !ok {
+			err := fmt.Errorf("no nickname word list for default locale %q", defaultLocale)
+			logrus.WithError(err).Error("Invalid nickname word lists")
+			return err
+		}
+	}
+
+	if err := s.freeFinishedGameCodes(); err != nil {
+		logrus.WithError(err).Error("Failed to free codes")
+		return err
+	}
+
+	if err := s.numberDuplicateNicknames(); err != nil {
+		logrus.WithError(err).Error("Failed to number nicknames")
+		return err
+	}
+
 	if err := s.database.AutoMigrate(
 		&domain.Game{},
 		&domain.Quiz{},
@@ -67,38 +140,186 @@ func (s *Server) Configure(router *gin.Engine) error {
 		&domain.Player{},
 		&domain.QuestionOption{},
 		&domain.GameAnswer{},
+		&domain.APIKey{},
+		&domain.Collaborator{},
+		&domain.Organization{},
+		&domain.Member{},
+		&domain.Invitation{},
+		&domain.Team{},
+		&domain.PresenterToken{},
+		&domain.QuestionPool{},
+		&domain.GameQuestion{},
+		&domain.QuizRevision{},
+		&domain.QuizDraft{},
+		&domain.QuizTag{},
+		&domain.BankQuestion{},
+		&domain.BankQuestionTag{},
 	); err != nil {
 		logrus.WithError(err).Error("Failed to migrate")
 		return err
 	}
 
+	if err := services.CreateSearchIndexes(s.database); err != nil {
+		logrus.WithError(err).Error("Failed to create search indexes")
+		return err
+	}
+
 	s.configureServices()
 	s.configureRoutes(router)
 	s.configureValidator()
+
+	if err := s.scheduler.Restore(); err != nil {
+		logrus.WithError(err).Error("Failed to restore scheduled games")
+		return err
+	}
+
+	return nil
+}
+
+// freeFinishedGameCodes clears codes of games that finished before codes were freed on finish,
+// otherwise they would clash with the unique index on open codes
+func (s *Server) freeFinishedGameCodes() error {
+	if !s.database.Migrator().HasTable(new(domain.Game)) {
+		return nil
+	}
+
+	return s.database.Model(new(domain.Game)).Where("finish_time > ? AND code <> ''", time.Time{}).Update("code", "").Error
+}
+
+// numberDuplicateNicknames numbers nicknames that players in the same game shared before nicknames
+// were unique, otherwise they would clash with the unique index on nicknames
+func (s *Server) numberDuplicateNicknames() error {
+	migrator := s.database.Migrator()
+	if !migrator.HasTable(new(domain.Player)) || migrator.HasIndex(new(domain.Player), "idx_player_nickname") {
+		return nil
+	}
+
+	var players []*domain.Player
+	if err := s.database.Where("nickname <> ''").Order("created_at").Find(&players).Error; err != nil {
+		return err
+	}
+
+	taken := map[uuid.UUID]map[string]bool{}
+	for _, player := range players {
+		if taken[player.GameID] == nil {
+			taken[player.GameID] = map[string]bool{}
+		}
+
+		taken[player.GameID][strings.ToLower(player.Nickname)] = true
+	}
+
+	kept := map[uuid.UUID]map[string]bool{}
+	for _, player := range players {
+		if kept[player.GameID] == nil {
+			kept[player.GameID] = map[string]bool{}
+		}
+
+		nickname := strings.ToLower(player.Nickname)
+		if !kept[player.GameID][nickname] {
+			kept[player.GameID][nickname] = true
+			continue
+		}
+
+		numbered := player.Nickname
+		for number := 2; taken[player.GameID][strings.ToLower(numbered)]; number++ {
+			numbered = fmt.Sprintf("%s %d", player.Nickname, number)
+		}
+
+		taken[player.GameID][strings.ToLower(numbered)] = true
+		kept[player.GameID][strings.ToLower(numbered)] = true
+
+		if err := s.database.Model(player).Update("nickname", numbered).Error; err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (s *Server) configureServices() {
+	if s.nicknames == nil {
+		s.nicknames = &services.WordListNicknameGenerator{Lists: services.DefaultWordLists()}
+	}
+
 	quizService := &services.DBQuizService{Database: s.database}
-	creatorService := &services.DBCreatorService{Database: s.database}
-	gameService := &services.DBGameService{Database: s.database}
-	playerService := &services.DBPlayerService{Database: s.database}
+	creatorService := &services.DBCreatorService{Database: s.database, Nicknames: s.nicknames}
+	if s.joinCodes == nil {
+		s.joinCodes = &services.RandomJoinCodeGenerator{Length: 6}
+	}
+
+	gameService := &services.DBGameService{Database: s.database, JoinCodes: s.joinCodes}
+	if s.nicknameBlocklist == nil {
+		s.nicknameBlocklist = services.DefaultBlocklist()
+	}
+
+	if s.nicknameAllowlist == nil {
+		s.nicknameAllowlist = services.DefaultAllowlist()
+	}
+
+	playerService := &services.DBPlayerService{
+		Database:       s.database,
+		NicknameFilter: &services.BlocklistNicknameFilter{Words: s.nicknameBlocklist, Allowed: s.nicknameAllowlist},
+		Nicknames:      s.nicknames,
+	}
+	apiKeyService := &services.DBAPIKeyService{Database: s.database}
+	collaboratorService := &services.DBCollaboratorService{Database: s.database}
+	organizationService := &services.DBOrganizationService{Database: s.database}
+	avatarService := &services.EmbeddedAvatarService{}
+	presenterTokenService := &services.DBPresenterTokenService{Database: s.database}
+	quizRevisionService := &services.DBQuizRevisionService{Database: s.database}
+	quizDraftService := &services.DBQuizDraftService{Database: s.database}
+	libraryService := &services.DBLibraryService{Database: s.database}
+	bankQuestionService := &services.DBBankQuestionService{Database: s.database}
+	spreadsheetReader := &services.FileSpreadsheetReader{}
 
-	gameCoordinator := &coordinator.LocalGameCoordinator{GameService: gameService}
+	gameCoordinator := &coordinator.LocalGameCoordinator{GameService: gameService, PlayerService: playerService}
+	s.scheduler = &coordinator.TimerGameScheduler{GameService: gameService, Coordinator: gameCoordinator}
+
+	if s.rateLimits == nil {
+		s.rateLimits = DefaultRateLimitConfig()
+	}
+
+	s.rateLimitHandler = &routes.RateLimitHandler{
+		IPLimiter:   &services.MemoryRateLimiter{Rate: s.rateLimits.IPRate, Burst: s.rateLimits.IPBurst},
+		GameLimiter: &services.MemoryRateLimiter{Rate: s.rateLimits.GameRate, Burst: s.rateLimits.GameBurst},
+	}
 
 	s.jwtService = &services.HMacJwtService{SecretKey: s.jwtSecret, Issuer: "QQ"}
 
-	s.tokenHandler = &routes.TokenHandler{CreatorService: creatorService, JwtService: s.jwtService, AuthConfig: s.oAuthConfig}
-	s.quizHandler = &routes.QuizHandler{QuizService: quizService}
-	s.creatorHandler = &routes.CreatorHandler{CreatorService: creatorService}
-	s.gameControlHandler = &routes.GameControlHandler{GameService: gameService, QuizService: quizService}
-	s.playerHandler = &routes.PlayerHandler{PlayerService: playerService, GameService: gameService}
-	s.publicGameHandler = &routes.PublicGameHandler{GameService: gameService}
+	s.tokenHandler = &routes.TokenHandler{CreatorService: creatorService, JwtService: s.jwtService, APIKeyService: apiKeyService, AuthConfig: s.oAuthConfig}
+	s.apiKeyHandler = &routes.APIKeyHandler{APIKeyService: apiKeyService}
+	s.collaboratorHandler = &routes.CollaboratorHandler{QuizService: quizService, CollaboratorService: collaboratorService}
+	s.organizationHandler = &routes.OrganizationHandler{OrganizationService: organizationService}
+	s.quizHandler = &routes.QuizHandler{
+		QuizService:         quizService,
+		QuizDraftService:    quizDraftService,
+		OrganizationService: organizationService,
+		BankQuestionService: bankQuestionService,
+		SpreadsheetReader:   spreadsheetReader,
+	}
+	s.creatorHandler = &routes.CreatorHandler{CreatorService: creatorService, AvatarService: avatarService}
+	s.gameControlHandler = &routes.GameControlHandler{GameService: gameService, QuizService: quizService, Scheduler: s.scheduler}
+	s.playerHandler = &routes.PlayerHandler{PlayerService: playerService, GameService: gameService, AvatarService: avatarService}
+	s.avatarHandler = &routes.AvatarHandler{AvatarService: avatarService}
+	s.presenterTokenHandler = &routes.PresenterTokenHandler{
+		GameService:           gameService,
+		PresenterTokenService: presenterTokenService,
+		Coordinator:           gameCoordinator,
+	}
+	s.quizRevisionHandler = &routes.QuizRevisionHandler{QuizService: quizService, QuizRevisionService: quizRevisionService, QuizDraftService: quizDraftService}
+	s.questionHandler = &routes.QuestionHandler{QuizService: quizService, QuizDraftService: quizDraftService, BankQuestionService: bankQuestionService}
+	s.libraryHandler = &routes.LibraryHandler{LibraryService: libraryService}
+	s.bankQuestionHandler = &routes.BankQuestionHandler{BankQuestionService: bankQuestionService, OrganizationService: organizationService}
+	s.publicGameHandler = &routes.PublicGameHandler{
+		GameService: gameService,
+		CodeLockout: &services.MemoryLockout{MaxAttempts: s.rateLimits.CodeAttempts, Duration: s.rateLimits.CodeLockout},
+	}
 	s.gameConnectionHandler = &routes.GameConnectionHandler{
-		GameService:    gameService,
-		PlayerService:  playerService,
-		CreatorService: creatorService,
-		Coordinator:    gameCoordinator,
+		GameService:           gameService,
+		PlayerService:         playerService,
+		CreatorService:        creatorService,
+		PresenterTokenService: presenterTokenService,
+		Coordinator:           gameCoordinator,
 	}
 }
 
@@ -110,28 +331,88 @@ func (s *Server) configureRoutes(router *gin.Engine) {
 	apiRoutes.Use(s.tokenHandler.JwtGuard())
 
 	apiRoutes.GET("/creators/self", s.creatorHandler.GetWithID)
-	apiRoutes.GET("/quizzes", s.quizHandler.Get)
-	apiRoutes.GET("/games/:id/players", s.playerHandler.Get)
-	apiRoutes.GET("/games/:id/connection", s.gameConnectionHandler.GetCreator)
-	apiRoutes.GET("/games/:id", s.gameControlHandler.GetByID)
+	apiRoutes.GET("/quizzes", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesRead), s.quizHandler.Get)
+	apiRoutes.GET("/games/:id/players", s.tokenHandler.ScopeGuard(domain.ScopeGamesControl), s.playerHandler.Get)
+	apiRoutes.GET("/games/:id/connection", s.tokenHandler.ScopeGuard(domain.ScopeGamesControl), s.gameConnectionHandler.GetCreator)
+	apiRoutes.GET("/games/:id", s.tokenHandler.ScopeGuard(domain.ScopeGamesControl), s.gameControlHandler.GetByID)
+	apiRoutes.GET("/games/:id/presenter-tokens", s.tokenHandler.ScopeGuard(domain.ScopeGamesControl), s.presenterTokenHandler.Get)
+	apiRoutes.GET("/games/:id/calendar", s.tokenHandler.ScopeGuard(domain.ScopeGamesControl), s.gameControlHandler.GetCalendar)
+	apiRoutes.GET("/games/:id/teams/leaderboard", s.tokenHandler.ScopeGuard(domain.ScopeGamesControl), s.gameControlHandler.GetTeamLeaderboard)
+	apiRoutes.GET("/api-keys", s.tokenHandler.SessionGuard(), s.apiKeyHandler.Get)
+	apiRoutes.GET("/quizzes/:id/games/active", s.tokenHandler.ScopeGuard(domain.ScopeGamesControl), s.gameControlHandler.GetActive)
+	apiRoutes.GET("/templates", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesRead), s.quizHandler.GetTemplates)
+	apiRoutes.GET("/quizzes/:id/export", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesRead), s.quizHandler.GetExport)
+	apiRoutes.GET("/quizzes/:id/draft", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesRead), s.quizHandler.GetDraft)
+	apiRoutes.GET("/quizzes/:id/revisions", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesRead), s.quizRevisionHandler.Get)
+	apiRoutes.GET("/quizzes/:id/revisions/diff", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesRead), s.quizRevisionHandler.GetDiff)
+	apiRoutes.GET("/quizzes/:id/collaborators", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesRead), s.collaboratorHandler.Get)
+	apiRoutes.GET("/bank-questions", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesRead), s.bankQuestionHandler.Get)
+	apiRoutes.GET("/bank-questions/:id", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesRead), s.bankQuestionHandler.GetByID)
+	apiRoutes.GET("/bank-questions/:id/stats", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesRead), s.bankQuestionHandler.GetStats)
+	apiRoutes.GET("/organizations", s.tokenHandler.SessionGuard(), s.organizationHandler.Get)
+	apiRoutes.GET("/organizations/:id", s.tokenHandler.SessionGuard(), s.organizationHandler.GetByID)
+	apiRoutes.GET("/invitations", s.tokenHandler.SessionGuard(), s.organizationHandler.GetInvitations)
 
-	apiRoutes.POST("/quizzes", s.quizHandler.Post)
-	apiRoutes.POST("/quizzes/:id/games", s.gameControlHandler.Post)
+	apiRoutes.POST("/quizzes", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.quizHandler.Post)
+	apiRoutes.POST("/quizzes/import", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.quizHandler.PostImport)
+	apiRoutes.POST("/quizzes/import/spreadsheet", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.quizHandler.PostSheetImport)
+	apiRoutes.POST("/quizzes/import/text", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.quizHandler.PostTextImport)
+	apiRoutes.POST("/quizzes/import/qti", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.quizHandler.PostQTIImport)
+	apiRoutes.POST("/quizzes/:id/clone", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.quizHandler.PostClone)
+	apiRoutes.POST("/quizzes/:id/fork", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.quizHandler.PostFork)
+	apiRoutes.POST("/quizzes/:id/draft/publish", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.quizHandler.PostPublish)
+	apiRoutes.POST("/quizzes/:id/revisions/:number/restore", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.quizRevisionHandler.PostRestore)
+	apiRoutes.POST("/quizzes/:id/questions", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.questionHandler.Post)
+	apiRoutes.POST("/quizzes/:id/questions/bank", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.questionHandler.PostFromBank)
+	apiRoutes.POST("/bank-questions", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.bankQuestionHandler.Post)
+	apiRoutes.POST("/quizzes/:id/questions/:question/options", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.questionHandler.PostOption)
+	apiRoutes.POST("/quizzes/:id/games", s.tokenHandler.ScopeGuard(domain.ScopeGamesControl), s.gameControlHandler.Post)
+	apiRoutes.POST("/games/:id/presenter-tokens", s.tokenHandler.ScopeGuard(domain.ScopeGamesControl), s.presenterTokenHandler.Post)
+	apiRoutes.POST("/api-keys", s.tokenHandler.SessionGuard(), s.apiKeyHandler.Post)
+	apiRoutes.POST("/organizations", s.tokenHandler.SessionGuard(), s.organizationHandler.Post)
+	apiRoutes.POST("/organizations/:id/invitations", s.tokenHandler.SessionGuard(), s.organizationHandler.PostInvitation)
 
-	apiRoutes.PUT("/tokens", s.tokenHandler.Refresh)
-	apiRoutes.PUT("/quizzes/:id", s.quizHandler.Put)
+	apiRoutes.PUT("/tokens", s.tokenHandler.SessionGuard(), s.tokenHandler.Refresh)
+	apiRoutes.PUT("/creators/self/avatar", s.tokenHandler.SessionGuard(), s.creatorHandler.PutAvatar)
+	apiRoutes.PUT("/quizzes/:id", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.quizHandler.Put)
+	apiRoutes.PUT("/quizzes/:id/draft", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.quizHandler.PutDraft)
+	apiRoutes.PUT("/quizzes/:id/questions/order", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.questionHandler.PutOrder)
+	apiRoutes.PUT("/quizzes/:id/questions/:question", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.questionHandler.Put)
+	apiRoutes.PUT("/quizzes/:id/questions/:question/options/:option", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.questionHandler.PutOption)
+	apiRoutes.PUT("/bank-questions/:id", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.bankQuestionHandler.Put)
+	apiRoutes.PUT("/quizzes/:id/collaborators", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.collaboratorHandler.Put)
+	apiRoutes.PUT("/organizations/:id/members/:creator", s.tokenHandler.SessionGuard(), s.organizationHandler.PutMember)
 
-	apiRoutes.PATCH("/games/:id", s.gameControlHandler.Patch)
+	apiRoutes.PATCH("/games/:id", s.tokenHandler.ScopeGuard(domain.ScopeGamesControl), s.gameControlHandler.Patch)
+	apiRoutes.PATCH("/invitations/:id", s.tokenHandler.SessionGuard(), s.organizationHandler.PatchInvitation)
 
-	apiRoutes.DELETE("/quizzes/:id", s.quizHandler.Delete)
-	apiRoutes.DELETE("/games/:id", s.gameControlHandler.Delete)
+	apiRoutes.DELETE("/quizzes/:id", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.quizHandler.Delete)
+	apiRoutes.DELETE("/quizzes/:id/draft", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.quizHandler.DeleteDraft)
+	apiRoutes.DELETE("/quizzes/:id/questions/:question", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.questionHandler.Delete)
+	apiRoutes.DELETE("/quizzes/:id/questions/:question/options/:option", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.questionHandler.DeleteOption)
+	apiRoutes.DELETE("/bank-questions/:id", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.bankQuestionHandler.Delete)
+	apiRoutes.DELETE("/games/:id", s.tokenHandler.ScopeGuard(domain.ScopeGamesControl), s.gameControlHandler.Delete)
+	apiRoutes.DELETE("/games/:id/presenter-tokens/:token", s.tokenHandler.ScopeGuard(domain.ScopeGamesControl), s.presenterTokenHandler.Delete)
+	apiRoutes.DELETE("/api-keys/:id", s.tokenHandler.SessionGuard(), s.apiKeyHandler.Delete)
+	apiRoutes.DELETE("/quizzes/:id/collaborators/:creator", s.tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite), s.collaboratorHandler.Delete)
+	apiRoutes.DELETE("/organizations/:id", s.tokenHandler.SessionGuard(), s.organizationHandler.Delete)
+	apiRoutes.DELETE("/organizations/:id/members/:creator", s.tokenHandler.SessionGuard(), s.organizationHandler.DeleteMember)
 
 	// Anonymous routes
 	publicRoutes := router.Group("/api/v1")
+	publicRoutes.Use(s.rateLimitHandler.IPGuard())
+
 	publicRoutes.GET("/games", s.publicGameHandler.GetByCode)
 	publicRoutes.GET("/games/:id/quiz", s.publicGameHandler.GetQuiz)
 	publicRoutes.GET("/games/:id/players/:player/connection", s.gameConnectionHandler.Get)
-	publicRoutes.POST("/games/:id/players", s.playerHandler.Post)
+	publicRoutes.GET("/games/:id/spectator/connection", s.gameConnectionHandler.GetSpectator)
+	publicRoutes.GET("/players/:id/progress", s.playerHandler.GetProgress)
+	publicRoutes.GET("/library", s.libraryHandler.Get)
+	publicRoutes.GET("/avatars", s.avatarHandler.Get)
+	publicRoutes.GET("/avatars/:id", s.avatarHandler.GetByID)
+	publicRoutes.POST("/games/:id/players", s.rateLimitHandler.GameGuard(), s.playerHandler.Post)
+	publicRoutes.POST("/players/:id/answers", s.playerHandler.PostAnswer)
+	publicRoutes.PATCH("/players/:id/progress", s.playerHandler.PatchProgress)
 	publicRoutes.DELETE("/players/:id", s.playerHandler.Delete)
 
 	// Swagger
@@ -143,6 +424,8 @@ func (s *Server) configureValidator() {
 	if val, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		val.RegisterStructValidation(inputs.IsValidator, new(inputs.Quiz))
 		val.RegisterStructValidation(inputs.IsValidator, new(inputs.MultipleChoiceQuestion))
+		val.RegisterStructValidation(inputs.IsValidator, new(inputs.Game))
+		val.RegisterStructValidation(inputs.IsValidator, new(inputs.BankQuestion))
 		return
 	}
 
@@ -14,6 +14,7 @@ import (
 	"github.com/survivorbat/qq.maarten.dev/server/domain"
 	"github.com/survivorbat/qq.maarten.dev/server/inputs"
 	"github.com/survivorbat/qq.maarten.dev/server/routes/outputs"
+	"github.com/survivorbat/qq.maarten.dev/server/services"
 	"golang.org/x/oauth2"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -67,8 +68,7 @@ func getValue[T any, K any](t *testing.T, res *http.Response, err error, getKey
 }
 
 func getCreator(id uuid.UUID) *domain.Creator {
-	result := &domain.Creator{BaseObject: domain.BaseObject{ID: id}, AuthID: id.String()}
-	result.GenerateNickname()
+	result := &domain.Creator{BaseObject: domain.BaseObject{ID: id}, AuthID: id.String(), Nickname: id.String()}
 	result.GenerateColors()
 	return result
 }
@@ -434,6 +434,22 @@ func TestNewServer_PutQuiz_UpdatesExistingQuiz(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, response.StatusCode)
 
+	// Only the draft changed so far
+	var unpublished *domain.Quiz
+	if err := instance.database.Where("id = ?", old.ID).First(&unpublished).Error; err != nil {
+		t.Fatal(err.Error())
+	}
+
+	assert.Equal(t, old.Name, unpublished.Name)
+
+	response, err = performRequest(http.MethodPost, ts.URL, "api/v1/quizzes/3660def9-bd13-4c94-b9cd-d449eef82503/draft/publish", token, nil)
+	assert.NoError(t, err)
+	if !assert.NotNil(t, response) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
 	var result *domain.Quiz
 	if err := instance.database.Preload("MultipleChoiceQuestions.Options").Where("id = ?", old.ID).First(&result).Error; err != nil {
 		t.Fatal(err.Error())
@@ -933,3 +949,362 @@ func TestNewServer_GameFlow_Works(t *testing.T) {
 		assert.Len(t, result.Answers, 4)
 	}
 }
+
+func TestNewServer_APIKey_AuthenticatesWithScopes(t *testing.T) {
+	// Arrange
+	instance := &Server{jwtSecret: "abc", oAuthConfig: &oauth2.Config{ClientID: "abc", ClientSecret: "abc", RedirectURL: "abc"}}
+	instance.database = gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+
+	// Test http server
+	engine := gin.Default()
+	_ = instance.Configure(engine)
+	ts := httptest.NewServer(engine)
+
+	userID := uuid.MustParse("7d87bab0-cf2d-45ae-bced-1de22db21a77")
+	token, _ := instance.jwtService.GenerateToken(userID.String())
+
+	quizzes := []*domain.Quiz{
+		{Name: "abc", Creator: getCreator(userID)},
+	}
+
+	// Populate database
+	populateDatabase(t, instance.database, quizzes...)
+
+	// Close it in the end
+	defer ts.Close()
+
+	input := &inputs.APIKey{Name: "CMS", Scopes: []string{"quizzes:read"}}
+	keyRes, err := performRequest(http.MethodPost, ts.URL, "api/v1/api-keys", token, input)
+	key := getValue(t, keyRes, err, func(output outputs.OutputCreatedAPIKey) string {
+		return output.Key
+	})
+
+	// Act
+	getResponse, getErr := performRequest(http.MethodGet, ts.URL, "api/v1/quizzes", key, nil)
+	postResponse, postErr := performRequest(http.MethodPost, ts.URL, "api/v1/quizzes", key, &inputs.Quiz{})
+	keyResponse, keyErr := performRequest(http.MethodPost, ts.URL, "api/v1/api-keys", key, input)
+
+	// Assert
+	assert.NoError(t, getErr)
+	assert.NoError(t, postErr)
+	assert.NoError(t, keyErr)
+
+	var result []*domain.Quiz
+	if gintestutil.Response(t, &result, http.StatusOK, getResponse) && assert.Len(t, result, 1) {
+		assert.Equal(t, quizzes[0].Name, result[0].Name)
+	}
+
+	assert.Equal(t, http.StatusForbidden, postResponse.StatusCode)
+	assert.Equal(t, http.StatusForbidden, keyResponse.StatusCode)
+}
+
+func TestNewServer_Collaborator_CanHostSharedQuiz(t *testing.T) {
+	// Arrange
+	instance := &Server{jwtSecret: "abc", oAuthConfig: &oauth2.Config{ClientID: "abc", ClientSecret: "abc", RedirectURL: "abc"}}
+	instance.database = gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+
+	// Test http server
+	engine := gin.Default()
+	_ = instance.Configure(engine)
+	ts := httptest.NewServer(engine)
+
+	ownerID := uuid.MustParse("7d87bab0-cf2d-45ae-bced-1de22db21a77")
+	ownerToken, _ := instance.jwtService.GenerateToken(ownerID.String())
+
+	hostID := uuid.MustParse("dc0057c9-553d-40aa-a0bf-6fb98990c634")
+	hostToken, _ := instance.jwtService.GenerateToken(hostID.String())
+
+	game := &domain.Game{
+		BaseObject: domain.BaseObject{ID: uuid.MustParse("342855cd-332c-4344-955e-a0e63be17f3a")},
+		Quiz: &domain.Quiz{
+			BaseObject:              domain.BaseObject{ID: uuid.MustParse("25e48148-3225-4ae9-a737-345b099bca72")},
+			Name:                    "def",
+			Creator:                 getCreator(ownerID),
+			MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{{}, {}},
+		},
+	}
+
+	// Populate database
+	populateDatabase(t, instance.database, game)
+	populateDatabase(t, instance.database, getCreator(hostID))
+
+	// Close it in the end
+	defer ts.Close()
+
+	// Act
+	forbiddenResponse, forbiddenErr := performRequest(http.MethodPatch, ts.URL, "api/v1/games/342855cd-332c-4344-955e-a0e63be17f3a?action=start", hostToken, nil)
+
+	input := &inputs.Collaborator{CreatorID: hostID, Role: "host"}
+	shareResponse, shareErr := performRequest(http.MethodPut, ts.URL, "api/v1/quizzes/25e48148-3225-4ae9-a737-345b099bca72/collaborators", ownerToken, input)
+
+	startResponse, startErr := performRequest(http.MethodPatch, ts.URL, "api/v1/games/342855cd-332c-4344-955e-a0e63be17f3a?action=start", hostToken, nil)
+	deleteResponse, deleteErr := performRequest(http.MethodDelete, ts.URL, "api/v1/quizzes/25e48148-3225-4ae9-a737-345b099bca72", hostToken, nil)
+
+	// Assert
+	assert.NoError(t, forbiddenErr)
+	assert.NoError(t, shareErr)
+	assert.NoError(t, startErr)
+	assert.NoError(t, deleteErr)
+
+	assert.Equal(t, http.StatusForbidden, forbiddenResponse.StatusCode)
+	assert.Equal(t, http.StatusOK, shareResponse.StatusCode)
+	assert.Equal(t, http.StatusOK, startResponse.StatusCode)
+	assert.Equal(t, http.StatusForbidden, deleteResponse.StatusCode)
+}
+
+func TestNewServer_Organization_SharesQuizzesWithMembers(t *testing.T) {
+	// Arrange
+	instance := &Server{jwtSecret: "abc", oAuthConfig: &oauth2.Config{ClientID: "abc", ClientSecret: "abc", RedirectURL: "abc"}}
+	instance.database = gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+
+	// Test http server
+	engine := gin.Default()
+	_ = instance.Configure(engine)
+	ts := httptest.NewServer(engine)
+
+	adminID := uuid.MustParse("7d87bab0-cf2d-45ae-bced-1de22db21a77")
+	adminToken, _ := instance.jwtService.GenerateToken(adminID.String())
+
+	memberID := uuid.MustParse("dc0057c9-553d-40aa-a0bf-6fb98990c634")
+	memberToken, _ := instance.jwtService.GenerateToken(memberID.String())
+
+	// Populate database
+	populateDatabase(t, instance.database, getCreator(adminID), getCreator(memberID))
+
+	// Close it in the end
+	defer ts.Close()
+
+	// Act
+	orgRes, err := performRequest(http.MethodPost, ts.URL, "api/v1/organizations", adminToken, &inputs.Organization{Name: "Math department"})
+	organizationID := getValue(t, orgRes, err, func(o domain.Organization) uuid.UUID { return o.ID })
+
+	invitationInput := &inputs.Invitation{CreatorID: memberID, Role: "member"}
+	inviteRes, err := performRequest(http.MethodPost, ts.URL, "api/v1/organizations/"+organizationID.String()+"/invitations", adminToken, invitationInput)
+	invitationID := getValue(t, inviteRes, err, func(i domain.Invitation) uuid.UUID { return i.ID })
+
+	invitationsRes, err := performRequest(http.MethodGet, ts.URL, "api/v1/invitations", memberToken, nil)
+	invitations := getValue(t, invitationsRes, err, func(i []*domain.Invitation) int { return len(i) })
+
+	acceptRes, acceptErr := performRequest(http.MethodPatch, ts.URL, "api/v1/invitations/"+invitationID.String()+"?action=accept", memberToken, nil)
+
+	quizInput := &inputs.Quiz{
+		Name:           "Shared quiz",
+		OrganizationID: &organizationID,
+		MultipleChoiceQuestions: []*inputs.MultipleChoiceQuestion{
+			{
+				Title:             "What is 2+2",
+				DurationInSeconds: 15,
+				Category:          "Math",
+				Options:           []*inputs.QuestionOption{{TextOption: "4", Answer: true}, {TextOption: "3"}},
+			},
+		},
+	}
+	quizRes, err := performRequest(http.MethodPost, ts.URL, "api/v1/quizzes", adminToken, quizInput)
+	quizID := getValue(t, quizRes, err, func(q domain.Quiz) uuid.UUID { return q.ID })
+
+	mineRes, err := performRequest(http.MethodGet, ts.URL, "api/v1/quizzes", memberToken, nil)
+	mine := getValue(t, mineRes, err, func(q []*domain.Quiz) int { return len(q) })
+
+	orgQuizzesRes, err := performRequest(http.MethodGet, ts.URL, "api/v1/quizzes?scope=organization", memberToken, nil)
+	orgQuizzes := getValue(t, orgQuizzesRes, err, func(q []*domain.Quiz) []*domain.Quiz { return q })
+
+	gameRes, gameErr := performRequest(http.MethodPost, ts.URL, "api/v1/quizzes/"+quizID.String()+"/games", memberToken, inputs.Game{PlayerLimit: 5})
+	deleteRes, deleteErr := performRequest(http.MethodDelete, ts.URL, "api/v1/quizzes/"+quizID.String(), memberToken, nil)
+
+	// Assert
+	assert.Equal(t, 1, invitations)
+
+	assert.NoError(t, acceptErr)
+	assert.Equal(t, http.StatusOK, acceptRes.StatusCode)
+
+	assert.Equal(t, 0, mine)
+	if assert.Len(t, orgQuizzes, 1) {
+		assert.Equal(t, quizID, orgQuizzes[0].ID)
+	}
+
+	assert.NoError(t, gameErr)
+	assert.Equal(t, http.StatusOK, gameRes.StatusCode)
+
+	assert.NoError(t, deleteErr)
+	assert.Equal(t, http.StatusForbidden, deleteRes.StatusCode)
+}
+
+func TestNewServer_RateLimits_ThrottlesPublicRoutes(t *testing.T) {
+	// Arrange
+	instance := &Server{jwtSecret: "abc", oAuthConfig: &oauth2.Config{ClientID: "abc", ClientSecret: "abc", RedirectURL: "abc"}}
+	instance.database = gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+
+	WithRateLimits(&RateLimitConfig{IPRate: 0.01, IPBurst: 3, GameRate: 0.01, GameBurst: 1, CodeAttempts: 2, CodeLockout: time.Minute})(instance)
+
+	// Test http server
+	engine := gin.Default()
+	_ = instance.Configure(engine)
+	ts := httptest.NewServer(engine)
+
+	// Close it in the end
+	defer ts.Close()
+
+	// Act
+	firstResponse, firstErr := performRequest(http.MethodGet, ts.URL, "api/v1/games?code=ABCDEF", "", nil)
+	secondResponse, secondErr := performRequest(http.MethodGet, ts.URL, "api/v1/games?code=ABCDEF", "", nil)
+	lockedResponse, lockedErr := performRequest(http.MethodGet, ts.URL, "api/v1/games?code=ABCDEF", "", nil)
+	limitedResponse, limitedErr := performRequest(http.MethodGet, ts.URL, "api/v1/games?code=ABCDEF", "", nil)
+
+	// Assert
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.NoError(t, lockedErr)
+	assert.NoError(t, limitedErr)
+
+	assert.Equal(t, http.StatusNotFound, firstResponse.StatusCode)
+	assert.Equal(t, http.StatusNotFound, secondResponse.StatusCode)
+
+	assert.Equal(t, http.StatusTooManyRequests, lockedResponse.StatusCode)
+	assert.Equal(t, "60", lockedResponse.Header.Get("Retry-After"))
+
+	assert.Equal(t, http.StatusTooManyRequests, limitedResponse.StatusCode)
+	assert.Equal(t, "100", limitedResponse.Header.Get("Retry-After"))
+}
+
+func TestServer_FreeFinishedGameCodes_ClearsFinishedGames(t *testing.T) {
+	// Arrange
+	instance := &Server{jwtSecret: "abc", oAuthConfig: &oauth2.Config{ClientID: "abc", ClientSecret: "abc", RedirectURL: "abc"}}
+	instance.database = gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+
+	_ = instance.Configure(gin.Default())
+
+	quiz := &domain.Quiz{Name: "abc", Creator: getCreator(uuid.MustParse("7d87bab0-cf2d-45ae-bced-1de22db21a77"))}
+	games := []*domain.Game{
+		{Quiz: quiz, Code: "ABC234", StartTime: time.Now(), FinishTime: time.Now()},
+		{Quiz: quiz, Code: "DEF567", StartTime: time.Now()},
+	}
+	populateDatabase(t, instance.database, games...)
+
+	// Act
+	err := instance.freeFinishedGameCodes()
+
+	// Assert
+	assert.NoError(t, err)
+
+	var result []*domain.Game
+	instance.database.Order("code").Find(&result)
+
+	if assert.Len(t, result, 2) {
+		assert.Equal(t, "", result[0].Code)
+		assert.Equal(t, "DEF567", result[1].Code)
+	}
+}
+
+func TestServer_NumberDuplicateNicknames_NumbersPlayersInSameGame(t *testing.T) {
+	// Arrange
+	instance := &Server{jwtSecret: "abc", oAuthConfig: &oauth2.Config{ClientID: "abc", ClientSecret: "abc", RedirectURL: "abc"}}
+	instance.database = gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+
+	_ = instance.Configure(gin.Default())
+
+	// Players from before nicknames were unique
+	if err := instance.database.Migrator().DropIndex(new(domain.Player), "idx_player_nickname"); err != nil {
+		t.Fatal(err)
+	}
+
+	quiz := &domain.Quiz{Name: "abc", Creator: getCreator(uuid.MustParse("5a0d7d25-8f0e-4f8b-9a8a-54b2f3c0f0a1"))}
+	game := &domain.Game{Quiz: quiz, Code: "ABC234"}
+	otherGame := &domain.Game{Quiz: quiz, Code: "DEF567"}
+	populateDatabase(t, instance.database, game, otherGame)
+
+	players := []*domain.Player{
+		{GameID: game.ID, Nickname: "Brave Fox", BaseObject: domain.BaseObject{CreatedAt: time.Now().Add(-3 * time.Minute)}},
+		{GameID: game.ID, Nickname: "brave fox", BaseObject: domain.BaseObject{CreatedAt: time.Now().Add(-2 * time.Minute)}},
+		{GameID: game.ID, Nickname: "Brave Fox 2", BaseObject: domain.BaseObject{CreatedAt: time.Now().Add(-1 * time.Minute)}},
+		{GameID: otherGame.ID, Nickname: "Brave Fox"},
+	}
+	populateDatabase(t, instance.database, players...)
+
+	// Act
+	err := instance.numberDuplicateNicknames()
+
+	// Assert
+	assert.NoError(t, err)
+
+	var result []*domain.Player
+	instance.database.Order("created_at").Find(&result)
+
+	if assert.Len(t, result, 4) {
+		assert.Equal(t, "Brave Fox", result[0].Nickname)
+		assert.Equal(t, "brave fox 3", result[1].Nickname)
+		assert.Equal(t, "Brave Fox 2", result[2].Nickname)
+		assert.Equal(t, "Brave Fox", result[3].Nickname)
+	}
+
+	assert.NoError(t, instance.database.AutoMigrate(new(domain.Player)))
+}
+
+func TestServer_Configure_RejectsJoinCodeLengths(t *testing.T) {
+	t.Parallel()
+	tests := map[string]int{
+		"too short": 3,
+		"too long":  13,
+	}
+
+	for name, length := range tests {
+		length := length
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			instance := &Server{}
+			WithJoinCodes(length, false)(instance)
+
+			// Act
+			err := instance.Configure(gin.New())
+
+			// Assert
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestServer_Configure_RejectsNicknameWordLists(t *testing.T) {
+	t.Parallel()
+	words := &services.WordList{Prefixes: []string{"Brave"}, Suffixes: []string{"Fox"}}
+
+	tests := map[string]struct {
+		lists         map[string]*services.WordList
+		defaultLocale string
+	}{
+		"no lists": {
+			lists:         map[string]*services.WordList{},
+			defaultLocale: "en",
+		},
+		"missing default locale": {
+			lists:         map[string]*services.WordList{"nl": words},
+			defaultLocale: "de",
+		},
+		"missing fallback locale": {
+			lists: map[string]*services.WordList{"nl": words},
+		},
+		"empty list": {
+			lists:         map[string]*services.WordList{"en": words, "nl": {Prefixes: []string{"Dappere"}}},
+			defaultLocale: "en",
+		},
+		"nil list": {
+			lists:         map[string]*services.WordList{"en": words, "nl": nil},
+			defaultLocale: "en",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			instance := &Server{}
+			WithNicknameWordLists(testData.lists, testData.defaultLocale)(instance)
+
+			// Act
+			err := instance.Configure(gin.New())
+
+			// Assert
+			assert.Error(t, err)
+		})
+	}
+}
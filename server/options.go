@@ -0,0 +1,77 @@
+package server
+
+import (
+	"github.com/survivorbat/qq.maarten.dev/server/services"
+	"time"
+)
+
+// Option can be passed to NewServer to change its defaults
+type Option func(*Server)
+
+// RateLimitConfig determines how aggressively the anonymous routes are throttled
+type RateLimitConfig struct {
+	// IPRate and IPBurst configure the token bucket of every client, keep in mind
+	// that an entire classroom may share the same address
+	IPRate  float64
+	IPBurst int
+
+	// GameRate and GameBurst configure the token bucket for joining a single game
+	GameRate  float64
+	GameBurst int
+
+	// CodeAttempts is the amount of unknown codes a client may look up within CodeLockout
+	// before being locked out for CodeLockout, finding a game doesn't reset the count
+	CodeAttempts int
+	CodeLockout  time.Duration
+}
+
+// DefaultRateLimitConfig is used if no other config is provided
+func DefaultRateLimitConfig() *RateLimitConfig {
+	return &RateLimitConfig{
+		IPRate:       10,
+		IPBurst:      60,
+		GameRate:     5,
+		GameBurst:    25,
+		CodeAttempts: 20,
+		CodeLockout:  time.Minute,
+	}
+}
+
+// WithRateLimits overrides the default rate limits
+func WithRateLimits(config *RateLimitConfig) Option {
+	return func(s *Server) {
+		s.rateLimits = config
+	}
+}
+
+// WithJoinCodes changes the length and characters of the codes players use to join a game, the length
+// has to be between 4 and 12 or Configure fails
+func WithJoinCodes(length int, numeric bool) Option {
+	return func(s *Server) {
+		s.joinCodes = &services.RandomJoinCodeGenerator{Length: length, Numeric: numeric}
+	}
+}
+
+// WithNicknameBlocklist replaces the words players may not use in their nickname
+func WithNicknameBlocklist(words []string) Option {
+	return func(s *Server) {
+		s.nicknameBlocklist = words
+	}
+}
+
+// WithNicknameAllowlist replaces the words players may use in their nickname even though they contain
+// a word from the blocklist
+func WithNicknameAllowlist(words []string) Option {
+	return func(s *Server) {
+		s.nicknameAllowlist = words
+	}
+}
+
+// WithNicknameWordLists replaces the word lists per locale that generated nicknames are made of, see
+// services.LoadWordLists to read them from a directory. The default locale is used if players' languages
+// are not supported and must be one of the lists.
+func WithNicknameWordLists(lists map[string]*services.WordList, defaultLocale string) Option {
+	return func(s *Server) {
+		s.nicknames = &services.WordListNicknameGenerator{Lists: lists, DefaultLocale: defaultLocale}
+	}
+}
@@ -0,0 +1,122 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"github.com/survivorbat/qq.maarten.dev/server/routes/outputs"
+	"github.com/survivorbat/qq.maarten.dev/server/services"
+	"net/http"
+)
+
+type APIKeyHandler struct {
+	APIKeyService services.APIKeyService
+}
+
+// Get godoc
+//
+//	@Summary	Fetch your API keys
+//	@Tags		APIKey
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{array}	[]domain.APIKey	"Your API keys"
+//	@Failure	403	"API keys can not manage API keys"
+//	@Failure	500	"Internal Server Error"
+//	@Router		/api/v1/api-keys [get]
+//	@Security	JWT
+func (a *APIKeyHandler) Get(c *gin.Context) {
+	authID := c.GetString("user")
+
+	keys, err := a.APIKeyService.GetByCreator(uuid.MustParse(authID))
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get by creator")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// Post godoc
+//
+//	@Summary	Create an API key, the key is only returned once
+//	@Tags		APIKey
+//	@Accept		json
+//	@Produce	json
+//	@Param		input	body		inputs.APIKey				true	"Your API key"
+//	@Success	200		{object}	outputs.OutputCreatedAPIKey	"Your API key"
+//	@Failure	400		"Malformed input"
+//	@Failure	403		"API keys can not manage API keys"
+//	@Failure	500		"Internal Server Error"
+//	@Router		/api/v1/api-keys [post]
+//	@Security	JWT
+func (a *APIKeyHandler) Post(c *gin.Context) {
+	authID := c.GetString("user")
+
+	var input *inputs.APIKey
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logrus.WithError(err).Error("Validation error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	apiKey := input.ToDomain()
+	apiKey.CreatorID = uuid.MustParse(authID)
+
+	key, err := a.APIKeyService.Create(apiKey)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to create")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, outputs.NewCreatedAPIKey(apiKey, key))
+}
+
+// Delete godoc
+//
+//	@Summary	Revoke an API key
+//	@Tags		APIKey
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path	string	true	"ID of the API key"
+//	@Success	200	"The revoked API key"
+//	@Failure	400	"Invalid uuid"
+//	@Failure	403	"You can only revoke your own API keys"
+//	@Failure	404	"Not found"
+//	@Failure	500	"Internal Server Error"
+//	@Router		/api/v1/api-keys/{id} [delete]
+//	@Security	JWT
+func (a *APIKeyHandler) Delete(c *gin.Context) {
+	authID := c.GetString("user")
+	id := c.Param("id")
+
+	keyID, err := uuid.Parse(id)
+	if err != nil {
+		logrus.WithError(err).Error("UUID error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	apiKey, err := a.APIKeyService.GetByID(keyID)
+	if err != nil {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	// Prevent users from revoking other people's keys
+	if apiKey.CreatorID.String() != authID {
+		logrus.Errorf("Creator is %s not %s", apiKey.CreatorID, authID)
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	if err := a.APIKeyService.Delete(apiKey); err != nil {
+		logrus.WithError(err).Error("Failed to delete")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, apiKey)
+}
@@ -0,0 +1,208 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"github.com/survivorbat/qq.maarten.dev/server/routes/outputs"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIKeyHandler_Get_ReturnsExpectedData(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	keys := []*domain.APIKey{
+		{BaseObject: domain.BaseObject{ID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58")}, Name: "a"},
+		{BaseObject: domain.BaseObject{ID: uuid.MustParse("adeb8482-4eb2-4c2d-8eec-97f705260fa8")}, Name: "b"},
+	}
+
+	mockAPIKeyService := &MockAPIKeyService{getByCreatorReturns: keys}
+	handler := &APIKeyHandler{APIKeyService: mockAPIKeyService}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "d2f584da-d340-459a-a1ce-8652446a86ef")
+
+	// Act
+	handler.Get(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, uuid.MustParse("d2f584da-d340-459a-a1ce-8652446a86ef"), mockAPIKeyService.getByCreatorCalledWith)
+
+	var result []*domain.APIKey
+	if err := json.Unmarshal(writer.Body.Bytes(), &result); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	assert.ElementsMatch(t, keys, result)
+}
+
+func TestAPIKeyHandler_Get_ReturnsErrorOnFetchError(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	mockAPIKeyService := &MockAPIKeyService{getByCreatorReturnsError: assert.AnError}
+	handler := &APIKeyHandler{APIKeyService: mockAPIKeyService}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "d2f584da-d340-459a-a1ce-8652446a86ef")
+
+	// Act
+	handler.Get(context)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, writer.Code)
+}
+
+func TestAPIKeyHandler_Post_ReturnsValidationError(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	handler := &APIKeyHandler{}
+
+	input := &inputs.APIKey{Name: "CMS", Scopes: []string{"everything"}}
+	inputJson, _ := json.Marshal(input)
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "d2f584da-d340-459a-a1ce-8652446a86ef")
+	context.Request, _ = http.NewRequest(http.MethodPost, "", io.NopCloser(bytes.NewBuffer(inputJson)))
+
+	// Act
+	handler.Post(context)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+}
+
+func TestAPIKeyHandler_Post_ReturnsKeyOnce(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	mockAPIKeyService := &MockAPIKeyService{createReturns: "qq_abc"}
+	handler := &APIKeyHandler{APIKeyService: mockAPIKeyService}
+
+	input := &inputs.APIKey{Name: "CMS", Scopes: []string{"quizzes:write"}}
+	inputJson, _ := json.Marshal(input)
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "d2f584da-d340-459a-a1ce-8652446a86ef")
+	context.Request, _ = http.NewRequest(http.MethodPost, "", io.NopCloser(bytes.NewBuffer(inputJson)))
+
+	// Act
+	handler.Post(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	expected := &domain.APIKey{
+		Name:      "CMS",
+		Scopes:    []domain.APIKeyScope{domain.ScopeQuizzesWrite},
+		CreatorID: uuid.MustParse("d2f584da-d340-459a-a1ce-8652446a86ef"),
+	}
+	assert.Equal(t, expected, mockAPIKeyService.createCalledWith)
+
+	var result *outputs.OutputCreatedAPIKey
+	if err := json.Unmarshal(writer.Body.Bytes(), &result); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	assert.Equal(t, "qq_abc", result.Key)
+}
+
+func TestAPIKeyHandler_Post_ReturnsAnyErrors(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	mockAPIKeyService := &MockAPIKeyService{createReturnsErr: assert.AnError}
+	handler := &APIKeyHandler{APIKeyService: mockAPIKeyService}
+
+	input := &inputs.APIKey{Name: "CMS"}
+	inputJson, _ := json.Marshal(input)
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "d2f584da-d340-459a-a1ce-8652446a86ef")
+	context.Request, _ = http.NewRequest(http.MethodPost, "", io.NopCloser(bytes.NewBuffer(inputJson)))
+
+	// Act
+	handler.Post(context)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, writer.Code)
+}
+
+func TestAPIKeyHandler_Delete_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		id            string
+		apiKey        *domain.APIKey
+		getError      error
+		deleteError   error
+		expected      int
+		expectDeleted bool
+	}{
+		"invalid id": {
+			id:       "no",
+			expected: http.StatusBadRequest,
+		},
+		"not found": {
+			id:       "ac1d0e93-b545-48be-bff9-656a933afa04",
+			getError: assert.AnError,
+			expected: http.StatusNotFound,
+		},
+		"not my key": {
+			id:       "ac1d0e93-b545-48be-bff9-656a933afa04",
+			apiKey:   &domain.APIKey{CreatorID: uuid.MustParse("e3274bf0-b154-4d37-a6fd-878d530025f9")},
+			expected: http.StatusForbidden,
+		},
+		"delete error": {
+			id:            "ac1d0e93-b545-48be-bff9-656a933afa04",
+			apiKey:        &domain.APIKey{CreatorID: uuid.MustParse("d2f584da-d340-459a-a1ce-8652446a86ef")},
+			deleteError:   assert.AnError,
+			expected:      http.StatusInternalServerError,
+			expectDeleted: true,
+		},
+		"success": {
+			id:            "ac1d0e93-b545-48be-bff9-656a933afa04",
+			apiKey:        &domain.APIKey{CreatorID: uuid.MustParse("d2f584da-d340-459a-a1ce-8652446a86ef")},
+			expected:      http.StatusOK,
+			expectDeleted: true,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			mockAPIKeyService := &MockAPIKeyService{
+				getByIdReturns:      testData.apiKey,
+				getByIdReturnsError: testData.getError,
+				deleteReturns:       testData.deleteError,
+			}
+			handler := &APIKeyHandler{APIKeyService: mockAPIKeyService}
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Set("user", "d2f584da-d340-459a-a1ce-8652446a86ef")
+			context.Params = []gin.Param{{Key: "id", Value: testData.id}}
+
+			// Act
+			handler.Delete(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+
+			if testData.expectDeleted {
+				assert.Equal(t, testData.apiKey, mockAPIKeyService.deleteCalledWith)
+			}
+		})
+	}
+}
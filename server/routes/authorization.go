@@ -0,0 +1,47 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/services"
+	"net/http"
+)
+
+// authorize is the central check whether the authenticated creator may perform an action on a quiz,
+// or on the games of that quiz. Aborts the request with a 403 if they may not.
+func authorize(c *gin.Context, quiz *domain.Quiz, permission domain.Permission) bool {
+	authID := c.GetString("user")
+
+	creatorID, err := uuid.Parse(authID)
+	if err != nil || !quiz.Allows(creatorID, permission) {
+		logrus.Errorf("Creator %s does not have permission %s on quiz %s", authID, permission, quiz.ID)
+		c.AbortWithStatus(http.StatusForbidden)
+		return false
+	}
+
+	return true
+}
+
+// authorizeOrganization verifies that the creator is a member of the organization something is added to
+func authorizeOrganization(c *gin.Context, organizationService services.OrganizationService, organizationID *uuid.UUID) bool {
+	if organizationID == nil {
+		return true
+	}
+
+	organization, err := organizationService.GetByID(*organizationID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get organization")
+		c.AbortWithStatus(http.StatusForbidden)
+		return false
+	}
+
+	if _, ok := organization.RoleOf(uuid.MustParse(c.GetString("user"))); !ok {
+		logrus.Errorf("Creator %s is not a member of organization %s", c.GetString("user"), organization.ID)
+		c.AbortWithStatus(http.StatusForbidden)
+		return false
+	}
+
+	return true
+}
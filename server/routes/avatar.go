@@ -0,0 +1,55 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/survivorbat/qq.maarten.dev/server/services"
+	"net/http"
+)
+
+const svgContentType = "image/svg+xml"
+
+type AvatarHandler struct {
+	AvatarService services.AvatarService
+}
+
+// Get godoc
+//
+//	@Summary	Fetch the names of the built-in avatars
+//	@Tags		Avatar
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{array}	[]string	"The built-in avatars"
+//	@Router		/api/v1/avatars [get]
+func (a *AvatarHandler) Get(c *gin.Context) {
+	c.JSON(http.StatusOK, a.AvatarService.Catalog())
+}
+
+// GetByID godoc
+//
+//	@Summary	Fetch an avatar, either a built-in one by name or a generated one by participant ID
+//	@Tags		Avatar
+//	@Produce	image/svg+xml
+//	@Param		id	path	string	true	"Name of the built-in avatar or ID of the participant"
+//	@Success	200	"The avatar"
+//	@Failure	404	"Avatar not found"
+//	@Router		/api/v1/avatars/{id} [get]
+func (a *AvatarHandler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+
+	// Avatars never change, so clients may keep them around
+	c.Header("Cache-Control", "public, max-age=86400")
+
+	if participantID, err := uuid.Parse(id); err == nil {
+		c.Data(http.StatusOK, svgContentType, a.AvatarService.Generate(participantID))
+		return
+	}
+
+	avatar, err := a.AvatarService.GetCatalogAvatar(id)
+	if err != nil {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	c.Data(http.StatusOK, svgContentType, avatar)
+}
@@ -0,0 +1,79 @@
+package routes
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/services"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAvatarHandler_Get_ReturnsCatalog(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	handler := &AvatarHandler{AvatarService: new(services.EmbeddedAvatarService)}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+
+	// Act
+	handler.Get(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	var result []string
+	if err := json.Unmarshal(writer.Body.Bytes(), &result); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	assert.Contains(t, result, "moon")
+}
+
+func TestAvatarHandler_GetByID_ReturnsExpectedAvatar(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		id       string
+		expected int
+	}{
+		"generated": {
+			id:       "2f80947c-e724-4b38-8c8d-3823864fef58",
+			expected: http.StatusOK,
+		},
+		"built-in": {
+			id:       "moon",
+			expected: http.StatusOK,
+		},
+		"unknown": {
+			id:       "sun",
+			expected: http.StatusNotFound,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &AvatarHandler{AvatarService: new(services.EmbeddedAvatarService)}
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Params = []gin.Param{{Key: "id", Value: testData.id}}
+
+			// Act
+			handler.GetByID(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+
+			if testData.expected == http.StatusOK {
+				assert.Equal(t, svgContentType, writer.Header().Get("Content-Type"))
+				assert.True(t, strings.HasPrefix(writer.Body.String(), "<svg"))
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"github.com/survivorbat/qq.maarten.dev/server/services"
+	"net/http"
+)
+
+// BankQuestionHandler manages the question bank, questions in it can be added to any quiz
+type BankQuestionHandler struct {
+	BankQuestionService services.BankQuestionService
+	OrganizationService services.OrganizationService
+}
+
+// Get godoc
+//
+//	@Summary	Search your bank questions and those of your organizations
+//	@Tags		Bank
+//	@Accept		json
+//	@Produce	json
+//	@Param		q			query	string					false	"Words in the title"
+//	@Param		category	query	string					false	"Only questions in this category"
+//	@Param		tag			query	string					false	"Only questions with this tag"
+//	@Success	200			{array}	[]domain.BankQuestion	"The questions"
+//	@Failure	400			"Invalid search"
+//	@Failure	500			"Internal Server Error"
+//	@Router		/api/v1/bank-questions [get]
+//	@Security	JWT
+func (g *BankQuestionHandler) Get(c *gin.Context) {
+	var input inputs.BankQuestionSearch
+	if err := c.ShouldBindQuery(&input); err != nil {
+		logrus.WithError(err).Error("Validation error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	filter := &services.BankQuestionFilter{Query: input.Query, Category: input.Category, Tag: input.Tag}
+
+	questions, err := g.BankQuestionService.GetAccessible(uuid.MustParse(c.GetString("user")), filter)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get bank questions")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, questions)
+}
+
+// GetByID godoc
+//
+//	@Summary	Get a bank question
+//	@Tags		Bank
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		string				true	"ID of the bank question"
+//	@Success	200	{object}	domain.BankQuestion	"The question"
+//	@Failure	400	"Invalid uuid"
+//	@Failure	403	"The question is not yours or your organization's"
+//	@Failure	404	"Question not found"
+//	@Router		/api/v1/bank-questions/{id} [get]
+//	@Security	JWT
+func (g *BankQuestionHandler) GetByID(c *gin.Context) {
+	question, ok := g.getQuestion(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, question)
+}
+
+// GetStats godoc
+//
+//	@Summary	See how often a bank question is used and answered
+//	@Tags		Bank
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		string						true	"ID of the bank question"
+//	@Success	200	{object}	domain.BankQuestionStats	"The statistics"
+//	@Failure	400	"Invalid uuid"
+//	@Failure	403	"The question is not yours or your organization's"
+//	@Failure	404	"Question not found"
+//	@Failure	500	"Internal Server Error"
+//	@Router		/api/v1/bank-questions/{id}/stats [get]
+//	@Security	JWT
+func (g *BankQuestionHandler) GetStats(c *gin.Context) {
+	question, ok := g.getQuestion(c)
+	if !ok {
+		return
+	}
+
+	stats, err := g.BankQuestionService.GetStats(question.ID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get stats")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// Post godoc
+//
+//	@Summary	Add a question to the bank
+//	@Tags		Bank
+//	@Accept		json
+//	@Produce	json
+//	@Param		input	body		inputs.BankQuestion	true	"The question"
+//	@Success	200		{object}	domain.BankQuestion	"The new question"
+//	@Failure	400		"Invalid question"
+//	@Failure	403		"You're not a member of the organization"
+//	@Failure	500		"Internal Server Error"
+//	@Router		/api/v1/bank-questions [post]
+//	@Security	JWT
+func (g *BankQuestionHandler) Post(c *gin.Context) {
+	var input *inputs.BankQuestion
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logrus.WithError(err).Error("Validation error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	question := input.ToDomain()
+	question.CreatorID = uuid.MustParse(c.GetString("user"))
+
+	if !authorizeOrganization(c, g.OrganizationService, question.OrganizationID) {
+		return
+	}
+
+	if err := g.BankQuestionService.Create(question); err != nil {
+		logrus.WithError(err).Error("Failed to create bank question")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, question)
+}
+
+// Put godoc
+//
+//	@Summary	Change a bank question, quizzes that link to it get a new revision with the change
+//	@Tags		Bank
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		string				true	"ID of the bank question"
+//	@Param		input	body		inputs.BankQuestion	true	"The question"
+//	@Success	200		{object}	domain.BankQuestion	"The question"
+//	@Failure	400		"Invalid uuid or question"
+//	@Failure	403		"Only the owner and admins of the organization may change the question"
+//	@Failure	404		"Question not found"
+//	@Failure	500		"Internal Server Error"
+//	@Router		/api/v1/bank-questions/{id} [put]
+//	@Security	JWT
+func (g *BankQuestionHandler) Put(c *gin.Context) {
+	question, ok := g.getQuestion(c)
+	if !ok {
+		return
+	}
+
+	authID := uuid.MustParse(c.GetString("user"))
+	if !question.CanEdit(authID) {
+		logrus.Errorf("Creator %s may not edit bank question %s", authID, question.ID)
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	var input *inputs.BankQuestion
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logrus.WithError(err).Error("Validation error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	update := input.ToDomain()
+	update.ID = question.ID
+	update.CreatedAt = question.CreatedAt
+	update.CreatorID = question.CreatorID
+
+	// Only the owner may take the question out of the organization
+	if authID != question.CreatorID {
+		update.OrganizationID = question.OrganizationID
+	}
+
+	if !authorizeOrganization(c, g.OrganizationService, update.OrganizationID) {
+		return
+	}
+
+	if err := g.BankQuestionService.Update(update); err != nil {
+		logrus.WithError(err).Error("Failed to update bank question")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, update)
+}
+
+// Delete godoc
+//
+//	@Summary	Remove a question from the bank, quizzes keep their copy of it
+//	@Tags		Bank
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path	string	true	"ID of the bank question"
+//	@Success	204
+//	@Failure	400	"Invalid uuid"
+//	@Failure	403	"Only the owner and admins of the organization may delete the question"
+//	@Failure	404	"Question not found"
+//	@Failure	500	"Internal Server Error"
+//	@Router		/api/v1/bank-questions/{id} [delete]
+//	@Security	JWT
+func (g *BankQuestionHandler) Delete(c *gin.Context) {
+	question, ok := g.getQuestion(c)
+	if !ok {
+		return
+	}
+
+	authID := uuid.MustParse(c.GetString("user"))
+	if !question.CanEdit(authID) {
+		logrus.Errorf("Creator %s may not delete bank question %s", authID, question.ID)
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	if err := g.BankQuestionService.Delete(question.ID); err != nil {
+		logrus.WithError(err).Error("Failed to delete bank question")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// getQuestion fetches the bank question and verifies the creator may use it
+func (g *BankQuestionHandler) getQuestion(c *gin.Context) (*domain.BankQuestion, bool) {
+	questionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		logrus.WithError(err).Error("UUID error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return nil, false
+	}
+
+	question, err := g.BankQuestionService.GetByID(questionID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get bank question")
+		c.AbortWithStatus(http.StatusNotFound)
+		return nil, false
+	}
+
+	if !question.CanUse(uuid.MustParse(c.GetString("user"))) {
+		logrus.Errorf("Creator %s may not use bank question %s", c.GetString("user"), question.ID)
+		c.AbortWithStatus(http.StatusForbidden)
+		return nil, false
+	}
+
+	return question, true
+}
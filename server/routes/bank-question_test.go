@@ -0,0 +1,291 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func bankQuestion() *domain.BankQuestion {
+	return &domain.BankQuestion{
+		BaseObject: domain.BaseObject{ID: uuid.MustParse("c6d0d2c4-8b5f-4f3a-9f1d-6f5c3e2b1a09")},
+		CreatorID:  uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"),
+		Title:      "What is 4+4",
+		AnswerID:   uuid.MustParse("0e3b1d1c-4a36-4f1e-a2c5-0f7d8d2b11c2"),
+		Options: []*domain.BankOption{
+			{ID: uuid.MustParse("0e3b1d1c-4a36-4f1e-a2c5-0f7d8d2b11c2"), TextOption: "8"},
+			{ID: uuid.MustParse("4f0b0a5e-1c1c-4f59-9f43-0b4b8c1c7d2e"), TextOption: "7"},
+		},
+	}
+}
+
+func bankQuestionContext(writer *httptest.ResponseRecorder, method string, body any, id string) *gin.Context {
+	inputJson, _ := json.Marshal(body)
+
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(method, "", io.NopCloser(bytes.NewBuffer(inputJson)))
+	context.Params = []gin.Param{{Key: "id", Value: id}}
+
+	return context
+}
+
+func TestBankQuestionHandler_Get_PassesFilter(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	bankQuestionService := &MockBankQuestionService{getAccessibleReturns: []*domain.BankQuestion{bankQuestion()}}
+	handler := &BankQuestionHandler{BankQuestionService: bankQuestionService}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/bank-questions?q=four&category=Math&tag=easy", nil)
+
+	// Act
+	handler.Get(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, "four", bankQuestionService.getAccessibleCalledWith.Query)
+	assert.Equal(t, "Math", bankQuestionService.getAccessibleCalledWith.Category)
+	assert.Equal(t, "easy", bankQuestionService.getAccessibleCalledWith.Tag)
+}
+
+func TestBankQuestionHandler_GetByID_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		id                  string
+		bankQuestionService *MockBankQuestionService
+		expected            int
+	}{
+		"invalid uuid": {
+			id:                  "no",
+			bankQuestionService: &MockBankQuestionService{},
+			expected:            http.StatusBadRequest,
+		},
+		"not found": {
+			id:                  "c6d0d2c4-8b5f-4f3a-9f1d-6f5c3e2b1a09",
+			bankQuestionService: &MockBankQuestionService{getByIdReturnsError: assert.AnError},
+			expected:            http.StatusNotFound,
+		},
+		"not mine": {
+			id:                  "c6d0d2c4-8b5f-4f3a-9f1d-6f5c3e2b1a09",
+			bankQuestionService: &MockBankQuestionService{getByIdReturns: &domain.BankQuestion{CreatorID: uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b")}},
+			expected:            http.StatusForbidden,
+		},
+		"shared through my organization": {
+			id: "c6d0d2c4-8b5f-4f3a-9f1d-6f5c3e2b1a09",
+			bankQuestionService: &MockBankQuestionService{getByIdReturns: &domain.BankQuestion{
+				CreatorID:    uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b"),
+				Organization: &domain.Organization{Members: []*domain.Member{{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), Role: domain.OrganizationMember}}},
+			}},
+			expected: http.StatusOK,
+		},
+		"success": {
+			id:                  "c6d0d2c4-8b5f-4f3a-9f1d-6f5c3e2b1a09",
+			bankQuestionService: &MockBankQuestionService{getByIdReturns: bankQuestion()},
+			expected:            http.StatusOK,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &BankQuestionHandler{BankQuestionService: testData.bankQuestionService}
+
+			writer := httptest.NewRecorder()
+			context := bankQuestionContext(writer, http.MethodGet, nil, testData.id)
+
+			// Act
+			handler.GetByID(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestBankQuestionHandler_Post_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	organizationID := uuid.MustParse("a8b9c0d1-e2f3-4a5b-8c6d-7e8f9a0b1c2d")
+
+	tests := map[string]struct {
+		input               *inputs.BankQuestion
+		organizationService *MockOrganizationService
+		bankQuestionService *MockBankQuestionService
+		expected            int
+	}{
+		"invalid": {
+			input:               &inputs.BankQuestion{Title: "What is 4+4"},
+			organizationService: &MockOrganizationService{},
+			bankQuestionService: &MockBankQuestionService{},
+			expected:            http.StatusBadRequest,
+		},
+		"not a member of the organization": {
+			input: &inputs.BankQuestion{
+				Title:             "What is 4+4",
+				DurationInSeconds: 15,
+				Category:          "Math",
+				Options:           []*inputs.QuestionOption{{TextOption: "8", Answer: true}, {TextOption: "7"}},
+				OrganizationID:    &organizationID,
+			},
+			organizationService: &MockOrganizationService{getByIdReturns: &domain.Organization{}},
+			bankQuestionService: &MockBankQuestionService{},
+			expected:            http.StatusForbidden,
+		},
+		"create error": {
+			input: &inputs.BankQuestion{
+				Title:             "What is 4+4",
+				DurationInSeconds: 15,
+				Category:          "Math",
+				Options:           []*inputs.QuestionOption{{TextOption: "8", Answer: true}, {TextOption: "7"}},
+			},
+			organizationService: &MockOrganizationService{},
+			bankQuestionService: &MockBankQuestionService{createReturns: assert.AnError},
+			expected:            http.StatusInternalServerError,
+		},
+		"success": {
+			input: &inputs.BankQuestion{
+				Title:             "What is 4+4",
+				DurationInSeconds: 15,
+				Category:          "Math",
+				Options:           []*inputs.QuestionOption{{TextOption: "8", Answer: true}, {TextOption: "7"}},
+				Tags:              []string{"Easy"},
+			},
+			organizationService: &MockOrganizationService{},
+			bankQuestionService: &MockBankQuestionService{},
+			expected:            http.StatusOK,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &BankQuestionHandler{BankQuestionService: testData.bankQuestionService, OrganizationService: testData.organizationService}
+
+			writer := httptest.NewRecorder()
+			context := bankQuestionContext(writer, http.MethodPost, testData.input, "")
+
+			// Act
+			handler.Post(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestBankQuestionHandler_Put_KeepsOwnerAndOrganization(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	adminID := uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58")
+	organizationID := uuid.MustParse("a8b9c0d1-e2f3-4a5b-8c6d-7e8f9a0b1c2d")
+	organization := &domain.Organization{
+		BaseObject: domain.BaseObject{ID: organizationID},
+		Members:    []*domain.Member{{CreatorID: adminID, Role: domain.OrganizationAdmin}},
+	}
+
+	existing := bankQuestion()
+	existing.CreatorID = uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b")
+	existing.OrganizationID = &organizationID
+	existing.Organization = organization
+
+	bankQuestionService := &MockBankQuestionService{getByIdReturns: existing}
+	handler := &BankQuestionHandler{BankQuestionService: bankQuestionService, OrganizationService: &MockOrganizationService{getByIdReturns: organization}}
+
+	input := &inputs.BankQuestion{
+		Title:             "What is 5+5",
+		DurationInSeconds: 15,
+		Category:          "Math",
+		Options:           []*inputs.QuestionOption{{TextOption: "10", Answer: true}, {TextOption: "11"}},
+	}
+
+	writer := httptest.NewRecorder()
+	context := bankQuestionContext(writer, http.MethodPut, input, "c6d0d2c4-8b5f-4f3a-9f1d-6f5c3e2b1a09")
+
+	// Act
+	handler.Put(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	update := bankQuestionService.updateCalledWith
+	assert.Equal(t, existing.ID, update.ID)
+	assert.Equal(t, existing.CreatorID, update.CreatorID)
+	assert.Equal(t, &organizationID, update.OrganizationID)
+	assert.Equal(t, "What is 5+5", update.Title)
+}
+
+func TestBankQuestionHandler_Delete_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		bankQuestionService *MockBankQuestionService
+		expected            int
+	}{
+		"member may not delete": {
+			bankQuestionService: &MockBankQuestionService{getByIdReturns: &domain.BankQuestion{
+				CreatorID:    uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b"),
+				Organization: &domain.Organization{Members: []*domain.Member{{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), Role: domain.OrganizationMember}}},
+			}},
+			expected: http.StatusForbidden,
+		},
+		"delete error": {
+			bankQuestionService: &MockBankQuestionService{getByIdReturns: bankQuestion(), deleteReturns: assert.AnError},
+			expected:            http.StatusInternalServerError,
+		},
+		"success": {
+			bankQuestionService: &MockBankQuestionService{getByIdReturns: bankQuestion()},
+			expected:            http.StatusNoContent,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &BankQuestionHandler{BankQuestionService: testData.bankQuestionService}
+
+			writer := httptest.NewRecorder()
+			context := bankQuestionContext(writer, http.MethodDelete, nil, "c6d0d2c4-8b5f-4f3a-9f1d-6f5c3e2b1a09")
+
+			// Act
+			handler.Delete(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestBankQuestionHandler_GetStats_ReturnsStats(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	stats := &domain.BankQuestionStats{Quizzes: 3, LinkedQuizzes: 1, Answers: 20, CorrectAnswers: 15}
+	handler := &BankQuestionHandler{BankQuestionService: &MockBankQuestionService{getByIdReturns: bankQuestion(), getStatsReturns: stats}}
+
+	writer := httptest.NewRecorder()
+	context := bankQuestionContext(writer, http.MethodGet, nil, "c6d0d2c4-8b5f-4f3a-9f1d-6f5c3e2b1a09")
+
+	// Act
+	handler.GetStats(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	var result *domain.BankQuestionStats
+	_ = json.Unmarshal(writer.Body.Bytes(), &result)
+	assert.Equal(t, stats, result)
+}
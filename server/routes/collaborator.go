@@ -0,0 +1,169 @@
+package routes
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"github.com/survivorbat/qq.maarten.dev/server/services"
+	"gorm.io/gorm"
+	"net/http"
+)
+
+type CollaboratorHandler struct {
+	QuizService         services.QuizService
+	CollaboratorService services.CollaboratorService
+}
+
+// Get godoc
+//
+//	@Summary	Fetch the collaborators of this quiz
+//	@Tags		Collaborator
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path	string					true	"ID of the quiz"
+//	@Success	200	{array}	[]domain.Collaborator	"The collaborators"
+//	@Failure	400	"Invalid uuid"
+//	@Failure	403	"You can only view collaborators of quizzes you have access to"
+//	@Failure	404	"Not found"
+//	@Failure	500	"Internal Server Error"
+//	@Router		/api/v1/quizzes/{id}/collaborators [get]
+//	@Security	JWT
+func (g *CollaboratorHandler) Get(c *gin.Context) {
+	quiz, ok := g.getQuiz(c)
+	if !ok {
+		return
+	}
+
+	if !authorize(c, quiz, domain.PermissionViewQuiz) {
+		return
+	}
+
+	collaborators, err := g.CollaboratorService.GetByQuiz(quiz.ID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get by quiz")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, collaborators)
+}
+
+// Put godoc
+//
+//	@Summary	Add a collaborator to this quiz or change their role
+//	@Tags		Collaborator
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		string				true	"ID of the quiz"
+//	@Param		input	body		inputs.Collaborator	true	"The collaborator"
+//	@Success	200		{object}	domain.Collaborator	"The collaborator"
+//	@Failure	400		"Invalid uuid"
+//	@Failure	400		"Malformed input"
+//	@Failure	403		"Only the owner can manage collaborators"
+//	@Failure	404		"Quiz or creator not found"
+//	@Failure	500		"Internal Server Error"
+//	@Router		/api/v1/quizzes/{id}/collaborators [put]
+//	@Security	JWT
+func (g *CollaboratorHandler) Put(c *gin.Context) {
+	quiz, ok := g.getQuiz(c)
+	if !ok {
+		return
+	}
+
+	if !authorize(c, quiz, domain.PermissionManageCollaborators) {
+		return
+	}
+
+	var input *inputs.Collaborator
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logrus.WithError(err).Error("Validation error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	// The owner already has every permission
+	if input.CreatorID == quiz.CreatorID {
+		logrus.Error("Owner can not be a collaborator")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	collaborator := input.ToDomain(quiz.ID)
+	if err := g.CollaboratorService.Save(collaborator); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+
+		logrus.WithError(err).Error("Failed to save")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, collaborator)
+}
+
+// Delete godoc
+//
+//	@Summary	Remove a collaborator from this quiz, collaborators may remove themselves
+//	@Tags		Collaborator
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path	string	true	"ID of the quiz"
+//	@Param		creator	path	string	true	"ID of the collaborating creator"
+//	@Success	204
+//	@Failure	400	"Invalid uuid"
+//	@Failure	403	"Only the owner can manage collaborators"
+//	@Failure	404	"Not found"
+//	@Failure	500	"Internal Server Error"
+//	@Router		/api/v1/quizzes/{id}/collaborators/{creator} [delete]
+//	@Security	JWT
+func (g *CollaboratorHandler) Delete(c *gin.Context) {
+	authID := c.GetString("user")
+
+	creatorID, err := uuid.Parse(c.Param("creator"))
+	if err != nil {
+		logrus.WithError(err).Error("UUID error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	quiz, ok := g.getQuiz(c)
+	if !ok {
+		return
+	}
+
+	// Leaving a quiz is always allowed
+	if creatorID.String() != authID && !authorize(c, quiz, domain.PermissionManageCollaborators) {
+		return
+	}
+
+	if err := g.CollaboratorService.Delete(quiz.ID, creatorID); err != nil {
+		logrus.WithError(err).Error("Failed to delete")
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (g *CollaboratorHandler) getQuiz(c *gin.Context) (*domain.Quiz, bool) {
+	quizID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		logrus.WithError(err).Error("UUID error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return nil, false
+	}
+
+	quiz, err := g.QuizService.GetByID(quizID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get quiz")
+		c.AbortWithStatus(http.StatusNotFound)
+		return nil, false
+	}
+
+	return quiz, true
+}
@@ -0,0 +1,273 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"gorm.io/gorm"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCollaboratorHandler_Get_ReturnsExpectedData(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quiz := &domain.Quiz{
+		BaseObject: domain.BaseObject{ID: uuid.MustParse("ac1d0e93-b545-48be-bff9-656a933afa04")},
+		CreatorID:  uuid.MustParse("e3274bf0-b154-4d37-a6fd-878d530025f9"),
+		Collaborators: []*domain.Collaborator{
+			{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), Role: domain.RoleViewer},
+		},
+	}
+
+	collaboratorService := &MockCollaboratorService{getByQuizReturns: quiz.Collaborators}
+	handler := &CollaboratorHandler{
+		QuizService:         &MockQuizService{getByIdReturns: quiz},
+		CollaboratorService: collaboratorService,
+	}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Params = []gin.Param{{Key: "id", Value: quiz.ID.String()}}
+
+	// Act
+	handler.Get(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, quiz.ID, collaboratorService.getByQuizCalledWith)
+
+	var result []*domain.Collaborator
+	if err := json.Unmarshal(writer.Body.Bytes(), &result); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	assert.ElementsMatch(t, quiz.Collaborators, result)
+}
+
+func TestCollaboratorHandler_Get_ReturnsExpectedErrors(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		id       string
+		quiz     *domain.Quiz
+		quizErr  error
+		fetchErr error
+		expected int
+	}{
+		"invalid id": {
+			id:       "no",
+			expected: http.StatusBadRequest,
+		},
+		"quiz not found": {
+			id:       "ac1d0e93-b545-48be-bff9-656a933afa04",
+			quizErr:  assert.AnError,
+			expected: http.StatusNotFound,
+		},
+		"no access": {
+			id:       "ac1d0e93-b545-48be-bff9-656a933afa04",
+			quiz:     &domain.Quiz{CreatorID: uuid.MustParse("e3274bf0-b154-4d37-a6fd-878d530025f9")},
+			expected: http.StatusForbidden,
+		},
+		"fetch error": {
+			id:       "ac1d0e93-b545-48be-bff9-656a933afa04",
+			quiz:     &domain.Quiz{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58")},
+			fetchErr: assert.AnError,
+			expected: http.StatusInternalServerError,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &CollaboratorHandler{
+				QuizService:         &MockQuizService{getByIdReturns: testData.quiz, getByIdReturnsError: testData.quizErr},
+				CollaboratorService: &MockCollaboratorService{getByQuizReturnsError: testData.fetchErr},
+			}
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+			context.Params = []gin.Param{{Key: "id", Value: testData.id}}
+
+			// Act
+			handler.Get(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestCollaboratorHandler_Put_SavesCollaborator(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quiz := &domain.Quiz{
+		BaseObject: domain.BaseObject{ID: uuid.MustParse("ac1d0e93-b545-48be-bff9-656a933afa04")},
+		CreatorID:  uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"),
+	}
+
+	collaboratorService := &MockCollaboratorService{}
+	handler := &CollaboratorHandler{
+		QuizService:         &MockQuizService{getByIdReturns: quiz},
+		CollaboratorService: collaboratorService,
+	}
+
+	input := &inputs.Collaborator{CreatorID: uuid.MustParse("e3274bf0-b154-4d37-a6fd-878d530025f9"), Role: "host"}
+	inputJson, _ := json.Marshal(input)
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Params = []gin.Param{{Key: "id", Value: quiz.ID.String()}}
+	context.Request, _ = http.NewRequest(http.MethodPut, "", io.NopCloser(bytes.NewBuffer(inputJson)))
+
+	// Act
+	handler.Put(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	expected := &domain.Collaborator{QuizID: quiz.ID, CreatorID: input.CreatorID, Role: domain.RoleHost}
+	assert.Equal(t, expected, collaboratorService.saveCalledWith)
+}
+
+func TestCollaboratorHandler_Put_ReturnsExpectedErrors(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		quiz     *domain.Quiz
+		input    *inputs.Collaborator
+		saveErr  error
+		expected int
+	}{
+		"editors can not manage collaborators": {
+			quiz: &domain.Quiz{
+				CreatorID:     uuid.MustParse("e3274bf0-b154-4d37-a6fd-878d530025f9"),
+				Collaborators: []*domain.Collaborator{{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), Role: domain.RoleEditor}},
+			},
+			input:    &inputs.Collaborator{CreatorID: uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b"), Role: "host"},
+			expected: http.StatusForbidden,
+		},
+		"owner role can not be given": {
+			quiz:     &domain.Quiz{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58")},
+			input:    &inputs.Collaborator{CreatorID: uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b"), Role: "owner"},
+			expected: http.StatusBadRequest,
+		},
+		"owner can not be a collaborator": {
+			quiz:     &domain.Quiz{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58")},
+			input:    &inputs.Collaborator{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), Role: "host"},
+			expected: http.StatusBadRequest,
+		},
+		"creator not found": {
+			quiz:     &domain.Quiz{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58")},
+			input:    &inputs.Collaborator{CreatorID: uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b"), Role: "host"},
+			saveErr:  gorm.ErrRecordNotFound,
+			expected: http.StatusNotFound,
+		},
+		"save error": {
+			quiz:     &domain.Quiz{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58")},
+			input:    &inputs.Collaborator{CreatorID: uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b"), Role: "host"},
+			saveErr:  assert.AnError,
+			expected: http.StatusInternalServerError,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &CollaboratorHandler{
+				QuizService:         &MockQuizService{getByIdReturns: testData.quiz},
+				CollaboratorService: &MockCollaboratorService{saveReturns: testData.saveErr},
+			}
+
+			inputJson, _ := json.Marshal(testData.input)
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+			context.Params = []gin.Param{{Key: "id", Value: "ac1d0e93-b545-48be-bff9-656a933afa04"}}
+			context.Request, _ = http.NewRequest(http.MethodPut, "", io.NopCloser(bytes.NewBuffer(inputJson)))
+
+			// Act
+			handler.Put(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestCollaboratorHandler_Delete_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		creator   string
+		quiz      *domain.Quiz
+		deleteErr error
+		expected  int
+	}{
+		"invalid creator": {
+			creator:  "no",
+			expected: http.StatusBadRequest,
+		},
+		"owner removes collaborator": {
+			creator:  "8fdc3e5a-b0a8-4103-af3b-c2f20d91889b",
+			quiz:     &domain.Quiz{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58")},
+			expected: http.StatusNoContent,
+		},
+		"collaborator leaves": {
+			creator: "2f80947c-e724-4b38-8c8d-3823864fef58",
+			quiz: &domain.Quiz{
+				CreatorID:     uuid.MustParse("e3274bf0-b154-4d37-a6fd-878d530025f9"),
+				Collaborators: []*domain.Collaborator{{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), Role: domain.RoleViewer}},
+			},
+			expected: http.StatusNoContent,
+		},
+		"collaborator removes someone else": {
+			creator: "8fdc3e5a-b0a8-4103-af3b-c2f20d91889b",
+			quiz: &domain.Quiz{
+				CreatorID:     uuid.MustParse("e3274bf0-b154-4d37-a6fd-878d530025f9"),
+				Collaborators: []*domain.Collaborator{{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), Role: domain.RoleEditor}},
+			},
+			expected: http.StatusForbidden,
+		},
+		"not found": {
+			creator:   "8fdc3e5a-b0a8-4103-af3b-c2f20d91889b",
+			quiz:      &domain.Quiz{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58")},
+			deleteErr: assert.AnError,
+			expected:  http.StatusNotFound,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &CollaboratorHandler{
+				QuizService:         &MockQuizService{getByIdReturns: testData.quiz},
+				CollaboratorService: &MockCollaboratorService{deleteReturns: testData.deleteErr},
+			}
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+			context.Params = []gin.Param{{Key: "id", Value: "ac1d0e93-b545-48be-bff9-656a933afa04"}, {Key: "creator", Value: testData.creator}}
+
+			// Act
+			handler.Delete(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
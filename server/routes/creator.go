@@ -4,12 +4,14 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
 	"github.com/survivorbat/qq.maarten.dev/server/services"
 	"net/http"
 )
 
 type CreatorHandler struct {
 	CreatorService services.CreatorService
+	AvatarService  services.AvatarService
 }
 
 // GetWithID godoc
@@ -34,3 +36,46 @@ func (g *CreatorHandler) GetWithID(c *gin.Context) {
 
 	c.JSON(http.StatusOK, creator)
 }
+
+// PutAvatar godoc
+//
+//	@Summary	Choose one of the built-in avatars
+//	@Tags		Creator
+//	@Accept		json
+//	@Produce	json
+//	@Param		input	body		inputs.Avatar	true	"Your avatar"
+//	@Success	200		{object}	domain.Creator	"The creator"
+//	@Failure	400		"Malformed input or unknown avatar"
+//	@Failure	500		"Internal Server Error"
+//	@Router		/api/v1/creators/self/avatar [put]
+//	@Security	JWT
+func (g *CreatorHandler) PutAvatar(c *gin.Context) {
+	authID := c.GetString("user")
+
+	var input *inputs.Avatar
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logrus.WithError(err).Error("Validation error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	if input.Avatar != "" && !g.AvatarService.Exists(input.Avatar) {
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	creator, err := g.CreatorService.GetByID(uuid.MustParse(authID))
+	if err != nil {
+		logrus.WithError(err).Error("Failed to fetch creator by ID")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	if err := g.CreatorService.UpdateAvatar(creator, input.Avatar); err != nil {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	creator.Avatar = input.Avatar
+	c.JSON(http.StatusOK, creator)
+}
@@ -1,11 +1,13 @@
 package routes
 
 import (
+	"bytes"
 	"encoding/json"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -72,3 +74,81 @@ func TestCreatorHandler_GetWithID_ReturnsErrorOnFetchError(t *testing.T) {
 	// Assert
 	assert.Equal(t, http.StatusInternalServerError, writer.Code)
 }
+
+func TestCreatorHandler_PutAvatar_UpdatesAvatar(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		avatar   string
+		exists   bool
+		expected int
+	}{
+		"built-in": {
+			avatar:   "moon",
+			exists:   true,
+			expected: http.StatusOK,
+		},
+		"reset": {
+			avatar:   "",
+			expected: http.StatusOK,
+		},
+		"unknown": {
+			avatar:   "sun",
+			exists:   false,
+			expected: http.StatusBadRequest,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			creator := &domain.Creator{BaseObject: domain.BaseObject{ID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58")}}
+
+			mockCreatorService := &MockCreatorService{getByIDReturns: creator}
+			handler := &CreatorHandler{
+				CreatorService: mockCreatorService,
+				AvatarService:  &MockAvatarService{existsReturns: testData.exists},
+			}
+
+			inputJson, _ := json.Marshal(&inputs.Avatar{Avatar: testData.avatar})
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Request, _ = http.NewRequest(http.MethodPut, "", bytes.NewBuffer(inputJson))
+			context.Set("user", creator.ID.String())
+
+			// Act
+			handler.PutAvatar(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+
+			if testData.expected == http.StatusOK {
+				assert.Equal(t, testData.avatar, mockCreatorService.updateAvatarCalledWith)
+			}
+		})
+	}
+}
+
+func TestCreatorHandler_PutAvatar_ReturnsErrorOnUpdateError(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	creator := &domain.Creator{BaseObject: domain.BaseObject{ID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58")}}
+
+	mockCreatorService := &MockCreatorService{getByIDReturns: creator, updateAvatarReturns: assert.AnError}
+	handler := &CreatorHandler{CreatorService: mockCreatorService, AvatarService: &MockAvatarService{existsReturns: true}}
+
+	inputJson, _ := json.Marshal(&inputs.Avatar{Avatar: "moon"})
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Request, _ = http.NewRequest(http.MethodPut, "", bytes.NewBuffer(inputJson))
+	context.Set("user", creator.ID.String())
+
+	// Act
+	handler.PutAvatar(context)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, writer.Code)
+}
@@ -6,8 +6,10 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/sirupsen/logrus"
 	"github.com/survivorbat/qq.maarten.dev/server/coordinator"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
 	"github.com/survivorbat/qq.maarten.dev/server/services"
 	"net/http"
+	"sync"
 )
 
 var upgrader = websocket.Upgrader{
@@ -17,10 +19,11 @@ var upgrader = websocket.Upgrader{
 }
 
 type GameConnectionHandler struct {
-	GameService    services.GameService
-	PlayerService  services.PlayerService
-	CreatorService services.CreatorService
-	Coordinator    coordinator.GameCoordinator
+	GameService           services.GameService
+	PlayerService         services.PlayerService
+	CreatorService        services.CreatorService
+	PresenterTokenService services.PresenterTokenService
+	Coordinator           coordinator.GameCoordinator
 }
 
 // Get godoc
@@ -169,9 +172,8 @@ func (g *GameConnectionHandler) GetCreator(c *gin.Context) {
 		return
 	}
 
-	if game.Quiz.CreatorID != creatorID {
-		logrus.Error("Not your game")
-		c.AbortWithStatus(http.StatusForbidden)
+	// Co-hosts may drive the game as well
+	if !authorize(c, game.Quiz, domain.PermissionHostGames) {
 		return
 	}
 
@@ -205,7 +207,7 @@ func (g *GameConnectionHandler) GetCreator(c *gin.Context) {
 			logrus.Errorf("Had to recover from a panic: %v", err)
 		}
 
-		g.Coordinator.UnsubscribeCreator(gameID)
+		g.Coordinator.UnsubscribeCreator(gameID, creator)
 	}()
 
 	logrus.Infof("Opening websocket for creator %s in game %s", authID, gameID)
@@ -228,6 +230,16 @@ func (g *GameConnectionHandler) GetCreator(c *gin.Context) {
 				continue
 			}
 
+			if err := result.Parse(); err != nil {
+				logrus.WithError(err).Error("Failed to parse message")
+				continue
+			}
+
+			if ok := result.IsValid(); !ok {
+				logrus.Error("Invalid message")
+				continue
+			}
+
 			logrus.Infof("Got message for game %s from creator %s", gameID, authID)
 			g.Coordinator.HandleCreatorMessage(gameID, result)
 
@@ -237,3 +249,102 @@ func (g *GameConnectionHandler) GetCreator(c *gin.Context) {
 		}
 	}
 }
+
+// GetSpectator godoc
+//
+//	@Summary	Connect to this game using a read-only websocket, for example on a big screen
+//	@Tags		Game
+//	@Accept		json
+//	@Produce	json
+//	@Param		id						path	string	true	"ID of the game"
+//	@Param		Sec-Websocket-Protocol	header	string	true	"Presenter token issued by the host"
+//	@Success	200						"An established connection"
+//	@Failure	400						"Invalid uuid"
+//	@Failure	400						"Invalid websocket headers"
+//	@Failure	401						"Invalid presenter token"
+//	@Failure	403						"Presenter token is for another game"
+//	@Failure	404						"Game not found"
+//	@Failure	404						"Game has finished"
+//	@Router		/api/v1/games/{id}/spectator/connection [get]
+func (g *GameConnectionHandler) GetSpectator(c *gin.Context) {
+	gameParam := c.Param("id")
+	gameID, err := uuid.Parse(gameParam)
+	if err != nil {
+		logrus.WithError(err).Error("UUID error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	plainToken := c.GetHeader("Sec-Websocket-Protocol")
+
+	token, err := g.PresenterTokenService.Authenticate(plainToken)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to validate presenter token")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	if token.GameID != gameID {
+		logrus.Error("Presenter token is for another game")
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	game, err := g.GameService.GetByID(gameID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to fetch game")
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	if !game.FinishTime.IsZero() {
+		logrus.Error("Game has finished")
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	// Like the creator, the token is sent as the subprotocol and the browser verifies it's echoed back
+	spectatorUpgrader := websocket.Upgrader{
+		Subprotocols: []string{plainToken},
+		CheckOrigin: func(*http.Request) bool {
+			return true
+		},
+	}
+
+	ws, err := spectatorUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		logrus.WithError(err).Error("Game can not be joined")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	defer ws.Close()
+
+	spectatorID := uuid.New()
+	defer g.Coordinator.UnsubscribeSpectator(gameID, spectatorID)
+
+	// Broadcasts may come from multiple goroutines, but the socket only allows one writer
+	var writeLock sync.Mutex
+
+	logrus.Infof("Opening websocket for spectator %s in game %s", spectatorID, gameID)
+	g.Coordinator.SubscribeSpectator(gameID, token.ID, spectatorID, func(message *coordinator.BroadcastMessage) {
+		writeLock.Lock()
+		defer writeLock.Unlock()
+
+		if err := ws.WriteJSON(message); err != nil {
+			logrus.WithError(err).Error("Failed to write JSON")
+		}
+
+		// Closing the socket ends the read loop below
+		if message.Type == coordinator.FinishGameType || message.Type == coordinator.RevokedType {
+			_ = ws.Close()
+		}
+	})
+
+	for {
+		// Spectators are read-only, anything they send is ignored
+		if _, _, err := ws.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestGameConnectionHandler_Get_ReturnsErrorOnInvalidGameUUID(t *testing.T) {
@@ -546,3 +547,110 @@ func TestGameConnectionHandler_GetCreator_PanicCallsUnsubscribe(t *testing.T) {
 
 	assert.Equal(t, game.ID, coord.unsubscribeCreatorCalledWithGame)
 }
+
+func TestGameConnectionHandler_GetSpectator_ReturnsErrorOnInvalidToken(t *testing.T) {
+	t.Parallel()
+	gameID := uuid.MustParse("f7422157-bc0c-4998-834a-0aeb7a800dc7")
+
+	tests := map[string]struct {
+		token    *domain.PresenterToken
+		err      error
+		game     *domain.Game
+		expected int
+	}{
+		"unknown token": {
+			err:      assert.AnError,
+			expected: http.StatusUnauthorized,
+		},
+		"other game": {
+			token:    &domain.PresenterToken{GameID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58")},
+			expected: http.StatusForbidden,
+		},
+		"finished game": {
+			token:    &domain.PresenterToken{GameID: gameID},
+			game:     &domain.Game{FinishTime: time.Now()},
+			expected: http.StatusNotFound,
+		},
+		"no websocket": {
+			token:    &domain.PresenterToken{GameID: gameID},
+			game:     &domain.Game{},
+			expected: http.StatusBadRequest,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			tokenService := &MockPresenterTokenService{authenticateReturns: testData.token, authenticateReturnsError: testData.err}
+			handler := &GameConnectionHandler{
+				GameService:           &MockGameService{getByIdReturns: testData.game},
+				PresenterTokenService: tokenService,
+			}
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Request, _ = http.NewRequest(http.MethodGet, "", nil)
+			context.Request.Header.Set("Sec-Websocket-Protocol", "qqp_abc")
+			context.Params = []gin.Param{{Key: "id", Value: gameID.String()}}
+
+			// Act
+			handler.GetSpectator(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+			assert.Equal(t, "qqp_abc", tokenService.authenticateCalledWith)
+		})
+	}
+}
+
+func TestGameConnectionHandler_GetSpectator_WritesBroadcastsToSocket(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	game := &domain.Game{BaseObject: domain.BaseObject{ID: uuid.MustParse("f7422157-bc0c-4998-834a-0aeb7a800dc7")}}
+	token := &domain.PresenterToken{BaseObject: domain.BaseObject{ID: uuid.MustParse("5c1f6a43-9d0e-4f6b-8f0a-3a7d8e2b1c90")}, GameID: game.ID}
+
+	coord := &MockCoordinator{
+		subscribeSpectatorReturns: &coordinator.BroadcastMessage{Type: coordinator.PresentationType},
+	}
+	coord.unsubscribeSpectatorWaitGroup.Add(1)
+
+	handler := &GameConnectionHandler{
+		GameService:           &MockGameService{getByIdReturns: game},
+		PresenterTokenService: &MockPresenterTokenService{authenticateReturns: token},
+		Coordinator:           coord,
+	}
+
+	engine := gin.Default()
+	engine.GET("/games/:id/spectator/connection", handler.GetSpectator)
+	ts := httptest.NewServer(engine)
+	defer ts.Close()
+
+	socketUrl := fmt.Sprintf("ws%s/games/f7422157-bc0c-4998-834a-0aeb7a800dc7/spectator/connection", strings.TrimPrefix(ts.URL, "http"))
+	dialer := &websocket.Dialer{Subprotocols: []string{"qqp_abc"}}
+
+	// Act
+	ws, _, err := dialer.Dial(socketUrl, nil)
+
+	// Assert
+	if !assert.NoError(t, err) {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "qqp_abc", ws.Subprotocol())
+
+	var message coordinator.BroadcastMessage
+	if err := ws.ReadJSON(&message); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, coordinator.PresentationType, message.Type)
+	assert.Equal(t, game.ID, coord.subscribeSpectatorCalledWithGame)
+	assert.Equal(t, token.ID, coord.subscribeSpectatorCalledWithToken)
+
+	// Disconnecting unsubscribes the spectator
+	_ = ws.Close()
+	coord.unsubscribeSpectatorWaitGroup.Wait()
+	assert.NotEqual(t, uuid.Nil, coord.unsubscribeSpectatorCalledWithID)
+}
@@ -1,18 +1,26 @@
 package routes
 
 import (
+	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/coordinator"
 	"github.com/survivorbat/qq.maarten.dev/server/domain"
 	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"github.com/survivorbat/qq.maarten.dev/server/routes/outputs"
 	"github.com/survivorbat/qq.maarten.dev/server/services"
 	"net/http"
+	"time"
 )
 
+const calendarContentType = "text/calendar; charset=utf-8"
+
 type GameControlHandler struct {
 	QuizService services.QuizService
 	GameService services.GameService
+	Scheduler   coordinator.GameScheduler
 }
 
 // GetByID godoc
@@ -29,7 +37,6 @@ type GameControlHandler struct {
 //	@Router		/api/v1/games/{id} [get]
 //	@Security	JWT
 func (g *GameControlHandler) GetByID(c *gin.Context) {
-	authID := c.GetString("user")
 	id := c.Param("id")
 
 	gameID, err := uuid.Parse(id)
@@ -47,15 +54,144 @@ func (g *GameControlHandler) GetByID(c *gin.Context) {
 	}
 
 	// Prevent users from viewing other people's games
-	if game.Quiz.CreatorID.String() != authID {
-		logrus.Errorf("Creator is %s not %s", game.Quiz.CreatorID, authID)
-		c.AbortWithStatus(http.StatusForbidden)
+	if !authorize(c, game.Quiz, domain.PermissionViewQuiz) {
 		return
 	}
 
 	c.JSON(http.StatusOK, game)
 }
 
+// GetActive godoc
+//
+//	@Summary	Fetch the games of this quiz that are in progress, with their player counts
+//	@Tags		Quiz
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path	string						true	"ID of the quiz"
+//	@Success	200	{array}	[]outputs.OutputActiveGame	"The active games"
+//	@Failure	400	"Invalid uuid"
+//	@Failure	403	"You can only view your own games"
+//	@Failure	404	"Quiz not found"
+//	@Failure	500	"Internal Server Error"
+//	@Router		/api/v1/quizzes/{id}/games/active [get]
+//	@Security	JWT
+func (g *GameControlHandler) GetActive(c *gin.Context) {
+	id := c.Param("id")
+
+	quizID, err := uuid.Parse(id)
+	if err != nil {
+		logrus.WithError(err).Error("UUID error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	quiz, err := g.QuizService.GetByID(quizID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get quiz")
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	// Prevent users from viewing other people's games
+	if !authorize(c, quiz, domain.PermissionViewQuiz) {
+		return
+	}
+
+	games, err := g.GameService.GetActiveByQuiz(quiz.ID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get active games")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, outputs.NewActiveGames(games))
+}
+
+// GetTeamLeaderboard godoc
+//
+//	@Summary	Fetch the scores of this game's teams, highest first
+//	@Tags		Game
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		string				true	"ID of the game"
+//	@Success	200	{array}		[]domain.TeamScore	"The team leaderboard"
+//	@Failure	400	"Invalid uuid"
+//	@Failure	403	"You can only view your own games"
+//	@Failure	404	"Game not found or has no teams"
+//	@Router		/api/v1/games/{id}/teams/leaderboard [get]
+//	@Security	JWT
+func (g *GameControlHandler) GetTeamLeaderboard(c *gin.Context) {
+	id := c.Param("id")
+
+	gameID, err := uuid.Parse(id)
+	if err != nil {
+		logrus.WithError(err).Error("UUID error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	game, err := g.GameService.GetByID(gameID)
+	if err != nil {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	// Prevent users from viewing other people's games
+	if !authorize(c, game.Quiz, domain.PermissionViewQuiz) {
+		return
+	}
+
+	if !game.HasTeams() {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	c.JSON(http.StatusOK, game.TeamLeaderboard())
+}
+
+// GetCalendar godoc
+//
+//	@Summary	Export a scheduled game as an iCalendar event
+//	@Tags		Game
+//	@Produce	text/calendar
+//	@Param		id	path	string	true	"ID of the game"
+//	@Success	200	"The calendar with a single event"
+//	@Failure	400	"Invalid uuid"
+//	@Failure	403	"You can only view your own games"
+//	@Failure	404	"Game not found or not scheduled"
+//	@Router		/api/v1/games/{id}/calendar [get]
+//	@Security	JWT
+func (g *GameControlHandler) GetCalendar(c *gin.Context) {
+	id := c.Param("id")
+
+	gameID, err := uuid.Parse(id)
+	if err != nil {
+		logrus.WithError(err).Error("UUID error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	game, err := g.GameService.GetByID(gameID)
+	if err != nil {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	// Prevent users from viewing other people's games
+	if !authorize(c, game.Quiz, domain.PermissionViewQuiz) {
+		return
+	}
+
+	calendar, ok := outputs.NewCalendar(game, time.Now())
+	if !ok {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.ics"`, game.ID))
+	c.Data(http.StatusOK, calendarContentType, calendar)
+}
+
 // Post godoc
 //
 //	@Summary	Create a new game for this quiz
@@ -71,7 +207,6 @@ func (g *GameControlHandler) GetByID(c *gin.Context) {
 //	@Router		/api/v1/quizzes/{id}/games [post]
 //	@Security	JWT
 func (g *GameControlHandler) Post(c *gin.Context) {
-	authID := c.GetString("user")
 	id := c.Param("id")
 
 	quizID, err := uuid.Parse(id)
@@ -88,10 +223,8 @@ func (g *GameControlHandler) Post(c *gin.Context) {
 		return
 	}
 
-	// Prevent users from viewing other people's games
-	if quiz.CreatorID.String() != authID {
-		logrus.Errorf("Creator is %s not %s", quiz.CreatorID, authID)
-		c.AbortWithStatus(http.StatusForbidden)
+	// Prevent users from creating games on other people's quizzes
+	if !authorize(c, quiz, domain.PermissionHostGames) {
 		return
 	}
 
@@ -102,10 +235,8 @@ func (g *GameControlHandler) Post(c *gin.Context) {
 		return
 	}
 
-	game := &domain.Game{
-		QuizID:      quizID,
-		PlayerLimit: input.PlayerLimit,
-	}
+	game := input.ToDomain()
+	game.QuizID = quizID
 
 	if err := g.GameService.Create(game); err != nil {
 		logrus.WithError(err).Error("Failed to create")
@@ -113,6 +244,8 @@ func (g *GameControlHandler) Post(c *gin.Context) {
 		return
 	}
 
+	g.Scheduler.Schedule(game)
+
 	c.JSON(http.StatusOK, game)
 }
 
@@ -135,7 +268,6 @@ func (g *GameControlHandler) Post(c *gin.Context) {
 //	@Router		/api/v1/games/{id} [patch]
 //	@Security	JWT
 func (g *GameControlHandler) Patch(c *gin.Context) {
-	authID := c.GetString("user")
 	id := c.Param("id")
 	action := c.Query("action")
 
@@ -153,26 +285,27 @@ func (g *GameControlHandler) Patch(c *gin.Context) {
 		return
 	}
 
-	// Prevent users from viewing other people's games
-	if game.Quiz.CreatorID.String() != authID {
-		logrus.Errorf("Creator is %s not %s", game.Quiz.CreatorID, authID)
-		c.AbortWithStatus(http.StatusForbidden)
+	// Prevent users from changing other people's games
+	if !authorize(c, game.Quiz, domain.PermissionHostGames) {
 		return
 	}
 
 	switch action {
 	case "start":
-		if game.Quiz.HasGameInProgress() {
-			logrus.WithError(err).Error("Another game is already in progress")
+		err := g.GameService.Start(game)
+		if errors.Is(err, services.ErrTooManyGamesInProgress) {
 			c.AbortWithStatus(http.StatusConflict)
 			return
 		}
 
-		if err := g.GameService.Start(game); err != nil {
+		if err != nil {
 			logrus.WithError(err).Error("Can not start game")
 			c.AbortWithStatus(http.StatusInternalServerError)
 			return
 		}
+
+		// Drops a scheduled start and schedules the finish, if any
+		g.Scheduler.Schedule(game)
 	default:
 		logrus.Errorf("Unknown action %s", action)
 		c.AbortWithStatus(http.StatusBadRequest)
@@ -198,7 +331,6 @@ func (g *GameControlHandler) Patch(c *gin.Context) {
 //	@Router		/api/v1/games/{id} [delete]
 //	@Security	JWT
 func (g *GameControlHandler) Delete(c *gin.Context) {
-	authID := c.GetString("user")
 	id := c.Param("id")
 
 	gameID, err := uuid.Parse(id)
@@ -215,9 +347,7 @@ func (g *GameControlHandler) Delete(c *gin.Context) {
 	}
 
 	// Prevent users from deleting other people's games
-	if game.Quiz.CreatorID.String() != authID {
-		logrus.Errorf("Creator is %s not %s", game.Quiz.CreatorID, authID)
-		c.AbortWithStatus(http.StatusForbidden)
+	if !authorize(c, game.Quiz, domain.PermissionHostGames) {
 		return
 	}
 
@@ -227,5 +357,7 @@ func (g *GameControlHandler) Delete(c *gin.Context) {
 		return
 	}
 
+	g.Scheduler.Cancel(game.ID)
+
 	c.JSON(http.StatusOK, game)
 }
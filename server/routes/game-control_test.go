@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/survivorbat/qq.maarten.dev/server/domain"
 	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"github.com/survivorbat/qq.maarten.dev/server/routes/outputs"
+	"github.com/survivorbat/qq.maarten.dev/server/services"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -97,6 +99,100 @@ func TestGameHandler_GetByID_ReturnsData(t *testing.T) {
 	assert.ElementsMatch(t, gameService.getByQuizReturns, result)
 }
 
+func TestGameHandler_GetActive_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		id          string
+		quizService *MockQuizService
+		gameService *MockGameService
+		expected    int
+	}{
+		"invalid uuid": {
+			id:          "no",
+			quizService: &MockQuizService{},
+			gameService: &MockGameService{},
+			expected:    http.StatusBadRequest,
+		},
+		"quiz not found": {
+			id:          "788f12a9-51e8-4c87-9b0c-06bcc9f0691b",
+			quizService: &MockQuizService{getByIdReturnsError: assert.AnError},
+			gameService: &MockGameService{},
+			expected:    http.StatusNotFound,
+		},
+		"not my quiz": {
+			id:          "788f12a9-51e8-4c87-9b0c-06bcc9f0691b",
+			quizService: &MockQuizService{getByIdReturns: &domain.Quiz{CreatorID: uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b")}},
+			gameService: &MockGameService{},
+			expected:    http.StatusForbidden,
+		},
+		"service error": {
+			id:          "788f12a9-51e8-4c87-9b0c-06bcc9f0691b",
+			quizService: &MockQuizService{getByIdReturns: &domain.Quiz{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58")}},
+			gameService: &MockGameService{getActiveByQuizReturnsError: assert.AnError},
+			expected:    http.StatusInternalServerError,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &GameControlHandler{QuizService: testData.quizService, GameService: testData.gameService}
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+			context.Request, _ = http.NewRequest(http.MethodGet, "", nil)
+			context.Params = []gin.Param{{Key: "id", Value: testData.id}}
+
+			// Act
+			handler.GetActive(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestGameHandler_GetActive_ReturnsGamesWithPlayerCounts(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quiz := &domain.Quiz{
+		BaseObject: domain.BaseObject{ID: uuid.MustParse("788f12a9-51e8-4c87-9b0c-06bcc9f0691b")},
+		CreatorID:  uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"),
+	}
+	quizService := &MockQuizService{getByIdReturns: quiz}
+	gameService := &MockGameService{getActiveByQuizReturns: []*domain.Game{
+		{BaseObject: domain.BaseObject{ID: uuid.MustParse("238fe389-dede-4ee0-b26f-d2b1a65befac")}, Code: "FIRST1", Players: []*domain.Player{{}, {}}},
+		{BaseObject: domain.BaseObject{ID: uuid.MustParse("4a1cdf60-cd2c-4c5f-88f5-0b31a5ec7b0e")}, Code: "SECND1", Players: []*domain.Player{{}}},
+	}}
+	handler := &GameControlHandler{QuizService: quizService, GameService: gameService}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodGet, "", nil)
+	context.Params = []gin.Param{{Key: "id", Value: quiz.ID.String()}}
+
+	// Act
+	handler.GetActive(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, quiz.ID, gameService.getActiveByQuizCalledWith)
+
+	var result []*outputs.OutputActiveGame
+	_ = json.Unmarshal(writer.Body.Bytes(), &result)
+
+	if assert.Len(t, result, 2) {
+		assert.Equal(t, "FIRST1", result[0].Code)
+		assert.Equal(t, uint(2), result[0].PlayerCount)
+		assert.Equal(t, "SECND1", result[1].Code)
+		assert.Equal(t, uint(1), result[1].PlayerCount)
+	}
+}
+
 func TestGameHandler_Post_ReturnsErrorOnInvalidUUID(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -210,7 +306,8 @@ func TestGameHandler_Post_ReturnsData(t *testing.T) {
 		CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"),
 	}}
 	gameService := &MockGameService{}
-	handler := &GameControlHandler{QuizService: quizService, GameService: gameService}
+	scheduler := &MockGameScheduler{}
+	handler := &GameControlHandler{QuizService: quizService, GameService: gameService, Scheduler: scheduler}
 
 	input := &inputs.Game{PlayerLimit: 2}
 	inputJson, _ := json.Marshal(input)
@@ -233,6 +330,119 @@ func TestGameHandler_Post_ReturnsData(t *testing.T) {
 	}
 
 	assert.Equal(t, input.PlayerLimit, result.PlayerLimit)
+	assert.Same(t, gameService.createCalledWith, scheduler.scheduleCalledWith)
+}
+
+func TestGameHandler_Post_CreatesTeams(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quizService := &MockQuizService{getByIdReturns: &domain.Quiz{
+		CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"),
+	}}
+	gameService := &MockGameService{}
+	handler := &GameControlHandler{QuizService: quizService, GameService: gameService, Scheduler: &MockGameScheduler{}}
+
+	inputJson, _ := json.Marshal(&inputs.Game{PlayerLimit: 4, Teams: []string{"Marketing", "Sales"}, TeamScoring: "best"})
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodPost, "", io.NopCloser(bytes.NewBuffer(inputJson)))
+	context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+	// Act
+	handler.Post(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	if assert.Len(t, gameService.createCalledWith.Teams, 2) {
+		assert.Equal(t, "Marketing", gameService.createCalledWith.Teams[0].Name)
+		assert.Equal(t, "Sales", gameService.createCalledWith.Teams[1].Name)
+	}
+	assert.Equal(t, domain.TeamScoringBest, gameService.createCalledWith.TeamScoring)
+}
+
+func TestGameHandler_Post_ReturnsErrorOnDuplicateTeams(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quizService := &MockQuizService{getByIdReturns: &domain.Quiz{
+		CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"),
+	}}
+	handler := &GameControlHandler{QuizService: quizService, GameService: &MockGameService{}}
+
+	inputJson, _ := json.Marshal(&inputs.Game{PlayerLimit: 4, Teams: []string{"Sales", "Sales"}})
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodPost, "", io.NopCloser(bytes.NewBuffer(inputJson)))
+	context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+	// Act
+	handler.Post(context)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+}
+
+func TestGameHandler_GetTeamLeaderboard_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	creatorID := uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58")
+
+	tests := map[string]struct {
+		game     *domain.Game
+		err      error
+		expected int
+	}{
+		"not found": {
+			err:      assert.AnError,
+			expected: http.StatusNotFound,
+		},
+		"not my quiz": {
+			game:     &domain.Game{Quiz: &domain.Quiz{CreatorID: uuid.MustParse("788f12a9-51e8-4c87-9b0c-06bcc9f0691b")}},
+			expected: http.StatusForbidden,
+		},
+		"no teams": {
+			game:     &domain.Game{Quiz: &domain.Quiz{CreatorID: creatorID}},
+			expected: http.StatusNotFound,
+		},
+		"teams": {
+			game:     &domain.Game{Quiz: &domain.Quiz{CreatorID: creatorID}, Teams: []*domain.Team{{Name: "Sales"}}},
+			expected: http.StatusOK,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			gameService := &MockGameService{getByIdReturns: testData.game, getByIdReturnsError: testData.err}
+			handler := &GameControlHandler{GameService: gameService}
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Set("user", creatorID.String())
+			context.Request, _ = http.NewRequest(http.MethodGet, "", nil)
+			context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+			// Act
+			handler.GetTeamLeaderboard(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+
+			if testData.expected == http.StatusOK {
+				var result []*domain.TeamScore
+				if err := json.Unmarshal(writer.Body.Bytes(), &result); err != nil {
+					t.Fatal(err.Error())
+				}
+
+				assert.Equal(t, "Sales", result[0].Team.Name)
+			}
+		})
+	}
 }
 
 func TestGameHandler_Patch_ReturnsErrorOnInvalidUUID(t *testing.T) {
@@ -293,6 +503,55 @@ func TestGameHandler_Patch_ReturnsErrorOnNotMyQuiz(t *testing.T) {
 	assert.Equal(t, http.StatusForbidden, writer.Code)
 }
 
+func TestGameHandler_Patch_ReturnsErrorOnViewer(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	gameService := &MockGameService{
+		getByIdReturns: &domain.Game{Quiz: &domain.Quiz{
+			CreatorID:     uuid.MustParse("76afc275-5454-4359-a52b-02693a9c48ba"),
+			Collaborators: []*domain.Collaborator{{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), Role: domain.RoleViewer}},
+		}},
+	}
+	handler := &GameControlHandler{GameService: gameService}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodPatch, "https://test.com?action=start", nil)
+	context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+	// Act
+	handler.Patch(context)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, writer.Code)
+	assert.Nil(t, gameService.startCalledWith)
+}
+
+func TestGameHandler_Patch_StartsGameAsCoHost(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	game := &domain.Game{Quiz: &domain.Quiz{
+		CreatorID:     uuid.MustParse("76afc275-5454-4359-a52b-02693a9c48ba"),
+		Collaborators: []*domain.Collaborator{{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), Role: domain.RoleHost}},
+	}}
+	gameService := &MockGameService{getByIdReturns: game}
+	handler := &GameControlHandler{GameService: gameService, Scheduler: &MockGameScheduler{}}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodPatch, "https://test.com?action=start", nil)
+	context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+	// Act
+	handler.Patch(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, game, gameService.startCalledWith)
+}
+
 func TestGameHandler_Patch_ReturnsErrorInvalidAction(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -321,9 +580,9 @@ func TestGameHandler_Patch_StartReturnsErrorOnGameAlreadyInProgress(t *testing.T
 		getByIdReturns: &domain.Game{
 			Quiz: &domain.Quiz{
 				CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"),
-				Games:     []*domain.Game{{StartTime: time.Now()}},
 			},
 		},
+		startReturns: services.ErrTooManyGamesInProgress,
 	}
 	handler := &GameControlHandler{GameService: gameService}
 
@@ -340,6 +599,34 @@ func TestGameHandler_Patch_StartReturnsErrorOnGameAlreadyInProgress(t *testing.T
 	assert.Equal(t, http.StatusConflict, writer.Code)
 }
 
+func TestGameHandler_Patch_StartsGameBelowConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	game := &domain.Game{
+		Quiz: &domain.Quiz{
+			CreatorID:          uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"),
+			MaxConcurrentGames: 2,
+			Games:              []*domain.Game{{StartTime: time.Now()}},
+		},
+	}
+	gameService := &MockGameService{getByIdReturns: game}
+	scheduler := &MockGameScheduler{}
+	handler := &GameControlHandler{GameService: gameService, Scheduler: scheduler}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodPatch, "https://test.com?action=start", nil)
+	context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+	// Act
+	handler.Patch(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Same(t, game, gameService.startCalledWith)
+}
+
 func TestGameHandler_Patch_ReturnsStartError(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -376,7 +663,8 @@ func TestGameHandler_Patch_ReturnsResult(t *testing.T) {
 			},
 		},
 	}
-	handler := &GameControlHandler{GameService: gameService}
+	scheduler := &MockGameScheduler{}
+	handler := &GameControlHandler{GameService: gameService, Scheduler: scheduler}
 
 	writer := httptest.NewRecorder()
 	context, _ := gin.CreateTestContext(writer)
@@ -396,6 +684,7 @@ func TestGameHandler_Patch_ReturnsResult(t *testing.T) {
 	}
 
 	assert.Equal(t, gameService.getByIdReturns.ID, result.ID)
+	assert.Same(t, gameService.startCalledWith, scheduler.scheduleCalledWith)
 }
 
 func TestGameHandler_Delete_ReturnsErrorOnInvalidUUID(t *testing.T) {
@@ -488,7 +777,8 @@ func TestGameHandler_Delete_ReturnsResult(t *testing.T) {
 			},
 		},
 	}
-	handler := &GameControlHandler{GameService: gameService}
+	scheduler := &MockGameScheduler{}
+	handler := &GameControlHandler{GameService: gameService, Scheduler: scheduler}
 
 	writer := httptest.NewRecorder()
 	context, _ := gin.CreateTestContext(writer)
@@ -508,4 +798,57 @@ func TestGameHandler_Delete_ReturnsResult(t *testing.T) {
 	}
 
 	assert.Equal(t, gameService.getByIdReturns.ID, result.ID)
+	assert.Equal(t, gameService.getByIdReturns.ID, scheduler.cancelCalledWith)
+}
+
+func TestGameHandler_GetCalendar_ReturnsErrorOnNotScheduled(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	gameService := &MockGameService{
+		getByIdReturns: &domain.Game{
+			Quiz: &domain.Quiz{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58")},
+		},
+	}
+	handler := &GameControlHandler{GameService: gameService}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+	// Act
+	handler.GetCalendar(context)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, writer.Code)
+}
+
+func TestGameHandler_GetCalendar_ReturnsCalendar(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	gameService := &MockGameService{
+		getByIdReturns: &domain.Game{
+			BaseObject:     domain.BaseObject{ID: uuid.MustParse("788f12a9-51e8-4c87-9b0c-06bcc9f0691b")},
+			ScheduledStart: time.Date(2030, 1, 2, 10, 0, 0, 0, time.UTC),
+			Quiz: &domain.Quiz{
+				Name:      "Pub quiz",
+				CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"),
+			},
+		},
+	}
+	handler := &GameControlHandler{GameService: gameService}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+	// Act
+	handler.GetCalendar(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, calendarContentType, writer.Header().Get("Content-Type"))
+	assert.Contains(t, writer.Body.String(), "DTSTART:20300102T100000Z")
+	assert.Contains(t, writer.Body.String(), "SUMMARY:Pub quiz")
 }
@@ -11,6 +11,9 @@ import (
 
 type PublicGameHandler struct {
 	GameService services.GameService
+
+	// CodeLockout prevents clients from guessing codes
+	CodeLockout services.Lockout
 }
 
 // GetByCode godoc
@@ -23,6 +26,7 @@ type PublicGameHandler struct {
 //	@Success	200		{object}	outputs.OutputGame	"The game ID"
 //	@Failure	403		"Can only be used for filtering on codes"
 //	@Failure	404		"Game not found"
+//	@Failure	429		"Too many failed attempts"
 //	@Failure	500		"Internal Server Error"
 //	@Router		/api/v1/games [get]
 func (g *PublicGameHandler) GetByCode(c *gin.Context) {
@@ -33,9 +37,16 @@ func (g *PublicGameHandler) GetByCode(c *gin.Context) {
 		return
 	}
 
+	if wait := g.CodeLockout.LockedFor(c.ClientIP()); wait > 0 {
+		logrus.Warnf("Code lookups of %s are locked", c.ClientIP())
+		abortTooManyRequests(c, wait)
+		return
+	}
+
 	game, err := g.GameService.GetByCode(code)
 	if err != nil {
 		logrus.WithError(err).Error("Failed to fetch game")
+		g.CodeLockout.Fail(c.ClientIP())
 		c.AbortWithStatus(http.StatusNotFound)
 		return
 	}
@@ -50,6 +61,7 @@ func (g *PublicGameHandler) GetByCode(c *gin.Context) {
 //	@Accept		json
 //	@Produce	json
 //	@Param		code	query		string				true	"ID of the game"
+//	@Param		player	query		string				false	"ID of the player, to get the options in their order"
 //	@Success	200		{object}	outputs.OutputQuiz	"The game ID"
 //	@Failure	400		"Invalid uuid"
 //	@Failure	404		"Game is not active"
@@ -72,5 +84,15 @@ func (g *PublicGameHandler) GetQuiz(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, outputs.NewPublicQuiz(game.Quiz))
+	// Without a player, the options are in the order that all players share
+	playerID := uuid.Nil
+	if player := c.Query("player"); player != "" {
+		if playerID, err = uuid.Parse(player); err != nil {
+			logrus.WithError(err).Error("UUID error")
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, outputs.NewPublicQuiz(game, playerID))
 }
@@ -11,23 +11,45 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestPublicGameHandler_GetByCode_ReturnsErrorOnGameNotFound(t *testing.T) {
 	t.Parallel()
 	// Arrange
 	gameService := &MockGameService{getByCodeReturnsError: assert.AnError}
-	handler := &PublicGameHandler{GameService: gameService}
+	lockout := &MockLockout{}
+	handler := &PublicGameHandler{GameService: gameService, CodeLockout: lockout}
 
 	writer := httptest.NewRecorder()
 	context, _ := gin.CreateTestContext(writer)
 	context.Request, _ = http.NewRequest(http.MethodGet, "?code=abc", nil)
+	context.Request.RemoteAddr = "10.0.0.1:1234"
 
 	// Act
 	handler.GetByCode(context)
 
 	// Assert
 	assert.Equal(t, http.StatusNotFound, writer.Code)
+	assert.Equal(t, "10.0.0.1", lockout.failCalledWith)
+}
+
+func TestPublicGameHandler_GetByCode_ReturnsErrorOnLockout(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	gameService := &MockGameService{}
+	handler := &PublicGameHandler{GameService: gameService, CodeLockout: &MockLockout{lockedForReturns: 90 * time.Second}}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Request, _ = http.NewRequest(http.MethodGet, "?code=abc", nil)
+
+	// Act
+	handler.GetByCode(context)
+
+	// Assert
+	assert.Equal(t, http.StatusTooManyRequests, writer.Code)
+	assert.Equal(t, "90", writer.Header().Get("Retry-After"))
 }
 
 func TestPublicGameHandler_GetByCode_ReturnsErrorOnNoCode(t *testing.T) {
@@ -52,17 +74,20 @@ func TestPublicGameHandler_GetByCode_ReturnsGameID(t *testing.T) {
 	// Arrange
 	game := &domain.Game{BaseObject: domain.BaseObject{ID: uuid.MustParse("788f12a9-51e8-4c87-9b0c-06bcc9f0691b")}}
 	gameService := &MockGameService{getByCodeReturns: game}
-	handler := &PublicGameHandler{GameService: gameService}
+	lockout := &MockLockout{}
+	handler := &PublicGameHandler{GameService: gameService, CodeLockout: lockout}
 
 	writer := httptest.NewRecorder()
 	context, _ := gin.CreateTestContext(writer)
 	context.Request, _ = http.NewRequest(http.MethodGet, "?code=abc", nil)
+	context.Request.RemoteAddr = "10.0.0.1:1234"
 
 	// Act
 	handler.GetByCode(context)
 
 	// Assert
 	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Empty(t, lockout.failCalledWith)
 
 	body, err := io.ReadAll(writer.Body)
 	if err != nil {
@@ -114,6 +139,24 @@ func TestPublicGameHandler_GetQuiz_ReturnsErrorOnGameNotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, writer.Code)
 }
 
+func TestPublicGameHandler_GetQuiz_ReturnsErrorOnInvalidPlayer(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	gameService := &MockGameService{getByIdReturns: &domain.Game{Quiz: &domain.Quiz{}}}
+	handler := &PublicGameHandler{GameService: gameService}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Request, _ = http.NewRequest(http.MethodGet, "https://test.com?player=no", nil)
+	context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+	// Act
+	handler.GetQuiz(context)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+}
+
 func TestPublicGameHandler_GetByQuiz_ReturnsQuiz(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -0,0 +1,57 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"github.com/survivorbat/qq.maarten.dev/server/routes/outputs"
+	"github.com/survivorbat/qq.maarten.dev/server/services"
+	"net/http"
+)
+
+type LibraryHandler struct {
+	LibraryService services.LibraryService
+}
+
+// Get godoc
+//
+//	@Summary	Search the public quizzes
+//	@Tags		Library
+//	@Accept		json
+//	@Produce	json
+//	@Param		q			query		string						false	"Words in the name, description, question titles or categories"
+//	@Param		tag			query		string						false	"Only quizzes with this tag"
+//	@Param		language	query		string						false	"Only quizzes in this language"
+//	@Param		sort		query		string						false	"Either popular (default), newest or name"
+//	@Param		page		query		int							false	"Page, starting at 1"
+//	@Param		pageSize	query		int							false	"Quizzes per page, at most 100"
+//	@Success	200			{object}	outputs.OutputLibraryPage	"The quizzes, fork one to use it"
+//	@Failure	400			"Invalid search"
+//	@Failure	500			"Internal Server Error"
+//	@Router		/api/v1/library [get]
+func (g *LibraryHandler) Get(c *gin.Context) {
+	var input inputs.LibrarySearch
+	if err := c.ShouldBindQuery(&input); err != nil {
+		logrus.WithError(err).Error("Validation error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	search := &services.LibrarySearch{
+		Query:    input.Query,
+		Tag:      input.Tag,
+		Language: input.Language,
+		Sort:     services.LibrarySort(input.Sort),
+		Offset:   (input.GetPage() - 1) * input.GetPageSize(),
+		Limit:    input.GetPageSize(),
+	}
+
+	quizzes, total, err := g.LibraryService.Search(search)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to search")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, outputs.NewLibraryPage(quizzes, total, input.GetPage(), input.GetPageSize()))
+}
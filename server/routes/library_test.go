@@ -0,0 +1,115 @@
+package routes
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/routes/outputs"
+	"github.com/survivorbat/qq.maarten.dev/server/services"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLibraryHandler_Get_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		query          string
+		libraryService *MockLibraryService
+		expected       int
+	}{
+		"unknown sort": {
+			query:          "?sort=random",
+			libraryService: &MockLibraryService{},
+			expected:       http.StatusBadRequest,
+		},
+		"zero falls back to defaults": {
+			query:          "?page=0&pageSize=0",
+			libraryService: &MockLibraryService{},
+			expected:       http.StatusOK,
+		},
+		"page size too big": {
+			query:          "?pageSize=500",
+			libraryService: &MockLibraryService{},
+			expected:       http.StatusBadRequest,
+		},
+		"invalid language": {
+			query:          "?language=123",
+			libraryService: &MockLibraryService{},
+			expected:       http.StatusBadRequest,
+		},
+		"search error": {
+			query:          "",
+			libraryService: &MockLibraryService{searchReturnsError: assert.AnError},
+			expected:       http.StatusInternalServerError,
+		},
+		"success": {
+			query:          "?q=capitals&tag=geography&language=en&sort=newest",
+			libraryService: &MockLibraryService{},
+			expected:       http.StatusOK,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &LibraryHandler{LibraryService: testData.libraryService}
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/library"+testData.query, nil)
+
+			// Act
+			handler.Get(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestLibraryHandler_Get_ReturnsPage(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quizzes := []*domain.Quiz{
+		{
+			BaseObject: domain.BaseObject{ID: uuid.MustParse("788f12a9-51e8-4c87-9b0c-06bcc9f0691b")},
+			Name:       "Capitals",
+			Language:   "en",
+			Tags:       []*domain.QuizTag{{Name: "geography"}},
+		},
+	}
+
+	libraryService := &MockLibraryService{searchReturns: quizzes, searchReturnsTotal: 11}
+	handler := &LibraryHandler{LibraryService: libraryService}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/library?q=capitals&tag=Geography&page=3&pageSize=5", nil)
+
+	// Act
+	handler.Get(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	expectedSearch := &services.LibrarySearch{Query: "capitals", Tag: "Geography", Offset: 10, Limit: 5}
+	assert.Equal(t, expectedSearch, libraryService.searchCalledWith)
+
+	var result *outputs.OutputLibraryPage
+	_ = json.Unmarshal(writer.Body.Bytes(), &result)
+
+	assert.Equal(t, int64(11), result.Total)
+	assert.Equal(t, 3, result.Page)
+	assert.Equal(t, 5, result.PageSize)
+
+	if assert.Len(t, result.Quizzes, 1) {
+		assert.Equal(t, "Capitals", result.Quizzes[0].Name)
+		assert.Equal(t, []string{"geography"}, result.Quizzes[0].Tags)
+		assert.Equal(t, "en", result.Quizzes[0].Language)
+	}
+}
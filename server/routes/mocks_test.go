@@ -7,6 +7,7 @@ import (
 	"github.com/survivorbat/qq.maarten.dev/server/domain"
 	"github.com/survivorbat/qq.maarten.dev/server/services"
 	"sync"
+	"time"
 )
 
 type MockCreatorService struct {
@@ -15,6 +16,9 @@ type MockCreatorService struct {
 	getByIDCalledWith   uuid.UUID
 	getByIDReturns      *domain.Creator
 	getByIDReturnsError error
+
+	updateAvatarCalledWith string
+	updateAvatarReturns    error
 }
 
 func (m *MockCreatorService) GetByID(id uuid.UUID) (*domain.Creator, error) {
@@ -22,6 +26,21 @@ func (m *MockCreatorService) GetByID(id uuid.UUID) (*domain.Creator, error) {
 	return m.getByIDReturns, m.getByIDReturnsError
 }
 
+func (m *MockCreatorService) UpdateAvatar(creator *domain.Creator, avatar string) error {
+	m.updateAvatarCalledWith = avatar
+	return m.updateAvatarReturns
+}
+
+type MockAvatarService struct {
+	services.AvatarService
+
+	existsReturns bool
+}
+
+func (m *MockAvatarService) Exists(string) bool {
+	return m.existsReturns
+}
+
 type MockPlayerService struct {
 	services.PlayerService
 
@@ -29,8 +48,9 @@ type MockPlayerService struct {
 	getByGameReturns      []*domain.Player
 	getByGameReturnsError error
 
-	createCalledWith *domain.Player
-	createReturns    error
+	createCalledWith        *domain.Player
+	createCalledWithLocales []string
+	createReturns           error
 
 	getByIdReturns      *domain.Player
 	getByIdReturnsError error
@@ -50,7 +70,8 @@ func (m *MockPlayerService) Delete(*domain.Player) error {
 	return m.deleteReturns
 }
 
-func (m *MockPlayerService) Create(player *domain.Player) error {
+func (m *MockPlayerService) Create(player *domain.Player, locales ...string) error {
+	m.createCalledWithLocales = locales
 	m.createCalledWith = player
 	return m.createReturns
 }
@@ -62,6 +83,10 @@ type MockGameService struct {
 	getByQuizReturns      []*domain.Game
 	getByQuizReturnsError error
 
+	getActiveByQuizCalledWith   uuid.UUID
+	getActiveByQuizReturns      []*domain.Game
+	getActiveByQuizReturnsError error
+
 	createCalledWith *domain.Game
 	createReturns    error
 
@@ -80,7 +105,11 @@ type MockGameService struct {
 	nextCalledWith *domain.Game
 	nextReturns    error
 
-	answerReturns error
+	answerCalledWithQuestion uuid.UUID
+	answerReturns            error
+
+	nextForPlayerCalledWith   *domain.Player
+	nextForPlayerReturnsError error
 
 	getByCodeCalledWith   string
 	getByCodeReturns      *domain.Game
@@ -92,6 +121,11 @@ func (m *MockGameService) GetByQuiz(id uuid.UUID) ([]*domain.Game, error) {
 	return m.getByQuizReturns, m.getByQuizReturnsError
 }
 
+func (m *MockGameService) GetActiveByQuiz(id uuid.UUID) ([]*domain.Game, error) {
+	m.getActiveByQuizCalledWith = id
+	return m.getActiveByQuizReturns, m.getActiveByQuizReturnsError
+}
+
 func (m *MockGameService) GetByCode(code string) (*domain.Game, error) {
 	m.getByCodeCalledWith = code
 	return m.getByCodeReturns, m.getByCodeReturnsError
@@ -121,10 +155,16 @@ func (m *MockGameService) Next(game *domain.Game) error {
 	return m.nextReturns
 }
 
-func (m *MockGameService) AnswerQuestion(*domain.Game, uuid.UUID, uuid.UUID, uuid.UUID) error {
+func (m *MockGameService) AnswerQuestion(_ *domain.Game, questionID uuid.UUID, _ uuid.UUID, _ uuid.UUID) error {
+	m.answerCalledWithQuestion = questionID
 	return m.answerReturns
 }
 
+func (m *MockGameService) NextForPlayer(_ *domain.Game, player *domain.Player) (domain.Question, error) {
+	m.nextForPlayerCalledWith = player
+	return nil, m.nextForPlayerReturnsError
+}
+
 func (m *MockGameService) Finish(game *domain.Game) error {
 	m.finishCalledWith = game
 	return m.finishReturns
@@ -137,6 +177,14 @@ type MockQuizService struct {
 	getByCreatorReturns      []*domain.Quiz
 	getByCreatorReturnsError error
 
+	getByOrganizationsCalledWith   uuid.UUID
+	getByOrganizationsReturns      []*domain.Quiz
+	getByOrganizationsReturnsError error
+
+	getTemplatesCalledWith   uuid.UUID
+	getTemplatesReturns      []*domain.Quiz
+	getTemplatesReturnsError error
+
 	createOrUpdateCalledWith *domain.Quiz
 	createOrUpdateReturns    error
 
@@ -152,6 +200,16 @@ func (m *MockQuizService) GetByCreator(id uuid.UUID) ([]*domain.Quiz, error) {
 	return m.getByCreatorReturns, m.getByCreatorReturnsError
 }
 
+func (m *MockQuizService) GetByOrganizations(id uuid.UUID) ([]*domain.Quiz, error) {
+	m.getByOrganizationsCalledWith = id
+	return m.getByOrganizationsReturns, m.getByOrganizationsReturnsError
+}
+
+func (m *MockQuizService) GetTemplates(id uuid.UUID) ([]*domain.Quiz, error) {
+	m.getTemplatesCalledWith = id
+	return m.getTemplatesReturns, m.getTemplatesReturnsError
+}
+
 func (m *MockQuizService) GetByID(uuid.UUID) (*domain.Quiz, error) {
 	return m.getByIdReturns, m.getByIdReturnsError
 }
@@ -160,11 +218,205 @@ func (m *MockQuizService) CreateOrUpdate(quiz *domain.Quiz) error {
 	m.createOrUpdateCalledWith = quiz
 	return m.createOrUpdateReturns
 }
+
 func (m *MockQuizService) Delete(id uuid.UUID) error {
 	m.deleteCalledWith = id
 	return m.deleteReturns
 }
 
+type MockAPIKeyService struct {
+	services.APIKeyService
+
+	getByCreatorCalledWith   uuid.UUID
+	getByCreatorReturns      []*domain.APIKey
+	getByCreatorReturnsError error
+
+	getByIdReturns      *domain.APIKey
+	getByIdReturnsError error
+
+	createCalledWith *domain.APIKey
+	createReturns    string
+	createReturnsErr error
+
+	deleteCalledWith *domain.APIKey
+	deleteReturns    error
+
+	authenticateCalledWith   string
+	authenticateReturns      *domain.APIKey
+	authenticateReturnsError error
+}
+
+func (m *MockAPIKeyService) GetByCreator(id uuid.UUID) ([]*domain.APIKey, error) {
+	m.getByCreatorCalledWith = id
+	return m.getByCreatorReturns, m.getByCreatorReturnsError
+}
+
+func (m *MockAPIKeyService) GetByID(uuid.UUID) (*domain.APIKey, error) {
+	return m.getByIdReturns, m.getByIdReturnsError
+}
+
+func (m *MockAPIKeyService) Create(key *domain.APIKey) (string, error) {
+	m.createCalledWith = key
+	return m.createReturns, m.createReturnsErr
+}
+
+func (m *MockAPIKeyService) Delete(key *domain.APIKey) error {
+	m.deleteCalledWith = key
+	return m.deleteReturns
+}
+
+func (m *MockAPIKeyService) Authenticate(key string) (*domain.APIKey, error) {
+	m.authenticateCalledWith = key
+	return m.authenticateReturns, m.authenticateReturnsError
+}
+
+type MockCollaboratorService struct {
+	services.CollaboratorService
+
+	getByQuizCalledWith   uuid.UUID
+	getByQuizReturns      []*domain.Collaborator
+	getByQuizReturnsError error
+
+	saveCalledWith *domain.Collaborator
+	saveReturns    error
+
+	deleteCalledWithQuiz    uuid.UUID
+	deleteCalledWithCreator uuid.UUID
+	deleteReturns           error
+}
+
+func (m *MockCollaboratorService) GetByQuiz(id uuid.UUID) ([]*domain.Collaborator, error) {
+	m.getByQuizCalledWith = id
+	return m.getByQuizReturns, m.getByQuizReturnsError
+}
+
+func (m *MockCollaboratorService) Save(collaborator *domain.Collaborator) error {
+	m.saveCalledWith = collaborator
+	return m.saveReturns
+}
+
+func (m *MockCollaboratorService) Delete(quizID uuid.UUID, creatorID uuid.UUID) error {
+	m.deleteCalledWithQuiz = quizID
+	m.deleteCalledWithCreator = creatorID
+	return m.deleteReturns
+}
+
+type MockOrganizationService struct {
+	services.OrganizationService
+
+	getByIdReturns      *domain.Organization
+	getByIdReturnsError error
+
+	getByCreatorCalledWith   uuid.UUID
+	getByCreatorReturns      []*domain.Organization
+	getByCreatorReturnsError error
+
+	createCalledWith        *domain.Organization
+	createCalledWithCreator uuid.UUID
+	createReturns           error
+
+	deleteCalledWith *domain.Organization
+	deleteReturns    error
+
+	saveMemberCalledWith *domain.Member
+	saveMemberReturns    error
+
+	removeMemberCalledWithOrganization uuid.UUID
+	removeMemberCalledWithCreator      uuid.UUID
+	removeMemberReturns                error
+
+	inviteCalledWith *domain.Invitation
+	inviteReturns    error
+
+	getInvitationByIdReturns      *domain.Invitation
+	getInvitationByIdReturnsError error
+
+	acceptInvitationCalledWith   *domain.Invitation
+	acceptInvitationReturns      *domain.Member
+	acceptInvitationReturnsError error
+
+	deleteInvitationCalledWith *domain.Invitation
+	deleteInvitationReturns    error
+}
+
+func (m *MockOrganizationService) GetByID(uuid.UUID) (*domain.Organization, error) {
+	return m.getByIdReturns, m.getByIdReturnsError
+}
+
+func (m *MockOrganizationService) GetByCreator(id uuid.UUID) ([]*domain.Organization, error) {
+	m.getByCreatorCalledWith = id
+	return m.getByCreatorReturns, m.getByCreatorReturnsError
+}
+
+func (m *MockOrganizationService) Create(organization *domain.Organization, creatorID uuid.UUID) error {
+	m.createCalledWith = organization
+	m.createCalledWithCreator = creatorID
+	return m.createReturns
+}
+
+func (m *MockOrganizationService) Delete(organization *domain.Organization) error {
+	m.deleteCalledWith = organization
+	return m.deleteReturns
+}
+
+func (m *MockOrganizationService) SaveMember(member *domain.Member) error {
+	m.saveMemberCalledWith = member
+	return m.saveMemberReturns
+}
+
+func (m *MockOrganizationService) RemoveMember(organizationID uuid.UUID, creatorID uuid.UUID) error {
+	m.removeMemberCalledWithOrganization = organizationID
+	m.removeMemberCalledWithCreator = creatorID
+	return m.removeMemberReturns
+}
+
+func (m *MockOrganizationService) Invite(invitation *domain.Invitation) error {
+	m.inviteCalledWith = invitation
+	return m.inviteReturns
+}
+
+func (m *MockOrganizationService) GetInvitationByID(uuid.UUID) (*domain.Invitation, error) {
+	return m.getInvitationByIdReturns, m.getInvitationByIdReturnsError
+}
+
+func (m *MockOrganizationService) AcceptInvitation(invitation *domain.Invitation) (*domain.Member, error) {
+	m.acceptInvitationCalledWith = invitation
+	return m.acceptInvitationReturns, m.acceptInvitationReturnsError
+}
+
+func (m *MockOrganizationService) DeleteInvitation(invitation *domain.Invitation) error {
+	m.deleteInvitationCalledWith = invitation
+	return m.deleteInvitationReturns
+}
+
+type MockRateLimiter struct {
+	services.RateLimiter
+
+	takeCalledWith  string
+	takeReturns     bool
+	takeReturnsWait time.Duration
+}
+
+func (m *MockRateLimiter) Take(key string) (bool, time.Duration) {
+	m.takeCalledWith = key
+	return m.takeReturns, m.takeReturnsWait
+}
+
+type MockLockout struct {
+	services.Lockout
+
+	lockedForReturns time.Duration
+	failCalledWith   string
+}
+
+func (m *MockLockout) LockedFor(string) time.Duration {
+	return m.lockedForReturns
+}
+
+func (m *MockLockout) Fail(key string) {
+	m.failCalledWith = key
+}
+
 type MockJwtService struct {
 	services.JwtService
 
@@ -190,8 +442,9 @@ type MockCoordinator struct {
 	handleCreatorMessageCalledWithGame    uuid.UUID
 	handleCreatorMessageCalledWithMessage *coordinator.CreatorMessage
 
-	unsubscribeCreatorWaitGroup      sync.WaitGroup
-	unsubscribeCreatorCalledWithGame uuid.UUID
+	unsubscribeCreatorWaitGroup         sync.WaitGroup
+	unsubscribeCreatorCalledWithGame    uuid.UUID
+	unsubscribeCreatorCalledWithCreator *domain.Creator
 
 	subscribePlayerCallbackCalledWithGame   uuid.UUID
 	subscribePlayerCallbackCalledWithPlayer *domain.Player
@@ -206,6 +459,35 @@ type MockCoordinator struct {
 	handlePlayerMessageCalledWithPlayer  uuid.UUID
 	handlePlayerMessageCalledWithMessage *coordinator.PlayerMessage
 	handlePlayerMessagePanicsWith        any
+
+	subscribeSpectatorCalledWithGame  uuid.UUID
+	subscribeSpectatorCalledWithToken uuid.UUID
+	subscribeSpectatorReturns         *coordinator.BroadcastMessage
+
+	unsubscribeSpectatorWaitGroup    sync.WaitGroup
+	unsubscribeSpectatorCalledWithID uuid.UUID
+
+	disconnectSpectatorsCalledWithGame  uuid.UUID
+	disconnectSpectatorsCalledWithToken uuid.UUID
+}
+
+func (m *MockCoordinator) SubscribeSpectator(gameID uuid.UUID, tokenID uuid.UUID, _ uuid.UUID, callback coordinator.BroadcastCallback) {
+	m.subscribeSpectatorCalledWithGame = gameID
+	m.subscribeSpectatorCalledWithToken = tokenID
+
+	if m.subscribeSpectatorReturns != nil {
+		callback(m.subscribeSpectatorReturns)
+	}
+}
+
+func (m *MockCoordinator) UnsubscribeSpectator(_ uuid.UUID, spectatorID uuid.UUID) {
+	defer m.unsubscribeSpectatorWaitGroup.Done()
+	m.unsubscribeSpectatorCalledWithID = spectatorID
+}
+
+func (m *MockCoordinator) DisconnectSpectators(gameID uuid.UUID, tokenID uuid.UUID) {
+	m.disconnectSpectatorsCalledWithGame = gameID
+	m.disconnectSpectatorsCalledWithToken = tokenID
 }
 
 func (m *MockCoordinator) SubscribeCreator(gameID uuid.UUID, creator *domain.Creator, callback coordinator.BroadcastCallback) {
@@ -226,9 +508,10 @@ func (m *MockCoordinator) SubscribePlayer(gameID uuid.UUID, player *domain.Playe
 	}
 }
 
-func (m *MockCoordinator) UnsubscribeCreator(gameId uuid.UUID) {
+func (m *MockCoordinator) UnsubscribeCreator(gameId uuid.UUID, creator *domain.Creator) {
 	defer m.unsubscribeCreatorWaitGroup.Done()
 	m.unsubscribeCreatorCalledWithGame = gameId
+	m.unsubscribeCreatorCalledWithCreator = creator
 }
 
 func (m *MockCoordinator) UnsubscribePlayer(gameId uuid.UUID, player *domain.Player) {
@@ -259,3 +542,201 @@ func (m *MockCoordinator) HandlePlayerMessage(gameID uuid.UUID, playerID uuid.UU
 		panic(m.handlePlayerMessagePanicsWith)
 	}
 }
+
+type MockPresenterTokenService struct {
+	services.PresenterTokenService
+
+	getByIDReturns      *domain.PresenterToken
+	getByIDReturnsError error
+
+	getByGameReturns []*domain.PresenterToken
+
+	createCalledWith *domain.PresenterToken
+	createReturns    string
+
+	deleteCalledWith *domain.PresenterToken
+
+	authenticateCalledWith   string
+	authenticateReturns      *domain.PresenterToken
+	authenticateReturnsError error
+}
+
+func (m *MockPresenterTokenService) GetByID(uuid.UUID) (*domain.PresenterToken, error) {
+	return m.getByIDReturns, m.getByIDReturnsError
+}
+
+func (m *MockPresenterTokenService) GetByGame(uuid.UUID) ([]*domain.PresenterToken, error) {
+	return m.getByGameReturns, nil
+}
+
+func (m *MockPresenterTokenService) Create(token *domain.PresenterToken) (string, error) {
+	m.createCalledWith = token
+	return m.createReturns, nil
+}
+
+func (m *MockPresenterTokenService) Delete(token *domain.PresenterToken) error {
+	m.deleteCalledWith = token
+	return nil
+}
+
+func (m *MockPresenterTokenService) Authenticate(token string) (*domain.PresenterToken, error) {
+	m.authenticateCalledWith = token
+	return m.authenticateReturns, m.authenticateReturnsError
+}
+
+type MockGameScheduler struct {
+	coordinator.GameScheduler
+
+	scheduleCalledWith *domain.Game
+	cancelCalledWith   uuid.UUID
+}
+
+func (m *MockGameScheduler) Schedule(game *domain.Game) {
+	m.scheduleCalledWith = game
+}
+
+func (m *MockGameScheduler) Cancel(gameID uuid.UUID) {
+	m.cancelCalledWith = gameID
+}
+
+type MockQuizRevisionService struct {
+	services.QuizRevisionService
+
+	getByQuizCalledWith   uuid.UUID
+	getByQuizReturns      []*domain.QuizRevision
+	getByQuizReturnsError error
+
+	getByNumberReturns      map[uint]*domain.QuizRevision
+	getByNumberReturnsError error
+}
+
+func (m *MockQuizRevisionService) GetByQuiz(quizID uuid.UUID) ([]*domain.QuizRevision, error) {
+	m.getByQuizCalledWith = quizID
+	return m.getByQuizReturns, m.getByQuizReturnsError
+}
+
+func (m *MockQuizRevisionService) GetByNumber(_ uuid.UUID, number uint) (*domain.QuizRevision, error) {
+	if m.getByNumberReturnsError != nil {
+		return nil, m.getByNumberReturnsError
+	}
+
+	return m.getByNumberReturns[number], nil
+}
+
+type MockQuizDraftService struct {
+	services.QuizDraftService
+
+	getByQuizReturns      *domain.QuizDraft
+	getByQuizReturnsError error
+
+	saveCalledWith *domain.QuizDraft
+	saveReturns    error
+
+	updateCalledWith        *domain.QuizDraft
+	updateCalledWithVersion uint
+	updateReturns           error
+
+	deleteCalledWith uuid.UUID
+	deleteReturns    error
+}
+
+func (m *MockQuizDraftService) GetByQuiz(uuid.UUID) (*domain.QuizDraft, error) {
+	return m.getByQuizReturns, m.getByQuizReturnsError
+}
+
+func (m *MockQuizDraftService) Save(draft *domain.QuizDraft) error {
+	m.saveCalledWith = draft
+	return m.saveReturns
+}
+
+func (m *MockQuizDraftService) Update(draft *domain.QuizDraft, version uint) error {
+	m.updateCalledWith = draft
+	m.updateCalledWithVersion = version
+
+	if m.updateReturns == nil {
+		draft.Version = version + 1
+	}
+
+	return m.updateReturns
+}
+
+func (m *MockQuizDraftService) Delete(quizID uuid.UUID) error {
+	m.deleteCalledWith = quizID
+	return m.deleteReturns
+}
+
+type MockLibraryService struct {
+	services.LibraryService
+
+	searchCalledWith   *services.LibrarySearch
+	searchReturns      []*domain.Quiz
+	searchReturnsTotal int64
+	searchReturnsError error
+}
+
+func (m *MockLibraryService) Search(search *services.LibrarySearch) ([]*domain.Quiz, int64, error) {
+	m.searchCalledWith = search
+	return m.searchReturns, m.searchReturnsTotal, m.searchReturnsError
+}
+
+type MockBankQuestionService struct {
+	services.BankQuestionService
+
+	getByIdReturns      *domain.BankQuestion
+	getByIdReturnsError error
+
+	getAccessibleCalledWith   *services.BankQuestionFilter
+	getAccessibleReturns      []*domain.BankQuestion
+	getAccessibleReturnsError error
+
+	getStatsReturns      *domain.BankQuestionStats
+	getStatsReturnsError error
+
+	createCalledWith *domain.BankQuestion
+	createReturns    error
+
+	updateCalledWith *domain.BankQuestion
+	updateReturns    error
+
+	deleteCalledWith uuid.UUID
+	deleteReturns    error
+}
+
+func (m *MockBankQuestionService) GetByID(uuid.UUID) (*domain.BankQuestion, error) {
+	return m.getByIdReturns, m.getByIdReturnsError
+}
+
+func (m *MockBankQuestionService) GetAccessible(_ uuid.UUID, filter *services.BankQuestionFilter) ([]*domain.BankQuestion, error) {
+	m.getAccessibleCalledWith = filter
+	return m.getAccessibleReturns, m.getAccessibleReturnsError
+}
+
+func (m *MockBankQuestionService) GetStats(uuid.UUID) (*domain.BankQuestionStats, error) {
+	return m.getStatsReturns, m.getStatsReturnsError
+}
+
+func (m *MockBankQuestionService) Create(question *domain.BankQuestion) error {
+	m.createCalledWith = question
+	return m.createReturns
+}
+
+func (m *MockBankQuestionService) Update(question *domain.BankQuestion) error {
+	m.updateCalledWith = question
+	return m.updateReturns
+}
+
+func (m *MockBankQuestionService) Delete(id uuid.UUID) error {
+	m.deleteCalledWith = id
+	return m.deleteReturns
+}
+
+type MockSpreadsheetReader struct {
+	readCalledWith []byte
+	readReturns    [][]string
+	readReturnsErr error
+}
+
+func (m *MockSpreadsheetReader) Read(content []byte, _ services.SpreadsheetFormat) ([][]string, error) {
+	m.readCalledWith = content
+	return m.readReturns, m.readReturnsErr
+}
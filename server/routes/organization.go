@@ -0,0 +1,420 @@
+package routes
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"github.com/survivorbat/qq.maarten.dev/server/services"
+	"gorm.io/gorm"
+	"net/http"
+)
+
+type OrganizationHandler struct {
+	OrganizationService services.OrganizationService
+}
+
+// Get godoc
+//
+//	@Summary	Fetch the organizations you are a member of
+//	@Tags		Organization
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{array}	[]domain.Organization	"Your organizations"
+//	@Failure	500	"Internal Server Error"
+//	@Router		/api/v1/organizations [get]
+//	@Security	JWT
+func (o *OrganizationHandler) Get(c *gin.Context) {
+	authID := c.GetString("user")
+
+	organizations, err := o.OrganizationService.GetByCreator(uuid.MustParse(authID))
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get by creator")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, organizations)
+}
+
+// GetByID godoc
+//
+//	@Summary	Fetch an organization and its members
+//	@Tags		Organization
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		string				true	"ID of the organization"
+//	@Success	200	{object}	domain.Organization	"The organization"
+//	@Failure	400	"Invalid uuid"
+//	@Failure	403	"You can only view organizations you are a member of"
+//	@Failure	404	"Not found"
+//	@Router		/api/v1/organizations/{id} [get]
+//	@Security	JWT
+func (o *OrganizationHandler) GetByID(c *gin.Context) {
+	organization, ok := o.getOrganization(c)
+	if !ok {
+		return
+	}
+
+	if _, ok := organization.RoleOf(uuid.MustParse(c.GetString("user"))); !ok {
+		logrus.Error("Creator is not a member")
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	c.JSON(http.StatusOK, organization)
+}
+
+// Post godoc
+//
+//	@Summary	Create an organization, you become its first admin
+//	@Tags		Organization
+//	@Accept		json
+//	@Produce	json
+//	@Param		input	body		inputs.Organization	true	"The organization"
+//	@Success	200		{object}	domain.Organization	"The organization"
+//	@Failure	400		"Malformed input"
+//	@Failure	500		"Internal Server Error"
+//	@Router		/api/v1/organizations [post]
+//	@Security	JWT
+func (o *OrganizationHandler) Post(c *gin.Context) {
+	authID := c.GetString("user")
+
+	var input *inputs.Organization
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logrus.WithError(err).Error("Validation error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	organization := input.ToDomain()
+	if err := o.OrganizationService.Create(organization, uuid.MustParse(authID)); err != nil {
+		logrus.WithError(err).Error("Failed to create")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, organization)
+}
+
+// Delete godoc
+//
+//	@Summary	Delete an organization, its quizzes are returned to their creators
+//	@Tags		Organization
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path	string	true	"ID of the organization"
+//	@Success	204
+//	@Failure	400	"Invalid uuid"
+//	@Failure	403	"Only admins can delete an organization"
+//	@Failure	404	"Not found"
+//	@Failure	500	"Internal Server Error"
+//	@Router		/api/v1/organizations/{id} [delete]
+//	@Security	JWT
+func (o *OrganizationHandler) Delete(c *gin.Context) {
+	organization, ok := o.getAdminOrganization(c)
+	if !ok {
+		return
+	}
+
+	if err := o.OrganizationService.Delete(organization); err != nil {
+		logrus.WithError(err).Error("Failed to delete")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// PostInvitation godoc
+//
+//	@Summary	Invite a creator to the organization
+//	@Tags		Organization
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		string				true	"ID of the organization"
+//	@Param		input	body		inputs.Invitation	true	"The invitation"
+//	@Success	200		{object}	domain.Invitation	"The invitation"
+//	@Failure	400		"Invalid uuid"
+//	@Failure	400		"Malformed input"
+//	@Failure	403		"Only admins can invite creators"
+//	@Failure	404		"Organization or creator not found"
+//	@Failure	409		"Creator is already a member"
+//	@Failure	500		"Internal Server Error"
+//	@Router		/api/v1/organizations/{id}/invitations [post]
+//	@Security	JWT
+func (o *OrganizationHandler) PostInvitation(c *gin.Context) {
+	authID := c.GetString("user")
+
+	organization, ok := o.getAdminOrganization(c)
+	if !ok {
+		return
+	}
+
+	var input *inputs.Invitation
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logrus.WithError(err).Error("Validation error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	if _, ok := organization.RoleOf(input.CreatorID); ok {
+		logrus.Errorf("Creator %s is already a member", input.CreatorID)
+		c.AbortWithStatus(http.StatusConflict)
+		return
+	}
+
+	invitation := input.ToDomain(organization.ID, uuid.MustParse(authID))
+	if err := o.OrganizationService.Invite(invitation); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+
+		logrus.WithError(err).Error("Failed to invite")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
+
+// PutMember godoc
+//
+//	@Summary	Change the role of a member
+//	@Tags		Organization
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		string			true	"ID of the organization"
+//	@Param		creator	path		string			true	"ID of the member"
+//	@Param		input	body		inputs.Member	true	"The new role"
+//	@Success	200		{object}	domain.Member	"The member"
+//	@Failure	400		"Invalid uuid"
+//	@Failure	400		"Malformed input"
+//	@Failure	400		"An organization needs at least one admin"
+//	@Failure	403		"Only admins can change roles"
+//	@Failure	404		"Not found"
+//	@Router		/api/v1/organizations/{id}/members/{creator} [put]
+//	@Security	JWT
+func (o *OrganizationHandler) PutMember(c *gin.Context) {
+	creatorID, err := uuid.Parse(c.Param("creator"))
+	if err != nil {
+		logrus.WithError(err).Error("UUID error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	organization, ok := o.getAdminOrganization(c)
+	if !ok {
+		return
+	}
+
+	var input *inputs.Member
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logrus.WithError(err).Error("Validation error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	member := input.ToDomain(organization.ID, creatorID)
+	if member.Role != domain.OrganizationAdmin && isLastAdmin(organization, creatorID) {
+		logrus.Error("Can not demote the last admin")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	if err := o.OrganizationService.SaveMember(member); err != nil {
+		logrus.WithError(err).Error("Failed to save member")
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+// DeleteMember godoc
+//
+//	@Summary	Remove a member from the organization, members may leave by removing themselves
+//	@Tags		Organization
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path	string	true	"ID of the organization"
+//	@Param		creator	path	string	true	"ID of the member"
+//	@Success	204
+//	@Failure	400	"Invalid uuid"
+//	@Failure	400	"An organization needs at least one admin"
+//	@Failure	403	"Only admins can remove members"
+//	@Failure	404	"Not found"
+//	@Router		/api/v1/organizations/{id}/members/{creator} [delete]
+//	@Security	JWT
+func (o *OrganizationHandler) DeleteMember(c *gin.Context) {
+	authID := c.GetString("user")
+
+	creatorID, err := uuid.Parse(c.Param("creator"))
+	if err != nil {
+		logrus.WithError(err).Error("UUID error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	organization, ok := o.getOrganization(c)
+	if !ok {
+		return
+	}
+
+	// Leaving an organization is always allowed
+	if creatorID.String() != authID && !organization.IsAdmin(uuid.MustParse(authID)) {
+		logrus.Errorf("Creator %s is not an admin of organization %s", authID, organization.ID)
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	if isLastAdmin(organization, creatorID) {
+		logrus.Error("Can not remove the last admin")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	if err := o.OrganizationService.RemoveMember(organization.ID, creatorID); err != nil {
+		logrus.WithError(err).Error("Failed to remove member")
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// GetInvitations godoc
+//
+//	@Summary	Fetch your pending invitations
+//	@Tags		Organization
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{array}	[]domain.Invitation	"Your invitations"
+//	@Failure	500	"Internal Server Error"
+//	@Router		/api/v1/invitations [get]
+//	@Security	JWT
+func (o *OrganizationHandler) GetInvitations(c *gin.Context) {
+	authID := c.GetString("user")
+
+	invitations, err := o.OrganizationService.GetInvitationsByCreator(uuid.MustParse(authID))
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get invitations")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+// PatchInvitation godoc
+//
+//	@Summary	Accept or decline an invitation
+//	@Tags		Organization
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		string			true	"ID of the invitation"
+//	@Param		action	query		string			true	"Action to perform"	Enums(accept, decline)
+//	@Success	200		{object}	domain.Member	"Your membership, if accepted"
+//	@Failure	400		"Invalid uuid"
+//	@Failure	400		"Unknown action"
+//	@Failure	403		"You can only respond to your own invitations"
+//	@Failure	404		"Not found"
+//	@Failure	410		"The invitation has expired"
+//	@Failure	500		"Internal Server Error"
+//	@Router		/api/v1/invitations/{id} [patch]
+//	@Security	JWT
+func (o *OrganizationHandler) PatchInvitation(c *gin.Context) {
+	authID := c.GetString("user")
+	action := c.Query("action")
+
+	invitationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		logrus.WithError(err).Error("UUID error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	invitation, err := o.OrganizationService.GetInvitationByID(invitationID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get invitation")
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	if invitation.CreatorID.String() != authID {
+		logrus.Errorf("Invitation %s is not meant for %s", invitation.ID, authID)
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	switch action {
+	case "accept":
+		if invitation.IsExpired() {
+			logrus.Errorf("Invitation %s has expired", invitation.ID)
+			c.AbortWithStatus(http.StatusGone)
+			return
+		}
+
+		member, err := o.OrganizationService.AcceptInvitation(invitation)
+		if err != nil {
+			logrus.WithError(err).Error("Failed to accept invitation")
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, member)
+	case "decline":
+		if err := o.OrganizationService.DeleteInvitation(invitation); err != nil {
+			logrus.WithError(err).Error("Failed to decline invitation")
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusNoContent, nil)
+	default:
+		logrus.Errorf("Unknown action %s", action)
+		c.AbortWithStatus(http.StatusBadRequest)
+	}
+}
+
+func (o *OrganizationHandler) getOrganization(c *gin.Context) (*domain.Organization, bool) {
+	organizationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		logrus.WithError(err).Error("UUID error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return nil, false
+	}
+
+	organization, err := o.OrganizationService.GetByID(organizationID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get organization")
+		c.AbortWithStatus(http.StatusNotFound)
+		return nil, false
+	}
+
+	return organization, true
+}
+
+func (o *OrganizationHandler) getAdminOrganization(c *gin.Context) (*domain.Organization, bool) {
+	organization, ok := o.getOrganization(c)
+	if !ok {
+		return nil, false
+	}
+
+	authID := c.GetString("user")
+	if !organization.IsAdmin(uuid.MustParse(authID)) {
+		logrus.Errorf("Creator %s is not an admin of organization %s", authID, organization.ID)
+		c.AbortWithStatus(http.StatusForbidden)
+		return nil, false
+	}
+
+	return organization, true
+}
+
+// isLastAdmin prevents organizations from ending up without anyone to manage them
+func isLastAdmin(organization *domain.Organization, creatorID uuid.UUID) bool {
+	return organization.IsAdmin(creatorID) && organization.CountAdmins() == 1
+}
@@ -0,0 +1,405 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"gorm.io/gorm"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestOrganizationHandler_Get_ReturnsExpectedData(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	organizations := []*domain.Organization{
+		{BaseObject: domain.BaseObject{ID: uuid.MustParse("ac1d0e93-b545-48be-bff9-656a933afa04")}, Name: "Math department"},
+	}
+
+	organizationService := &MockOrganizationService{getByCreatorReturns: organizations}
+	handler := &OrganizationHandler{OrganizationService: organizationService}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+
+	// Act
+	handler.Get(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), organizationService.getByCreatorCalledWith)
+
+	var result []*domain.Organization
+	if err := json.Unmarshal(writer.Body.Bytes(), &result); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	assert.ElementsMatch(t, organizations, result)
+}
+
+func TestOrganizationHandler_GetByID_ReturnsExpectedCodes(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		id           string
+		organization *domain.Organization
+		err          error
+		expected     int
+	}{
+		"invalid id": {
+			id:       "no",
+			expected: http.StatusBadRequest,
+		},
+		"not found": {
+			id:       "ac1d0e93-b545-48be-bff9-656a933afa04",
+			err:      assert.AnError,
+			expected: http.StatusNotFound,
+		},
+		"not a member": {
+			id:           "ac1d0e93-b545-48be-bff9-656a933afa04",
+			organization: &domain.Organization{},
+			expected:     http.StatusForbidden,
+		},
+		"member": {
+			id: "ac1d0e93-b545-48be-bff9-656a933afa04",
+			organization: &domain.Organization{
+				Members: []*domain.Member{{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), Role: domain.OrganizationMember}},
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &OrganizationHandler{
+				OrganizationService: &MockOrganizationService{getByIdReturns: testData.organization, getByIdReturnsError: testData.err},
+			}
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+			context.Params = []gin.Param{{Key: "id", Value: testData.id}}
+
+			// Act
+			handler.GetByID(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestOrganizationHandler_Post_CreatesOrganization(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	organizationService := &MockOrganizationService{}
+	handler := &OrganizationHandler{OrganizationService: organizationService}
+
+	inputJson, _ := json.Marshal(&inputs.Organization{Name: "Math department"})
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodPost, "", io.NopCloser(bytes.NewBuffer(inputJson)))
+
+	// Act
+	handler.Post(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, "Math department", organizationService.createCalledWith.Name)
+	assert.Equal(t, uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), organizationService.createCalledWithCreator)
+}
+
+func TestOrganizationHandler_Delete_RequiresAdmin(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		role     domain.OrganizationRole
+		expected int
+	}{
+		"admin": {
+			role:     domain.OrganizationAdmin,
+			expected: http.StatusNoContent,
+		},
+		"member": {
+			role:     domain.OrganizationMember,
+			expected: http.StatusForbidden,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			organization := &domain.Organization{
+				Members: []*domain.Member{{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), Role: testData.role}},
+			}
+
+			organizationService := &MockOrganizationService{getByIdReturns: organization}
+			handler := &OrganizationHandler{OrganizationService: organizationService}
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+			context.Params = []gin.Param{{Key: "id", Value: "ac1d0e93-b545-48be-bff9-656a933afa04"}}
+
+			// Act
+			handler.Delete(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestOrganizationHandler_PostInvitation_ReturnsExpectedCodes(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		creatorID string
+		inviteErr error
+		expected  int
+	}{
+		"invites": {
+			creatorID: "e3274bf0-b154-4d37-a6fd-878d530025f9",
+			expected:  http.StatusOK,
+		},
+		"already a member": {
+			creatorID: "2f80947c-e724-4b38-8c8d-3823864fef58",
+			expected:  http.StatusConflict,
+		},
+		"creator not found": {
+			creatorID: "e3274bf0-b154-4d37-a6fd-878d530025f9",
+			inviteErr: gorm.ErrRecordNotFound,
+			expected:  http.StatusNotFound,
+		},
+		"invite error": {
+			creatorID: "e3274bf0-b154-4d37-a6fd-878d530025f9",
+			inviteErr: assert.AnError,
+			expected:  http.StatusInternalServerError,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			organization := &domain.Organization{
+				BaseObject: domain.BaseObject{ID: uuid.MustParse("ac1d0e93-b545-48be-bff9-656a933afa04")},
+				Members:    []*domain.Member{{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), Role: domain.OrganizationAdmin}},
+			}
+
+			organizationService := &MockOrganizationService{getByIdReturns: organization, inviteReturns: testData.inviteErr}
+			handler := &OrganizationHandler{OrganizationService: organizationService}
+
+			inputJson, _ := json.Marshal(&inputs.Invitation{CreatorID: uuid.MustParse(testData.creatorID), Role: "member"})
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+			context.Params = []gin.Param{{Key: "id", Value: organization.ID.String()}}
+			context.Request, _ = http.NewRequest(http.MethodPost, "", io.NopCloser(bytes.NewBuffer(inputJson)))
+
+			// Act
+			handler.PostInvitation(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+
+			if testData.expected == http.StatusOK {
+				assert.Equal(t, organization.ID, organizationService.inviteCalledWith.OrganizationID)
+				assert.Equal(t, uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), organizationService.inviteCalledWith.InvitedByID)
+				assert.False(t, organizationService.inviteCalledWith.IsExpired())
+			}
+		})
+	}
+}
+
+func TestOrganizationHandler_PutMember_PreventsDemotingLastAdmin(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	organization := &domain.Organization{
+		Members: []*domain.Member{{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), Role: domain.OrganizationAdmin}},
+	}
+
+	organizationService := &MockOrganizationService{getByIdReturns: organization}
+	handler := &OrganizationHandler{OrganizationService: organizationService}
+
+	inputJson, _ := json.Marshal(&inputs.Member{Role: "member"})
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Params = []gin.Param{
+		{Key: "id", Value: "ac1d0e93-b545-48be-bff9-656a933afa04"},
+		{Key: "creator", Value: "2f80947c-e724-4b38-8c8d-3823864fef58"},
+	}
+	context.Request, _ = http.NewRequest(http.MethodPut, "", io.NopCloser(bytes.NewBuffer(inputJson)))
+
+	// Act
+	handler.PutMember(context)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	assert.Nil(t, organizationService.saveMemberCalledWith)
+}
+
+func TestOrganizationHandler_DeleteMember_ReturnsExpectedCodes(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		user     string
+		creator  string
+		expected int
+	}{
+		"admin removes member": {
+			user:     "2f80947c-e724-4b38-8c8d-3823864fef58",
+			creator:  "e3274bf0-b154-4d37-a6fd-878d530025f9",
+			expected: http.StatusNoContent,
+		},
+		"member leaves": {
+			user:     "e3274bf0-b154-4d37-a6fd-878d530025f9",
+			creator:  "e3274bf0-b154-4d37-a6fd-878d530025f9",
+			expected: http.StatusNoContent,
+		},
+		"member removes admin": {
+			user:     "e3274bf0-b154-4d37-a6fd-878d530025f9",
+			creator:  "2f80947c-e724-4b38-8c8d-3823864fef58",
+			expected: http.StatusForbidden,
+		},
+		"last admin leaves": {
+			user:     "2f80947c-e724-4b38-8c8d-3823864fef58",
+			creator:  "2f80947c-e724-4b38-8c8d-3823864fef58",
+			expected: http.StatusBadRequest,
+		},
+		"invalid creator": {
+			user:     "2f80947c-e724-4b38-8c8d-3823864fef58",
+			creator:  "no",
+			expected: http.StatusBadRequest,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			organization := &domain.Organization{
+				Members: []*domain.Member{
+					{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), Role: domain.OrganizationAdmin},
+					{CreatorID: uuid.MustParse("e3274bf0-b154-4d37-a6fd-878d530025f9"), Role: domain.OrganizationMember},
+				},
+			}
+
+			handler := &OrganizationHandler{OrganizationService: &MockOrganizationService{getByIdReturns: organization}}
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Set("user", testData.user)
+			context.Params = []gin.Param{
+				{Key: "id", Value: "ac1d0e93-b545-48be-bff9-656a933afa04"},
+				{Key: "creator", Value: testData.creator},
+			}
+
+			// Act
+			handler.DeleteMember(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestOrganizationHandler_PatchInvitation_ReturnsExpectedCodes(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		action     string
+		creatorID  string
+		expiresAt  time.Time
+		expected   int
+		accepted   bool
+		declined   bool
+		fetchError error
+	}{
+		"accept": {
+			action:    "accept",
+			creatorID: "2f80947c-e724-4b38-8c8d-3823864fef58",
+			expiresAt: time.Now().Add(time.Hour),
+			expected:  http.StatusOK,
+			accepted:  true,
+		},
+		"decline": {
+			action:    "decline",
+			creatorID: "2f80947c-e724-4b38-8c8d-3823864fef58",
+			expiresAt: time.Now().Add(time.Hour),
+			expected:  http.StatusNoContent,
+			declined:  true,
+		},
+		"expired": {
+			action:    "accept",
+			creatorID: "2f80947c-e724-4b38-8c8d-3823864fef58",
+			expiresAt: time.Now().Add(-time.Hour),
+			expected:  http.StatusGone,
+		},
+		"someone else's": {
+			action:    "accept",
+			creatorID: "e3274bf0-b154-4d37-a6fd-878d530025f9",
+			expiresAt: time.Now().Add(time.Hour),
+			expected:  http.StatusForbidden,
+		},
+		"unknown action": {
+			action:    "ignore",
+			creatorID: "2f80947c-e724-4b38-8c8d-3823864fef58",
+			expiresAt: time.Now().Add(time.Hour),
+			expected:  http.StatusBadRequest,
+		},
+		"not found": {
+			action:     "accept",
+			fetchError: assert.AnError,
+			expected:   http.StatusNotFound,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			var invitation *domain.Invitation
+			if testData.fetchError == nil {
+				invitation = &domain.Invitation{CreatorID: uuid.MustParse(testData.creatorID), ExpiresAt: testData.expiresAt}
+			}
+
+			organizationService := &MockOrganizationService{
+				getInvitationByIdReturns:      invitation,
+				getInvitationByIdReturnsError: testData.fetchError,
+				acceptInvitationReturns:       &domain.Member{Role: domain.OrganizationMember},
+			}
+			handler := &OrganizationHandler{OrganizationService: organizationService}
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+			context.Params = []gin.Param{{Key: "id", Value: "ac1d0e93-b545-48be-bff9-656a933afa04"}}
+			context.Request, _ = http.NewRequest(http.MethodPatch, "/?action="+testData.action, nil)
+
+			// Act
+			handler.PatchInvitation(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+			assert.Equal(t, testData.accepted, organizationService.acceptInvitationCalledWith != nil)
+			assert.Equal(t, testData.declined, organizationService.deleteInvitationCalledWith != nil)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package outputs
+
+import (
+	"github.com/google/uuid"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"time"
+)
+
+func NewActiveGames(games []*domain.Game) []*OutputActiveGame {
+	result := make([]*OutputActiveGame, len(games))
+	for index, game := range games {
+		result[index] = &OutputActiveGame{
+			ID:              game.ID,
+			Code:            game.Code,
+			Mode:            game.Mode,
+			StartTime:       game.StartTime,
+			CurrentQuestion: game.CurrentQuestion,
+			PlayerCount:     uint(len(game.Players)),
+		}
+	}
+
+	return result
+}
+
+// OutputActiveGame is a summary of a game that is in progress
+type OutputActiveGame struct {
+	ID              uuid.UUID       `json:"id"`
+	Code            string          `json:"code" example:"KP384B"`
+	Mode            domain.GameMode `json:"mode" example:"live"`
+	StartTime       time.Time       `json:"startTime"`
+	CurrentQuestion uuid.UUID       `json:"currentQuestion" example:"00000000-0000-0000-0000-000000000000"` // desc: Live games only, the question the players are at
+	PlayerCount     uint            `json:"playerCount" example:"25"`
+}
@@ -0,0 +1,14 @@
+package outputs
+
+import "github.com/survivorbat/qq.maarten.dev/server/domain"
+
+func NewCreatedAPIKey(apiKey *domain.APIKey, key string) *OutputCreatedAPIKey {
+	return &OutputCreatedAPIKey{APIKey: apiKey, Key: key}
+}
+
+// OutputCreatedAPIKey is only returned once, the key can not be retrieved afterwards
+type OutputCreatedAPIKey struct {
+	*domain.APIKey
+
+	Key string `json:"key" example:"qq_3f9a..."` // desc: Use this as a bearer token
+}
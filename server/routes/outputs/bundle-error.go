@@ -0,0 +1,61 @@
+package outputs
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"strings"
+	"unicode"
+)
+
+// OutputBundleError points at a field of an imported bundle that didn't pass validation
+type OutputBundleError struct {
+	Field string `json:"field" example:"questions[2].options"` // desc: Path to the field in the bundle
+	Rule  string `json:"rule" example:"min"`                   // desc: The rule the field broke
+	Param string `json:"param,omitempty" example:"2"`          // desc: Parameter of the rule, if any
+}
+
+// bundleFields maps fields of the quiz input to their name in the bundle, if they differ
+var bundleFields = map[string]string{
+	"multipleChoiceQuestions": "questions",
+}
+
+// NewBundleErrors lists every failed validation in err, errors that are not validation errors are left out
+func NewBundleErrors(err error) []*OutputBundleError {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil
+	}
+
+	result := make([]*OutputBundleError, len(validationErrors))
+	for index, fieldError := range validationErrors {
+		result[index] = &OutputBundleError{Field: bundlePath(fieldError.Namespace()), Rule: fieldError.Tag(), Param: fieldError.Param()}
+	}
+
+	return result
+}
+
+// bundlePath turns a namespace like Quiz.MultipleChoiceQuestions[0].Title into questions[0].title
+func bundlePath(namespace string) string {
+	segments := strings.Split(namespace, ".")[1:]
+
+	for index, segment := range segments {
+		name, suffix, _ := strings.Cut(segment, "[")
+		if suffix != "" {
+			suffix = "[" + suffix
+		}
+
+		runes := []rune(name)
+		if len(runes) > 0 {
+			runes[0] = unicode.ToLower(runes[0])
+		}
+
+		name = string(runes)
+		if bundleName, ok := bundleFields[name]; ok {
+			name = bundleName
+		}
+
+		segments[index] = name + suffix
+	}
+
+	return strings.Join(segments, ".")
+}
@@ -0,0 +1,87 @@
+package outputs
+
+import (
+	"fmt"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"strings"
+	"time"
+)
+
+const (
+	// calendarTimeFormat is the UTC date-time format of RFC 5545
+	calendarTimeFormat = "20060102T150405Z"
+
+	// calendarLineLength is the max amount of octets on a line, longer lines are folded
+	calendarLineLength = 75
+)
+
+// NewCalendar returns an iCalendar file with a single event for a scheduled or self-paced game,
+// the event of a live game without a scheduled finish lasts as long as its questions
+func NewCalendar(game *domain.Game, now time.Time) ([]byte, bool) {
+	var start, end time.Time
+
+	switch {
+	case game.IsSelfPaced():
+		start, end = game.OpenTime, game.CloseTime
+	case !game.ScheduledStart.IsZero():
+		start, end = game.ScheduledStart, game.ScheduledFinish
+		if end.IsZero() {
+			end = start.Add(game.Quiz.ExpectedDuration())
+		}
+	default:
+		return nil, false
+	}
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//QQ//Quizness//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"BEGIN:VEVENT",
+		fmt.Sprintf("UID:%s@qq.maarten.dev", game.ID),
+		"DTSTAMP:" + now.UTC().Format(calendarTimeFormat),
+		"DTSTART:" + start.UTC().Format(calendarTimeFormat),
+		"DTEND:" + end.UTC().Format(calendarTimeFormat),
+		"SUMMARY:" + escapeCalendarText(game.Quiz.Name),
+	}
+
+	if game.Quiz.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+escapeCalendarText(game.Quiz.Description))
+	}
+
+	lines = append(lines, "END:VEVENT", "END:VCALENDAR")
+
+	var result strings.Builder
+	for _, line := range lines {
+		result.WriteString(foldCalendarLine(line))
+		result.WriteString("\r\n")
+	}
+
+	return []byte(result.String()), true
+}
+
+// escapeCalendarText escapes the characters that have a meaning in iCalendar text values
+func escapeCalendarText(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
+
+// foldCalendarLine splits lines longer than 75 octets, continuation lines start with a space,
+// multibyte characters are never split
+func foldCalendarLine(line string) string {
+	var result strings.Builder
+
+	length := 0
+	for _, character := range line {
+		size := len(string(character))
+		if length+size > calendarLineLength {
+			result.WriteString("\r\n ")
+			length = 1
+		}
+
+		result.WriteRune(character)
+		length += size
+	}
+
+	return result.String()
+}
@@ -0,0 +1,113 @@
+package outputs
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewCalendar_ReturnsFalseOnNotScheduled(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	game := &domain.Game{Quiz: &domain.Quiz{}}
+
+	// Act
+	result, ok := NewCalendar(game, time.Now())
+
+	// Assert
+	assert.False(t, ok)
+	assert.Nil(t, result)
+}
+
+func TestNewCalendar_ReturnsExpectedEvent(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	amsterdam, _ := time.LoadLocation("Europe/Amsterdam")
+
+	tests := map[string]struct {
+		game     *domain.Game
+		expected string
+	}{
+		"live game without finish": {
+			game: &domain.Game{
+				BaseObject:     domain.BaseObject{ID: uuid.MustParse("942ee70d-1d18-4b1d-8abb-fdb696d2da0b")},
+				ScheduledStart: time.Date(2030, 1, 2, 11, 0, 0, 0, amsterdam),
+				Quiz: &domain.Quiz{
+					Name: "Pub quiz",
+					MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{
+						{BaseQuestion: domain.BaseQuestion{DurationInSeconds: 30}},
+						{BaseQuestion: domain.BaseQuestion{DurationInSeconds: 60}},
+					},
+				},
+			},
+			expected: "BEGIN:VCALENDAR\r\n" +
+				"VERSION:2.0\r\n" +
+				"PRODID:-//QQ//Quizness//EN\r\n" +
+				"CALSCALE:GREGORIAN\r\n" +
+				"METHOD:PUBLISH\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"UID:942ee70d-1d18-4b1d-8abb-fdb696d2da0b@qq.maarten.dev\r\n" +
+				"DTSTAMP:20300101T120000Z\r\n" +
+				"DTSTART:20300102T100000Z\r\n" +
+				"DTEND:20300102T100130Z\r\n" +
+				"SUMMARY:Pub quiz\r\n" +
+				"END:VEVENT\r\n" +
+				"END:VCALENDAR\r\n",
+		},
+		"self-paced game": {
+			game: &domain.Game{
+				BaseObject: domain.BaseObject{ID: uuid.MustParse("942ee70d-1d18-4b1d-8abb-fdb696d2da0b")},
+				Mode:       domain.GameModeSelfPaced,
+				OpenTime:   time.Date(2030, 1, 2, 8, 0, 0, 0, time.UTC),
+				CloseTime:  time.Date(2030, 1, 9, 8, 0, 0, 0, time.UTC),
+				Quiz:       &domain.Quiz{Name: "Homework", Description: "Chapter 1; 2, and 3"},
+			},
+			expected: "BEGIN:VCALENDAR\r\n" +
+				"VERSION:2.0\r\n" +
+				"PRODID:-//QQ//Quizness//EN\r\n" +
+				"CALSCALE:GREGORIAN\r\n" +
+				"METHOD:PUBLISH\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"UID:942ee70d-1d18-4b1d-8abb-fdb696d2da0b@qq.maarten.dev\r\n" +
+				"DTSTAMP:20300101T120000Z\r\n" +
+				"DTSTART:20300102T080000Z\r\n" +
+				"DTEND:20300109T080000Z\r\n" +
+				"SUMMARY:Homework\r\n" +
+				"DESCRIPTION:Chapter 1\\; 2\\, and 3\r\n" +
+				"END:VEVENT\r\n" +
+				"END:VCALENDAR\r\n",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, ok := NewCalendar(testData.game, now)
+
+			// Assert
+			assert.True(t, ok)
+			assert.Equal(t, testData.expected, string(result))
+		})
+	}
+}
+
+func TestFoldCalendarLine_FoldsLongLines(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	line := "SUMMARY:" + strings.Repeat("é", 40)
+
+	// Act
+	result := foldCalendarLine(line)
+
+	// Assert
+	for _, part := range strings.Split(result, "\r\n") {
+		assert.LessOrEqual(t, len(part), calendarLineLength)
+	}
+
+	assert.Equal(t, line, strings.ReplaceAll(result, "\r\n ", ""))
+}
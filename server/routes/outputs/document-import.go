@@ -0,0 +1,34 @@
+package outputs
+
+import (
+	"fmt"
+	"github.com/survivorbat/qq.maarten.dev/server/formats"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+)
+
+// OutputDocumentImport previews the questions of another tool's document before it's imported
+type OutputDocumentImport struct {
+	Questions []*inputs.MultipleChoiceQuestion `json:"questions"` // desc: The questions that can be imported
+	Problems  []*formats.Problem               `json:"problems"`  // desc: The parts of the document that were left out
+}
+
+// NewDocumentProblems describes the rules a question of the document broke, if the question is nil
+// the problems are about the quiz as a whole
+func NewDocumentProblems(question *formats.Question, err error) []*formats.Problem {
+	var result []*formats.Problem
+	for _, bundleError := range NewBundleErrors(err) {
+		reason := fmt.Sprintf("%s does not pass the %s rule", bundleError.Field, bundleError.Rule)
+		if bundleError.Param != "" {
+			reason = fmt.Sprintf("%s does not pass the %s=%s rule", bundleError.Field, bundleError.Rule, bundleError.Param)
+		}
+
+		problem := &formats.Problem{Reason: reason, Skipped: true}
+		if question != nil {
+			problem.Line, problem.File = question.Line, question.File
+		}
+
+		result = append(result, problem)
+	}
+
+	return result
+}
@@ -0,0 +1,14 @@
+package outputs
+
+import "github.com/survivorbat/qq.maarten.dev/server/domain"
+
+func NewCreatedPresenterToken(presenterToken *domain.PresenterToken, token string) *OutputCreatedPresenterToken {
+	return &OutputCreatedPresenterToken{PresenterToken: presenterToken, Token: token}
+}
+
+// OutputCreatedPresenterToken is only returned once, the token can not be retrieved afterwards
+type OutputCreatedPresenterToken struct {
+	*domain.PresenterToken
+
+	Token string `json:"token" example:"qqp_3f9a..."` // desc: Use this as the websocket subprotocol of the spectator connection
+}
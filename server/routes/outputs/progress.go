@@ -0,0 +1,46 @@
+package outputs
+
+import (
+	"github.com/google/uuid"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"time"
+)
+
+func NewProgress(game *domain.Game, player *domain.Player) *OutputProgress {
+	result := &OutputProgress{
+		CurrentDeadline: player.CurrentDeadline,
+		CloseTime:       game.CloseTime,
+		Finished:        !player.FinishTime.IsZero(),
+		QuestionCount:   uint(len(game.Questions())),
+	}
+
+	for _, answer := range game.Answers {
+		if answer.PlayerID == player.ID {
+			result.AnswerCount++
+		}
+	}
+
+	if result.Finished || player.CurrentQuestion == uuid.Nil {
+		return result
+	}
+
+	result.Answered = game.Answers.Contains(player.CurrentQuestion, player.ID)
+	for index, question := range game.Questions() {
+		if question.ID == player.CurrentQuestion {
+			result.Question = NewPublicMultipleChoiceQuestion(game, question, index, player.ID)
+		}
+	}
+
+	return result
+}
+
+// OutputProgress is where a player of a self-paced game is at
+type OutputProgress struct {
+	Question        *OutputMultipleChoiceQuestion `json:"question,omitempty"` // desc: The player's current question, empty if they haven't started or are done
+	CurrentDeadline time.Time                     `json:"currentDeadline"`    // desc: Past this deadline, the player may not answer the current question
+	CloseTime       time.Time                     `json:"closeTime"`          // desc: The time the game finishes
+	Answered        bool                          `json:"answered"`           // desc: Whether the player answered the current question
+	Finished        bool                          `json:"finished"`           // desc: Whether the player went through all questions
+	AnswerCount     uint                          `json:"answerCount" example:"3"`
+	QuestionCount   uint                          `json:"questionCount" example:"10"`
+}
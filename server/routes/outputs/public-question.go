@@ -5,12 +5,26 @@ import (
 	"github.com/survivorbat/qq.maarten.dev/server/domain"
 )
 
+// NewPublicMultipleChoiceQuestion leaves out the answer and puts the options in the order the player sees them,
+// the order is the position of the question in the game
+func NewPublicMultipleChoiceQuestion(game *domain.Game, question *domain.MultipleChoiceQuestion, order int, playerID uuid.UUID) *OutputMultipleChoiceQuestion {
+	return &OutputMultipleChoiceQuestion{
+		ID:                question.ID,
+		Title:             question.Title,
+		Description:       question.Description,
+		DurationInSeconds: question.DurationInSeconds,
+		Category:          question.Category,
+		Order:             uint(order),
+		Options:           game.Options(question, playerID),
+	}
+}
+
 type OutputMultipleChoiceQuestion struct {
 	ID                uuid.UUID                `json:"id" example:"00000000-0000-0000-0000-000000000000"`
 	Title             string                   `json:"title" example:"What is 5+5?"`
 	Description       string                   `json:"description" example:"We want to test your math skills for no apparent reason"`
 	DurationInSeconds uint                     `json:"durationInSeconds" example:"30"`
 	Category          string                   `json:"category" example:"Geography"`
-	Order             uint                     `json:"order" example:"2"`
+	Order             uint                     `json:"order" example:"2"` // desc: The position of the question in this game
 	Options           []*domain.QuestionOption `json:"options"`
 }
@@ -5,24 +5,20 @@ import (
 	"github.com/survivorbat/qq.maarten.dev/server/domain"
 )
 
-func NewPublicQuiz(quiz *domain.Quiz) *OutputQuiz {
+// NewPublicQuiz returns the questions in the order of the game, with the options in the order
+// the player sees them
+func NewPublicQuiz(game *domain.Game, playerID uuid.UUID) *OutputQuiz {
+	questions := game.Questions()
+
 	result := &OutputQuiz{
-		ID:                      quiz.ID,
-		Name:                    quiz.Name,
-		Description:             quiz.Description,
-		MultipleChoiceQuestions: make([]*OutputMultipleChoiceQuestion, len(quiz.MultipleChoiceQuestions)),
+		ID:                      game.Quiz.ID,
+		Name:                    game.Quiz.Name,
+		Description:             game.Quiz.Description,
+		MultipleChoiceQuestions: make([]*OutputMultipleChoiceQuestion, len(questions)),
 	}
 
-	for index, mc := range quiz.MultipleChoiceQuestions {
-		result.MultipleChoiceQuestions[index] = &OutputMultipleChoiceQuestion{
-			ID:                mc.ID,
-			Title:             mc.Title,
-			Description:       mc.Description,
-			DurationInSeconds: mc.DurationInSeconds,
-			Category:          mc.Category,
-			Order:             mc.Order,
-			Options:           mc.Options,
-		}
+	for index, mc := range questions {
+		result.MultipleChoiceQuestions[index] = NewPublicMultipleChoiceQuestion(game, mc, index, playerID)
 	}
 
 	return result
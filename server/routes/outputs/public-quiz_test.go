@@ -20,7 +20,7 @@ func TestNewPublicQuiz_ReturnsExpectedOutput(t *testing.T) {
 	}
 
 	// Act
-	result := NewPublicQuiz(quiz)
+	result := NewPublicQuiz(&domain.Game{Quiz: quiz}, uuid.Nil)
 
 	// Assert
 	assert.Equal(t, quiz.ID, result.ID)
@@ -40,3 +40,29 @@ func TestNewPublicQuiz_ReturnsExpectedOutput(t *testing.T) {
 		assert.Equal(t, expected, result.MultipleChoiceQuestions[0])
 	}
 }
+
+func TestNewPublicQuiz_ReturnsOptionsInPlayerOrder(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	question := &domain.MultipleChoiceQuestion{
+		BaseQuestion: domain.BaseQuestion{BaseObject: domain.BaseObject{ID: uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8")}},
+	}
+	for i := 0; i < 8; i++ {
+		question.Options = append(question.Options, &domain.QuestionOption{BaseObject: domain.BaseObject{ID: uuid.New()}})
+	}
+
+	game := &domain.Game{
+		Seed:                    42,
+		ShuffleOptionsPerPlayer: true,
+		Quiz:                    &domain.Quiz{MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{question}},
+	}
+	playerID := uuid.MustParse("a28ca8c6-63d9-45a2-b990-9b41e306f156")
+
+	// Act
+	result := NewPublicQuiz(game, playerID)
+
+	// Assert
+	if assert.Len(t, result.MultipleChoiceQuestions, 1) {
+		assert.Equal(t, game.Options(question, playerID), result.MultipleChoiceQuestions[0].Options)
+	}
+}
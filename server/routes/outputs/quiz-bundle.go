@@ -0,0 +1,48 @@
+package outputs
+
+import (
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"sort"
+)
+
+// NewQuizBundle exports the quiz in a format that can be imported again, IDs are left out so the
+// same bundle can be imported any number of times
+func NewQuizBundle(quiz *domain.Quiz) *inputs.QuizBundle {
+	result := &inputs.QuizBundle{
+		Version:            inputs.BundleVersion,
+		Name:               quiz.Name,
+		Description:        quiz.Description,
+		Language:           quiz.Language,
+		Tags:               quiz.TagNames(),
+		Public:             quiz.Public,
+		Template:           quiz.Template,
+		MaxConcurrentGames: quiz.MaxConcurrentGames,
+	}
+
+	for _, pool := range quiz.QuestionPools {
+		result.QuestionPools = append(result.QuestionPools, &inputs.BundleQuestionPool{Category: pool.Category, DrawCount: pool.DrawCount})
+	}
+
+	questions := make([]*domain.MultipleChoiceQuestion, len(quiz.MultipleChoiceQuestions))
+	copy(questions, quiz.MultipleChoiceQuestions)
+	sort.SliceStable(questions, func(i, j int) bool { return questions[i].Order < questions[j].Order })
+
+	for _, question := range questions {
+		bundleQuestion := &inputs.BundleQuestion{
+			Type:              question.GetType(),
+			Title:             question.Title,
+			Description:       question.Description,
+			DurationInSeconds: question.DurationInSeconds,
+			Category:          question.Category,
+		}
+
+		for _, option := range question.Options {
+			bundleQuestion.Options = append(bundleQuestion.Options, &inputs.BundleOption{TextOption: option.TextOption, Answer: option.ID == question.AnswerID})
+		}
+
+		result.Questions = append(result.Questions, bundleQuestion)
+	}
+
+	return result
+}
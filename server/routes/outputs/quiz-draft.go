@@ -0,0 +1,28 @@
+package outputs
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"time"
+)
+
+func NewQuizDraft(draft *domain.QuizDraft, publishable bool) *OutputQuizDraft {
+	return &OutputQuizDraft{
+		QuizID:      draft.QuizID,
+		UpdatedAt:   draft.UpdatedAt,
+		UpdatedByID: draft.UpdatedByID,
+		Quiz:        draft.Content,
+		Publishable: publishable,
+		Version:     draft.Version,
+	}
+}
+
+type OutputQuizDraft struct {
+	QuizID      uuid.UUID       `json:"quizID"`
+	UpdatedAt   time.Time       `json:"updatedAt"`   // desc: The time the draft was saved last
+	UpdatedByID uuid.UUID       `json:"updatedByID"` // desc: The creator that saved the draft last
+	Quiz        json.RawMessage `json:"quiz" swaggertype:"object"`
+	Publishable bool            `json:"publishable"` // desc: Whether the draft passes validation, only then it can be published
+	Version     uint            `json:"version"`     // desc: Send this in If-Match when changing single questions
+}
@@ -0,0 +1,31 @@
+package outputs
+
+import (
+	"github.com/google/uuid"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"time"
+)
+
+// NewQuizRevisions leaves out the contents of the revisions
+func NewQuizRevisions(revisions []*domain.QuizRevision) []*OutputQuizRevision {
+	result := make([]*OutputQuizRevision, len(revisions))
+	for index, revision := range revisions {
+		result[index] = &OutputQuizRevision{
+			ID:            revision.ID,
+			Number:        revision.Number,
+			CreatedAt:     revision.CreatedAt,
+			Name:          revision.Snapshot.Name,
+			QuestionCount: uint(len(revision.Snapshot.MultipleChoiceQuestions)),
+		}
+	}
+
+	return result
+}
+
+type OutputQuizRevision struct {
+	ID            uuid.UUID `json:"id"`
+	Number        uint      `json:"number" example:"3"`
+	CreatedAt     time.Time `json:"createdAt"` // desc: The time the quiz was saved
+	Name          string    `json:"name" example:"Daniel's funky quiz'"`
+	QuestionCount uint      `json:"questionCount" example:"10"`
+}
@@ -0,0 +1,78 @@
+package outputs
+
+import (
+	"github.com/google/uuid"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"sort"
+)
+
+// NewQuizSummaries describes the quizzes without giving away their questions, so they can be
+// listed to creators that don't have access to them
+func NewQuizSummaries(quizzes []*domain.Quiz) []*OutputQuizSummary {
+	result := make([]*OutputQuizSummary, len(quizzes))
+	for index, quiz := range quizzes {
+		result[index] = &OutputQuizSummary{
+			ID:            quiz.ID,
+			Name:          quiz.Name,
+			Description:   quiz.Description,
+			QuestionCount: uint(len(quiz.MultipleChoiceQuestions)),
+			Categories:    quizCategories(quiz),
+			Tags:          quiz.TagNames(),
+			Language:      quiz.Language,
+			GamesPlayed:   quiz.CountGamesPlayed(),
+			ForkedFromID:  quiz.ForkedFromID,
+		}
+
+		if quiz.Creator != nil {
+			result[index].CreatorNickname = quiz.Creator.Nickname
+		}
+	}
+
+	return result
+}
+
+type OutputQuizSummary struct {
+	ID              uuid.UUID  `json:"id"`
+	Name            string     `json:"name" example:"Daniel's funky quiz'"`
+	Description     string     `json:"description" example:"My first attempt!"`
+	CreatorNickname string     `json:"creatorNickname" example:"Adorable Beaver"`
+	QuestionCount   uint       `json:"questionCount" example:"10"`
+	Categories      []string   `json:"categories" example:"Geography"`
+	Tags            []string   `json:"tags" example:"history"`
+	Language        string     `json:"language,omitempty" example:"en"`
+	GamesPlayed     uint       `json:"gamesPlayed" example:"12"`
+	ForkedFromID    *uuid.UUID `json:"forkedFromID,omitempty"` // desc: The quiz this one was forked from, if any
+}
+
+func NewLibraryPage(quizzes []*domain.Quiz, total int64, page int, pageSize int) *OutputLibraryPage {
+	return &OutputLibraryPage{
+		Quizzes:  NewQuizSummaries(quizzes),
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}
+}
+
+type OutputLibraryPage struct {
+	Quizzes  []*OutputQuizSummary `json:"quizzes"`
+	Total    int64                `json:"total" example:"42"` // desc: The amount of quizzes that match, across all pages
+	Page     int                  `json:"page" example:"1"`
+	PageSize int                  `json:"pageSize" example:"20"`
+}
+
+// quizCategories returns the distinct categories of the quiz's questions, sorted by name
+func quizCategories(quiz *domain.Quiz) []string {
+	seen := map[string]bool{}
+	result := []string{}
+
+	for _, question := range quiz.MultipleChoiceQuestions {
+		if !seen[question.Category] {
+			seen[question.Category] = true
+			result = append(result, question.Category)
+		}
+	}
+
+	sort.Strings(result)
+
+	return result
+}
@@ -0,0 +1,63 @@
+package outputs
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+)
+
+// OutputSheetImport previews the questions of a spreadsheet before it's imported
+type OutputSheetImport struct {
+	Questions []*inputs.MultipleChoiceQuestion `json:"questions"` // desc: The questions from the rows without errors
+	Errors    []*OutputRowError                `json:"errors"`
+}
+
+// OutputRowError is a cell of the spreadsheet that is invalid
+type OutputRowError struct {
+	Row    int    `json:"row" example:"4"`             // desc: Number of the row in the spreadsheet, 0 if the error is about the quiz as a whole
+	Column string `json:"column" example:"title"`      // desc: Name of the column, or the quiz's field if the row is 0
+	Rule   string `json:"rule" example:"min"`          // desc: The rule the cell broke
+	Param  string `json:"param,omitempty" example:"3"` // desc: Parameter of the rule, if any
+}
+
+// sheetColumns maps fields of the question input to the columns they're read from
+var sheetColumns = map[string]string{
+	"title":             inputs.ColumnTitle,
+	"description":       inputs.ColumnDescription,
+	"category":          inputs.ColumnCategory,
+	"durationInSeconds": inputs.ColumnDuration,
+}
+
+// NewRowErrors lists the cells of the row that could not be read and the ones that broke a rule
+func NewRowErrors(row *inputs.SheetRow, err error) []*OutputRowError {
+	var result []*OutputRowError
+	for _, sheetError := range row.Errors {
+		result = append(result, &OutputRowError{Row: row.Number, Column: sheetError.Column, Rule: sheetError.Rule, Param: sheetError.Param})
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return result
+	}
+
+	for _, fieldError := range validationErrors {
+		column := bundlePath(fieldError.Namespace())
+		if sheetColumn, ok := sheetColumns[column]; ok {
+			column = sheetColumn
+		}
+
+		result = append(result, &OutputRowError{Row: row.Number, Column: column, Rule: fieldError.Tag(), Param: fieldError.Param()})
+	}
+
+	return result
+}
+
+// NewQuizRowErrors lists the rules the quiz as a whole broke, like having too many questions
+func NewQuizRowErrors(err error) []*OutputRowError {
+	var result []*OutputRowError
+	for _, bundleError := range NewBundleErrors(err) {
+		result = append(result, &OutputRowError{Column: bundleError.Field, Rule: bundleError.Rule, Param: bundleError.Param})
+	}
+
+	return result
+}
@@ -1,17 +1,22 @@
 package routes
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"github.com/survivorbat/qq.maarten.dev/server/routes/outputs"
 	"github.com/survivorbat/qq.maarten.dev/server/services"
+	"golang.org/x/text/language"
 	"net/http"
 )
 
 type PlayerHandler struct {
 	PlayerService services.PlayerService
 	GameService   services.GameService
+	AvatarService services.AvatarService
 }
 
 // Get godoc
@@ -28,7 +33,6 @@ type PlayerHandler struct {
 //	@Router		/api/v1/games/{id}/players [get]
 //	@Security	JWT
 func (g *PlayerHandler) Get(c *gin.Context) {
-	authID := c.GetString("user")
 	id := c.Param("id")
 
 	gameID, err := uuid.Parse(id)
@@ -45,9 +49,7 @@ func (g *PlayerHandler) Get(c *gin.Context) {
 	}
 
 	// Prevent users from viewing other people's games
-	if game.Quiz.CreatorID.String() != authID {
-		logrus.Errorf("Creator is %s not %s", game.Quiz.CreatorID, authID)
-		c.AbortWithStatus(http.StatusForbidden)
+	if !authorize(c, game.Quiz, domain.PermissionViewQuiz) {
 		return
 	}
 
@@ -67,12 +69,18 @@ func (g *PlayerHandler) Get(c *gin.Context) {
 //	@Tags		Player
 //	@Accept		json
 //	@Produce	json
-//	@Param		id	path		string			true	"ID of the game"
-//	@Success	200	{object}	domain.Player	"The new player"
-//	@Failure	400	"Invalid uuid"
-//	@Failure	404	"Game not found"
-//	@Failure	409	"Game full"
-//	@Failure	500	"Internal Server Error"
+//	@Param		id				path		string			true	"ID of the game"
+//	@Param		input			body		inputs.Player	false	"Your chosen nickname and avatar"
+//	@Param		Accept-Language	header		string			false	"Language of the generated nickname if the game has none"
+//	@Success	200				{object}	domain.Player	"The new player"
+//	@Failure	400				"Invalid uuid"
+//	@Failure	400				"Nickname is not allowed"
+//	@Failure	400				"Unknown avatar"
+//	@Failure	400				"Unknown team"
+//	@Failure	404				"Game not found"
+//	@Failure	409				"Game full"
+//	@Failure	409				"Nickname is already taken"
+//	@Failure	500				"Internal Server Error"
 //	@Router		/api/v1/games/{id}/players [post]
 func (g *PlayerHandler) Post(c *gin.Context) {
 	id := c.Param("id")
@@ -101,9 +109,39 @@ func (g *PlayerHandler) Post(c *gin.Context) {
 		return
 	}
 
-	player := &domain.Player{Game: game}
-	if err := g.PlayerService.Create(player); err != nil {
-		c.AbortWithStatus(http.StatusInternalServerError)
+	// The body is optional, older clients don't send one
+	var input inputs.Player
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			logrus.WithError(err).Error("Validation error")
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+	}
+
+	if input.Avatar != "" && !g.AvatarService.Exists(input.Avatar) {
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	player := &domain.Player{Game: game, Nickname: input.GetNickname(), Avatar: input.Avatar}
+	if err := game.AssignTeam(player, input.TeamID); err != nil {
+		logrus.WithError(err).Error("Failed to assign team")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	// The game's language takes precedence over the player's browser
+	locales := append([]string{game.Locale}, acceptedLocales(c)...)
+	if err := g.PlayerService.Create(player, locales...); err != nil {
+		switch {
+		case errors.Is(err, services.ErrNicknameNotAllowed):
+			c.AbortWithStatus(http.StatusBadRequest)
+		case errors.Is(err, services.ErrNicknameTaken):
+			c.AbortWithStatus(http.StatusConflict)
+		default:
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
 		return
 	}
 
@@ -145,3 +183,145 @@ func (g *PlayerHandler) Delete(c *gin.Context) {
 
 	c.JSON(http.StatusOK, player)
 }
+
+// GetProgress godoc
+//
+//	@Summary	Fetch where this player of a self-paced game is at
+//	@Tags		Player
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		string					true	"ID of the player"
+//	@Success	200	{object}	outputs.OutputProgress	"The player's progress"
+//	@Failure	400	"Invalid uuid"
+//	@Failure	404	"Player not found or game is not self-paced"
+//	@Router		/api/v1/players/{id}/progress [get]
+func (g *PlayerHandler) GetProgress(c *gin.Context) {
+	game, player, ok := g.getSelfPacedPlayer(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, outputs.NewProgress(game, player))
+}
+
+// PatchProgress godoc
+//
+//	@Summary	Move this player of a self-paced game on to their next question
+//	@Tags		Player
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		string					true	"ID of the player"
+//	@Param		action	query		string					true	"Action to perform"	Enums(next)
+//	@Success	200		{object}	outputs.OutputProgress	"The player's progress"
+//	@Failure	400		"Invalid uuid"
+//	@Failure	400		"Unknown action"
+//	@Failure	404		"Player not found or game is not self-paced"
+//	@Failure	409		"Game is closed, player is done or the deadline has not passed"
+//	@Failure	500		"Internal Server Error"
+//	@Router		/api/v1/players/{id}/progress [patch]
+func (g *PlayerHandler) PatchProgress(c *gin.Context) {
+	if action := c.Query("action"); action != "next" {
+		logrus.Errorf("Unknown action %s", action)
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	game, player, ok := g.getSelfPacedPlayer(c)
+	if !ok {
+		return
+	}
+
+	if _, err := g.GameService.NextForPlayer(game, player); err != nil {
+		c.AbortWithStatus(http.StatusConflict)
+		return
+	}
+
+	c.JSON(http.StatusOK, outputs.NewProgress(game, player))
+}
+
+// PostAnswer godoc
+//
+//	@Summary	Answer the current question of this player of a self-paced game
+//	@Tags		Player
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		string					true	"ID of the player"
+//	@Param		input	body		inputs.Answer			true	"The chosen option"
+//	@Success	200		{object}	outputs.OutputProgress	"The player's progress"
+//	@Failure	400		"Invalid uuid"
+//	@Failure	400		"Malformed input"
+//	@Failure	404		"Player not found or game is not self-paced"
+//	@Failure	409		"Not the current question, deadline passed or already answered"
+//	@Failure	500		"Failed to save the answer"
+//	@Router		/api/v1/players/{id}/answers [post]
+func (g *PlayerHandler) PostAnswer(c *gin.Context) {
+	var input *inputs.Answer
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logrus.WithError(err).Error("Validation error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	game, player, ok := g.getSelfPacedPlayer(c)
+	if !ok {
+		return
+	}
+
+	err := g.GameService.AnswerQuestion(game, player.CurrentQuestion, player.ID, input.OptionID)
+	switch {
+	case errors.Is(err, services.ErrAnswerRejected), errors.Is(err, services.ErrAlreadyAnswered):
+		c.AbortWithStatus(http.StatusConflict)
+		return
+	case err != nil:
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, outputs.NewProgress(game, player))
+}
+
+// getSelfPacedPlayer fetches the player in the id param and their game, only if the game is self-paced
+func (g *PlayerHandler) getSelfPacedPlayer(c *gin.Context) (*domain.Game, *domain.Player, bool) {
+	playerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		logrus.WithError(err).Error("UUID error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return nil, nil, false
+	}
+
+	player, err := g.PlayerService.GetByID(playerID)
+	if err != nil {
+		c.AbortWithStatus(http.StatusNotFound)
+		return nil, nil, false
+	}
+
+	game, err := g.GameService.GetByID(player.GameID)
+	if err != nil || !game.IsSelfPaced() {
+		c.AbortWithStatus(http.StatusNotFound)
+		return nil, nil, false
+	}
+
+	// Use the game's player, so progress and answers are validated against the same data
+	gamePlayer, ok := game.Players.Get(player.ID)
+	if !ok {
+		c.AbortWithStatus(http.StatusNotFound)
+		return nil, nil, false
+	}
+
+	return game, gamePlayer, true
+}
+
+// acceptedLocales returns the locales in the Accept-Language header, most preferred first
+func acceptedLocales(c *gin.Context) []string {
+	tags, _, err := language.ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+	if err != nil {
+		return nil
+	}
+
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		result = append(result, tag.String())
+	}
+
+	return result
+}
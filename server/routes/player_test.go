@@ -1,11 +1,16 @@
 package routes
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"github.com/survivorbat/qq.maarten.dev/server/routes/outputs"
+	"github.com/survivorbat/qq.maarten.dev/server/services"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -249,6 +254,62 @@ func TestPlayerHandler_Post_ReturnsNewPlayer(t *testing.T) {
 	assert.Equal(t, gameService.getByIdReturns.ID, result.GameID)
 }
 
+func TestPlayerHandler_Post_UsesChosenNickname(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		nickname    string
+		createError error
+		expected    int
+	}{
+		"chosen": {
+			nickname: " Quiz Wizard ",
+			expected: http.StatusOK,
+		},
+		"too long": {
+			nickname: "This nickname is way too long",
+			expected: http.StatusBadRequest,
+		},
+		"not allowed": {
+			nickname:    "Quiz Wizard",
+			createError: services.ErrNicknameNotAllowed,
+			expected:    http.StatusBadRequest,
+		},
+		"taken": {
+			nickname:    "Quiz Wizard",
+			createError: services.ErrNicknameTaken,
+			expected:    http.StatusConflict,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			gameService := &MockGameService{getByIdReturns: &domain.Game{StartTime: time.Now(), PlayerLimit: 5}}
+			playerService := &MockPlayerService{createReturns: testData.createError}
+			handler := &PlayerHandler{GameService: gameService, PlayerService: playerService}
+
+			inputJson, _ := json.Marshal(&inputs.Player{Nickname: testData.nickname})
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Request, _ = http.NewRequest(http.MethodPost, "", bytes.NewBuffer(inputJson))
+			context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+			// Act
+			handler.Post(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+
+			if testData.expected == http.StatusOK {
+				assert.Equal(t, "Quiz Wizard", playerService.createCalledWith.Nickname)
+			}
+		})
+	}
+}
+
 func TestPlayerHandler_Delete_ReturnsErrorOnInvalidUUID(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -323,3 +384,325 @@ func TestPlayerHandler_Delete_ReturnsSuccess(t *testing.T) {
 	// Assert
 	assert.Equal(t, http.StatusOK, writer.Code)
 }
+
+func TestPlayerHandler_Post_UsesChosenAvatar(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		exists   bool
+		expected int
+	}{
+		"built-in": {
+			exists:   true,
+			expected: http.StatusOK,
+		},
+		"unknown": {
+			exists:   false,
+			expected: http.StatusBadRequest,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			gameService := &MockGameService{getByIdReturns: &domain.Game{StartTime: time.Now(), PlayerLimit: 5}}
+			playerService := &MockPlayerService{}
+			handler := &PlayerHandler{GameService: gameService, PlayerService: playerService, AvatarService: &MockAvatarService{existsReturns: testData.exists}}
+
+			inputJson, _ := json.Marshal(&inputs.Player{Avatar: "moon"})
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Request, _ = http.NewRequest(http.MethodPost, "", bytes.NewBuffer(inputJson))
+			context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+			// Act
+			handler.Post(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+
+			if testData.expected == http.StatusOK {
+				assert.Equal(t, "moon", playerService.createCalledWith.Avatar)
+			}
+		})
+	}
+}
+
+func TestPlayerHandler_Post_PassesLocales(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		gameLocale     string
+		acceptLanguage string
+		expected       []string
+	}{
+		"game": {
+			gameLocale: "de",
+			expected:   []string{"de"},
+		},
+		"browser": {
+			acceptLanguage: "fr;q=0.5, nl-BE",
+			expected:       []string{"", "nl-BE", "fr"},
+		},
+		"both": {
+			gameLocale:     "de",
+			acceptLanguage: "nl",
+			expected:       []string{"de", "nl"},
+		},
+		"malformed header": {
+			acceptLanguage: "this is not a language",
+			expected:       []string{""},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			gameService := &MockGameService{getByIdReturns: &domain.Game{StartTime: time.Now(), PlayerLimit: 5, Locale: testData.gameLocale}}
+			playerService := &MockPlayerService{}
+			handler := &PlayerHandler{GameService: gameService, PlayerService: playerService}
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Request, _ = http.NewRequest(http.MethodPost, "", nil)
+			context.Request.Header.Set("Accept-Language", testData.acceptLanguage)
+			context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+			// Act
+			handler.Post(context)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, writer.Code)
+			assert.Equal(t, testData.expected, playerService.createCalledWithLocales)
+		})
+	}
+}
+
+func TestPlayerHandler_Post_AssignsTeam(t *testing.T) {
+	t.Parallel()
+	marketing := uuid.MustParse("3e0ec4b9-4d8b-44d4-9fa2-d4e7d1fbfc0b")
+	sales := uuid.MustParse("c37bbf7c-5d2c-4a55-8c2e-4bb1a78f8f7e")
+
+	tests := map[string]struct {
+		teamID         *uuid.UUID
+		expected       int
+		expectedTeamID *uuid.UUID
+	}{
+		"balanced": {
+			expected:       http.StatusOK,
+			expectedTeamID: &sales,
+		},
+		"chosen": {
+			teamID:         &marketing,
+			expected:       http.StatusOK,
+			expectedTeamID: &marketing,
+		},
+		"unknown": {
+			teamID:   &uuid.Nil,
+			expected: http.StatusBadRequest,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			game := &domain.Game{
+				StartTime:   time.Now(),
+				PlayerLimit: 5,
+				Teams:       []*domain.Team{{BaseObject: domain.BaseObject{ID: marketing}}, {BaseObject: domain.BaseObject{ID: sales}}},
+				Players:     []*domain.Player{{TeamID: &marketing}},
+			}
+
+			playerService := &MockPlayerService{}
+			handler := &PlayerHandler{GameService: &MockGameService{getByIdReturns: game}, PlayerService: playerService}
+
+			inputJson, _ := json.Marshal(&inputs.Player{TeamID: testData.teamID})
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Request, _ = http.NewRequest(http.MethodPost, "", bytes.NewBuffer(inputJson))
+			context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+			// Act
+			handler.Post(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+
+			if testData.expected == http.StatusOK {
+				assert.Equal(t, testData.expectedTeamID, playerService.createCalledWith.TeamID)
+			}
+		})
+	}
+}
+
+func TestPlayerHandler_GetProgress_ReturnsErrorOnLiveGame(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	player := &domain.Player{BaseObject: domain.BaseObject{ID: uuid.MustParse("a28ca8c6-63d9-45a2-b990-9b41e306f156")}}
+	handler := &PlayerHandler{
+		PlayerService: &MockPlayerService{getByIdReturns: player},
+		GameService:   &MockGameService{getByIdReturns: &domain.Game{Mode: domain.GameModeLive, Players: []*domain.Player{player}}},
+	}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Params = []gin.Param{{Key: "id", Value: player.ID.String()}}
+
+	// Act
+	handler.GetProgress(context)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, writer.Code)
+}
+
+func TestPlayerHandler_PatchProgress_MovesPlayerOn(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	player := &domain.Player{BaseObject: domain.BaseObject{ID: uuid.MustParse("a28ca8c6-63d9-45a2-b990-9b41e306f156")}}
+	game := &domain.Game{Mode: domain.GameModeSelfPaced, Players: []*domain.Player{player}, Quiz: &domain.Quiz{}}
+
+	gameService := &MockGameService{getByIdReturns: game}
+	handler := &PlayerHandler{
+		PlayerService: &MockPlayerService{getByIdReturns: &domain.Player{BaseObject: player.BaseObject}},
+		GameService:   gameService,
+	}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Request, _ = http.NewRequest(http.MethodPatch, "/?action=next", nil)
+	context.Params = []gin.Param{{Key: "id", Value: player.ID.String()}}
+
+	// Act
+	handler.PatchProgress(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	// The game's player is used, since it's validated against the game's answers
+	assert.Same(t, player, gameService.nextForPlayerCalledWith)
+}
+
+func TestPlayerHandler_PatchProgress_ReturnsConflictOnError(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	player := &domain.Player{BaseObject: domain.BaseObject{ID: uuid.MustParse("a28ca8c6-63d9-45a2-b990-9b41e306f156")}}
+	game := &domain.Game{Mode: domain.GameModeSelfPaced, Players: []*domain.Player{player}, Quiz: &domain.Quiz{}}
+
+	handler := &PlayerHandler{
+		PlayerService: &MockPlayerService{getByIdReturns: player},
+		GameService:   &MockGameService{getByIdReturns: game, nextForPlayerReturnsError: assert.AnError},
+	}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Request, _ = http.NewRequest(http.MethodPatch, "/?action=next", nil)
+	context.Params = []gin.Param{{Key: "id", Value: player.ID.String()}}
+
+	// Act
+	handler.PatchProgress(context)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, writer.Code)
+}
+
+func TestPlayerHandler_PostAnswer_AnswersCurrentQuestion(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	question := &domain.MultipleChoiceQuestion{BaseQuestion: domain.BaseQuestion{
+		BaseObject: domain.BaseObject{ID: uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8")},
+		Title:      "What is 5+5?",
+	}}
+	player := &domain.Player{
+		BaseObject:      domain.BaseObject{ID: uuid.MustParse("a28ca8c6-63d9-45a2-b990-9b41e306f156")},
+		CurrentQuestion: question.ID,
+		CurrentDeadline: time.Now().Add(time.Minute),
+	}
+	game := &domain.Game{
+		Mode:    domain.GameModeSelfPaced,
+		Players: []*domain.Player{player},
+		Quiz:    &domain.Quiz{MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{question}},
+	}
+
+	gameService := &MockGameService{getByIdReturns: game}
+	handler := &PlayerHandler{
+		PlayerService: &MockPlayerService{getByIdReturns: player},
+		GameService:   gameService,
+	}
+
+	inputJson, _ := json.Marshal(&inputs.Answer{OptionID: uuid.MustParse("c7ff1cdf-72d3-4ea9-ae48-e1c1d61f8bc8")})
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Request, _ = http.NewRequest(http.MethodPost, "", bytes.NewBuffer(inputJson))
+	context.Params = []gin.Param{{Key: "id", Value: player.ID.String()}}
+
+	// Act
+	handler.PostAnswer(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, question.ID, gameService.answerCalledWithQuestion)
+
+	var result *outputs.OutputProgress
+	if err := json.Unmarshal(writer.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "What is 5+5?", result.Question.Title)
+	assert.Equal(t, uint(1), result.QuestionCount)
+}
+
+func TestPlayerHandler_PostAnswer_ReturnsErrorStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		err      error
+		expected int
+	}{
+		"rejected": {
+			err:      fmt.Errorf("%w: deadline passed", services.ErrAnswerRejected),
+			expected: http.StatusConflict,
+		},
+		"already answered": {
+			err:      services.ErrAlreadyAnswered,
+			expected: http.StatusConflict,
+		},
+		"database error": {
+			err:      assert.AnError,
+			expected: http.StatusInternalServerError,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			player := &domain.Player{BaseObject: domain.BaseObject{ID: uuid.MustParse("a28ca8c6-63d9-45a2-b990-9b41e306f156")}}
+			game := &domain.Game{Mode: domain.GameModeSelfPaced, Players: []*domain.Player{player}}
+
+			handler := &PlayerHandler{
+				PlayerService: &MockPlayerService{getByIdReturns: player},
+				GameService:   &MockGameService{getByIdReturns: game, answerReturns: testData.err},
+			}
+
+			inputJson, _ := json.Marshal(&inputs.Answer{OptionID: uuid.MustParse("c7ff1cdf-72d3-4ea9-ae48-e1c1d61f8bc8")})
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Request, _ = http.NewRequest(http.MethodPost, "", bytes.NewBuffer(inputJson))
+			context.Params = []gin.Param{{Key: "id", Value: player.ID.String()}}
+
+			// Act
+			handler.PostAnswer(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/coordinator"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"github.com/survivorbat/qq.maarten.dev/server/routes/outputs"
+	"github.com/survivorbat/qq.maarten.dev/server/services"
+	"net/http"
+)
+
+type PresenterTokenHandler struct {
+	GameService           services.GameService
+	PresenterTokenService services.PresenterTokenService
+	Coordinator           coordinator.GameCoordinator
+}
+
+// Get godoc
+//
+//	@Summary	Fetch the presenter tokens of this game
+//	@Tags		Game
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		string					true	"ID of the game"
+//	@Success	200	{array}		[]domain.PresenterToken	"The presenter tokens"
+//	@Failure	400	"Invalid uuid"
+//	@Failure	403	"You can only view tokens of games you host"
+//	@Failure	404	"Game not found"
+//	@Failure	500	"Internal Server Error"
+//	@Router		/api/v1/games/{id}/presenter-tokens [get]
+//	@Security	JWT
+func (p *PresenterTokenHandler) Get(c *gin.Context) {
+	game, ok := p.getHostedGame(c)
+	if !ok {
+		return
+	}
+
+	tokens, err := p.PresenterTokenService.GetByGame(game.ID)
+	if err != nil {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// Post godoc
+//
+//	@Summary	Issue a presenter token for a read-only display, the token is only returned once
+//	@Tags		Game
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		string								true	"ID of the game"
+//	@Param		input	body		inputs.PresenterToken				true	"Your presenter token"
+//	@Success	200		{object}	outputs.OutputCreatedPresenterToken	"Your presenter token"
+//	@Failure	400		"Invalid uuid"
+//	@Failure	400		"Malformed input"
+//	@Failure	403		"You can only issue tokens for games you host"
+//	@Failure	404		"Game not found"
+//	@Failure	500		"Internal Server Error"
+//	@Router		/api/v1/games/{id}/presenter-tokens [post]
+//	@Security	JWT
+func (p *PresenterTokenHandler) Post(c *gin.Context) {
+	authID := c.GetString("user")
+
+	game, ok := p.getHostedGame(c)
+	if !ok {
+		return
+	}
+
+	var input *inputs.PresenterToken
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logrus.WithError(err).Error("Validation error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	presenterToken := input.ToDomain(game.ID, uuid.MustParse(authID))
+
+	token, err := p.PresenterTokenService.Create(presenterToken)
+	if err != nil {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, outputs.NewCreatedPresenterToken(presenterToken, token))
+}
+
+// Delete godoc
+//
+//	@Summary	Revoke a presenter token, displays that are connected with it are disconnected
+//	@Tags		Game
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path	string	true	"ID of the game"
+//	@Param		token	path	string	true	"ID of the presenter token"
+//	@Success	204		"No Content"
+//	@Failure	400		"Invalid uuid"
+//	@Failure	403		"You can only revoke tokens of games you host"
+//	@Failure	404		"Not found"
+//	@Failure	500		"Internal Server Error"
+//	@Router		/api/v1/games/{id}/presenter-tokens/{token} [delete]
+//	@Security	JWT
+func (p *PresenterTokenHandler) Delete(c *gin.Context) {
+	game, ok := p.getHostedGame(c)
+	if !ok {
+		return
+	}
+
+	tokenID, err := uuid.Parse(c.Param("token"))
+	if err != nil {
+		logrus.WithError(err).Error("UUID error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	token, err := p.PresenterTokenService.GetByID(tokenID)
+	if err != nil || token.GameID != game.ID {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	if err := p.PresenterTokenService.Delete(token); err != nil {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	p.Coordinator.DisconnectSpectators(game.ID, token.ID)
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// getHostedGame fetches the game in the id param and aborts if the user may not host it
+func (p *PresenterTokenHandler) getHostedGame(c *gin.Context) (*domain.Game, bool) {
+	gameID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		logrus.WithError(err).Error("UUID error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return nil, false
+	}
+
+	game, err := p.GameService.GetByID(gameID)
+	if err != nil {
+		c.AbortWithStatus(http.StatusNotFound)
+		return nil, false
+	}
+
+	if !authorize(c, game.Quiz, domain.PermissionHostGames) {
+		return nil, false
+	}
+
+	return game, true
+}
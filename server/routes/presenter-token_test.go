@@ -0,0 +1,165 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"github.com/survivorbat/qq.maarten.dev/server/routes/outputs"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func presenterTokenGame() *domain.Game {
+	return &domain.Game{
+		BaseObject: domain.BaseObject{ID: uuid.MustParse("788f12a9-51e8-4c87-9b0c-06bcc9f0691b")},
+		Quiz:       &domain.Quiz{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58")},
+	}
+}
+
+func TestPresenterTokenHandler_Get_ReturnsErrorOnNotMyGame(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	handler := &PresenterTokenHandler{GameService: &MockGameService{getByIdReturns: presenterTokenGame()}}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "8fdc3e5a-b0a8-4103-af3b-c2f20d91889b")
+	context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+	// Act
+	handler.Get(context)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, writer.Code)
+}
+
+func TestPresenterTokenHandler_Get_ReturnsTokens(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	tokens := []*domain.PresenterToken{{Name: "Big screen", Hint: "qqp_ab"}}
+	handler := &PresenterTokenHandler{
+		GameService:           &MockGameService{getByIdReturns: presenterTokenGame()},
+		PresenterTokenService: &MockPresenterTokenService{getByGameReturns: tokens},
+	}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+	// Act
+	handler.Get(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	var result []*domain.PresenterToken
+	if err := json.Unmarshal(writer.Body.Bytes(), &result); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	assert.Equal(t, tokens, result)
+}
+
+func TestPresenterTokenHandler_Post_ReturnsTokenOnce(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	tokenService := &MockPresenterTokenService{createReturns: "qqp_abc"}
+	handler := &PresenterTokenHandler{
+		GameService:           &MockGameService{getByIdReturns: presenterTokenGame()},
+		PresenterTokenService: tokenService,
+	}
+
+	inputJson, _ := json.Marshal(&inputs.PresenterToken{Name: "Big screen"})
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodPost, "", io.NopCloser(bytes.NewBuffer(inputJson)))
+	context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+	// Act
+	handler.Post(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	assert.Equal(t, "Big screen", tokenService.createCalledWith.Name)
+	assert.Equal(t, uuid.MustParse("788f12a9-51e8-4c87-9b0c-06bcc9f0691b"), tokenService.createCalledWith.GameID)
+	assert.Equal(t, uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), tokenService.createCalledWith.CreatorID)
+
+	var result *outputs.OutputCreatedPresenterToken
+	if err := json.Unmarshal(writer.Body.Bytes(), &result); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	assert.Equal(t, "qqp_abc", result.Token)
+}
+
+func TestPresenterTokenHandler_Delete_ReturnsErrorOnOtherGame(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	tokenService := &MockPresenterTokenService{getByIDReturns: &domain.PresenterToken{
+		GameID: uuid.MustParse("adeb8482-4eb2-4c2d-8eec-97f705260fa8"),
+	}}
+	handler := &PresenterTokenHandler{
+		GameService:           &MockGameService{getByIdReturns: presenterTokenGame()},
+		PresenterTokenService: tokenService,
+	}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Params = []gin.Param{
+		{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"},
+		{Key: "token", Value: "d2f584da-d340-459a-a1ce-8652446a86ef"},
+	}
+
+	// Act
+	handler.Delete(context)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, writer.Code)
+	assert.Nil(t, tokenService.deleteCalledWith)
+}
+
+func TestPresenterTokenHandler_Delete_DeletesToken(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	token := &domain.PresenterToken{
+		BaseObject: domain.BaseObject{ID: uuid.MustParse("d2f584da-d340-459a-a1ce-8652446a86ef")},
+		GameID:     uuid.MustParse("788f12a9-51e8-4c87-9b0c-06bcc9f0691b"),
+	}
+	tokenService := &MockPresenterTokenService{getByIDReturns: token}
+	coord := new(MockCoordinator)
+	handler := &PresenterTokenHandler{
+		GameService:           &MockGameService{getByIdReturns: presenterTokenGame()},
+		PresenterTokenService: tokenService,
+		Coordinator:           coord,
+	}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Params = []gin.Param{
+		{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"},
+		{Key: "token", Value: "d2f584da-d340-459a-a1ce-8652446a86ef"},
+	}
+
+	// Act
+	handler.Delete(context)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, writer.Code)
+	assert.Equal(t, token, tokenService.deleteCalledWith)
+
+	// Displays using the token are disconnected
+	assert.Equal(t, token.GameID, coord.disconnectSpectatorsCalledWithGame)
+	assert.Equal(t, token.ID, coord.disconnectSpectatorsCalledWithToken)
+}
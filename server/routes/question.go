@@ -0,0 +1,595 @@
+package routes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"github.com/survivorbat/qq.maarten.dev/server/services"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// QuestionHandler changes single questions and options of a quiz, so the IDs of everything else
+// stay the same. The changes end up in the draft of the quiz and only reach games once it's published.
+// Every request has to send the version of the draft in If-Match, or that of the quiz if it has no draft.
+// If someone else changed the draft in the meantime the request fails with 412.
+type QuestionHandler struct {
+	QuizService         services.QuizService
+	QuizDraftService    services.QuizDraftService
+	BankQuestionService services.BankQuestionService
+}
+
+// Post godoc
+//
+//	@Summary	Add a question to a quiz at the given order
+//	@Tags		Question
+//	@Accept		json
+//	@Produce	json
+//	@Param		id			path		string							true	"ID of the quiz"
+//	@Param		If-Match	header		string							true	"Version of the quiz"
+//	@Param		input		body		inputs.MultipleChoiceQuestion	true	"The question"
+//	@Success	200			{object}	domain.MultipleChoiceQuestion	"The question"
+//	@Failure	400			"Invalid uuid or question"
+//	@Failure	403			"You can only edit quizzes you may edit"
+//	@Failure	404			"Quiz not found"
+//	@Failure	412			"The quiz was changed in the meantime"
+//	@Failure	428			"No If-Match header"
+//	@Failure	500			"Internal Server Error"
+//	@Router		/api/v1/quizzes/{id}/questions [post]
+//	@Security	JWT
+func (g *QuestionHandler) Post(c *gin.Context) {
+	quiz, version, ok := g.getQuiz(c)
+	if !ok {
+		return
+	}
+
+	var input *inputs.MultipleChoiceQuestion
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logrus.WithError(err).Error("Validation error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	question := input.ToDomain()
+	if err := quiz.AddQuestion(question); err != nil {
+		logrus.WithError(err).Error("Failed to add question")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	if !g.save(c, quiz, version) {
+		return
+	}
+
+	c.JSON(http.StatusOK, question)
+}
+
+// PostFromBank godoc
+//
+//	@Summary	Add a question from the question bank to a quiz, either as a copy or linked to the bank question
+//	@Tags		Question
+//	@Accept		json
+//	@Produce	json
+//	@Param		id			path		string							true	"ID of the quiz"
+//	@Param		If-Match	header		string							true	"Version of the quiz"
+//	@Param		input		body		inputs.BankReference			true	"The bank question"
+//	@Success	200			{object}	domain.MultipleChoiceQuestion	"The question"
+//	@Failure	400			"Invalid uuid or reference"
+//	@Failure	403			"You can only edit quizzes you may edit and use bank questions you have access to"
+//	@Failure	404			"Quiz or bank question not found"
+//	@Failure	412			"The quiz was changed in the meantime"
+//	@Failure	428			"No If-Match header"
+//	@Failure	500			"Internal Server Error"
+//	@Router		/api/v1/quizzes/{id}/questions/bank [post]
+//	@Security	JWT
+func (g *QuestionHandler) PostFromBank(c *gin.Context) {
+	quiz, version, ok := g.getQuiz(c)
+	if !ok {
+		return
+	}
+
+	var input *inputs.BankReference
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logrus.WithError(err).Error("Validation error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	bankQuestion, err := g.BankQuestionService.GetByID(input.BankQuestionID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get bank question")
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	if !bankQuestion.CanUse(uuid.MustParse(c.GetString("user"))) {
+		logrus.Errorf("Creator %s may not use bank question %s", c.GetString("user"), bankQuestion.ID)
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	question := bankQuestion.NewQuestion(input.Linked)
+	question.Order = input.Order
+
+	if err := quiz.AddQuestion(question); err != nil {
+		logrus.WithError(err).Error("Failed to add question")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	if !g.save(c, quiz, version) {
+		return
+	}
+
+	c.JSON(http.StatusOK, question)
+}
+
+// Put godoc
+//
+//	@Summary	Change a question of a quiz, its options stay the same
+//	@Tags		Question
+//	@Accept		json
+//	@Produce	json
+//	@Param		id			path		string							true	"ID of the quiz"
+//	@Param		question	path		string							true	"ID of the question"
+//	@Param		If-Match	header		string							true	"Version of the quiz"
+//	@Param		input		body		inputs.QuestionUpdate			true	"The question"
+//	@Success	200			{object}	domain.MultipleChoiceQuestion	"The question"
+//	@Failure	400			"Invalid uuid or question"
+//	@Failure	403			"You can only edit quizzes you may edit"
+//	@Failure	404			"Quiz or question not found"
+//	@Failure	409			"A question pool would no longer have enough questions"
+//	@Failure	412			"The quiz was changed in the meantime"
+//	@Failure	428			"No If-Match header"
+//	@Failure	500			"Internal Server Error"
+//	@Router		/api/v1/quizzes/{id}/questions/{question} [put]
+//	@Security	JWT
+func (g *QuestionHandler) Put(c *gin.Context) {
+	quiz, version, ok := g.getQuiz(c)
+	if !ok {
+		return
+	}
+
+	question, ok := g.getQuestion(c, quiz)
+	if !ok {
+		return
+	}
+
+	var input *inputs.QuestionUpdate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logrus.WithError(err).Error("Validation error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	validPools := inputs.NewQuiz(quiz).HasValidPools()
+
+	question.Title = input.Title
+	question.Description = input.Description
+	question.DurationInSeconds = input.DurationInSeconds
+	question.Category = input.Category
+
+	if input.Order != nil {
+		if err := quiz.MoveQuestion(question.ID, *input.Order); err != nil {
+			logrus.WithError(err).Error("Failed to move question")
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+	}
+
+	if !keepsPools(c, quiz, validPools) {
+		return
+	}
+
+	if !g.save(c, quiz, version) {
+		return
+	}
+
+	c.JSON(http.StatusOK, question)
+}
+
+// PutOrder godoc
+//
+//	@Summary	Change the order of all questions of a quiz at once
+//	@Tags		Question
+//	@Accept		json
+//	@Produce	json
+//	@Param		id			path	string							true	"ID of the quiz"
+//	@Param		If-Match	header	string							true	"Version of the quiz"
+//	@Param		input		body	inputs.QuestionOrder			true	"Every question of the quiz"
+//	@Success	200			{array}	[]domain.MultipleChoiceQuestion	"The questions in their new order"
+//	@Failure	400			"Invalid uuid or not every question is in the order"
+//	@Failure	403			"You can only edit quizzes you may edit"
+//	@Failure	404			"Quiz not found"
+//	@Failure	412			"The quiz was changed in the meantime"
+//	@Failure	428			"No If-Match header"
+//	@Failure	500			"Internal Server Error"
+//	@Router		/api/v1/quizzes/{id}/questions/order [put]
+//	@Security	JWT
+func (g *QuestionHandler) PutOrder(c *gin.Context) {
+	quiz, version, ok := g.getQuiz(c)
+	if !ok {
+		return
+	}
+
+	var input *inputs.QuestionOrder
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logrus.WithError(err).Error("Validation error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	if err := quiz.ReorderQuestions(input.QuestionIDs); err != nil {
+		logrus.WithError(err).Error("Failed to reorder questions")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	if !g.save(c, quiz, version) {
+		return
+	}
+
+	c.JSON(http.StatusOK, quiz.MultipleChoiceQuestions)
+}
+
+// Delete godoc
+//
+//	@Summary	Remove a question from a quiz, the questions after it move up
+//	@Tags		Question
+//	@Accept		json
+//	@Produce	json
+//	@Param		id			path	string	true	"ID of the quiz"
+//	@Param		question	path	string	true	"ID of the question"
+//	@Param		If-Match	header	string	true	"Version of the quiz"
+//	@Success	204
+//	@Failure	400	"Invalid uuid or it's the last question"
+//	@Failure	403	"You can only edit quizzes you may edit"
+//	@Failure	404	"Quiz or question not found"
+//	@Failure	409	"A question pool would no longer have enough questions"
+//	@Failure	412	"The quiz was changed in the meantime"
+//	@Failure	428	"No If-Match header"
+//	@Failure	500	"Internal Server Error"
+//	@Router		/api/v1/quizzes/{id}/questions/{question} [delete]
+//	@Security	JWT
+func (g *QuestionHandler) Delete(c *gin.Context) {
+	quiz, version, ok := g.getQuiz(c)
+	if !ok {
+		return
+	}
+
+	question, ok := g.getQuestion(c, quiz)
+	if !ok {
+		return
+	}
+
+	validPools := inputs.NewQuiz(quiz).HasValidPools()
+
+	if err := quiz.RemoveQuestion(question.ID); err != nil {
+		logrus.WithError(err).Error("Failed to remove question")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	if !keepsPools(c, quiz, validPools) {
+		return
+	}
+
+	if !g.save(c, quiz, version) {
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// PostOption godoc
+//
+//	@Summary	Add an option to a question
+//	@Tags		Question
+//	@Accept		json
+//	@Produce	json
+//	@Param		id			path		string							true	"ID of the quiz"
+//	@Param		question	path		string							true	"ID of the question"
+//	@Param		If-Match	header		string							true	"Version of the quiz"
+//	@Param		input		body		inputs.QuestionOption			true	"The option, marking it as the answer replaces the current answer"
+//	@Success	200			{object}	domain.MultipleChoiceQuestion	"The question"
+//	@Failure	400			"Invalid uuid or the question has too many options"
+//	@Failure	403			"You can only edit quizzes you may edit"
+//	@Failure	404			"Quiz or question not found"
+//	@Failure	412			"The quiz was changed in the meantime"
+//	@Failure	428			"No If-Match header"
+//	@Failure	500			"Internal Server Error"
+//	@Router		/api/v1/quizzes/{id}/questions/{question}/options [post]
+//	@Security	JWT
+func (g *QuestionHandler) PostOption(c *gin.Context) {
+	quiz, version, ok := g.getQuiz(c)
+	if !ok {
+		return
+	}
+
+	question, ok := g.getQuestion(c, quiz)
+	if !ok {
+		return
+	}
+
+	var input *inputs.QuestionOption
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logrus.WithError(err).Error("Validation error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	option := &domain.QuestionOption{BaseObject: domain.BaseObject{ID: inputs.NewUuid()}, TextOption: input.TextOption}
+	if err := question.AddOption(option, input.Answer); err != nil {
+		logrus.WithError(err).Error("Failed to add option")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	if !g.save(c, quiz, version) {
+		return
+	}
+
+	c.JSON(http.StatusOK, question)
+}
+
+// PutOption godoc
+//
+//	@Summary	Change an option of a question
+//	@Tags		Question
+//	@Accept		json
+//	@Produce	json
+//	@Param		id			path		string							true	"ID of the quiz"
+//	@Param		question	path		string							true	"ID of the question"
+//	@Param		option		path		string							true	"ID of the option"
+//	@Param		If-Match	header		string							true	"Version of the quiz"
+//	@Param		input		body		inputs.QuestionOption			true	"The option, the answer can only change by marking another option as the answer"
+//	@Success	200			{object}	domain.MultipleChoiceQuestion	"The question"
+//	@Failure	400			"Invalid uuid or the question would be left without an answer"
+//	@Failure	403			"You can only edit quizzes you may edit"
+//	@Failure	404			"Quiz, question or option not found"
+//	@Failure	412			"The quiz was changed in the meantime"
+//	@Failure	428			"No If-Match header"
+//	@Failure	500			"Internal Server Error"
+//	@Router		/api/v1/quizzes/{id}/questions/{question}/options/{option} [put]
+//	@Security	JWT
+func (g *QuestionHandler) PutOption(c *gin.Context) {
+	quiz, version, ok := g.getQuiz(c)
+	if !ok {
+		return
+	}
+
+	question, ok := g.getQuestion(c, quiz)
+	if !ok {
+		return
+	}
+
+	option, ok := g.getOption(c, question)
+	if !ok {
+		return
+	}
+
+	var input *inputs.QuestionOption
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logrus.WithError(err).Error("Validation error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	if err := question.UpdateOption(option.ID, input.TextOption, input.Answer); err != nil {
+		logrus.WithError(err).Error("Failed to update option")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	if !g.save(c, quiz, version) {
+		return
+	}
+
+	c.JSON(http.StatusOK, question)
+}
+
+// DeleteOption godoc
+//
+//	@Summary	Remove an option from a question
+//	@Tags		Question
+//	@Accept		json
+//	@Produce	json
+//	@Param		id			path		string							true	"ID of the quiz"
+//	@Param		question	path		string							true	"ID of the question"
+//	@Param		option		path		string							true	"ID of the option"
+//	@Param		If-Match	header		string							true	"Version of the quiz"
+//	@Success	200			{object}	domain.MultipleChoiceQuestion	"The question"
+//	@Failure	400			"Invalid uuid, the option is the answer or the question would have too few options"
+//	@Failure	403			"You can only edit quizzes you may edit"
+//	@Failure	404			"Quiz, question or option not found"
+//	@Failure	412			"The quiz was changed in the meantime"
+//	@Failure	428			"No If-Match header"
+//	@Failure	500			"Internal Server Error"
+//	@Router		/api/v1/quizzes/{id}/questions/{question}/options/{option} [delete]
+//	@Security	JWT
+func (g *QuestionHandler) DeleteOption(c *gin.Context) {
+	quiz, version, ok := g.getQuiz(c)
+	if !ok {
+		return
+	}
+
+	question, ok := g.getQuestion(c, quiz)
+	if !ok {
+		return
+	}
+
+	option, ok := g.getOption(c, question)
+	if !ok {
+		return
+	}
+
+	if err := question.RemoveOption(option.ID); err != nil {
+		logrus.WithError(err).Error("Failed to remove option")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	if !g.save(c, quiz, version) {
+		return
+	}
+
+	c.JSON(http.StatusOK, question)
+}
+
+// getQuiz fetches the draft of the quiz, or the quiz itself if it has none, verifies the creator may
+// edit it and returns the version from If-Match
+func (g *QuestionHandler) getQuiz(c *gin.Context) (*domain.Quiz, uint, bool) {
+	quizID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		logrus.WithError(err).Error("UUID error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return nil, 0, false
+	}
+
+	quiz, err := g.QuizService.GetByID(quizID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get quiz")
+		c.AbortWithStatus(http.StatusNotFound)
+		return nil, 0, false
+	}
+
+	if !authorize(c, quiz, domain.PermissionEditQuiz) {
+		return nil, 0, false
+	}
+
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		c.AbortWithStatus(http.StatusPreconditionRequired)
+		return nil, 0, false
+	}
+
+	version, err := strconv.ParseUint(strings.Trim(strings.TrimPrefix(header, "W/"), `"`), 10, 0)
+	if err != nil {
+		logrus.WithError(err).Error("Invalid If-Match")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return nil, 0, false
+	}
+
+	draft, current, ok := g.getDraft(c, quiz)
+	if !ok {
+		return nil, 0, false
+	}
+
+	// Saves a pointless round trip to the database
+	if uint(version) != current {
+		c.AbortWithStatus(http.StatusPreconditionFailed)
+		return nil, 0, false
+	}
+
+	return draft, uint(version), true
+}
+
+// getDraft returns the quiz as it is in its draft and the version of the draft, a quiz without a draft
+// is returned as it is
+func (g *QuestionHandler) getDraft(c *gin.Context, quiz *domain.Quiz) (*domain.Quiz, uint, bool) {
+	draft, err := g.QuizDraftService.GetByQuiz(quiz.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return quiz, quiz.Version, true
+	}
+
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get draft")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return nil, 0, false
+	}
+
+	var input *inputs.Quiz
+	if err := json.Unmarshal(draft.Content, &input); err != nil || input == nil {
+		logrus.WithError(err).Error("Failed to parse draft")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return nil, 0, false
+	}
+
+	assignIDs(input)
+
+	result := input.ToDomain()
+	result.ID = quiz.ID
+	result.CreatorID = quiz.CreatorID
+
+	return result, draft.Version, true
+}
+
+func (g *QuestionHandler) getQuestion(c *gin.Context, quiz *domain.Quiz) (*domain.MultipleChoiceQuestion, bool) {
+	questionID, err := uuid.Parse(c.Param("question"))
+	if err != nil {
+		logrus.WithError(err).Error("UUID error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return nil, false
+	}
+
+	question, ok := quiz.Question(questionID)
+	if !ok {
+		c.AbortWithStatus(http.StatusNotFound)
+		return nil, false
+	}
+
+	// Changes to the bank question would otherwise overwrite this edit
+	question.Linked = false
+
+	return question, true
+}
+
+func (g *QuestionHandler) getOption(c *gin.Context, question *domain.MultipleChoiceQuestion) (*domain.QuestionOption, bool) {
+	optionID, err := uuid.Parse(c.Param("option"))
+	if err != nil {
+		logrus.WithError(err).Error("UUID error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return nil, false
+	}
+
+	option, ok := question.Option(optionID)
+	if !ok {
+		c.AbortWithStatus(http.StatusNotFound)
+		return nil, false
+	}
+
+	return option, true
+}
+
+// keepsPools makes sure a change doesn't take away questions the pools of the quiz draw from, pools that
+// were already invalid are left for publishing to report
+func keepsPools(c *gin.Context, quiz *domain.Quiz, validPools bool) bool {
+	if validPools && !inputs.NewQuiz(quiz).HasValidPools() {
+		logrus.Errorf("Change would leave the pools of quiz %s without enough questions", quiz.ID)
+		c.AbortWithStatus(http.StatusConflict)
+		return false
+	}
+
+	return true
+}
+
+// save stores the quiz as its draft and returns the new version of the draft in the ETag header
+func (g *QuestionHandler) save(c *gin.Context, quiz *domain.Quiz, version uint) bool {
+	content, _ := json.Marshal(inputs.NewQuiz(quiz))
+	draft := &domain.QuizDraft{
+		QuizID:      quiz.ID,
+		UpdatedByID: uuid.MustParse(c.GetString("user")),
+		Content:     content,
+	}
+
+	if err := g.QuizDraftService.Update(draft, version); err != nil {
+		if errors.Is(err, services.ErrVersionConflict) {
+			c.AbortWithStatus(http.StatusPreconditionFailed)
+			return false
+		}
+
+		logrus.WithError(err).Error("Failed to update draft")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return false
+	}
+
+	c.Header("ETag", fmt.Sprintf(`"%d"`, draft.Version))
+	return true
+}
@@ -0,0 +1,620 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"github.com/survivorbat/qq.maarten.dev/server/services"
+	"gorm.io/gorm"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func questionQuiz() *domain.Quiz {
+	return &domain.Quiz{
+		BaseObject: domain.BaseObject{ID: uuid.MustParse("788f12a9-51e8-4c87-9b0c-06bcc9f0691b")},
+		CreatorID:  uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"),
+		Version:    2,
+		MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{
+			{
+				BaseQuestion: domain.BaseQuestion{BaseObject: domain.BaseObject{ID: uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8")}, Title: "What is 2+2", Order: 0},
+				AnswerID:     uuid.MustParse("c7ff1cdf-72d3-4ea9-ae48-e1c1d61f8bc8"),
+				Options: []*domain.QuestionOption{
+					{BaseObject: domain.BaseObject{ID: uuid.MustParse("c7ff1cdf-72d3-4ea9-ae48-e1c1d61f8bc8")}, TextOption: "4"},
+					{BaseObject: domain.BaseObject{ID: uuid.MustParse("bd787fed-8a2e-40d3-abf6-6b90fa89f862")}, TextOption: "3"},
+					{BaseObject: domain.BaseObject{ID: uuid.MustParse("6c3ff3fa-d6b6-4ae0-a2e4-b8b9b1d4c5e1")}, TextOption: "5"},
+				},
+			},
+			{
+				BaseQuestion: domain.BaseQuestion{BaseObject: domain.BaseObject{ID: uuid.MustParse("f6d2fa67-c12d-4096-958b-18206fbf3538")}, Title: "What is 3+3", Order: 1},
+			},
+		},
+	}
+}
+
+// poolQuiz is questionQuiz with a pool that draws its first question
+func poolQuiz() *domain.Quiz {
+	quiz := questionQuiz()
+	quiz.MultipleChoiceQuestions[0].Category = "Math"
+	quiz.QuestionPools = []*domain.QuestionPool{{Category: "Math", DrawCount: 1}}
+
+	return quiz
+}
+
+// noDraft is a draft service for quizzes without a draft
+func noDraft() *MockQuizDraftService {
+	return &MockQuizDraftService{getByQuizReturnsError: gorm.ErrRecordNotFound}
+}
+
+// questionDraft is a draft of questionQuiz without its second question
+func questionDraft() *domain.QuizDraft {
+	quiz := questionQuiz()
+	quiz.MultipleChoiceQuestions = quiz.MultipleChoiceQuestions[:1]
+
+	content, _ := json.Marshal(inputs.NewQuiz(quiz))
+	return &domain.QuizDraft{QuizID: quiz.ID, Content: content, Version: 5}
+}
+
+// savedDraft returns the quiz that was saved in the draft
+func savedDraft(t *testing.T, draftService *MockQuizDraftService) *domain.Quiz {
+	t.Helper()
+
+	var input *inputs.Quiz
+	if !assert.NoError(t, json.Unmarshal(draftService.updateCalledWith.Content, &input)) {
+		t.FailNow()
+	}
+
+	return input.ToDomain()
+}
+
+func questionContext(writer *httptest.ResponseRecorder, method string, body any, ifMatch string, params ...gin.Param) *gin.Context {
+	inputJson, _ := json.Marshal(body)
+
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(method, "", io.NopCloser(bytes.NewBuffer(inputJson)))
+	context.Params = append([]gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}, params...)
+
+	if ifMatch != "" {
+		context.Request.Header.Set("If-Match", ifMatch)
+	}
+
+	return context
+}
+
+func TestQuestionHandler_Delete_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		quizService  *MockQuizService
+		draftService *MockQuizDraftService
+		ifMatch      string
+		question     string
+		expected     int
+	}{
+		"quiz not found": {
+			quizService:  &MockQuizService{getByIdReturnsError: assert.AnError},
+			draftService: noDraft(),
+			ifMatch:      `"2"`,
+			question:     "ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8",
+			expected:     http.StatusNotFound,
+		},
+		"not my quiz": {
+			quizService:  &MockQuizService{getByIdReturns: &domain.Quiz{CreatorID: uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b")}},
+			draftService: noDraft(),
+			ifMatch:      `"2"`,
+			question:     "ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8",
+			expected:     http.StatusForbidden,
+		},
+		"no if-match": {
+			quizService:  &MockQuizService{getByIdReturns: questionQuiz()},
+			draftService: noDraft(),
+			question:     "ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8",
+			expected:     http.StatusPreconditionRequired,
+		},
+		"invalid if-match": {
+			quizService:  &MockQuizService{getByIdReturns: questionQuiz()},
+			draftService: noDraft(),
+			ifMatch:      `"abc"`,
+			question:     "ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8",
+			expected:     http.StatusBadRequest,
+		},
+		"old version": {
+			quizService:  &MockQuizService{getByIdReturns: questionQuiz()},
+			draftService: noDraft(),
+			ifMatch:      `"1"`,
+			question:     "ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8",
+			expected:     http.StatusPreconditionFailed,
+		},
+		"invalid question uuid": {
+			quizService:  &MockQuizService{getByIdReturns: questionQuiz()},
+			draftService: noDraft(),
+			ifMatch:      `"2"`,
+			question:     "no",
+			expected:     http.StatusBadRequest,
+		},
+		"question not found": {
+			quizService:  &MockQuizService{getByIdReturns: questionQuiz()},
+			draftService: noDraft(),
+			ifMatch:      `"2"`,
+			question:     "a28ca8c6-63d9-45a2-b990-9b41e306f156",
+			expected:     http.StatusNotFound,
+		},
+		"pool needs the question": {
+			quizService:  &MockQuizService{getByIdReturns: poolQuiz()},
+			draftService: noDraft(),
+			ifMatch:      `"2"`,
+			question:     "ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8",
+			expected:     http.StatusConflict,
+		},
+		"changed in the meantime": {
+			quizService:  &MockQuizService{getByIdReturns: questionQuiz()},
+			draftService: &MockQuizDraftService{getByQuizReturnsError: gorm.ErrRecordNotFound, updateReturns: services.ErrVersionConflict},
+			ifMatch:      `"2"`,
+			question:     "ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8",
+			expected:     http.StatusPreconditionFailed,
+		},
+		"save error": {
+			quizService:  &MockQuizService{getByIdReturns: questionQuiz()},
+			draftService: &MockQuizDraftService{getByQuizReturnsError: gorm.ErrRecordNotFound, updateReturns: assert.AnError},
+			ifMatch:      `"2"`,
+			question:     "ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8",
+			expected:     http.StatusInternalServerError,
+		},
+		"draft changed since": {
+			quizService:  &MockQuizService{getByIdReturns: questionQuiz()},
+			draftService: &MockQuizDraftService{getByQuizReturns: questionDraft()},
+			ifMatch:      `"2"`,
+			question:     "ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8",
+			expected:     http.StatusPreconditionFailed,
+		},
+		"draft error": {
+			quizService:  &MockQuizService{getByIdReturns: questionQuiz()},
+			draftService: &MockQuizDraftService{getByQuizReturnsError: assert.AnError},
+			ifMatch:      `"2"`,
+			question:     "ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8",
+			expected:     http.StatusInternalServerError,
+		},
+		"success": {
+			quizService:  &MockQuizService{getByIdReturns: questionQuiz()},
+			draftService: noDraft(),
+			ifMatch:      `W/"2"`,
+			question:     "ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8",
+			expected:     http.StatusNoContent,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &QuestionHandler{QuizService: testData.quizService, QuizDraftService: testData.draftService}
+
+			writer := httptest.NewRecorder()
+			context := questionContext(writer, http.MethodDelete, nil, testData.ifMatch, gin.Param{Key: "question", Value: testData.question})
+
+			// Act
+			handler.Delete(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestQuestionHandler_Delete_RenumbersQuestions(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	draftService := noDraft()
+	handler := &QuestionHandler{QuizService: &MockQuizService{getByIdReturns: questionQuiz()}, QuizDraftService: draftService}
+
+	writer := httptest.NewRecorder()
+	context := questionContext(writer, http.MethodDelete, nil, `"2"`, gin.Param{Key: "question", Value: "ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"})
+
+	// Act
+	handler.Delete(context)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, writer.Code)
+	assert.Equal(t, `"3"`, writer.Header().Get("ETag"))
+	assert.Equal(t, uint(2), draftService.updateCalledWithVersion)
+
+	if assert.Len(t, savedDraft(t, draftService).MultipleChoiceQuestions, 1) {
+		assert.Equal(t, uint(0), savedDraft(t, draftService).MultipleChoiceQuestions[0].Order)
+	}
+}
+
+func TestQuestionHandler_Post_ChangesExistingDraft(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	draftService := &MockQuizDraftService{getByQuizReturns: questionDraft()}
+	handler := &QuestionHandler{QuizService: &MockQuizService{getByIdReturns: questionQuiz()}, QuizDraftService: draftService}
+
+	input := &inputs.MultipleChoiceQuestion{
+		Title:             "What is 4+4",
+		DurationInSeconds: 15,
+		Category:          "Math",
+		Order:             1,
+		Options:           []*inputs.QuestionOption{{TextOption: "8", Answer: true}, {TextOption: "7"}},
+	}
+
+	writer := httptest.NewRecorder()
+	context := questionContext(writer, http.MethodPost, input, `"5"`)
+
+	// Act
+	handler.Post(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, `"6"`, writer.Header().Get("ETag"))
+	assert.Equal(t, uint(5), draftService.updateCalledWithVersion)
+
+	questions := savedDraft(t, draftService).MultipleChoiceQuestions
+	if assert.Len(t, questions, 2) {
+		assert.Equal(t, uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"), questions[0].ID)
+		assert.Equal(t, uuid.MustParse("c7ff1cdf-72d3-4ea9-ae48-e1c1d61f8bc8"), questions[0].AnswerID)
+		assert.Equal(t, "What is 4+4", questions[1].Title)
+	}
+}
+
+func TestQuestionHandler_Post_AddsQuestion(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	draftService := noDraft()
+	handler := &QuestionHandler{QuizService: &MockQuizService{getByIdReturns: questionQuiz()}, QuizDraftService: draftService}
+
+	input := &inputs.MultipleChoiceQuestion{
+		Title:             "What is 4+4",
+		DurationInSeconds: 15,
+		Category:          "Math",
+		Order:             1,
+		Options:           []*inputs.QuestionOption{{TextOption: "8", Answer: true}, {TextOption: "7"}},
+	}
+
+	writer := httptest.NewRecorder()
+	context := questionContext(writer, http.MethodPost, input, `"2"`)
+
+	// Act
+	handler.Post(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	questions := savedDraft(t, draftService).MultipleChoiceQuestions
+	if assert.Len(t, questions, 3) {
+		assert.Equal(t, uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"), questions[0].ID)
+		assert.Equal(t, "What is 4+4", questions[1].Title)
+		assert.Equal(t, uuid.MustParse("f6d2fa67-c12d-4096-958b-18206fbf3538"), questions[2].ID)
+		assert.Equal(t, uint(2), questions[2].Order)
+	}
+}
+
+func TestQuestionHandler_Post_ReturnsValidationError(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	draftService := noDraft()
+	handler := &QuestionHandler{QuizService: &MockQuizService{getByIdReturns: questionQuiz()}, QuizDraftService: draftService}
+
+	writer := httptest.NewRecorder()
+	context := questionContext(writer, http.MethodPost, &inputs.MultipleChoiceQuestion{}, `"2"`)
+
+	// Act
+	handler.Post(context)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	assert.Nil(t, draftService.updateCalledWith)
+}
+
+func TestQuestionHandler_Put_UpdatesQuestionInPlace(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	draftService := noDraft()
+	handler := &QuestionHandler{QuizService: &MockQuizService{getByIdReturns: questionQuiz()}, QuizDraftService: draftService}
+
+	order := uint(1)
+	input := &inputs.QuestionUpdate{Title: "What is 2+3", DurationInSeconds: 20, Category: "Math", Order: &order}
+
+	writer := httptest.NewRecorder()
+	context := questionContext(writer, http.MethodPut, input, `"2"`, gin.Param{Key: "question", Value: "ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"})
+
+	// Act
+	handler.Put(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	question, _ := savedDraft(t, draftService).Question(uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"))
+	assert.Equal(t, "What is 2+3", question.Title)
+	assert.Equal(t, uint(1), question.Order)
+	assert.Equal(t, uuid.MustParse("c7ff1cdf-72d3-4ea9-ae48-e1c1d61f8bc8"), question.AnswerID)
+	assert.Len(t, question.Options, 3)
+}
+
+func TestQuestionHandler_Put_KeepsOrderIfLeftOut(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	draftService := noDraft()
+	handler := &QuestionHandler{QuizService: &MockQuizService{getByIdReturns: questionQuiz()}, QuizDraftService: draftService}
+
+	input := &inputs.QuestionUpdate{Title: "What is 3+4", DurationInSeconds: 20, Category: "Math"}
+
+	writer := httptest.NewRecorder()
+	context := questionContext(writer, http.MethodPut, input, `"2"`, gin.Param{Key: "question", Value: "f6d2fa67-c12d-4096-958b-18206fbf3538"})
+
+	// Act
+	handler.Put(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	question, _ := savedDraft(t, draftService).Question(uuid.MustParse("f6d2fa67-c12d-4096-958b-18206fbf3538"))
+	assert.Equal(t, "What is 3+4", question.Title)
+	assert.Equal(t, uint(1), question.Order)
+}
+
+func TestQuestionHandler_Put_ReturnsConflictOnEmptiedPool(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	draftService := noDraft()
+	handler := &QuestionHandler{QuizService: &MockQuizService{getByIdReturns: poolQuiz()}, QuizDraftService: draftService}
+
+	input := &inputs.QuestionUpdate{Title: "What is 2+2", DurationInSeconds: 20, Category: "Science"}
+
+	writer := httptest.NewRecorder()
+	context := questionContext(writer, http.MethodPut, input, `"2"`, gin.Param{Key: "question", Value: "ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"})
+
+	// Act
+	handler.Put(context)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, writer.Code)
+	assert.Nil(t, draftService.updateCalledWith)
+}
+
+func TestQuestionHandler_PutOrder_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		input    *inputs.QuestionOrder
+		expected int
+	}{
+		"empty": {
+			input:    &inputs.QuestionOrder{},
+			expected: http.StatusBadRequest,
+		},
+		"incomplete": {
+			input:    &inputs.QuestionOrder{QuestionIDs: []uuid.UUID{uuid.MustParse("f6d2fa67-c12d-4096-958b-18206fbf3538")}},
+			expected: http.StatusBadRequest,
+		},
+		"success": {
+			input: &inputs.QuestionOrder{QuestionIDs: []uuid.UUID{
+				uuid.MustParse("f6d2fa67-c12d-4096-958b-18206fbf3538"),
+				uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"),
+			}},
+			expected: http.StatusOK,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &QuestionHandler{QuizService: &MockQuizService{getByIdReturns: questionQuiz()}, QuizDraftService: noDraft()}
+
+			writer := httptest.NewRecorder()
+			context := questionContext(writer, http.MethodPut, testData.input, `"2"`)
+
+			// Act
+			handler.PutOrder(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestQuestionHandler_PostOption_KeepsOtherOptionIDs(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	draftService := noDraft()
+	handler := &QuestionHandler{QuizService: &MockQuizService{getByIdReturns: questionQuiz()}, QuizDraftService: draftService}
+
+	writer := httptest.NewRecorder()
+	context := questionContext(writer, http.MethodPost, &inputs.QuestionOption{TextOption: "6", Answer: true}, `"2"`, gin.Param{Key: "question", Value: "ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"})
+
+	// Act
+	handler.PostOption(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	question := savedDraft(t, draftService).MultipleChoiceQuestions[0]
+	if assert.Len(t, question.Options, 4) {
+		assert.Equal(t, uuid.MustParse("c7ff1cdf-72d3-4ea9-ae48-e1c1d61f8bc8"), question.Options[0].ID)
+		assert.Equal(t, question.Options[3].ID, question.AnswerID)
+	}
+}
+
+func TestQuestionHandler_PutOption_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		option   string
+		input    *inputs.QuestionOption
+		expected int
+	}{
+		"invalid uuid": {
+			option:   "no",
+			input:    &inputs.QuestionOption{TextOption: "abc"},
+			expected: http.StatusBadRequest,
+		},
+		"not found": {
+			option:   "a28ca8c6-63d9-45a2-b990-9b41e306f156",
+			input:    &inputs.QuestionOption{TextOption: "abc"},
+			expected: http.StatusNotFound,
+		},
+		"unmark answer": {
+			option:   "c7ff1cdf-72d3-4ea9-ae48-e1c1d61f8bc8",
+			input:    &inputs.QuestionOption{TextOption: "4"},
+			expected: http.StatusBadRequest,
+		},
+		"success": {
+			option:   "bd787fed-8a2e-40d3-abf6-6b90fa89f862",
+			input:    &inputs.QuestionOption{TextOption: "three"},
+			expected: http.StatusOK,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &QuestionHandler{QuizService: &MockQuizService{getByIdReturns: questionQuiz()}, QuizDraftService: noDraft()}
+
+			writer := httptest.NewRecorder()
+			context := questionContext(writer, http.MethodPut, testData.input, `"2"`,
+				gin.Param{Key: "question", Value: "ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"},
+				gin.Param{Key: "option", Value: testData.option},
+			)
+
+			// Act
+			handler.PutOption(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestQuestionHandler_DeleteOption_RemovesOption(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	draftService := noDraft()
+	handler := &QuestionHandler{QuizService: &MockQuizService{getByIdReturns: questionQuiz()}, QuizDraftService: draftService}
+
+	writer := httptest.NewRecorder()
+	context := questionContext(writer, http.MethodDelete, nil, `"2"`,
+		gin.Param{Key: "question", Value: "ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"},
+		gin.Param{Key: "option", Value: "6c3ff3fa-d6b6-4ae0-a2e4-b8b9b1d4c5e1"},
+	)
+
+	// Act
+	handler.DeleteOption(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Len(t, savedDraft(t, draftService).MultipleChoiceQuestions[0].Options, 2)
+}
+
+func TestQuestionHandler_PostFromBank_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		input               *inputs.BankReference
+		bankQuestionService *MockBankQuestionService
+		expected            int
+	}{
+		"no bank question": {
+			input:               &inputs.BankReference{},
+			bankQuestionService: &MockBankQuestionService{},
+			expected:            http.StatusBadRequest,
+		},
+		"bank question not found": {
+			input:               &inputs.BankReference{BankQuestionID: uuid.MustParse("c6d0d2c4-8b5f-4f3a-9f1d-6f5c3e2b1a09")},
+			bankQuestionService: &MockBankQuestionService{getByIdReturnsError: assert.AnError},
+			expected:            http.StatusNotFound,
+		},
+		"not my bank question": {
+			input:               &inputs.BankReference{BankQuestionID: uuid.MustParse("c6d0d2c4-8b5f-4f3a-9f1d-6f5c3e2b1a09")},
+			bankQuestionService: &MockBankQuestionService{getByIdReturns: &domain.BankQuestion{CreatorID: uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b")}},
+			expected:            http.StatusForbidden,
+		},
+		"copy past the end": {
+			input:               &inputs.BankReference{BankQuestionID: uuid.MustParse("c6d0d2c4-8b5f-4f3a-9f1d-6f5c3e2b1a09"), Order: 5},
+			bankQuestionService: &MockBankQuestionService{getByIdReturns: bankQuestion()},
+			expected:            http.StatusOK,
+		},
+		"success": {
+			input:               &inputs.BankReference{BankQuestionID: uuid.MustParse("c6d0d2c4-8b5f-4f3a-9f1d-6f5c3e2b1a09"), Linked: true},
+			bankQuestionService: &MockBankQuestionService{getByIdReturns: bankQuestion()},
+			expected:            http.StatusOK,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &QuestionHandler{QuizService: &MockQuizService{getByIdReturns: questionQuiz()}, QuizDraftService: noDraft(), BankQuestionService: testData.bankQuestionService}
+
+			writer := httptest.NewRecorder()
+			context := questionContext(writer, http.MethodPost, testData.input, `"2"`)
+
+			// Act
+			handler.PostFromBank(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestQuestionHandler_PostFromBank_LinksQuestion(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	draftService := noDraft()
+	handler := &QuestionHandler{QuizService: &MockQuizService{getByIdReturns: questionQuiz()}, QuizDraftService: draftService, BankQuestionService: &MockBankQuestionService{getByIdReturns: bankQuestion()}}
+
+	input := &inputs.BankReference{BankQuestionID: uuid.MustParse("c6d0d2c4-8b5f-4f3a-9f1d-6f5c3e2b1a09"), Linked: true}
+
+	writer := httptest.NewRecorder()
+	context := questionContext(writer, http.MethodPost, input, `"2"`)
+
+	// Act
+	handler.PostFromBank(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	questions := savedDraft(t, draftService).MultipleChoiceQuestions
+	if assert.Len(t, questions, 3) {
+		assert.Equal(t, "What is 4+4", questions[0].Title)
+		assert.Equal(t, uuid.MustParse("c6d0d2c4-8b5f-4f3a-9f1d-6f5c3e2b1a09"), *questions[0].BankQuestionID)
+		assert.True(t, questions[0].Linked)
+		assert.Equal(t, uint(1), questions[1].Order)
+	}
+}
+
+func TestQuestionHandler_Put_UnlinksBankQuestion(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quiz := questionQuiz()
+	bankQuestionID := uuid.MustParse("c6d0d2c4-8b5f-4f3a-9f1d-6f5c3e2b1a09")
+	quiz.MultipleChoiceQuestions[0].BankQuestionID = &bankQuestionID
+	quiz.MultipleChoiceQuestions[0].Linked = true
+
+	draftService := noDraft()
+	handler := &QuestionHandler{QuizService: &MockQuizService{getByIdReturns: quiz}, QuizDraftService: draftService}
+
+	input := &inputs.QuestionUpdate{Title: "What is 2+3", DurationInSeconds: 15, Category: "Math"}
+
+	writer := httptest.NewRecorder()
+	context := questionContext(writer, http.MethodPut, input, `"2"`, gin.Param{Key: "question", Value: "ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"})
+
+	// Act
+	handler.Put(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	question, _ := savedDraft(t, draftService).Question(uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"))
+	assert.False(t, question.Linked)
+	assert.Equal(t, &bankQuestionID, question.BankQuestionID)
+}
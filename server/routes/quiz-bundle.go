@@ -0,0 +1,114 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/formats"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"github.com/survivorbat/qq.maarten.dev/server/routes/outputs"
+	"net/http"
+	"strings"
+)
+
+// GetExport godoc
+//
+//	@Summary	Export a quiz as a bundle that can be imported again
+//	@Description	GIFT, Aiken and QTI only contain the questions, parts that can't be written are listed in Warning headers.
+//	@Tags		Quiz
+//	@Accept		json
+//	@Produce	json,x-yaml,plain,application/zip
+//	@Param		id		path		string				true	"ID of the quiz"
+//	@Param		format	query		string				false	"Either json (default), yaml, gift, aiken or qti"
+//	@Success	200		{object}	inputs.QuizBundle	"The bundle"
+//	@Failure	400		"Invalid uuid or format"
+//	@Failure	403		"You can only export quizzes you have access to"
+//	@Failure	404		"Quiz not found"
+//	@Router		/api/v1/quizzes/{id}/export [get]
+//	@Security	JWT
+func (g *QuizHandler) GetExport(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	documentFormat, isDocument := formats.ByName(format)
+	if format != "json" && format != "yaml" && !isDocument {
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	quiz, ok := g.getQuiz(c)
+	if !ok {
+		return
+	}
+
+	if !authorize(c, quiz, domain.PermissionViewQuiz) {
+		return
+	}
+
+	if isDocument {
+		writeDocumentExport(c, quiz, documentFormat)
+		return
+	}
+
+	bundle := outputs.NewQuizBundle(quiz)
+
+	if format == "yaml" {
+		c.YAML(http.StatusOK, bundle)
+		return
+	}
+
+	c.JSON(http.StatusOK, bundle)
+}
+
+// PostImport godoc
+//
+//	@Summary	Create a quiz from a bundle, send YAML with a YAML content type
+//	@Tags		Quiz
+//	@Accept		json,x-yaml
+//	@Produce	json
+//	@Param		input	body		inputs.QuizBundle			true	"The bundle"
+//	@Success	200		{object}	domain.Quiz					"The new quiz"
+//	@Failure	400		{array}		outputs.OutputBundleError	"Every field that is invalid, empty if the bundle could not be read"
+//	@Failure	500		"Internal Server Error"
+//	@Router		/api/v1/quizzes/import [post]
+//	@Security	JWT
+func (g *QuizHandler) PostImport(c *gin.Context) {
+	var bundle inputs.QuizBundle
+
+	bindErr := c.ShouldBindWith(&bundle, bundleBinding(c.ContentType()))
+	problems := outputs.NewBundleErrors(bindErr)
+	if bindErr != nil && problems == nil {
+		logrus.WithError(bindErr).Error("Failed to read bundle")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	input := bundle.ToQuiz()
+	problems = append(problems, outputs.NewBundleErrors(binding.Validator.ValidateStruct(input))...)
+
+	if len(problems) > 0 {
+		logrus.Infof("Bundle has %d invalid fields", len(problems))
+		c.AbortWithStatusJSON(http.StatusBadRequest, problems)
+		return
+	}
+
+	quiz := input.ToDomain()
+	quiz.CreatorID = uuid.MustParse(c.GetString("user"))
+
+	if err := g.QuizService.CreateOrUpdate(quiz); err != nil {
+		logrus.WithError(err).Error("Failed to import quiz")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, quiz)
+}
+
+// bundleBinding reads YAML if the content type says so, JSON otherwise
+func bundleBinding(contentType string) binding.BindingBody {
+	if strings.Contains(contentType, "yaml") {
+		return binding.YAML
+	}
+
+	return binding.JSON
+}
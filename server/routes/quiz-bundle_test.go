@@ -0,0 +1,234 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/routes/outputs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func bundleQuiz() *domain.Quiz {
+	return &domain.Quiz{
+		BaseObject:         domain.BaseObject{ID: uuid.MustParse("788f12a9-51e8-4c87-9b0c-06bcc9f0691b")},
+		CreatorID:          uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"),
+		Name:               "Capitals",
+		Description:        "Where is what",
+		Language:           "en",
+		MaxConcurrentGames: 1,
+		Tags:               domain.NewQuizTags([]string{"geography"}),
+		MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{
+			{
+				BaseQuestion: domain.BaseQuestion{Title: "Capital of Spain", DurationInSeconds: 20, Category: "Europe", Order: 1},
+				AnswerID:     uuid.MustParse("bd787fed-8a2e-40d3-abf6-6b90fa89f862"),
+				Options: []*domain.QuestionOption{
+					{BaseObject: domain.BaseObject{ID: uuid.MustParse("c7ff1cdf-72d3-4ea9-ae48-e1c1d61f8bc8")}, TextOption: "Barcelona"},
+					{BaseObject: domain.BaseObject{ID: uuid.MustParse("bd787fed-8a2e-40d3-abf6-6b90fa89f862")}, TextOption: "Madrid"},
+				},
+			},
+			{
+				BaseQuestion: domain.BaseQuestion{Title: "Capital of France", DurationInSeconds: 15, Category: "Europe", Order: 0},
+				AnswerID:     uuid.MustParse("6c3ff3fa-d6b6-4ae0-a2e4-b8b9b1d4c5e1"),
+				Options: []*domain.QuestionOption{
+					{BaseObject: domain.BaseObject{ID: uuid.MustParse("6c3ff3fa-d6b6-4ae0-a2e4-b8b9b1d4c5e1")}, TextOption: "Paris"},
+					{BaseObject: domain.BaseObject{ID: uuid.MustParse("a28ca8c6-63d9-45a2-b990-9b41e306f156")}, TextOption: "Lyon"},
+				},
+			},
+		},
+	}
+}
+
+func bundleContext(writer *httptest.ResponseRecorder, method string, url string, contentType string, body []byte) *gin.Context {
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(method, url, bytes.NewBuffer(body))
+	context.Request.Header.Set("Content-Type", contentType)
+	context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+	return context
+}
+
+func TestQuizHandler_GetExport_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		format      string
+		quizService *MockQuizService
+		expected    int
+	}{
+		"unknown format": {
+			format:      "xml",
+			quizService: &MockQuizService{getByIdReturns: bundleQuiz()},
+			expected:    http.StatusBadRequest,
+		},
+		"quiz not found": {
+			format:      "json",
+			quizService: &MockQuizService{getByIdReturnsError: assert.AnError},
+			expected:    http.StatusNotFound,
+		},
+		"no access": {
+			format:      "json",
+			quizService: &MockQuizService{getByIdReturns: &domain.Quiz{CreatorID: uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b")}},
+			expected:    http.StatusForbidden,
+		},
+		"json": {
+			format:      "json",
+			quizService: &MockQuizService{getByIdReturns: bundleQuiz()},
+			expected:    http.StatusOK,
+		},
+		"yaml": {
+			format:      "yaml",
+			quizService: &MockQuizService{getByIdReturns: bundleQuiz()},
+			expected:    http.StatusOK,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &QuizHandler{QuizService: testData.quizService}
+
+			writer := httptest.NewRecorder()
+			context := bundleContext(writer, http.MethodGet, "/api/v1/quizzes/788f12a9-51e8-4c87-9b0c-06bcc9f0691b/export?format="+testData.format, "", nil)
+
+			// Act
+			handler.GetExport(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestQuizHandler_GetExport_ReturnsBundle(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	handler := &QuizHandler{QuizService: &MockQuizService{getByIdReturns: bundleQuiz()}}
+
+	writer := httptest.NewRecorder()
+	context := bundleContext(writer, http.MethodGet, "/api/v1/quizzes/788f12a9-51e8-4c87-9b0c-06bcc9f0691b/export", "", nil)
+
+	// Act
+	handler.GetExport(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	expected := `{
+		"version": 1,
+		"name": "Capitals",
+		"description": "Where is what",
+		"language": "en",
+		"tags": ["geography"],
+		"maxConcurrentGames": 1,
+		"questions": [
+			{
+				"type": "mc",
+				"title": "Capital of France",
+				"durationInSeconds": 15,
+				"category": "Europe",
+				"options": [{"textOption": "Paris", "answer": true}, {"textOption": "Lyon"}]
+			},
+			{
+				"type": "mc",
+				"title": "Capital of Spain",
+				"durationInSeconds": 20,
+				"category": "Europe",
+				"options": [{"textOption": "Barcelona"}, {"textOption": "Madrid", "answer": true}]
+			}
+		]
+	}`
+	assert.JSONEq(t, expected, writer.Body.String())
+}
+
+func TestQuizHandler_PostImport_ImportsExportedYAML(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	exportWriter := httptest.NewRecorder()
+	exportHandler := &QuizHandler{QuizService: &MockQuizService{getByIdReturns: bundleQuiz()}}
+	exportHandler.GetExport(bundleContext(exportWriter, http.MethodGet, "/api/v1/quizzes/788f12a9-51e8-4c87-9b0c-06bcc9f0691b/export?format=yaml", "", nil))
+
+	quizService := &MockQuizService{}
+	handler := &QuizHandler{QuizService: quizService}
+
+	writer := httptest.NewRecorder()
+	context := bundleContext(writer, http.MethodPost, "", "application/x-yaml", exportWriter.Body.Bytes())
+
+	// Act
+	handler.PostImport(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	result := quizService.createOrUpdateCalledWith
+	assert.Equal(t, "Capitals", result.Name)
+	assert.Equal(t, uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), result.CreatorID)
+	assert.Equal(t, []string{"geography"}, result.TagNames())
+
+	if assert.Len(t, result.MultipleChoiceQuestions, 2) {
+		question := result.MultipleChoiceQuestions[1]
+		assert.Equal(t, "Capital of Spain", question.Title)
+		assert.Equal(t, uint(1), question.Order)
+
+		answer, _ := question.Option(question.AnswerID)
+		assert.Equal(t, "Madrid", answer.TextOption)
+	}
+}
+
+func TestQuizHandler_PostImport_ReturnsInvalidFields(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	handler := &QuizHandler{QuizService: &MockQuizService{}}
+
+	body := `{
+		"version": 2,
+		"name": "Capitals",
+		"questions": [
+			{"type": "essay", "title": "Capital of France", "durationInSeconds": 15, "category": "Europe", "options": [{"textOption": "Paris", "answer": true}, {"textOption": "Lyon"}]},
+			{"title": "Hi", "durationInSeconds": 15, "category": "Europe", "options": [{"textOption": "Madrid", "answer": true}, {"textOption": "Barcelona"}]}
+		]
+	}`
+
+	writer := httptest.NewRecorder()
+	context := bundleContext(writer, http.MethodPost, "", "application/json", []byte(body))
+
+	// Act
+	handler.PostImport(context)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+
+	var result []*outputs.OutputBundleError
+	_ = json.Unmarshal(writer.Body.Bytes(), &result)
+
+	expected := []*outputs.OutputBundleError{
+		{Field: "version", Rule: "eq", Param: "1"},
+		{Field: "questions[0].type", Rule: "oneof", Param: "mc"},
+		{Field: "questions[1].title", Rule: "min", Param: "3"},
+	}
+	assert.Equal(t, expected, result)
+}
+
+func TestQuizHandler_PostImport_ReturnsBadRequestOnUnreadableBundle(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quizService := &MockQuizService{}
+	handler := &QuizHandler{QuizService: quizService}
+
+	writer := httptest.NewRecorder()
+	context := bundleContext(writer, http.MethodPost, "", "application/x-yaml", []byte("name: [unclosed"))
+
+	// Act
+	handler.PostImport(context)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	assert.Empty(t, writer.Body.String())
+	assert.Nil(t, quizService.createOrUpdateCalledWith)
+}
@@ -0,0 +1,110 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/routes/outputs"
+	"net/http"
+)
+
+// PostClone godoc
+//
+//	@Summary	Copy a quiz you have access to, for example to adapt it for another class
+//	@Tags		Quiz
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		string		true	"ID of the quiz"
+//	@Success	200	{object}	domain.Quiz	"The copy, it stays in the organization of the quiz if you're a member"
+//	@Failure	400	"Invalid uuid"
+//	@Failure	403	"You can only copy quizzes you have access to"
+//	@Failure	404	"Quiz not found"
+//	@Failure	500	"Internal Server Error"
+//	@Router		/api/v1/quizzes/{id}/clone [post]
+//	@Security	JWT
+func (g *QuizHandler) PostClone(c *gin.Context) {
+	quiz, ok := g.getQuiz(c)
+	if !ok {
+		return
+	}
+
+	if !authorize(c, quiz, domain.PermissionViewQuiz) {
+		return
+	}
+
+	authID := uuid.MustParse(c.GetString("user"))
+	clone := quiz.Copy(authID)
+
+	if quiz.Organization != nil {
+		if _, ok := quiz.Organization.RoleOf(authID); ok {
+			clone.OrganizationID = quiz.OrganizationID
+		}
+	}
+
+	logrus.Infof("Cloning %s", quiz.ID)
+	if err := g.QuizService.CreateOrUpdate(clone); err != nil {
+		logrus.WithError(err).Error("Failed to clone")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, clone)
+}
+
+// PostFork godoc
+//
+//	@Summary	Copy a public quiz into your own account, the copy refers back to it
+//	@Tags		Quiz
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		string		true	"ID of the quiz"
+//	@Success	200	{object}	domain.Quiz	"The fork"
+//	@Failure	400	"Invalid uuid"
+//	@Failure	403	"The quiz is not public and you don't have access to it"
+//	@Failure	404	"Quiz not found"
+//	@Failure	500	"Internal Server Error"
+//	@Router		/api/v1/quizzes/{id}/fork [post]
+//	@Security	JWT
+func (g *QuizHandler) PostFork(c *gin.Context) {
+	quiz, ok := g.getQuiz(c)
+	if !ok {
+		return
+	}
+
+	if !quiz.Public && !authorize(c, quiz, domain.PermissionViewQuiz) {
+		return
+	}
+
+	fork := quiz.Fork(uuid.MustParse(c.GetString("user")))
+
+	logrus.Infof("Forking %s", quiz.ID)
+	if err := g.QuizService.CreateOrUpdate(fork); err != nil {
+		logrus.WithError(err).Error("Failed to fork")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, fork)
+}
+
+// GetTemplates godoc
+//
+//	@Summary	Fetch the template gallery, public templates and the ones you have access to
+//	@Tags		Quiz
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{array}	[]outputs.OutputQuizSummary	"The templates, fork one to use it"
+//	@Failure	500	"Internal Server Error"
+//	@Router		/api/v1/templates [get]
+//	@Security	JWT
+func (g *QuizHandler) GetTemplates(c *gin.Context) {
+	templates, err := g.QuizService.GetTemplates(uuid.MustParse(c.GetString("user")))
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get templates")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, outputs.NewQuizSummaries(templates))
+}
@@ -0,0 +1,246 @@
+package routes
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/routes/outputs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestQuizHandler_PostClone_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		quizService *MockQuizService
+		expected    int
+	}{
+		"quiz not found": {
+			quizService: &MockQuizService{getByIdReturnsError: assert.AnError},
+			expected:    http.StatusNotFound,
+		},
+		"no access": {
+			quizService: &MockQuizService{getByIdReturns: &domain.Quiz{CreatorID: uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b"), Public: true}},
+			expected:    http.StatusForbidden,
+		},
+		"save error": {
+			quizService: &MockQuizService{getByIdReturns: draftQuiz(), createOrUpdateReturns: assert.AnError},
+			expected:    http.StatusInternalServerError,
+		},
+		"success": {
+			quizService: &MockQuizService{getByIdReturns: draftQuiz()},
+			expected:    http.StatusOK,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &QuizHandler{QuizService: testData.quizService}
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+			context.Request, _ = http.NewRequest(http.MethodPost, "", nil)
+			context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+			// Act
+			handler.PostClone(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestQuizHandler_PostClone_KeepsOrganizationOfMembers(t *testing.T) {
+	t.Parallel()
+	organizationID := uuid.MustParse("bd787fed-8a2e-40d3-abf6-6b90fa89f862")
+
+	tests := map[string]struct {
+		members  []*domain.Member
+		expected *uuid.UUID
+	}{
+		"member": {
+			members:  []*domain.Member{{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), Role: domain.OrganizationMember}},
+			expected: &organizationID,
+		},
+		"collaborator outside the organization": {
+			members:  []*domain.Member{},
+			expected: nil,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			quiz := &domain.Quiz{
+				BaseObject:     domain.BaseObject{ID: uuid.MustParse("788f12a9-51e8-4c87-9b0c-06bcc9f0691b")},
+				CreatorID:      uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b"),
+				OrganizationID: &organizationID,
+				Organization:   &domain.Organization{BaseObject: domain.BaseObject{ID: organizationID}, Members: testData.members},
+				Collaborators:  []*domain.Collaborator{{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), Role: domain.RoleViewer}},
+			}
+
+			quizService := &MockQuizService{getByIdReturns: quiz}
+			handler := &QuizHandler{QuizService: quizService}
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+			context.Request, _ = http.NewRequest(http.MethodPost, "", nil)
+			context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+			// Act
+			handler.PostClone(context)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, writer.Code)
+			assert.Equal(t, uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), quizService.createOrUpdateCalledWith.CreatorID)
+			assert.Equal(t, testData.expected, quizService.createOrUpdateCalledWith.OrganizationID)
+		})
+	}
+}
+
+func TestQuizHandler_PostFork_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		quiz     *domain.Quiz
+		expected int
+	}{
+		"private quiz of someone else": {
+			quiz:     &domain.Quiz{CreatorID: uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b")},
+			expected: http.StatusForbidden,
+		},
+		"public quiz of someone else": {
+			quiz:     &domain.Quiz{CreatorID: uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b"), Public: true},
+			expected: http.StatusOK,
+		},
+		"private quiz of mine": {
+			quiz:     draftQuiz(),
+			expected: http.StatusOK,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &QuizHandler{QuizService: &MockQuizService{getByIdReturns: testData.quiz}}
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+			context.Request, _ = http.NewRequest(http.MethodPost, "", nil)
+			context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+			// Act
+			handler.PostFork(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestQuizHandler_PostFork_RefersToSource(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quiz := &domain.Quiz{
+		BaseObject: domain.BaseObject{ID: uuid.MustParse("788f12a9-51e8-4c87-9b0c-06bcc9f0691b")},
+		CreatorID:  uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b"),
+		Public:     true,
+	}
+
+	quizService := &MockQuizService{getByIdReturns: quiz}
+	handler := &QuizHandler{QuizService: quizService}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodPost, "", nil)
+	context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+	// Act
+	handler.PostFork(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	fork := quizService.createOrUpdateCalledWith
+	assert.Equal(t, uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), fork.CreatorID)
+	assert.Equal(t, &quiz.ID, fork.ForkedFromID)
+	assert.False(t, fork.Public)
+}
+
+func TestQuizHandler_GetTemplates_ReturnsSummaries(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	templates := []*domain.Quiz{
+		{
+			BaseObject: domain.BaseObject{ID: uuid.MustParse("788f12a9-51e8-4c87-9b0c-06bcc9f0691b")},
+			Name:       "Template",
+			Creator:    &domain.Creator{Nickname: "Adorable Beaver"},
+			MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{
+				{BaseQuestion: domain.BaseQuestion{Category: "Math"}},
+				{BaseQuestion: domain.BaseQuestion{Category: "Geography"}},
+				{BaseQuestion: domain.BaseQuestion{Category: "Math"}},
+			},
+		},
+	}
+
+	quizService := &MockQuizService{getTemplatesReturns: templates}
+	handler := &QuizHandler{QuizService: quizService}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodGet, "", nil)
+
+	// Act
+	handler.GetTemplates(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), quizService.getTemplatesCalledWith)
+
+	var result []*outputs.OutputQuizSummary
+	_ = json.Unmarshal(writer.Body.Bytes(), &result)
+
+	expected := []*outputs.OutputQuizSummary{
+		{
+			ID:              uuid.MustParse("788f12a9-51e8-4c87-9b0c-06bcc9f0691b"),
+			Name:            "Template",
+			CreatorNickname: "Adorable Beaver",
+			QuestionCount:   3,
+			Categories:      []string{"Geography", "Math"},
+			Tags:            []string{},
+		},
+	}
+	assert.Equal(t, expected, result)
+}
+
+func TestQuizHandler_GetTemplates_ReturnsErrorOnFetchError(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	handler := &QuizHandler{QuizService: &MockQuizService{getTemplatesReturnsError: assert.AnError}}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodGet, "", nil)
+
+	// Act
+	handler.GetTemplates(context)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, writer.Code)
+}
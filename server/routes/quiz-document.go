@@ -0,0 +1,115 @@
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/formats"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"github.com/survivorbat/qq.maarten.dev/server/routes/outputs"
+	"io"
+	"net/http"
+)
+
+// importDocument creates a quiz from the document in the request's body, which may be at most maxSize bytes
+func (g *QuizHandler) importDocument(c *gin.Context, input *inputs.DocumentImport, format formats.Format, maxSize int64) {
+	content, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxSize))
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			c.AbortWithStatus(http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		logrus.WithError(err).Error("Failed to read document")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	quiz, preview, valid := readDocumentQuiz(input, format, content)
+
+	if input.DryRun {
+		c.JSON(http.StatusOK, preview)
+		return
+	}
+
+	// Skipping everything leaves nothing to import
+	if !valid || len(preview.Questions) == 0 || (!input.SkipUnsupported && hasSkippedProblems(preview.Problems)) {
+		logrus.Infof("Document has %d problems", len(preview.Problems))
+		c.AbortWithStatusJSON(http.StatusBadRequest, preview)
+		return
+	}
+
+	result := quiz.ToDomain()
+	result.CreatorID = uuid.MustParse(c.GetString("user"))
+
+	if err := g.QuizService.CreateOrUpdate(result); err != nil {
+		logrus.WithError(err).Error("Failed to import document")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// readDocumentQuiz validates every question on its own and then the quiz made of the valid questions,
+// it returns false if the quiz as a whole is invalid
+func readDocumentQuiz(input *inputs.DocumentImport, format formats.Format, content []byte) (*inputs.Quiz, *outputs.OutputDocumentImport, bool) {
+	preview := &outputs.OutputDocumentImport{Questions: []*inputs.MultipleChoiceQuestion{}, Problems: []*formats.Problem{}}
+
+	questions, problems := format.Parse(content)
+	preview.Problems = append(preview.Problems, problems...)
+
+	for _, question := range questions {
+		question.Question.DurationInSeconds = input.DurationInSeconds
+		if question.Question.Category == "" {
+			question.Question.Category = input.Category
+		}
+
+		questionProblems := outputs.NewDocumentProblems(question, binding.Validator.ValidateStruct(question.Question))
+		if len(questionProblems) > 0 {
+			preview.Problems = append(preview.Problems, questionProblems...)
+			continue
+		}
+
+		question.Question.Order = uint(len(preview.Questions))
+		preview.Questions = append(preview.Questions, question.Question)
+	}
+
+	quiz := &inputs.Quiz{Name: input.Name, MultipleChoiceQuestions: preview.Questions}
+	quizProblems := outputs.NewDocumentProblems(nil, binding.Validator.ValidateStruct(quiz))
+	preview.Problems = append(preview.Problems, quizProblems...)
+
+	return quiz, preview, len(quizProblems) == 0
+}
+
+// hasSkippedProblems returns true if any of the problems left out a whole question
+func hasSkippedProblems(problems []*formats.Problem) bool {
+	for _, problem := range problems {
+		if problem.Skipped {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeDocumentExport writes the quiz's questions in another tool's format, problems are added as warnings
+func writeDocumentExport(c *gin.Context, quiz *domain.Quiz, format formats.Format) {
+	content, problems := format.Write(quiz.MultipleChoiceQuestions)
+
+	for _, problem := range problems {
+		reason := problem.Reason
+		if problem.Question != "" {
+			reason = problem.Question + ": " + reason
+		}
+
+		c.Writer.Header().Add("Warning", fmt.Sprintf("199 - %q", reason))
+	}
+
+	c.Data(http.StatusOK, format.ContentType(), content)
+}
@@ -0,0 +1,256 @@
+package routes
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"github.com/survivorbat/qq.maarten.dev/server/routes/outputs"
+	"io"
+	"net/http"
+)
+
+// GetDraft godoc
+//
+//	@Summary	Fetch the unpublished changes to this quiz
+//	@Tags		Quiz
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		string					true	"ID of the quiz"
+//	@Success	200	{object}	outputs.OutputQuizDraft	"The draft"
+//	@Failure	400	"Invalid uuid"
+//	@Failure	403	"You can only view drafts of quizzes you have access to"
+//	@Failure	404	"Quiz not found or it has no draft"
+//	@Router		/api/v1/quizzes/{id}/draft [get]
+//	@Security	JWT
+func (g *QuizHandler) GetDraft(c *gin.Context) {
+	quiz, ok := g.getQuiz(c)
+	if !ok {
+		return
+	}
+
+	if !authorize(c, quiz, domain.PermissionViewQuiz) {
+		return
+	}
+
+	draft, err := g.QuizDraftService.GetByQuiz(quiz.ID)
+	if err != nil {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	_, publishable := parseDraft(draft)
+	c.JSON(http.StatusOK, outputs.NewQuizDraft(draft, publishable))
+}
+
+// PutDraft godoc
+//
+//	@Summary	Save unpublished changes to this quiz, the quiz does not have to be complete yet
+//	@Tags		Quiz
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		string					true	"ID of the quiz"
+//	@Param		input	body		inputs.Quiz				true	"Your quiz, validation only happens when publishing"
+//	@Success	200		{object}	outputs.OutputQuizDraft	"The draft"
+//	@Failure	400		"Invalid uuid or malformed JSON"
+//	@Failure	403		"You can only edit drafts of quizzes you may edit"
+//	@Failure	404		"Quiz not found"
+//	@Failure	500		"Internal Server Error"
+//	@Router		/api/v1/quizzes/{id}/draft [put]
+//	@Security	JWT
+func (g *QuizHandler) PutDraft(c *gin.Context) {
+	quiz, ok := g.getQuiz(c)
+	if !ok {
+		return
+	}
+
+	if !authorize(c, quiz, domain.PermissionEditQuiz) {
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to read body")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	// Only the shape is checked, incomplete quizzes are fine
+	var input *inputs.Quiz
+	if err := json.Unmarshal(body, &input); err != nil || input == nil {
+		logrus.WithError(err).Error("Failed to parse draft")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	g.saveDraft(c, quiz, input)
+}
+
+// DeleteDraft godoc
+//
+//	@Summary	Throw away the unpublished changes to this quiz
+//	@Tags		Quiz
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path	string	true	"ID of the quiz"
+//	@Success	204
+//	@Failure	400	"Invalid uuid"
+//	@Failure	403	"You can only edit drafts of quizzes you may edit"
+//	@Failure	404	"Quiz not found"
+//	@Failure	500	"Internal Server Error"
+//	@Router		/api/v1/quizzes/{id}/draft [delete]
+//	@Security	JWT
+func (g *QuizHandler) DeleteDraft(c *gin.Context) {
+	quiz, ok := g.getQuiz(c)
+	if !ok {
+		return
+	}
+
+	if !authorize(c, quiz, domain.PermissionEditQuiz) {
+		return
+	}
+
+	if err := g.QuizDraftService.Delete(quiz.ID); err != nil {
+		logrus.WithError(err).Error("Failed to delete draft")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// PostPublish godoc
+//
+//	@Summary	Validate the draft and make it the version that new games use
+//	@Tags		Quiz
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		string		true	"ID of the quiz"
+//	@Success	200	{object}	domain.Quiz	"The published quiz"
+//	@Failure	400	"Invalid uuid or the draft is not valid"
+//	@Failure	403	"You can only publish quizzes you may edit and link bank questions you have access to"
+//	@Failure	404	"Quiz, draft or a newly linked bank question not found"
+//	@Failure	412	"The quiz changed after the draft was started, throw the draft away and start again"
+//	@Failure	500	"Internal Server Error"
+//	@Router		/api/v1/quizzes/{id}/draft/publish [post]
+//	@Security	JWT
+func (g *QuizHandler) PostPublish(c *gin.Context) {
+	quiz, ok := g.getQuiz(c)
+	if !ok {
+		return
+	}
+
+	if !authorize(c, quiz, domain.PermissionEditQuiz) {
+		return
+	}
+
+	draft, err := g.QuizDraftService.GetByQuiz(quiz.ID)
+	if err != nil {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	// Publishing would undo what happened to the quiz since, like a restored revision
+	if draft.QuizVersion != quiz.Version {
+		logrus.Errorf("Draft of quiz %s started from version %d, the quiz is at %d", quiz.ID, draft.QuizVersion, quiz.Version)
+		c.AbortWithStatus(http.StatusPreconditionFailed)
+		return
+	}
+
+	input, publishable := parseDraft(draft)
+	if !publishable {
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	update, ok := g.newUpdate(c, quiz.ID, quiz, input)
+	if !ok {
+		return
+	}
+
+	if err := g.QuizService.CreateOrUpdate(update); err != nil {
+		logrus.WithError(err).Error("Failed to publish")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	if err := g.QuizDraftService.Delete(quiz.ID); err != nil {
+		logrus.WithError(err).Error("Failed to delete published draft")
+	}
+
+	c.JSON(http.StatusOK, update)
+}
+
+// saveDraft replaces the draft of the quiz with the input and returns it
+func (g *QuizHandler) saveDraft(c *gin.Context, quiz *domain.Quiz, input *inputs.Quiz) {
+	assignIDs(input)
+
+	content, _ := json.Marshal(input)
+	draft := &domain.QuizDraft{
+		QuizID:      quiz.ID,
+		UpdatedByID: uuid.MustParse(c.GetString("user")),
+		Content:     content,
+		Version:     quiz.Version,
+	}
+
+	if err := g.QuizDraftService.Save(draft); err != nil {
+		logrus.WithError(err).Error("Failed to save draft")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	_, publishable := parseDraft(draft)
+	c.JSON(http.StatusOK, outputs.NewQuizDraft(draft, publishable))
+}
+
+// assignIDs gives questions and options without an ID one, so they can be changed one by one
+func assignIDs(input *inputs.Quiz) {
+	for _, question := range input.MultipleChoiceQuestions {
+		if question.ID == uuid.Nil {
+			question.ID = inputs.NewUuid()
+		}
+
+		for _, option := range question.Options {
+			if option.ID == uuid.Nil {
+				option.ID = inputs.NewUuid()
+			}
+		}
+	}
+}
+
+func (g *QuizHandler) getQuiz(c *gin.Context) (*domain.Quiz, bool) {
+	quizID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		logrus.WithError(err).Error("UUID error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return nil, false
+	}
+
+	quiz, err := g.QuizService.GetByID(quizID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get quiz")
+		c.AbortWithStatus(http.StatusNotFound)
+		return nil, false
+	}
+
+	return quiz, true
+}
+
+// parseDraft returns the quiz of the draft and whether it passes the same validation as a published quiz
+func parseDraft(draft *domain.QuizDraft) (*inputs.Quiz, bool) {
+	var result *inputs.Quiz
+	if err := json.Unmarshal(draft.Content, &result); err != nil || result == nil {
+		logrus.WithError(err).Error("Failed to parse draft")
+		return nil, false
+	}
+
+	if err := binding.Validator.ValidateStruct(result); err != nil {
+		logrus.WithError(err).Info("Draft is not valid")
+		return result, false
+	}
+
+	return result, true
+}
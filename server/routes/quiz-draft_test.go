@@ -0,0 +1,421 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"github.com/survivorbat/qq.maarten.dev/server/routes/outputs"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func draftQuiz() *domain.Quiz {
+	return &domain.Quiz{
+		BaseObject: domain.BaseObject{ID: uuid.MustParse("788f12a9-51e8-4c87-9b0c-06bcc9f0691b")},
+		CreatorID:  uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"),
+	}
+}
+
+func publishableDraft() *domain.QuizDraft {
+	content, _ := json.Marshal(&inputs.Quiz{
+		Name: "My Awesome Quiz",
+		MultipleChoiceQuestions: []*inputs.MultipleChoiceQuestion{
+			{
+				Title:             "What is 2+2",
+				DurationInSeconds: 15,
+				Category:          "Math",
+				Options:           []*inputs.QuestionOption{{TextOption: "4", Answer: true}, {TextOption: "3"}},
+			},
+		},
+	})
+
+	return &domain.QuizDraft{QuizID: uuid.MustParse("788f12a9-51e8-4c87-9b0c-06bcc9f0691b"), Content: content}
+}
+
+func TestQuizHandler_GetDraft_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		id           string
+		quizService  *MockQuizService
+		draftService *MockQuizDraftService
+		expected     int
+	}{
+		"invalid uuid": {
+			id:           "no",
+			quizService:  &MockQuizService{},
+			draftService: &MockQuizDraftService{},
+			expected:     http.StatusBadRequest,
+		},
+		"quiz not found": {
+			id:           "788f12a9-51e8-4c87-9b0c-06bcc9f0691b",
+			quizService:  &MockQuizService{getByIdReturnsError: assert.AnError},
+			draftService: &MockQuizDraftService{},
+			expected:     http.StatusNotFound,
+		},
+		"not my quiz": {
+			id:           "788f12a9-51e8-4c87-9b0c-06bcc9f0691b",
+			quizService:  &MockQuizService{getByIdReturns: &domain.Quiz{CreatorID: uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b")}},
+			draftService: &MockQuizDraftService{},
+			expected:     http.StatusForbidden,
+		},
+		"no draft": {
+			id:           "788f12a9-51e8-4c87-9b0c-06bcc9f0691b",
+			quizService:  &MockQuizService{getByIdReturns: draftQuiz()},
+			draftService: &MockQuizDraftService{getByQuizReturnsError: assert.AnError},
+			expected:     http.StatusNotFound,
+		},
+		"success": {
+			id:           "788f12a9-51e8-4c87-9b0c-06bcc9f0691b",
+			quizService:  &MockQuizService{getByIdReturns: draftQuiz()},
+			draftService: &MockQuizDraftService{getByQuizReturns: publishableDraft()},
+			expected:     http.StatusOK,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &QuizHandler{QuizService: testData.quizService, QuizDraftService: testData.draftService}
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+			context.Request, _ = http.NewRequest(http.MethodGet, "", nil)
+			context.Params = []gin.Param{{Key: "id", Value: testData.id}}
+
+			// Act
+			handler.GetDraft(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestQuizHandler_PutDraft_SavesIncompleteQuiz(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	draftService := &MockQuizDraftService{}
+	handler := &QuizHandler{QuizService: &MockQuizService{getByIdReturns: draftQuiz()}, QuizDraftService: draftService}
+
+	inputJson, _ := json.Marshal(&inputs.Quiz{Name: "My"})
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodPut, "", io.NopCloser(bytes.NewBuffer(inputJson)))
+	context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+	// Act
+	handler.PutDraft(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	assert.Equal(t, uuid.MustParse("788f12a9-51e8-4c87-9b0c-06bcc9f0691b"), draftService.saveCalledWith.QuizID)
+	assert.Equal(t, uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), draftService.saveCalledWith.UpdatedByID)
+
+	var result *outputs.OutputQuizDraft
+	_ = json.Unmarshal(writer.Body.Bytes(), &result)
+	assert.False(t, result.Publishable)
+	assert.JSONEq(t, string(draftService.saveCalledWith.Content), string(result.Quiz))
+}
+
+func TestQuizHandler_PutDraft_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		quizService  *MockQuizService
+		draftService *MockQuizDraftService
+		body         string
+		expected     int
+	}{
+		"not my quiz": {
+			quizService:  &MockQuizService{getByIdReturns: &domain.Quiz{CreatorID: uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b")}},
+			draftService: &MockQuizDraftService{},
+			body:         `{}`,
+			expected:     http.StatusForbidden,
+		},
+		"malformed json": {
+			quizService:  &MockQuizService{getByIdReturns: draftQuiz()},
+			draftService: &MockQuizDraftService{},
+			body:         `{"name": 3`,
+			expected:     http.StatusBadRequest,
+		},
+		"wrong types": {
+			quizService:  &MockQuizService{getByIdReturns: draftQuiz()},
+			draftService: &MockQuizDraftService{},
+			body:         `{"name": 3}`,
+			expected:     http.StatusBadRequest,
+		},
+		"save error": {
+			quizService:  &MockQuizService{getByIdReturns: draftQuiz()},
+			draftService: &MockQuizDraftService{saveReturns: assert.AnError},
+			body:         `{}`,
+			expected:     http.StatusInternalServerError,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &QuizHandler{QuizService: testData.quizService, QuizDraftService: testData.draftService}
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+			context.Request, _ = http.NewRequest(http.MethodPut, "", io.NopCloser(bytes.NewBufferString(testData.body)))
+			context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+			// Act
+			handler.PutDraft(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestQuizHandler_DeleteDraft_DeletesDraft(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	draftService := &MockQuizDraftService{}
+	handler := &QuizHandler{QuizService: &MockQuizService{getByIdReturns: draftQuiz()}, QuizDraftService: draftService}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodDelete, "", nil)
+	context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+	// Act
+	handler.DeleteDraft(context)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, writer.Code)
+	assert.Equal(t, uuid.MustParse("788f12a9-51e8-4c87-9b0c-06bcc9f0691b"), draftService.deleteCalledWith)
+}
+
+func TestQuizHandler_PostPublish_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		quizService  *MockQuizService
+		draftService *MockQuizDraftService
+		expected     int
+	}{
+		"not my quiz": {
+			quizService:  &MockQuizService{getByIdReturns: &domain.Quiz{CreatorID: uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b")}},
+			draftService: &MockQuizDraftService{},
+			expected:     http.StatusForbidden,
+		},
+		"no draft": {
+			quizService:  &MockQuizService{getByIdReturns: draftQuiz()},
+			draftService: &MockQuizDraftService{getByQuizReturnsError: assert.AnError},
+			expected:     http.StatusNotFound,
+		},
+		"invalid draft": {
+			quizService:  &MockQuizService{getByIdReturns: draftQuiz()},
+			draftService: &MockQuizDraftService{getByQuizReturns: &domain.QuizDraft{Content: []byte(`{"name": "My"}`)}},
+			expected:     http.StatusBadRequest,
+		},
+		"quiz changed since the draft": {
+			quizService:  &MockQuizService{getByIdReturns: &domain.Quiz{BaseObject: draftQuiz().BaseObject, CreatorID: draftQuiz().CreatorID, Version: 3}},
+			draftService: &MockQuizDraftService{getByQuizReturns: &domain.QuizDraft{Content: publishableDraft().Content, QuizVersion: 2, Version: 5}},
+			expected:     http.StatusPreconditionFailed,
+		},
+		"save error": {
+			quizService:  &MockQuizService{getByIdReturns: draftQuiz(), createOrUpdateReturns: assert.AnError},
+			draftService: &MockQuizDraftService{getByQuizReturns: publishableDraft()},
+			expected:     http.StatusInternalServerError,
+		},
+		"success": {
+			quizService:  &MockQuizService{getByIdReturns: draftQuiz()},
+			draftService: &MockQuizDraftService{getByQuizReturns: publishableDraft()},
+			expected:     http.StatusOK,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &QuizHandler{QuizService: testData.quizService, QuizDraftService: testData.draftService}
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+			context.Request, _ = http.NewRequest(http.MethodPost, "", nil)
+			context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+			// Act
+			handler.PostPublish(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestQuizHandler_PostPublish_SavesDraftAndDeletesIt(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quizService := &MockQuizService{getByIdReturns: draftQuiz()}
+	draftService := &MockQuizDraftService{getByQuizReturns: publishableDraft()}
+	handler := &QuizHandler{QuizService: quizService, QuizDraftService: draftService}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodPost, "", nil)
+	context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+	// Act
+	handler.PostPublish(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	assert.Equal(t, uuid.MustParse("788f12a9-51e8-4c87-9b0c-06bcc9f0691b"), quizService.createOrUpdateCalledWith.ID)
+	assert.Equal(t, "My Awesome Quiz", quizService.createOrUpdateCalledWith.Name)
+	assert.Len(t, quizService.createOrUpdateCalledWith.MultipleChoiceQuestions, 1)
+	assert.Equal(t, uuid.MustParse("788f12a9-51e8-4c87-9b0c-06bcc9f0691b"), draftService.deleteCalledWith)
+}
+
+func TestQuizHandler_PostPublish_KeepsOwnerOnEditorPublish(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quiz := draftQuiz()
+	quiz.CreatorID = uuid.MustParse("e3274bf0-b154-4d37-a6fd-878d530025f9")
+	quiz.Collaborators = []*domain.Collaborator{{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), Role: domain.RoleEditor}}
+
+	quizService := &MockQuizService{getByIdReturns: quiz}
+	handler := &QuizHandler{QuizService: quizService, QuizDraftService: &MockQuizDraftService{getByQuizReturns: publishableDraft()}}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodPost, "", nil)
+	context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+	// Act
+	handler.PostPublish(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, uuid.MustParse("e3274bf0-b154-4d37-a6fd-878d530025f9"), quizService.createOrUpdateCalledWith.CreatorID)
+}
+
+func TestQuizHandler_PostPublish_OnlyKeepsIDsOfTheQuiz(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quiz := draftQuiz()
+	quiz.MultipleChoiceQuestions = []*domain.MultipleChoiceQuestion{
+		{
+			BaseQuestion: domain.BaseQuestion{BaseObject: domain.BaseObject{ID: uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8")}},
+			Options:      []*domain.QuestionOption{{BaseObject: domain.BaseObject{ID: uuid.MustParse("c7ff1cdf-72d3-4ea9-ae48-e1c1d61f8bc8")}}},
+		},
+	}
+
+	options := []*inputs.QuestionOption{
+		{ID: uuid.MustParse("c7ff1cdf-72d3-4ea9-ae48-e1c1d61f8bc8"), TextOption: "4"},
+		{ID: uuid.MustParse("bd787fed-8a2e-40d3-abf6-6b90fa89f862"), TextOption: "3", Answer: true},
+	}
+	content, _ := json.Marshal(&inputs.Quiz{
+		Name: "My Awesome Quiz",
+		MultipleChoiceQuestions: []*inputs.MultipleChoiceQuestion{
+			{ID: uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"), Title: "What is 2+2", DurationInSeconds: 15, Category: "Math", Options: options},
+			{ID: uuid.MustParse("f6d2fa67-c12d-4096-958b-18206fbf3538"), Title: "What is 3+3", DurationInSeconds: 15, Category: "Math", Options: options, Order: 1},
+		},
+	})
+
+	quizService := &MockQuizService{getByIdReturns: quiz}
+	draft := &domain.QuizDraft{QuizID: quiz.ID, Content: content}
+	handler := &QuizHandler{QuizService: quizService, QuizDraftService: &MockQuizDraftService{getByQuizReturns: draft}}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodPost, "", nil)
+	context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+	// Act
+	handler.PostPublish(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	questions := quizService.createOrUpdateCalledWith.MultipleChoiceQuestions
+	if assert.Len(t, questions, 2) {
+		assert.Equal(t, uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"), questions[0].ID)
+		assert.Equal(t, uuid.MustParse("c7ff1cdf-72d3-4ea9-ae48-e1c1d61f8bc8"), questions[0].Options[0].ID)
+		assert.NotEqual(t, uuid.MustParse("bd787fed-8a2e-40d3-abf6-6b90fa89f862"), questions[0].Options[1].ID)
+		assert.Equal(t, questions[0].Options[1].ID, questions[0].AnswerID)
+
+		assert.Equal(t, uuid.Nil, questions[1].ID)
+		assert.NotEqual(t, uuid.MustParse("c7ff1cdf-72d3-4ea9-ae48-e1c1d61f8bc8"), questions[1].Options[0].ID)
+	}
+}
+
+func TestQuizHandler_PostPublish_KeepsExistingBankLinks(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	bankQuestionID := uuid.MustParse("c6d0d2c4-8b5f-4f3a-9f1d-6f5c3e2b1a09")
+
+	quiz := draftQuiz()
+	quiz.MultipleChoiceQuestions = []*domain.MultipleChoiceQuestion{
+		{
+			BaseQuestion:   domain.BaseQuestion{BaseObject: domain.BaseObject{ID: uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8")}},
+			BankQuestionID: &bankQuestionID,
+			Linked:         true,
+		},
+	}
+
+	content, _ := json.Marshal(&inputs.Quiz{
+		Name: "My Awesome Quiz",
+		MultipleChoiceQuestions: []*inputs.MultipleChoiceQuestion{
+			{
+				ID:                uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"),
+				BankQuestionID:    &bankQuestionID,
+				Linked:            true,
+				Title:             "What is 4+4",
+				DurationInSeconds: 15,
+				Category:          "Math",
+				Options:           []*inputs.QuestionOption{{TextOption: "8", Answer: true}, {TextOption: "7"}},
+			},
+		},
+	})
+
+	quizService := &MockQuizService{getByIdReturns: quiz}
+	handler := &QuizHandler{
+		QuizService:         quizService,
+		QuizDraftService:    &MockQuizDraftService{getByQuizReturns: &domain.QuizDraft{QuizID: quiz.ID, Content: content}},
+		BankQuestionService: &MockBankQuestionService{getByIdReturnsError: assert.AnError},
+	}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodPost, "", nil)
+	context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+	// Act
+	handler.PostPublish(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	question := quizService.createOrUpdateCalledWith.MultipleChoiceQuestions[0]
+	assert.Equal(t, uuid.MustParse("ae2a9fd4-f861-4c99-a9bd-2bf49e1b1cd8"), question.ID)
+	assert.Equal(t, &bankQuestionID, question.BankQuestionID)
+	assert.True(t, question.Linked)
+}
@@ -0,0 +1,51 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/formats"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"net/http"
+)
+
+// maxPackageSize is the largest QTI package that may be imported
+const maxPackageSize = 10 << 20
+
+// PostQTIImport godoc
+//
+//	@Summary	Create a quiz from an IMS QTI 2.1 content package
+//	@Description	Items with a single choice interaction are imported in the order of the manifest, other
+//	@Description	interactions and parts like images and feedback are reported as problems. Problems that are
+//	@Description	skipped leave out the whole item, the import fails on those unless skipUnsupported is set.
+//	@Tags		Quiz
+//	@Accept		application/zip
+//	@Produce	json
+//	@Param		name				query		string							true	"Name of the quiz"
+//	@Param		category			query		string							false	"Category of the questions, defaults to General"
+//	@Param		durationInSeconds	query		int								false	"Duration of every question, defaults to 20"
+//	@Param		skipUnsupported		query		bool							false	"Import the other items if some are skipped"
+//	@Param		dryRun				query		bool							false	"Only preview the import"
+//	@Param		input				body		string							true	"The package"
+//	@Success	200					{object}	domain.Quiz						"The new quiz, or an outputs.OutputDocumentImport for a dry run"
+//	@Failure	400					{object}	outputs.OutputDocumentImport	"The items that were skipped"
+//	@Failure	413					"The package is too large"
+//	@Failure	415					"Not a zip file"
+//	@Failure	500					"Internal Server Error"
+//	@Router		/api/v1/quizzes/import/qti [post]
+//	@Security	JWT
+func (g *QuizHandler) PostQTIImport(c *gin.Context) {
+	var input inputs.DocumentImport
+	if err := c.ShouldBindQuery(&input); err != nil {
+		logrus.WithError(err).Error("Validation error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	format := new(formats.QTI)
+	if c.ContentType() != format.ContentType() {
+		c.AbortWithStatus(http.StatusUnsupportedMediaType)
+		return
+	}
+
+	g.importDocument(c, &input, format, maxPackageSize)
+}
@@ -0,0 +1,200 @@
+package routes
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/formats"
+	"github.com/survivorbat/qq.maarten.dev/server/routes/outputs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// qtiPackage exports the bundle quiz as QTI and adds an item with a text entry interaction
+func qtiPackage(t *testing.T) []byte {
+	t.Helper()
+
+	exportWriter := httptest.NewRecorder()
+	exportHandler := &QuizHandler{QuizService: &MockQuizService{getByIdReturns: bundleQuiz()}}
+	exportHandler.GetExport(bundleContext(exportWriter, http.MethodGet, "/api/v1/quizzes/788f12a9-51e8-4c87-9b0c-06bcc9f0691b/export?format=qti", "", nil))
+
+	exported := exportWriter.Body.Bytes()
+	archive, err := zip.NewReader(bytes.NewReader(exported), int64(len(exported)))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	var buffer bytes.Buffer
+	result := zip.NewWriter(&buffer)
+
+	for _, file := range archive.File {
+		if file.Name == "imsmanifest.xml" {
+			continue
+		}
+
+		if err := result.Copy(file); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	files := map[string]string{
+		"imsmanifest.xml": `<manifest xmlns="http://www.imsglobal.org/xsd/imscp_v1p1" identifier="manifest"><resources>
+			<resource identifier="item-1" type="imsqti_item_xmlv2p1" href="items/item-1.xml"/>
+			<resource identifier="item-2" type="imsqti_item_xmlv2p1" href="items/item-2.xml"/>
+			<resource identifier="essay" type="imsqti_item_xmlv2p1" href="items/essay.xml"/>
+		</resources></manifest>`,
+		"items/essay.xml": `<assessmentItem xmlns="http://www.imsglobal.org/xsd/imsqti_v2p1" identifier="essay" title="Essay">
+			<itemBody><extendedTextInteraction responseIdentifier="RESPONSE"/></itemBody>
+		</assessmentItem>`,
+	}
+
+	for name, content := range files {
+		writer, _ := result.Create(name)
+		_, _ = writer.Write([]byte(content))
+	}
+
+	_ = result.Close()
+
+	return buffer.Bytes()
+}
+
+func TestQuizHandler_PostQTIImport_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		query       string
+		contentType string
+		body        []byte
+		quizService *MockQuizService
+		expected    int
+	}{
+		"no name": {
+			contentType: "application/zip",
+			body:        qtiPackage(t),
+			quizService: &MockQuizService{},
+			expected:    http.StatusBadRequest,
+		},
+		"not a zip file": {
+			query:       "name=Capitals",
+			contentType: "application/xml",
+			body:        qtiPackage(t),
+			quizService: &MockQuizService{},
+			expected:    http.StatusUnsupportedMediaType,
+		},
+		"invalid zip file": {
+			query:       "name=Capitals&skipUnsupported=true",
+			contentType: "application/zip",
+			body:        []byte("<manifest/>"),
+			quizService: &MockQuizService{},
+			expected:    http.StatusBadRequest,
+		},
+		"unsupported items": {
+			query:       "name=Capitals",
+			contentType: "application/zip",
+			body:        qtiPackage(t),
+			quizService: &MockQuizService{},
+			expected:    http.StatusBadRequest,
+		},
+		"too large": {
+			query:       "name=Capitals",
+			contentType: "application/zip",
+			body:        make([]byte, maxPackageSize+1),
+			quizService: &MockQuizService{},
+			expected:    http.StatusRequestEntityTooLarge,
+		},
+		"error on create": {
+			query:       "name=Capitals&skipUnsupported=true",
+			contentType: "application/zip",
+			body:        qtiPackage(t),
+			quizService: &MockQuizService{createOrUpdateReturns: assert.AnError},
+			expected:    http.StatusInternalServerError,
+		},
+		"dry run": {
+			query:       "name=Capitals&dryRun=true",
+			contentType: "application/zip",
+			body:        qtiPackage(t),
+			quizService: &MockQuizService{},
+			expected:    http.StatusOK,
+		},
+		"skip unsupported": {
+			query:       "name=Capitals&skipUnsupported=true",
+			contentType: "application/zip",
+			body:        qtiPackage(t),
+			quizService: &MockQuizService{},
+			expected:    http.StatusOK,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &QuizHandler{QuizService: testData.quizService}
+
+			writer := httptest.NewRecorder()
+			context := bundleContext(writer, http.MethodPost, "/api/v1/quizzes/import/qti?"+testData.query, testData.contentType, testData.body)
+
+			// Act
+			handler.PostQTIImport(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestQuizHandler_PostQTIImport_ReturnsPreview(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	handler := &QuizHandler{QuizService: &MockQuizService{}}
+
+	writer := httptest.NewRecorder()
+	context := bundleContext(writer, http.MethodPost, "/api/v1/quizzes/import/qti?name=Capitals&dryRun=true", "application/zip", qtiPackage(t))
+
+	// Act
+	handler.PostQTIImport(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	var result outputs.OutputDocumentImport
+	_ = json.Unmarshal(writer.Body.Bytes(), &result)
+
+	assert.Len(t, result.Questions, 2)
+
+	expected := []*formats.Problem{{File: "items/essay.xml", Reason: "essay questions are not supported", Skipped: true}}
+	assert.Equal(t, expected, result.Problems)
+}
+
+func TestQuizHandler_PostQTIImport_ImportsExportedPackage(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quizService := &MockQuizService{}
+	handler := &QuizHandler{QuizService: quizService}
+
+	writer := httptest.NewRecorder()
+	context := bundleContext(writer, http.MethodPost, "/api/v1/quizzes/import/qti?name=Capitals&category=Europe&skipUnsupported=true", "application/zip", qtiPackage(t))
+
+	// Act
+	handler.PostQTIImport(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	result := quizService.createOrUpdateCalledWith
+	assert.Equal(t, "Capitals", result.Name)
+	assert.Equal(t, uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), result.CreatorID)
+
+	if assert.Len(t, result.MultipleChoiceQuestions, 2) {
+		question := result.MultipleChoiceQuestions[1]
+		assert.Equal(t, "Capital of Spain", question.Title)
+		assert.Equal(t, "Europe", question.Category)
+		assert.Equal(t, uint(1), question.Order)
+
+		answer, _ := question.Option(question.AnswerID)
+		assert.Equal(t, "Madrid", answer.TextOption)
+	}
+}
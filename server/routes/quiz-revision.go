@@ -0,0 +1,172 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/routes/outputs"
+	"github.com/survivorbat/qq.maarten.dev/server/services"
+	"net/http"
+	"strconv"
+)
+
+type QuizRevisionHandler struct {
+	QuizService         services.QuizService
+	QuizRevisionService services.QuizRevisionService
+	QuizDraftService    services.QuizDraftService
+}
+
+// Get godoc
+//
+//	@Summary	Fetch the revisions of this quiz, the latest first
+//	@Tags		Quiz
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path	string							true	"ID of the quiz"
+//	@Success	200	{array}	[]outputs.OutputQuizRevision	"The revisions"
+//	@Failure	400	"Invalid uuid"
+//	@Failure	403	"You can only view revisions of quizzes you have access to"
+//	@Failure	404	"Not found"
+//	@Failure	500	"Internal Server Error"
+//	@Router		/api/v1/quizzes/{id}/revisions [get]
+//	@Security	JWT
+func (g *QuizRevisionHandler) Get(c *gin.Context) {
+	quiz, ok := g.getQuiz(c)
+	if !ok {
+		return
+	}
+
+	if !authorize(c, quiz, domain.PermissionViewQuiz) {
+		return
+	}
+
+	revisions, err := g.QuizRevisionService.GetByQuiz(quiz.ID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get by quiz")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, outputs.NewQuizRevisions(revisions))
+}
+
+// GetDiff godoc
+//
+//	@Summary	Compare two revisions of this quiz
+//	@Tags		Quiz
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		string				true	"ID of the quiz"
+//	@Param		from	query		int					true	"Number of the old revision"
+//	@Param		to		query		int					true	"Number of the new revision"
+//	@Success	200		{object}	domain.RevisionDiff	"What changed"
+//	@Failure	400		"Invalid uuid or revision number"
+//	@Failure	403		"You can only view revisions of quizzes you have access to"
+//	@Failure	404		"Not found"
+//	@Router		/api/v1/quizzes/{id}/revisions/diff [get]
+//	@Security	JWT
+func (g *QuizRevisionHandler) GetDiff(c *gin.Context) {
+	quiz, ok := g.getQuiz(c)
+	if !ok {
+		return
+	}
+
+	if !authorize(c, quiz, domain.PermissionViewQuiz) {
+		return
+	}
+
+	from, ok := g.getRevision(c, quiz.ID, c.Query("from"))
+	if !ok {
+		return
+	}
+
+	to, ok := g.getRevision(c, quiz.ID, c.Query("to"))
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, from.Diff(to))
+}
+
+// PostRestore godoc
+//
+//	@Summary	Publish an old revision of this quiz as the newest revision
+//	@Description	The restored revision is published right away, the draft of the quiz is thrown away
+//	@Tags		Quiz
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		string		true	"ID of the quiz"
+//	@Param		number	path		int			true	"Number of the revision"
+//	@Success	200		{object}	domain.Quiz	"The restored quiz"
+//	@Failure	400		"Invalid uuid or revision number"
+//	@Failure	403		"You can only restore quizzes you may edit"
+//	@Failure	404		"Not found"
+//	@Failure	500		"Internal Server Error"
+//	@Router		/api/v1/quizzes/{id}/revisions/{number}/restore [post]
+//	@Security	JWT
+func (g *QuizRevisionHandler) PostRestore(c *gin.Context) {
+	quiz, ok := g.getQuiz(c)
+	if !ok {
+		return
+	}
+
+	if !authorize(c, quiz, domain.PermissionEditQuiz) {
+		return
+	}
+
+	revision, ok := g.getRevision(c, quiz.ID, c.Param("number"))
+	if !ok {
+		return
+	}
+
+	restored := revision.Restore(quiz)
+	if err := g.QuizService.CreateOrUpdate(restored); err != nil {
+		logrus.WithError(err).Error("Failed to restore")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	// The draft was based on what was just replaced, it can no longer be published anyway
+	if err := g.QuizDraftService.Delete(quiz.ID); err != nil {
+		logrus.WithError(err).Error("Failed to delete draft of restored quiz")
+	}
+
+	c.JSON(http.StatusOK, restored)
+}
+
+func (g *QuizRevisionHandler) getQuiz(c *gin.Context) (*domain.Quiz, bool) {
+	quizID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		logrus.WithError(err).Error("UUID error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return nil, false
+	}
+
+	quiz, err := g.QuizService.GetByID(quizID)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get quiz")
+		c.AbortWithStatus(http.StatusNotFound)
+		return nil, false
+	}
+
+	return quiz, true
+}
+
+func (g *QuizRevisionHandler) getRevision(c *gin.Context, quizID uuid.UUID, number string) (*domain.QuizRevision, bool) {
+	parsed, err := strconv.ParseUint(number, 10, 32)
+	if err != nil {
+		logrus.WithError(err).Error("Invalid revision number")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return nil, false
+	}
+
+	revision, err := g.QuizRevisionService.GetByNumber(quizID, uint(parsed))
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get revision")
+		c.AbortWithStatus(http.StatusNotFound)
+		return nil, false
+	}
+
+	return revision, true
+}
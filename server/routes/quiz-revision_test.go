@@ -0,0 +1,281 @@
+package routes
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/routes/outputs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func revisionQuiz() *domain.Quiz {
+	return &domain.Quiz{
+		BaseObject: domain.BaseObject{ID: uuid.MustParse("788f12a9-51e8-4c87-9b0c-06bcc9f0691b")},
+		CreatorID:  uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"),
+	}
+}
+
+func revisions() map[uint]*domain.QuizRevision {
+	return map[uint]*domain.QuizRevision{
+		1: {Number: 1, Snapshot: &domain.QuizSnapshot{Name: "old"}},
+		2: {Number: 2, Snapshot: &domain.QuizSnapshot{Name: "new"}},
+	}
+}
+
+func TestQuizRevisionHandler_Get_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		id              string
+		quizService     *MockQuizService
+		revisionService *MockQuizRevisionService
+		expected        int
+	}{
+		"invalid uuid": {
+			id:              "no",
+			quizService:     &MockQuizService{},
+			revisionService: &MockQuizRevisionService{},
+			expected:        http.StatusBadRequest,
+		},
+		"quiz not found": {
+			id:              "788f12a9-51e8-4c87-9b0c-06bcc9f0691b",
+			quizService:     &MockQuizService{getByIdReturnsError: assert.AnError},
+			revisionService: &MockQuizRevisionService{},
+			expected:        http.StatusNotFound,
+		},
+		"not my quiz": {
+			id:              "788f12a9-51e8-4c87-9b0c-06bcc9f0691b",
+			quizService:     &MockQuizService{getByIdReturns: &domain.Quiz{CreatorID: uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b")}},
+			revisionService: &MockQuizRevisionService{},
+			expected:        http.StatusForbidden,
+		},
+		"service error": {
+			id:              "788f12a9-51e8-4c87-9b0c-06bcc9f0691b",
+			quizService:     &MockQuizService{getByIdReturns: revisionQuiz()},
+			revisionService: &MockQuizRevisionService{getByQuizReturnsError: assert.AnError},
+			expected:        http.StatusInternalServerError,
+		},
+		"success": {
+			id:              "788f12a9-51e8-4c87-9b0c-06bcc9f0691b",
+			quizService:     &MockQuizService{getByIdReturns: revisionQuiz()},
+			revisionService: &MockQuizRevisionService{getByQuizReturns: []*domain.QuizRevision{revisions()[2], revisions()[1]}},
+			expected:        http.StatusOK,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &QuizRevisionHandler{QuizService: testData.quizService, QuizRevisionService: testData.revisionService, QuizDraftService: &MockQuizDraftService{}}
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+			context.Request, _ = http.NewRequest(http.MethodGet, "", nil)
+			context.Params = []gin.Param{{Key: "id", Value: testData.id}}
+
+			// Act
+			handler.Get(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestQuizRevisionHandler_Get_ReturnsRevisions(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	revisionService := &MockQuizRevisionService{getByQuizReturns: []*domain.QuizRevision{revisions()[2], revisions()[1]}}
+	handler := &QuizRevisionHandler{QuizService: &MockQuizService{getByIdReturns: revisionQuiz()}, QuizRevisionService: revisionService}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodGet, "", nil)
+	context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+	// Act
+	handler.Get(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, revisionQuiz().ID, revisionService.getByQuizCalledWith)
+
+	var result []*outputs.OutputQuizRevision
+	_ = json.Unmarshal(writer.Body.Bytes(), &result)
+
+	if assert.Len(t, result, 2) {
+		assert.Equal(t, uint(2), result[0].Number)
+		assert.Equal(t, "new", result[0].Name)
+	}
+}
+
+func TestQuizRevisionHandler_GetDiff_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		query           string
+		revisionService *MockQuizRevisionService
+		expected        int
+	}{
+		"invalid from": {
+			query:           "?from=abc&to=2",
+			revisionService: &MockQuizRevisionService{getByNumberReturns: revisions()},
+			expected:        http.StatusBadRequest,
+		},
+		"missing to": {
+			query:           "?from=1",
+			revisionService: &MockQuizRevisionService{getByNumberReturns: revisions()},
+			expected:        http.StatusBadRequest,
+		},
+		"revision not found": {
+			query:           "?from=1&to=2",
+			revisionService: &MockQuizRevisionService{getByNumberReturnsError: assert.AnError},
+			expected:        http.StatusNotFound,
+		},
+		"success": {
+			query:           "?from=1&to=2",
+			revisionService: &MockQuizRevisionService{getByNumberReturns: revisions()},
+			expected:        http.StatusOK,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &QuizRevisionHandler{QuizService: &MockQuizService{getByIdReturns: revisionQuiz()}, QuizRevisionService: testData.revisionService}
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+			context.Request, _ = http.NewRequest(http.MethodGet, "https://test.com"+testData.query, nil)
+			context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+			// Act
+			handler.GetDiff(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestQuizRevisionHandler_GetDiff_ReturnsDiff(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	handler := &QuizRevisionHandler{
+		QuizService:         &MockQuizService{getByIdReturns: revisionQuiz()},
+		QuizRevisionService: &MockQuizRevisionService{getByNumberReturns: revisions()},
+	}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodGet, "https://test.com?from=1&to=2", nil)
+	context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+	// Act
+	handler.GetDiff(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	var result *domain.RevisionDiff
+	_ = json.Unmarshal(writer.Body.Bytes(), &result)
+
+	assert.Equal(t, []string{"name"}, result.Fields)
+}
+
+func TestQuizRevisionHandler_PostRestore_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	viewerQuiz := revisionQuiz()
+	viewerQuiz.CreatorID = uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b")
+	viewerQuiz.Collaborators = []*domain.Collaborator{{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), Role: domain.RoleViewer}}
+
+	tests := map[string]struct {
+		number          string
+		quizService     *MockQuizService
+		revisionService *MockQuizRevisionService
+		expected        int
+	}{
+		"viewer": {
+			number:          "1",
+			quizService:     &MockQuizService{getByIdReturns: viewerQuiz},
+			revisionService: &MockQuizRevisionService{getByNumberReturns: revisions()},
+			expected:        http.StatusForbidden,
+		},
+		"invalid number": {
+			number:          "-1",
+			quizService:     &MockQuizService{getByIdReturns: revisionQuiz()},
+			revisionService: &MockQuizRevisionService{getByNumberReturns: revisions()},
+			expected:        http.StatusBadRequest,
+		},
+		"revision not found": {
+			number:          "3",
+			quizService:     &MockQuizService{getByIdReturns: revisionQuiz()},
+			revisionService: &MockQuizRevisionService{getByNumberReturnsError: assert.AnError},
+			expected:        http.StatusNotFound,
+		},
+		"save error": {
+			number:          "1",
+			quizService:     &MockQuizService{getByIdReturns: revisionQuiz(), createOrUpdateReturns: assert.AnError},
+			revisionService: &MockQuizRevisionService{getByNumberReturns: revisions()},
+			expected:        http.StatusInternalServerError,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &QuizRevisionHandler{QuizService: testData.quizService, QuizRevisionService: testData.revisionService, QuizDraftService: &MockQuizDraftService{}}
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+			context.Request, _ = http.NewRequest(http.MethodPost, "", nil)
+			context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}, {Key: "number", Value: testData.number}}
+
+			// Act
+			handler.PostRestore(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestQuizRevisionHandler_PostRestore_SavesRevisionAsQuiz(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quizService := &MockQuizService{getByIdReturns: revisionQuiz()}
+	draftService := &MockQuizDraftService{}
+	handler := &QuizRevisionHandler{QuizService: quizService, QuizRevisionService: &MockQuizRevisionService{getByNumberReturns: revisions()}, QuizDraftService: draftService}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodPost, "", nil)
+	context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}, {Key: "number", Value: "1"}}
+
+	// Act
+	handler.PostRestore(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	if assert.NotNil(t, quizService.createOrUpdateCalledWith) {
+		assert.Equal(t, revisionQuiz().ID, quizService.createOrUpdateCalledWith.ID)
+		assert.Equal(t, "old", quizService.createOrUpdateCalledWith.Name)
+	}
+
+	assert.Equal(t, revisionQuiz().ID, draftService.deleteCalledWith)
+}
@@ -0,0 +1,127 @@
+package routes
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"github.com/survivorbat/qq.maarten.dev/server/routes/outputs"
+	"github.com/survivorbat/qq.maarten.dev/server/services"
+	"io"
+	"net/http"
+)
+
+// maxSheetSize is the largest spreadsheet that may be imported, 100 questions fit easily
+const maxSheetSize = 2 << 20
+
+// sheetFormats maps the content types of spreadsheets to their format
+var sheetFormats = map[string]services.SpreadsheetFormat{
+	"text/csv": services.SpreadsheetCSV,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": services.SpreadsheetXLSX,
+}
+
+// PostSheetImport godoc
+//
+//	@Summary	Create a quiz from a CSV or XLSX spreadsheet with a row per question
+//	@Description	The first row names the columns: title, description, category, duration, option 1 up to option 4 and correct option.
+//	@Description	The correct option is the number of the option or its text. Only the first sheet of a workbook is read.
+//	@Tags		Quiz
+//	@Accept		text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Produce	json
+//	@Param		name	query		string						true	"Name of the quiz"
+//	@Param		dryRun	query		bool						false	"Only preview the import"
+//	@Param		input	body		string						true	"The spreadsheet"
+//	@Success	200		{object}	domain.Quiz					"The new quiz, or an outputs.OutputSheetImport for a dry run"
+//	@Failure	400		{object}	outputs.OutputSheetImport	"The rows that are invalid, empty if the spreadsheet could not be read"
+//	@Failure	413		"The spreadsheet is too large"
+//	@Failure	415		"Not a CSV or XLSX spreadsheet"
+//	@Failure	500		"Internal Server Error"
+//	@Router		/api/v1/quizzes/import/spreadsheet [post]
+//	@Security	JWT
+func (g *QuizHandler) PostSheetImport(c *gin.Context) {
+	var input inputs.SheetImport
+	if err := c.ShouldBindQuery(&input); err != nil {
+		logrus.WithError(err).Error("Validation error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	format, ok := sheetFormats[c.ContentType()]
+	if !ok {
+		c.AbortWithStatus(http.StatusUnsupportedMediaType)
+		return
+	}
+
+	content, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxSheetSize))
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			c.AbortWithStatus(http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		logrus.WithError(err).Error("Failed to read spreadsheet")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	cells, err := g.SpreadsheetReader.Read(content, format)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to read spreadsheet")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	quiz, preview := readSheetQuiz(input.Name, cells)
+
+	if input.DryRun {
+		c.JSON(http.StatusOK, preview)
+		return
+	}
+
+	if len(preview.Errors) > 0 {
+		logrus.Infof("Spreadsheet has %d invalid cells", len(preview.Errors))
+		c.AbortWithStatusJSON(http.StatusBadRequest, preview)
+		return
+	}
+
+	result := quiz.ToDomain()
+	result.CreatorID = uuid.MustParse(c.GetString("user"))
+
+	if err := g.QuizService.CreateOrUpdate(result); err != nil {
+		logrus.WithError(err).Error("Failed to import spreadsheet")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// readSheetQuiz validates every row on its own and then the quiz made of the valid rows
+func readSheetQuiz(name string, cells [][]string) (*inputs.Quiz, *outputs.OutputSheetImport) {
+	preview := &outputs.OutputSheetImport{Questions: []*inputs.MultipleChoiceQuestion{}, Errors: []*outputs.OutputRowError{}}
+
+	header, rows := inputs.ReadQuestionSheet(cells)
+	if len(header.Errors) > 0 {
+		preview.Errors = outputs.NewRowErrors(header, nil)
+		return nil, preview
+	}
+
+	for _, row := range rows {
+		rowErrors := outputs.NewRowErrors(row, binding.Validator.ValidateStruct(row.Question))
+		if len(rowErrors) > 0 {
+			preview.Errors = append(preview.Errors, rowErrors...)
+			continue
+		}
+
+		row.Question.Order = uint(len(preview.Questions))
+		preview.Questions = append(preview.Questions, row.Question)
+	}
+
+	quiz := &inputs.Quiz{Name: name, MultipleChoiceQuestions: preview.Questions}
+	preview.Errors = append(preview.Errors, outputs.NewQuizRowErrors(binding.Validator.ValidateStruct(quiz))...)
+
+	return quiz, preview
+}
@@ -0,0 +1,195 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/routes/outputs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func sheetCells() [][]string {
+	return [][]string{
+		{"title", "category", "duration", "option 1", "option 2", "correct option"},
+		{"Capital of France", "Geography", "15", "Lyon", "Paris", "2"},
+		{"ES", "Geography", "90", "Madrid", "Barcelona", "1"},
+		{"Capital of Italy", "Geography", "20", "Rome", "Milan", "4"},
+	}
+}
+
+func sheetContext(writer *httptest.ResponseRecorder, query string, contentType string, body []byte) *gin.Context {
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/quizzes/import/spreadsheet"+query, bytes.NewBuffer(body))
+	context.Request.Header.Set("Content-Type", contentType)
+
+	return context
+}
+
+func TestQuizHandler_PostSheetImport_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	valid := [][]string{sheetCells()[0], sheetCells()[1]}
+
+	tests := map[string]struct {
+		query       string
+		contentType string
+		body        []byte
+		reader      *MockSpreadsheetReader
+		quizService *MockQuizService
+		expected    int
+	}{
+		"no name": {
+			query:       "",
+			contentType: "text/csv",
+			reader:      &MockSpreadsheetReader{readReturns: valid},
+			quizService: &MockQuizService{},
+			expected:    http.StatusBadRequest,
+		},
+		"unknown content type": {
+			query:       "?name=Capitals",
+			contentType: "application/pdf",
+			reader:      &MockSpreadsheetReader{readReturns: valid},
+			quizService: &MockQuizService{},
+			expected:    http.StatusUnsupportedMediaType,
+		},
+		"too large": {
+			query:       "?name=Capitals",
+			contentType: "text/csv",
+			body:        make([]byte, maxSheetSize+1),
+			reader:      &MockSpreadsheetReader{readReturns: valid},
+			quizService: &MockQuizService{},
+			expected:    http.StatusRequestEntityTooLarge,
+		},
+		"unreadable": {
+			query:       "?name=Capitals",
+			contentType: "text/csv",
+			reader:      &MockSpreadsheetReader{readReturnsErr: assert.AnError},
+			quizService: &MockQuizService{},
+			expected:    http.StatusBadRequest,
+		},
+		"missing columns": {
+			query:       "?name=Capitals",
+			contentType: "text/csv",
+			reader:      &MockSpreadsheetReader{readReturns: [][]string{{"title"}}},
+			quizService: &MockQuizService{},
+			expected:    http.StatusBadRequest,
+		},
+		"invalid rows": {
+			query:       "?name=Capitals",
+			contentType: "text/csv",
+			reader:      &MockSpreadsheetReader{readReturns: sheetCells()},
+			quizService: &MockQuizService{},
+			expected:    http.StatusBadRequest,
+		},
+		"dry run with invalid rows": {
+			query:       "?name=Capitals&dryRun=true",
+			contentType: "text/csv",
+			reader:      &MockSpreadsheetReader{readReturns: sheetCells()},
+			quizService: &MockQuizService{},
+			expected:    http.StatusOK,
+		},
+		"save error": {
+			query:       "?name=Capitals",
+			contentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			reader:      &MockSpreadsheetReader{readReturns: valid},
+			quizService: &MockQuizService{createOrUpdateReturns: assert.AnError},
+			expected:    http.StatusInternalServerError,
+		},
+		"success": {
+			query:       "?name=Capitals",
+			contentType: "text/csv",
+			reader:      &MockSpreadsheetReader{readReturns: valid},
+			quizService: &MockQuizService{},
+			expected:    http.StatusOK,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &QuizHandler{QuizService: testData.quizService, SpreadsheetReader: testData.reader}
+
+			writer := httptest.NewRecorder()
+			context := sheetContext(writer, testData.query, testData.contentType, testData.body)
+
+			// Act
+			handler.PostSheetImport(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestQuizHandler_PostSheetImport_DryRunReturnsPreview(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quizService := &MockQuizService{}
+	handler := &QuizHandler{QuizService: quizService, SpreadsheetReader: &MockSpreadsheetReader{readReturns: sheetCells()}}
+
+	writer := httptest.NewRecorder()
+	context := sheetContext(writer, "?name=Capitals&dryRun=true", "text/csv", []byte("title"))
+
+	// Act
+	handler.PostSheetImport(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Nil(t, quizService.createOrUpdateCalledWith)
+
+	var result *outputs.OutputSheetImport
+	_ = json.Unmarshal(writer.Body.Bytes(), &result)
+
+	if assert.Len(t, result.Questions, 1) {
+		assert.Equal(t, "Capital of France", result.Questions[0].Title)
+		assert.True(t, result.Questions[0].Options[1].Answer)
+	}
+
+	expected := []*outputs.OutputRowError{
+		{Row: 3, Column: "title", Rule: "min", Param: "3"},
+		{Row: 3, Column: "duration", Rule: "max", Param: "60"},
+		{Row: 4, Column: "correct option", Rule: "correctOption", Param: "4"},
+	}
+	assert.Equal(t, expected, result.Errors)
+}
+
+func TestQuizHandler_PostSheetImport_CreatesQuiz(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	cells := sheetCells()
+	cells[2][0] = "Capital of Spain"
+	cells[2][2] = "30"
+	cells[3][5] = "Rome"
+
+	quizService := &MockQuizService{}
+	reader := &MockSpreadsheetReader{readReturns: cells}
+	handler := &QuizHandler{QuizService: quizService, SpreadsheetReader: reader}
+
+	writer := httptest.NewRecorder()
+	context := sheetContext(writer, "?name=Capitals", "text/csv", []byte("the spreadsheet"))
+
+	// Act
+	handler.PostSheetImport(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, []byte("the spreadsheet"), reader.readCalledWith)
+
+	result := quizService.createOrUpdateCalledWith
+	assert.Equal(t, "Capitals", result.Name)
+	assert.Equal(t, uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), result.CreatorID)
+
+	if assert.Len(t, result.MultipleChoiceQuestions, 3) {
+		question := result.MultipleChoiceQuestions[2]
+		assert.Equal(t, uint(2), question.Order)
+
+		answer, _ := question.Option(question.AnswerID)
+		assert.Equal(t, "Rome", answer.TextOption)
+	}
+}
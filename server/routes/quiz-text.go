@@ -0,0 +1,47 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/formats"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"net/http"
+)
+
+// maxTextSize is the largest GIFT or Aiken document that may be imported
+const maxTextSize = 1 << 20
+
+// PostTextImport godoc
+//
+//	@Summary	Create a quiz from a Moodle GIFT or Aiken document
+//	@Description	Multiple choice and true/false questions are imported, other question types and
+//	@Description	constructs like feedback are reported as problems. Problems that are skipped leave out
+//	@Description	the whole question, the import fails on those unless skipUnsupported is set.
+//	@Tags		Quiz
+//	@Accept		plain
+//	@Produce	json
+//	@Param		name				query		string							true	"Name of the quiz"
+//	@Param		format				query		string							true	"Either gift or aiken"
+//	@Param		category			query		string							false	"Category of questions without one, defaults to General"
+//	@Param		durationInSeconds	query		int								false	"Duration of every question, defaults to 20"
+//	@Param		skipUnsupported		query		bool							false	"Import the other questions if some are skipped"
+//	@Param		dryRun				query		bool							false	"Only preview the import"
+//	@Param		input				body		string							true	"The document"
+//	@Success	200					{object}	domain.Quiz						"The new quiz, or an outputs.OutputDocumentImport for a dry run"
+//	@Failure	400					{object}	outputs.OutputDocumentImport	"The questions that were skipped, empty if the document could not be read"
+//	@Failure	413					"The document is too large"
+//	@Failure	500					"Internal Server Error"
+//	@Router		/api/v1/quizzes/import/text [post]
+//	@Security	JWT
+func (g *QuizHandler) PostTextImport(c *gin.Context) {
+	var input inputs.TextImport
+	if err := c.ShouldBindQuery(&input); err != nil {
+		logrus.WithError(err).Error("Validation error")
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	format, _ := formats.ByName(input.Format)
+
+	g.importDocument(c, &input.DocumentImport, format, maxTextSize)
+}
@@ -0,0 +1,195 @@
+package routes
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/formats"
+	"github.com/survivorbat/qq.maarten.dev/server/routes/outputs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const textImportGIFT = `$CATEGORY: top/Europe
+
+::Capital of France:: What is the capital of France? {=Paris ~Lyon ~Marseille}
+
+How many is 2 + 2? {#4}
+
+The Sahara is in Africa. {T}
+`
+
+func TestQuizHandler_PostTextImport_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		query       string
+		body        string
+		quizService *MockQuizService
+		expected    int
+	}{
+		"no name": {
+			query:       "format=gift",
+			body:        textImportGIFT,
+			quizService: &MockQuizService{},
+			expected:    http.StatusBadRequest,
+		},
+		"unknown format": {
+			query:       "name=Capitals&format=qti",
+			body:        textImportGIFT,
+			quizService: &MockQuizService{},
+			expected:    http.StatusBadRequest,
+		},
+		"invalid duration": {
+			query:       "name=Capitals&format=gift&durationInSeconds=90",
+			body:        textImportGIFT,
+			quizService: &MockQuizService{},
+			expected:    http.StatusBadRequest,
+		},
+		"unsupported questions": {
+			query:       "name=Capitals&format=gift",
+			body:        textImportGIFT,
+			quizService: &MockQuizService{},
+			expected:    http.StatusBadRequest,
+		},
+		"too large": {
+			query:       "name=Capitals&format=gift",
+			body:        string(make([]byte, maxTextSize+1)),
+			quizService: &MockQuizService{},
+			expected:    http.StatusRequestEntityTooLarge,
+		},
+		"error on create": {
+			query:       "name=Capitals&format=gift&skipUnsupported=true",
+			body:        textImportGIFT,
+			quizService: &MockQuizService{createOrUpdateReturns: assert.AnError},
+			expected:    http.StatusInternalServerError,
+		},
+		"dry run": {
+			query:       "name=Capitals&format=gift&dryRun=true",
+			body:        textImportGIFT,
+			quizService: &MockQuizService{},
+			expected:    http.StatusOK,
+		},
+		"skip unsupported": {
+			query:       "name=Capitals&format=gift&skipUnsupported=true",
+			body:        textImportGIFT,
+			quizService: &MockQuizService{},
+			expected:    http.StatusOK,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &QuizHandler{QuizService: testData.quizService}
+
+			writer := httptest.NewRecorder()
+			context := bundleContext(writer, http.MethodPost, "/api/v1/quizzes/import/text?"+testData.query, "text/plain", []byte(testData.body))
+
+			// Act
+			handler.PostTextImport(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestQuizHandler_PostTextImport_ReturnsPreview(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quizService := &MockQuizService{}
+	handler := &QuizHandler{QuizService: quizService}
+
+	writer := httptest.NewRecorder()
+	context := bundleContext(writer, http.MethodPost, "/api/v1/quizzes/import/text?name=Capitals&format=gift&durationInSeconds=15&dryRun=true", "text/plain", []byte(textImportGIFT))
+
+	// Act
+	handler.PostTextImport(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Nil(t, quizService.createOrUpdateCalledWith)
+
+	var result outputs.OutputDocumentImport
+	_ = json.Unmarshal(writer.Body.Bytes(), &result)
+
+	if assert.Len(t, result.Questions, 2) {
+		assert.Equal(t, "Capital of France", result.Questions[0].Title)
+		assert.Equal(t, "Europe", result.Questions[0].Category)
+		assert.Equal(t, uint(15), result.Questions[0].DurationInSeconds)
+		assert.Equal(t, uint(1), result.Questions[1].Order)
+	}
+
+	expected := []*formats.Problem{{Line: 5, Reason: "numeric questions are not supported", Skipped: true}}
+	assert.Equal(t, expected, result.Problems)
+}
+
+func TestQuizHandler_PostTextImport_UsesDefaults(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quizService := &MockQuizService{}
+	handler := &QuizHandler{QuizService: quizService}
+
+	body := "Which river flows through Cairo?\nA. The Nile\nB. The Amazon\nANSWER: A\n"
+
+	writer := httptest.NewRecorder()
+	context := bundleContext(writer, http.MethodPost, "/api/v1/quizzes/import/text?name=Rivers&format=aiken", "text/plain", []byte(body))
+
+	// Act
+	handler.PostTextImport(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	result := quizService.createOrUpdateCalledWith
+	if assert.Len(t, result.MultipleChoiceQuestions, 1) {
+		question := result.MultipleChoiceQuestions[0]
+		assert.Equal(t, "General", question.Category)
+		assert.Equal(t, uint(20), question.DurationInSeconds)
+
+		answer, _ := question.Option(question.AnswerID)
+		assert.Equal(t, "The Nile", answer.TextOption)
+	}
+}
+
+func TestQuizHandler_GetExport_WritesTextFormats(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		format   string
+		expected string
+		warnings []string
+	}{
+		"gift": {
+			format:   "gift",
+			expected: "$CATEGORY: Europe\n\nCapital of France {\n\t=Paris\n\t~Lyon\n}\n\nCapital of Spain {\n\t~Barcelona\n\t=Madrid\n}\n",
+			warnings: []string{`199 - "GIFT has no durations, they were left out"`},
+		},
+		"aiken": {
+			format:   "aiken",
+			expected: "Capital of France\nA. Paris\nB. Lyon\nANSWER: A\n\nCapital of Spain\nA. Barcelona\nB. Madrid\nANSWER: B\n",
+			warnings: []string{`199 - "Aiken has no categories, they were left out"`, `199 - "Aiken has no durations, they were left out"`},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			handler := &QuizHandler{QuizService: &MockQuizService{getByIdReturns: bundleQuiz()}}
+
+			writer := httptest.NewRecorder()
+			context := bundleContext(writer, http.MethodGet, "/api/v1/quizzes/788f12a9-51e8-4c87-9b0c-06bcc9f0691b/export?format="+testData.format, "", nil)
+
+			// Act
+			handler.GetExport(context)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, writer.Code)
+			assert.Equal(t, testData.expected, writer.Body.String())
+			assert.Equal(t, testData.warnings, writer.Header().Values("Warning"))
+		})
+	}
+}
@@ -4,13 +4,18 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
 	"github.com/survivorbat/qq.maarten.dev/server/inputs"
 	"github.com/survivorbat/qq.maarten.dev/server/services"
 	"net/http"
 )
 
 type QuizHandler struct {
-	QuizService services.QuizService
+	QuizService         services.QuizService
+	QuizDraftService    services.QuizDraftService
+	OrganizationService services.OrganizationService
+	BankQuestionService services.BankQuestionService
+	SpreadsheetReader   services.SpreadsheetReader
 }
 
 // Get godoc
@@ -19,16 +24,31 @@ type QuizHandler struct {
 //	@Tags		Quiz
 //	@Accept		json
 //	@Produce	json
-//	@Success	200	{array}	[]domain.Quiz	"Your quizzes"
-//	@Failure	500	"Internal Server Error"
+//	@Param		scope	query	string			false	"Either mine (default) or organization"
+//	@Success	200		{array}	[]domain.Quiz	"Your quizzes"
+//	@Failure	400		"Invalid scope"
+//	@Failure	500		"Internal Server Error"
 //	@Router		/api/v1/quizzes [get]
 //	@Security	JWT
 func (g *QuizHandler) Get(c *gin.Context) {
-	authID := c.GetString("user")
+	authID := uuid.MustParse(c.GetString("user"))
+
+	var quizzes []*domain.Quiz
+	var err error
+
+	switch c.DefaultQuery("scope", "mine") {
+	case "mine":
+		quizzes, err = g.QuizService.GetByCreator(authID)
+	case "organization":
+		quizzes, err = g.QuizService.GetByOrganizations(authID)
+	default:
+		logrus.Errorf("Unknown scope %s", c.Query("scope"))
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
 
-	quizzes, err := g.QuizService.GetByCreator(uuid.MustParse(authID))
 	if err != nil {
-		logrus.WithError(err).Error("Failed to get by creator")
+		logrus.WithError(err).Error("Failed to get quizzes")
 		c.AbortWithStatus(http.StatusInternalServerError)
 		return
 	}
@@ -44,6 +64,7 @@ func (g *QuizHandler) Get(c *gin.Context) {
 //	@Produce	json
 //	@Param		input	body		inputs.Quiz	true	"Your quiz"
 //	@Success	200		{object}	domain.Quiz	"Your quiz"
+//	@Failure	403		"You can only add quizzes to your own organizations"
 //	@Failure	500		"Internal Server Error"
 //	@Router		/api/v1/quizzes [post]
 //	@Security	JWT
@@ -60,6 +81,10 @@ func (g *QuizHandler) Post(c *gin.Context) {
 	quiz := input.ToDomain()
 	quiz.CreatorID = uuid.MustParse(authID)
 
+	if !authorizeOrganization(c, g.OrganizationService, quiz.OrganizationID) {
+		return
+	}
+
 	logrus.Infof("Creating %#v", quiz)
 	if err := g.QuizService.CreateOrUpdate(quiz); err != nil {
 		logrus.WithError(err).Error("Failed to create")
@@ -72,19 +97,19 @@ func (g *QuizHandler) Post(c *gin.Context) {
 
 // Put godoc
 //
-//	@Summary	Update or create a quiz
+//	@Summary	Create a quiz, changes to an existing quiz are saved as its draft until they are published
 //	@Tags		Quiz
 //	@Accept		json
 //	@Produce	json
 //	@Param		id		path		string		true	"ID of the quiz"
 //	@Param		input	body		inputs.Quiz	true	"Your quiz"
-//	@Success	200		{object}	inputs.Quiz	"Your quiz"
-//	@Failure	403		"You can only update your own quizzes"
+//	@Success	200		{object}	inputs.Quiz	"Your quiz, or an outputs.OutputQuizDraft if the quiz already existed"
+//	@Failure	403		"You can only update your own quizzes and link bank questions you have access to"
+//	@Failure	404		"A linked bank question was not found"
 //	@Failure	500		"Internal Server Error"
 //	@Router		/api/v1/quizzes/{id} [put]
 //	@Security	JWT
 func (g *QuizHandler) Put(c *gin.Context) {
-	authID := c.GetString("user")
 	id := c.Param("id")
 
 	quizID, err := uuid.Parse(id)
@@ -95,9 +120,7 @@ func (g *QuizHandler) Put(c *gin.Context) {
 	}
 
 	quiz, err := g.QuizService.GetByID(quizID)
-	if err == nil && quiz.CreatorID.String() != authID {
-		logrus.Errorf("Creator is %s not %s", quiz.CreatorID, authID)
-		c.AbortWithStatus(http.StatusForbidden)
+	if err == nil && !authorize(c, quiz, domain.PermissionEditQuiz) {
 		return
 	}
 
@@ -108,11 +131,17 @@ func (g *QuizHandler) Put(c *gin.Context) {
 		return
 	}
 
-	update := input.ToDomain()
-	update.CreatorID = uuid.MustParse(authID)
-	update.ID = quizID
+	// Games that start in the meantime should not get a half-edited quiz
+	if err == nil {
+		g.saveDraft(c, quiz, input)
+		return
+	}
+
+	update, ok := g.newUpdate(c, quizID, nil, input)
+	if !ok {
+		return
+	}
 
-	logrus.Infof("Overwriting %#v", quiz)
 	if err := g.QuizService.CreateOrUpdate(update); err != nil {
 		logrus.WithError(err).Error("Failed create or update")
 		c.AbortWithStatus(http.StatusInternalServerError)
@@ -135,7 +164,6 @@ func (g *QuizHandler) Put(c *gin.Context) {
 //	@Router		/api/v1/quizzes/{id} [delete]
 //	@Security	JWT
 func (g *QuizHandler) Delete(c *gin.Context) {
-	authID := c.GetString("user")
 	id := c.Param("id")
 
 	quizID, err := uuid.Parse(id)
@@ -153,9 +181,7 @@ func (g *QuizHandler) Delete(c *gin.Context) {
 	}
 
 	// Prevent users from deleting other people's quizzes
-	if quiz.CreatorID.String() != authID {
-		logrus.Errorf("Creator is %s not %s", quiz.CreatorID, authID)
-		c.AbortWithStatus(http.StatusForbidden)
+	if !authorize(c, quiz, domain.PermissionDeleteQuiz) {
 		return
 	}
 
@@ -168,3 +194,94 @@ func (g *QuizHandler) Delete(c *gin.Context) {
 
 	c.JSON(http.StatusNoContent, quiz)
 }
+
+// newUpdate turns the input into an update of the quiz, the quiz is nil if it doesn't exist yet
+func (g *QuizHandler) newUpdate(c *gin.Context, quizID uuid.UUID, quiz *domain.Quiz, input *inputs.Quiz) (*domain.Quiz, bool) {
+	authID := uuid.MustParse(c.GetString("user"))
+
+	keepOwnIDs(quiz, input)
+
+	update := input.ToDomain()
+	update.CreatorID = authID
+	update.ID = quizID
+
+	// Editors should not take over ownership or move the quiz to another organization
+	if quiz != nil {
+		update.CreatorID = quiz.CreatorID
+		update.ForkedFromID = quiz.ForkedFromID
+
+		if !quiz.Allows(authID, domain.PermissionDeleteQuiz) {
+			update.OrganizationID = quiz.OrganizationID
+		}
+	}
+
+	if !authorizeOrganization(c, g.OrganizationService, update.OrganizationID) {
+		return nil, false
+	}
+
+	if !g.authorizeBankQuestions(c, quiz, update) {
+		return nil, false
+	}
+
+	return update, true
+}
+
+// authorizeBankQuestions verifies that the creator may use the bank questions that questions are linked
+// to, links the quiz already had stay as they are
+func (g *QuizHandler) authorizeBankQuestions(c *gin.Context, quiz *domain.Quiz, update *domain.Quiz) bool {
+	authID := uuid.MustParse(c.GetString("user"))
+
+	for _, question := range update.MultipleChoiceQuestions {
+		if question.BankQuestionID == nil {
+			continue
+		}
+
+		if quiz != nil {
+			existing, ok := quiz.Question(question.ID)
+			if ok && existing.BankQuestionID != nil && *existing.BankQuestionID == *question.BankQuestionID {
+				continue
+			}
+		}
+
+		bankQuestion, err := g.BankQuestionService.GetByID(*question.BankQuestionID)
+		if err != nil {
+			logrus.WithError(err).Error("Failed to get bank question")
+			c.AbortWithStatus(http.StatusNotFound)
+			return false
+		}
+
+		if !bankQuestion.CanUse(authID) {
+			logrus.Errorf("Creator %s may not use bank question %s", authID, bankQuestion.ID)
+			c.AbortWithStatus(http.StatusForbidden)
+			return false
+		}
+	}
+
+	return true
+}
+
+// keepOwnIDs clears the IDs of questions and options that aren't part of the quiz yet, so an update
+// can't take over the questions of other quizzes
+func keepOwnIDs(quiz *domain.Quiz, input *inputs.Quiz) {
+	for _, question := range input.MultipleChoiceQuestions {
+		var existing *domain.MultipleChoiceQuestion
+		if quiz != nil {
+			existing, _ = quiz.Question(question.ID)
+		}
+
+		if existing == nil {
+			question.ID = uuid.Nil
+		}
+
+		for _, option := range question.Options {
+			if existing == nil {
+				option.ID = uuid.Nil
+				continue
+			}
+
+			if _, ok := existing.Option(option.ID); !ok {
+				option.ID = uuid.Nil
+			}
+		}
+	}
+}
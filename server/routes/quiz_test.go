@@ -66,6 +66,115 @@ func TestQuizHandler_Get_ReturnsErrorOnFetchError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, writer.Code)
 }
 
+func TestQuizHandler_Get_ReturnsOrganizationQuizzes(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quizzes := []*domain.Quiz{
+		{BaseObject: domain.BaseObject{ID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58")}, CreatorID: uuid.MustParse("d2f584da-d340-459a-a1ce-8652446a86ef")},
+	}
+
+	mockQuizService := &MockQuizService{getByOrganizationsReturns: quizzes}
+	handler := &QuizHandler{
+		QuizService: mockQuizService,
+	}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodGet, "/?scope=organization", nil)
+
+	// Act
+	handler.Get(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), mockQuizService.getByOrganizationsCalledWith)
+
+	var result []*domain.Quiz
+	if err := json.Unmarshal(writer.Body.Bytes(), &result); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	assert.ElementsMatch(t, quizzes, result)
+}
+
+func TestQuizHandler_Get_ReturnsErrorOnUnknownScope(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	handler := &QuizHandler{QuizService: &MockQuizService{}}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodGet, "/?scope=everything", nil)
+
+	// Act
+	handler.Get(context)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+}
+
+func TestQuizHandler_Post_ReturnsForbiddenOnForeignOrganization(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		organization *domain.Organization
+		err          error
+	}{
+		"not found": {
+			err: assert.AnError,
+		},
+		"not a member": {
+			organization: &domain.Organization{
+				Members: []*domain.Member{{CreatorID: uuid.MustParse("d2f584da-d340-459a-a1ce-8652446a86ef"), Role: domain.OrganizationAdmin}},
+			},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			quizService := &MockQuizService{}
+			handler := &QuizHandler{
+				QuizService:         quizService,
+				OrganizationService: &MockOrganizationService{getByIdReturns: testData.organization, getByIdReturnsError: testData.err},
+			}
+
+			organizationID := uuid.MustParse("ac1d0e93-b545-48be-bff9-656a933afa04")
+			input := &inputs.Quiz{
+				Name:           "My Awesome Quiz",
+				OrganizationID: &organizationID,
+				MultipleChoiceQuestions: []*inputs.MultipleChoiceQuestion{
+					{
+						Title:             "What is 2+2",
+						DurationInSeconds: 15,
+						Category:          "Math",
+						Options: []*inputs.QuestionOption{
+							{TextOption: "4", Answer: true},
+							{TextOption: "3"},
+						},
+					},
+				},
+			}
+			inputJson, _ := json.Marshal(input)
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+			context.Request, _ = http.NewRequest(http.MethodPost, "", io.NopCloser(bytes.NewBuffer(inputJson)))
+
+			// Act
+			handler.Post(context)
+
+			// Assert
+			assert.Equal(t, http.StatusForbidden, writer.Code)
+			assert.Nil(t, quizService.createOrUpdateCalledWith)
+		})
+	}
+}
+
 func TestQuizHandler_Post_ReturnsValidationError(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -143,9 +252,10 @@ func TestQuizHandler_Post_CallsService(t *testing.T) {
 	assert.Equal(t, http.StatusOK, writer.Code)
 
 	expected := &domain.Quiz{
-		Name:        "My Awesome Quiz",
-		Description: "Best quiz ever",
-		CreatorID:   uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"),
+		Name:               "My Awesome Quiz",
+		Description:        "Best quiz ever",
+		CreatorID:          uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"),
+		MaxConcurrentGames: 1,
 		MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{
 			{
 				BaseQuestion: domain.BaseQuestion{
@@ -255,6 +365,122 @@ func TestQuizHandler_Put_ReturnsForbiddenOnNotMyQuiz(t *testing.T) {
 	assert.Equal(t, http.StatusForbidden, writer.Code)
 }
 
+func TestQuizHandler_Put_SavesDraftOfExistingQuiz(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	quizService := &MockQuizService{
+		getByIdReturns: &domain.Quiz{
+			BaseObject:    domain.BaseObject{ID: uuid.MustParse("ac1d0e93-b545-48be-bff9-656a933afa04")},
+			CreatorID:     uuid.MustParse("e3274bf0-b154-4d37-a6fd-878d530025f9"),
+			Collaborators: []*domain.Collaborator{{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"), Role: domain.RoleEditor}},
+			Version:       3,
+		},
+	}
+	draftService := &MockQuizDraftService{}
+	handler := &QuizHandler{QuizService: quizService, QuizDraftService: draftService}
+
+	input := &inputs.Quiz{
+		Name: "My Awesome Quiz",
+		MultipleChoiceQuestions: []*inputs.MultipleChoiceQuestion{
+			{
+				Title:             "What is 2+2",
+				DurationInSeconds: 15,
+				Category:          "Math",
+				Options:           []*inputs.QuestionOption{{TextOption: "4", Answer: true}, {TextOption: "3"}},
+			},
+		},
+	}
+	inputJson, _ := json.Marshal(input)
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+	context.Request, _ = http.NewRequest(http.MethodPut, "", io.NopCloser(bytes.NewBuffer(inputJson)))
+	context.Params = []gin.Param{{Key: "id", Value: "ac1d0e93-b545-48be-bff9-656a933afa04"}}
+
+	// Act
+	handler.Put(context)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Nil(t, quizService.createOrUpdateCalledWith)
+
+	if assert.NotNil(t, draftService.saveCalledWith) {
+		assert.Equal(t, uuid.MustParse("ac1d0e93-b545-48be-bff9-656a933afa04"), draftService.saveCalledWith.QuizID)
+		assert.Equal(t, uint(3), draftService.saveCalledWith.Version)
+
+		var saved *inputs.Quiz
+		_ = json.Unmarshal(draftService.saveCalledWith.Content, &saved)
+		assert.Equal(t, "My Awesome Quiz", saved.Name)
+		assert.NotEqual(t, uuid.Nil, saved.MultipleChoiceQuestions[0].ID)
+	}
+}
+
+func TestQuizHandler_Put_KeepsLinksToUsableBankQuestions(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		bankQuestionService *MockBankQuestionService
+		expected            int
+	}{
+		"bank question not found": {
+			bankQuestionService: &MockBankQuestionService{getByIdReturnsError: assert.AnError},
+			expected:            http.StatusNotFound,
+		},
+		"not my bank question": {
+			bankQuestionService: &MockBankQuestionService{getByIdReturns: &domain.BankQuestion{CreatorID: uuid.MustParse("8fdc3e5a-b0a8-4103-af3b-c2f20d91889b")}},
+			expected:            http.StatusForbidden,
+		},
+		"success": {
+			bankQuestionService: &MockBankQuestionService{getByIdReturns: bankQuestion()},
+			expected:            http.StatusOK,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			quizService := &MockQuizService{getByIdReturnsError: gorm.ErrRecordNotFound}
+			handler := &QuizHandler{QuizService: quizService, BankQuestionService: testData.bankQuestionService}
+
+			bankQuestionID := uuid.MustParse("c6d0d2c4-8b5f-4f3a-9f1d-6f5c3e2b1a09")
+			input := &inputs.Quiz{
+				Name: "My Awesome Quiz",
+				MultipleChoiceQuestions: []*inputs.MultipleChoiceQuestion{
+					{
+						BankQuestionID:    &bankQuestionID,
+						Linked:            true,
+						Title:             "What is 4+4",
+						DurationInSeconds: 15,
+						Category:          "Math",
+						Options:           []*inputs.QuestionOption{{TextOption: "8", Answer: true}, {TextOption: "7"}},
+					},
+				},
+			}
+			inputJson, _ := json.Marshal(input)
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Set("user", "2f80947c-e724-4b38-8c8d-3823864fef58")
+			context.Request, _ = http.NewRequest(http.MethodPut, "", io.NopCloser(bytes.NewBuffer(inputJson)))
+			context.Params = []gin.Param{{Key: "id", Value: "ac1d0e93-b545-48be-bff9-656a933afa04"}}
+
+			// Act
+			handler.Put(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+
+			if testData.expected == http.StatusOK {
+				question := quizService.createOrUpdateCalledWith.MultipleChoiceQuestions[0]
+				assert.Equal(t, &bankQuestionID, question.BankQuestionID)
+				assert.True(t, question.Linked)
+			}
+		})
+	}
+}
+
 func TestQuizHandler_Put_ReturnsValidationError(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -285,9 +511,7 @@ func TestQuizHandler_Put_CallsService(t *testing.T) {
 	//t.Parallel() Can't be run in parallel because of the override
 	// Arrange
 	quizService := &MockQuizService{
-		getByIdReturns: &domain.Quiz{
-			CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"),
-		},
+		getByIdReturnsError: gorm.ErrRecordNotFound,
 	}
 	handler := &QuizHandler{QuizService: quizService}
 
@@ -343,10 +567,11 @@ func TestQuizHandler_Put_CallsService(t *testing.T) {
 	assert.Equal(t, http.StatusOK, writer.Code)
 
 	expected := &domain.Quiz{
-		BaseObject:  domain.BaseObject{ID: uuid.MustParse("ac1d0e93-b545-48be-bff9-656a933afa04")},
-		Name:        "My Awesome Quiz",
-		Description: "Best quiz ever",
-		CreatorID:   uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"),
+		BaseObject:         domain.BaseObject{ID: uuid.MustParse("ac1d0e93-b545-48be-bff9-656a933afa04")},
+		Name:               "My Awesome Quiz",
+		Description:        "Best quiz ever",
+		CreatorID:          uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"),
+		MaxConcurrentGames: 1,
 		MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{
 			{
 				BaseQuestion: domain.BaseQuestion{
@@ -374,9 +599,7 @@ func TestQuizHandler_Put_ReturnsAnyErrors(t *testing.T) {
 	t.Parallel()
 	// Arrange
 	quizService := &MockQuizService{
-		getByIdReturns: &domain.Quiz{
-			CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58"),
-		},
+		getByIdReturnsError:   gorm.ErrRecordNotFound,
 		createOrUpdateReturns: assert.AnError,
 	}
 	handler := &QuizHandler{QuizService: quizService}
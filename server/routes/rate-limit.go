@@ -0,0 +1,58 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/services"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type RateLimitHandler struct {
+	IPLimiter   services.RateLimiter
+	GameLimiter services.RateLimiter
+}
+
+// IPGuard throttles requests coming from the same client
+func (r *RateLimitHandler) IPGuard() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ok, wait := r.IPLimiter.Take(c.ClientIP()); !ok {
+			logrus.Warnf("Rate limited %s", c.ClientIP())
+			abortTooManyRequests(c, wait)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// GameGuard throttles requests to the same game, regardless of where they come from. Only valid
+// IDs get a bucket, so made up IDs can't fill up memory.
+func (r *RateLimitHandler) GameGuard() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		gameID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			logrus.WithError(err).Error("UUID error")
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		if ok, wait := r.GameLimiter.Take(gameID.String()); !ok {
+			logrus.Warnf("Rate limited game %s", gameID)
+			abortTooManyRequests(c, wait)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// abortTooManyRequests tells the client how many seconds to wait before trying again
+func abortTooManyRequests(c *gin.Context, wait time.Duration) {
+	seconds := int(math.Max(1, math.Ceil(wait.Seconds())))
+	c.Header("Retry-After", strconv.Itoa(seconds))
+	c.AbortWithStatus(http.StatusTooManyRequests)
+}
@@ -0,0 +1,94 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimitHandler_IPGuard_ReturnsExpectedResponse(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		allowed    bool
+		wait       time.Duration
+		expected   int
+		retryAfter string
+	}{
+		"allowed": {
+			allowed:  true,
+			expected: http.StatusOK,
+		},
+		"limited": {
+			wait:       1500 * time.Millisecond,
+			expected:   http.StatusTooManyRequests,
+			retryAfter: "2",
+		},
+		"limited less than a second": {
+			wait:       time.Millisecond,
+			expected:   http.StatusTooManyRequests,
+			retryAfter: "1",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			limiter := &MockRateLimiter{takeReturns: testData.allowed, takeReturnsWait: testData.wait}
+			handler := &RateLimitHandler{IPLimiter: limiter}
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+			context.Request, _ = http.NewRequest(http.MethodGet, "", nil)
+			context.Request.RemoteAddr = "10.0.0.1:1234"
+
+			// Act
+			handler.IPGuard()(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+			assert.Equal(t, testData.retryAfter, writer.Header().Get("Retry-After"))
+			assert.Equal(t, "10.0.0.1", limiter.takeCalledWith)
+		})
+	}
+}
+
+func TestRateLimitHandler_GameGuard_UsesGameID(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	limiter := &MockRateLimiter{takeReturnsWait: time.Second}
+	handler := &RateLimitHandler{GameLimiter: limiter}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Params = []gin.Param{{Key: "id", Value: "788f12a9-51e8-4c87-9b0c-06bcc9f0691b"}}
+
+	// Act
+	handler.GameGuard()(context)
+
+	// Assert
+	assert.Equal(t, http.StatusTooManyRequests, writer.Code)
+	assert.Equal(t, "788f12a9-51e8-4c87-9b0c-06bcc9f0691b", limiter.takeCalledWith)
+}
+
+func TestRateLimitHandler_GameGuard_ReturnsBadRequestOnInvalidID(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	limiter := &MockRateLimiter{takeReturns: true}
+	handler := &RateLimitHandler{GameLimiter: limiter}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Params = []gin.Param{{Key: "id", Value: "not-a-game"}}
+
+	// Act
+	handler.GameGuard()(context)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	assert.Empty(t, limiter.takeCalledWith)
+}
@@ -6,12 +6,14 @@ import (
 	"github.com/dgrijalva/jwt-go"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
 	"github.com/survivorbat/qq.maarten.dev/server/inputs"
 	"github.com/survivorbat/qq.maarten.dev/server/services"
 	"github.com/zalando/gin-oauth2/google"
 	"golang.org/x/oauth2"
 	"io"
 	"net/http"
+	"strings"
 )
 
 const bearerSchema = "Bearer"
@@ -19,6 +21,7 @@ const bearerSchema = "Bearer"
 type TokenHandler struct {
 	CreatorService services.CreatorService
 	JwtService     services.JwtService
+	APIKeyService  services.APIKeyService
 	AuthConfig     *oauth2.Config
 }
 
@@ -105,6 +108,13 @@ func (t *TokenHandler) JwtGuard() gin.HandlerFunc {
 		}
 
 		tokenString := authHeader[len(bearerSchema)+1:]
+
+		// API keys are accepted as an alternative to a JWT
+		if strings.HasPrefix(tokenString, domain.APIKeyPrefix) {
+			t.authenticateAPIKey(c, tokenString)
+			return
+		}
+
 		token, err := t.JwtService.ValidateToken(tokenString)
 
 		if err != nil {
@@ -123,6 +133,50 @@ func (t *TokenHandler) JwtGuard() gin.HandlerFunc {
 	}
 }
 
+func (t *TokenHandler) authenticateAPIKey(c *gin.Context, plainKey string) {
+	if t.APIKeyService == nil {
+		logrus.Error("API keys are not enabled")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	key, err := t.APIKeyService.Authenticate(plainKey)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to validate API key")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	c.Set("user", key.CreatorID.String())
+	c.Set("apiKey", key)
+}
+
+// ScopeGuard prevents API keys without the given scope from using a route, regular sessions
+// are always allowed through
+func (t *TokenHandler) ScopeGuard(scope domain.APIKeyScope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, ok := c.Get("apiKey")
+		if !ok {
+			return
+		}
+
+		if key := value.(*domain.APIKey); !key.HasScope(scope) {
+			logrus.Errorf("API key %s does not have scope %s", key.ID, scope)
+			c.AbortWithStatus(http.StatusForbidden)
+		}
+	}
+}
+
+// SessionGuard prevents API keys from using a route at all, for example to create new keys
+func (t *TokenHandler) SessionGuard() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get("apiKey"); ok {
+			logrus.Error("API keys may not use this route")
+			c.AbortWithStatus(http.StatusForbidden)
+		}
+	}
+}
+
 // Refresh godoc
 //
 //	@Summary	Refresh your authentication token
@@ -3,7 +3,9 @@ package routes
 import (
 	"github.com/dgrijalva/jwt-go"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -143,3 +145,123 @@ func TestTokenHandler_JwtGuard_ReturnsErrorOnTokenInvalid(t *testing.T) {
 	// Assert
 	assert.Equal(t, http.StatusUnauthorized, writer.Code)
 }
+
+func TestTokenHandler_JwtGuard_SetsUserOnValidAPIKey(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	apiKey := &domain.APIKey{CreatorID: uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58")}
+	mockAPIKeyService := &MockAPIKeyService{authenticateReturns: apiKey}
+	tokenHandler := &TokenHandler{APIKeyService: mockAPIKeyService}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Request, _ = http.NewRequest("", "", nil)
+	context.Request.Header = http.Header{"Authorization": []string{"Bearer qq_abc"}}
+
+	// Act
+	tokenHandler.JwtGuard()(context)
+
+	// Assert
+	assert.Equal(t, "qq_abc", mockAPIKeyService.authenticateCalledWith)
+
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, "2f80947c-e724-4b38-8c8d-3823864fef58", context.GetString("user"))
+	assert.Equal(t, apiKey, context.MustGet("apiKey"))
+}
+
+func TestTokenHandler_JwtGuard_ReturnsErrorOnInvalidAPIKey(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	mockAPIKeyService := &MockAPIKeyService{authenticateReturnsError: assert.AnError}
+	tokenHandler := &TokenHandler{APIKeyService: mockAPIKeyService}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Request, _ = http.NewRequest("", "", nil)
+	context.Request.Header = http.Header{"Authorization": []string{"Bearer qq_abc"}}
+
+	// Act
+	tokenHandler.JwtGuard()(context)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, writer.Code)
+}
+
+func TestTokenHandler_JwtGuard_ReturnsErrorOnAPIKeysDisabled(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	tokenHandler := &TokenHandler{}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Request, _ = http.NewRequest("", "", nil)
+	context.Request.Header = http.Header{"Authorization": []string{"Bearer qq_abc"}}
+
+	// Act
+	tokenHandler.JwtGuard()(context)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, writer.Code)
+}
+
+func TestTokenHandler_ScopeGuard_ReturnsExpectedStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		apiKey   *domain.APIKey
+		expected int
+	}{
+		"session": {
+			expected: http.StatusOK,
+		},
+		"unrestricted key": {
+			apiKey:   &domain.APIKey{},
+			expected: http.StatusOK,
+		},
+		"key with scope": {
+			apiKey:   &domain.APIKey{Scopes: []domain.APIKeyScope{domain.ScopeQuizzesWrite}},
+			expected: http.StatusOK,
+		},
+		"key without scope": {
+			apiKey:   &domain.APIKey{Scopes: []domain.APIKeyScope{domain.ScopeGamesControl}},
+			expected: http.StatusForbidden,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			tokenHandler := &TokenHandler{}
+
+			writer := httptest.NewRecorder()
+			context, _ := gin.CreateTestContext(writer)
+
+			if testData.apiKey != nil {
+				context.Set("apiKey", testData.apiKey)
+			}
+
+			// Act
+			tokenHandler.ScopeGuard(domain.ScopeQuizzesWrite)(context)
+
+			// Assert
+			assert.Equal(t, testData.expected, writer.Code)
+		})
+	}
+}
+
+func TestTokenHandler_SessionGuard_ReturnsErrorOnAPIKey(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	tokenHandler := &TokenHandler{}
+
+	writer := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(writer)
+	context.Set("apiKey", &domain.APIKey{})
+
+	// Act
+	tokenHandler.SessionGuard()(context)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, writer.Code)
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"gorm.io/gorm"
+	"time"
+)
+
+// Compile-time interface checks
+var _ APIKeyService = new(DBAPIKeyService)
+
+type APIKeyService interface {
+	GetByID(id uuid.UUID) (*domain.APIKey, error)
+	GetByCreator(creatorID uuid.UUID) ([]*domain.APIKey, error)
+
+	// Create saves the key and returns the plain key, which can not be retrieved afterwards
+	Create(key *domain.APIKey) (string, error)
+	Delete(key *domain.APIKey) error
+
+	// Authenticate looks up the key and updates its last used timestamp
+	Authenticate(key string) (*domain.APIKey, error)
+}
+
+type DBAPIKeyService struct {
+	Database *gorm.DB
+}
+
+func (a *DBAPIKeyService) GetByID(id uuid.UUID) (*domain.APIKey, error) {
+	var result *domain.APIKey
+	if err := a.Database.First(&result, id).Error; err != nil {
+		logrus.WithError(err).Error("Failed to get by id")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (a *DBAPIKeyService) GetByCreator(creatorID uuid.UUID) ([]*domain.APIKey, error) {
+	var result []*domain.APIKey
+	if err := a.Database.Where("creator_id = ?", creatorID).Find(&result).Error; err != nil {
+		logrus.WithError(err).Error("Failed to get by creator")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (a *DBAPIKeyService) Create(key *domain.APIKey) (string, error) {
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		logrus.WithError(err).Error("Failed to generate key")
+		return "", err
+	}
+
+	plain := domain.APIKeyPrefix + hex.EncodeToString(secret)
+	key.Hash = domain.HashAPIKey(plain)
+	key.Hint = plain[:len(domain.APIKeyPrefix)+4]
+
+	if err := a.Database.Create(key).Error; err != nil {
+		logrus.WithError(err).Error("Failed to create")
+		return "", err
+	}
+
+	return plain, nil
+}
+
+func (a *DBAPIKeyService) Delete(key *domain.APIKey) error {
+	if err := a.Database.Delete(key).Error; err != nil {
+		logrus.WithError(err).Error("Failed to delete")
+		return err
+	}
+
+	return nil
+}
+
+func (a *DBAPIKeyService) Authenticate(key string) (*domain.APIKey, error) {
+	var result *domain.APIKey
+	if err := a.Database.Where("hash = ?", domain.HashAPIKey(key)).First(&result).Error; err != nil {
+		logrus.WithError(err).Error("Failed to find key")
+		return nil, err
+	}
+
+	result.LastUsed = time.Now()
+	if err := a.Database.Model(result).Update("last_used", result.LastUsed).Error; err != nil {
+		logrus.WithError(err).Error("Failed to update last used")
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"strings"
+	"testing"
+)
+
+func TestDBAPIKeyService_GetByCreator_ReturnsExpected(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBAPIKeyService{Database: database}
+
+	creators := []*domain.Creator{
+		{BaseObject: domain.BaseObject{ID: uuid.MustParse("3c97f06b-1078-46ef-a2c3-71fc4d9a3d3d")}, Nickname: "a", AuthID: "a"},
+		{BaseObject: domain.BaseObject{ID: uuid.MustParse("5dff22a7-afc7-4e4b-a63d-9903dedd66bf")}, Nickname: "b", AuthID: "b"},
+	}
+
+	keys := []*domain.APIKey{
+		{Name: "a", Hash: "a", Creator: creators[0], Scopes: []domain.APIKeyScope{domain.ScopeQuizzesWrite}},
+		{Name: "b", Hash: "b", Creator: creators[1]},
+	}
+
+	database.CreateInBatches(keys, 10)
+
+	// Act
+	result, err := service.GetByCreator(creators[0].ID)
+
+	// Assert
+	assert.NoError(t, err)
+
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, keys[0].ID, result[0].ID)
+		assert.Equal(t, keys[0].Scopes, result[0].Scopes)
+	}
+}
+
+func TestDBAPIKeyService_GetByCreator_ReturnsDatabaseError(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+
+	// By not running this, we're sure it will return an error
+	// autoMigrate(t, database)
+
+	service := &DBAPIKeyService{Database: database}
+
+	// Act
+	result, err := service.GetByCreator(uuid.MustParse("3c97f06b-1078-46ef-a2c3-71fc4d9a3d3d"))
+
+	// Assert
+	assert.Empty(t, result)
+	assert.ErrorContains(t, err, "no such table")
+}
+
+func TestDBAPIKeyService_Create_StoresHashAndReturnsKey(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBAPIKeyService{Database: database}
+
+	creator := &domain.Creator{Nickname: "a", AuthID: "a"}
+	database.Create(creator)
+
+	key := &domain.APIKey{Name: "CMS", CreatorID: creator.ID}
+
+	// Act
+	result, err := service.Create(key)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(result, domain.APIKeyPrefix))
+	assert.True(t, strings.HasPrefix(result, key.Hint))
+
+	var saved *domain.APIKey
+	database.First(&saved, key.ID)
+	assert.Equal(t, domain.HashAPIKey(result), saved.Hash)
+}
+
+func TestDBAPIKeyService_Authenticate_UpdatesLastUsed(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBAPIKeyService{Database: database}
+
+	creator := &domain.Creator{Nickname: "a", AuthID: "a"}
+	database.Create(creator)
+
+	key := &domain.APIKey{Name: "CMS", CreatorID: creator.ID}
+	plain, _ := service.Create(key)
+
+	// Act
+	result, err := service.Authenticate(plain)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, key.ID, result.ID)
+	assert.Equal(t, creator.ID, result.CreatorID)
+
+	var saved *domain.APIKey
+	database.First(&saved, key.ID)
+	assert.False(t, saved.LastUsed.IsZero())
+}
+
+func TestDBAPIKeyService_Authenticate_ReturnsErrorOnUnknownKey(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBAPIKeyService{Database: database}
+
+	// Act
+	result, err := service.Authenticate("qq_abc")
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorContains(t, err, "record not found")
+}
+
+func TestDBAPIKeyService_Delete_DeletesKey(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBAPIKeyService{Database: database}
+
+	creator := &domain.Creator{Nickname: "a", AuthID: "a"}
+	key := &domain.APIKey{Name: "CMS", Hash: "abc", Creator: creator}
+	database.Create(key)
+
+	// Act
+	err := service.Delete(key)
+
+	// Assert
+	assert.NoError(t, err)
+
+	_, err = service.GetByID(key.ID)
+	assert.ErrorContains(t, err, "record not found")
+}
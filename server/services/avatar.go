@@ -0,0 +1,107 @@
+package services
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/binary"
+	"fmt"
+	"github.com/google/uuid"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Compile-time interface checks
+var _ AvatarService = new(EmbeddedAvatarService)
+
+//go:embed avatars/*.svg
+var avatarFiles embed.FS
+
+const (
+	// identiconCells is the width and height of the identicon grid, the left half is mirrored
+	identiconCells = 5
+
+	// identiconCellSize is the size of a cell in a 100x100 viewbox with 10 padding on every side
+	identiconCellSize = 16
+)
+
+type AvatarService interface {
+	// Catalog returns the names of all built-in avatars
+	Catalog() []string
+
+	// Exists returns whether a built-in avatar with this name exists
+	Exists(name string) bool
+
+	// GetCatalogAvatar returns the SVG of a built-in avatar
+	GetCatalogAvatar(name string) ([]byte, error)
+
+	// Generate returns an identicon-style SVG that is always the same for the given ID
+	Generate(id uuid.UUID) []byte
+}
+
+// EmbeddedAvatarService serves the avatars that are embedded in the binary
+type EmbeddedAvatarService struct{}
+
+func (e *EmbeddedAvatarService) Catalog() []string {
+	entries, _ := avatarFiles.ReadDir("avatars")
+
+	result := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, strings.TrimSuffix(entry.Name(), ".svg"))
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+func (e *EmbeddedAvatarService) Exists(name string) bool {
+	for _, avatar := range e.Catalog() {
+		if avatar == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *EmbeddedAvatarService) GetCatalogAvatar(name string) ([]byte, error) {
+	if !e.Exists(name) {
+		return nil, fmt.Errorf("avatar %q does not exist", name)
+	}
+
+	return avatarFiles.ReadFile(path.Join("avatars", name+".svg"))
+}
+
+func (e *EmbeddedAvatarService) Generate(id uuid.UUID) []byte {
+	hash := sha256.Sum256(id[:])
+
+	hue := binary.BigEndian.Uint16(hash[:2]) % 360
+	color := fmt.Sprintf("hsl(%d, 55%%, 50%%)", hue)
+
+	var result strings.Builder
+	result.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 100 100">`)
+	result.WriteString(`<rect width="100" height="100" fill="#f0f0f0"/>`)
+
+	half := (identiconCells + 1) / 2
+	for row := 0; row < identiconCells; row++ {
+		for column := 0; column < half; column++ {
+			if hash[2+row*half+column]%2 != 0 {
+				continue
+			}
+
+			// Draw the cell and its mirror image, the middle column only once
+			columns := []int{column}
+			if mirror := identiconCells - 1 - column; mirror != column {
+				columns = append(columns, mirror)
+			}
+
+			for _, x := range columns {
+				result.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`,
+					10+x*identiconCellSize, 10+row*identiconCellSize, identiconCellSize, identiconCellSize, color))
+			}
+		}
+	}
+
+	result.WriteString(`</svg>`)
+	return []byte(result.String())
+}
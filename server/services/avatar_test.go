@@ -0,0 +1,64 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedAvatarService_Catalog_ReturnsBuiltInAvatars(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	service := new(EmbeddedAvatarService)
+
+	// Act
+	result := service.Catalog()
+
+	// Assert
+	assert.Contains(t, result, "moon")
+	assert.NotContains(t, result, "moon.svg")
+}
+
+func TestEmbeddedAvatarService_GetCatalogAvatar_ReturnsSVG(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	service := new(EmbeddedAvatarService)
+
+	// Act
+	result, err := service.GetCatalogAvatar("moon")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(result), "<svg"))
+}
+
+func TestEmbeddedAvatarService_GetCatalogAvatar_ReturnsErrorOnUnknownAvatar(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	service := new(EmbeddedAvatarService)
+
+	// Act
+	result, err := service.GetCatalogAvatar("../avatar.go")
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorContains(t, err, "does not exist")
+}
+
+func TestEmbeddedAvatarService_Generate_IsDeterministic(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	service := new(EmbeddedAvatarService)
+
+	first := uuid.MustParse("6aacfb41-e478-46ec-857e-11221f2a97fc")
+	second := uuid.MustParse("2389b70a-74df-439c-8d5f-cf4f3f9471bd")
+
+	// Act
+	result := service.Generate(first)
+
+	// Assert
+	assert.True(t, strings.HasPrefix(string(result), "<svg"))
+	assert.Equal(t, result, service.Generate(first))
+	assert.NotEqual(t, result, service.Generate(second))
+}
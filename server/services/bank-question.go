@@ -0,0 +1,238 @@
+package services
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+)
+
+// Compile-time interface checks
+var _ BankQuestionService = new(DBBankQuestionService)
+
+// BankQuestionFilter narrows down the bank questions, empty fields are ignored
+type BankQuestionFilter struct {
+	Query    string
+	Category string
+	Tag      string
+}
+
+type BankQuestionService interface {
+	GetByID(id uuid.UUID) (*domain.BankQuestion, error)
+
+	// GetAccessible returns the bank questions of the creator and of their organizations
+	GetAccessible(creatorID uuid.UUID, filter *BankQuestionFilter) ([]*domain.BankQuestion, error)
+	GetStats(id uuid.UUID) (*domain.BankQuestionStats, error)
+	Create(question *domain.BankQuestion) error
+
+	// Update saves the question and copies it into every quiz and draft that links to it
+	Update(question *domain.BankQuestion) error
+	Delete(id uuid.UUID) error
+}
+
+type DBBankQuestionService struct {
+	Database *gorm.DB
+}
+
+func (d *DBBankQuestionService) GetByID(id uuid.UUID) (*domain.BankQuestion, error) {
+	var result *domain.BankQuestion
+	if err := d.Database.Preload("Tags").Preload("Organization.Members").Where("id = ?", id).First(&result).Error; err != nil {
+		logrus.WithError(err).Error("Failed to get by id")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (d *DBBankQuestionService) GetAccessible(creatorID uuid.UUID, filter *BankQuestionFilter) ([]*domain.BankQuestion, error) {
+	organizations := d.Database.Model(new(domain.Member)).Select("organization_id").Where("creator_id = ?", creatorID)
+
+	query := d.Database.Where(d.Database.Where("creator_id = ?", creatorID).Or("organization_id IN (?)", organizations))
+
+	if filter.Query != "" {
+		query = query.Where(`LOWER(title) LIKE ? ESCAPE '\'`, "%"+likeEscaper.Replace(strings.ToLower(filter.Query))+"%")
+	}
+
+	if filter.Category != "" {
+		query = query.Where("LOWER(category) = ?", strings.ToLower(filter.Category))
+	}
+
+	if filter.Tag != "" {
+		tagged := d.Database.Model(new(domain.BankQuestionTag)).Select("bank_question_id").Where("name = ?", domain.NormalizeTag(filter.Tag))
+		query = query.Where("id IN (?)", tagged)
+	}
+
+	var result []*domain.BankQuestion
+	if err := query.Preload("Tags").Order("category").Order("title").Find(&result).Error; err != nil {
+		logrus.WithError(err).Error("Failed to get accessible")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (d *DBBankQuestionService) GetStats(id uuid.UUID) (*domain.BankQuestionStats, error) {
+	// Replacing all questions of a quiz leaves the old ones without a quiz_id, they still count for answers
+	questions := d.Database.Model(new(domain.MultipleChoiceQuestion)).Where("bank_question_id = ?", id)
+
+	result := new(domain.BankQuestionStats)
+
+	if err := questions.Session(&gorm.Session{}).Where("quiz_id IS NOT NULL").Distinct("quiz_id").Count(&result.Quizzes).Error; err != nil {
+		logrus.WithError(err).Error("Failed to count quizzes")
+		return nil, err
+	}
+
+	if err := questions.Session(&gorm.Session{}).Where("quiz_id IS NOT NULL AND linked = ?", true).Distinct("quiz_id").Count(&result.LinkedQuizzes).Error; err != nil {
+		logrus.WithError(err).Error("Failed to count linked quizzes")
+		return nil, err
+	}
+
+	answers := d.Database.Model(new(domain.GameAnswer)).
+		Joins("JOIN multiple_choice_questions ON multiple_choice_questions.id = game_answers.question_id").
+		Where("multiple_choice_questions.bank_question_id = ?", id)
+
+	if err := answers.Session(&gorm.Session{}).Count(&result.Answers).Error; err != nil {
+		logrus.WithError(err).Error("Failed to count answers")
+		return nil, err
+	}
+
+	if err := answers.Session(&gorm.Session{}).Where("game_answers.option_id = multiple_choice_questions.answer_id").Count(&result.CorrectAnswers).Error; err != nil {
+		logrus.WithError(err).Error("Failed to count correct answers")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (d *DBBankQuestionService) Create(question *domain.BankQuestion) error {
+	if err := d.Database.Create(question).Error; err != nil {
+		logrus.WithError(err).Error("Failed to create bank question")
+		return err
+	}
+
+	return nil
+}
+
+func (d *DBBankQuestionService) Update(question *domain.BankQuestion) error {
+	return d.Database.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("bank_question_id = ?", question.ID).Delete(new(domain.BankQuestionTag)).Error; err != nil {
+			logrus.WithError(err).Error("Failed to delete tags")
+			return err
+		}
+
+		if err := tx.Omit("Organization", "Creator").Clauses(clause.OnConflict{UpdateAll: true}).Create(question).Error; err != nil {
+			logrus.WithError(err).Error("Failed to update bank question")
+			return err
+		}
+
+		var linked []*domain.MultipleChoiceQuestion
+		if err := tx.Preload("Options", orderByPosition).Where("bank_question_id = ? AND linked = ? AND quiz_id IS NOT NULL", question.ID, true).Find(&linked).Error; err != nil {
+			logrus.WithError(err).Error("Failed to get linked questions")
+			return err
+		}
+
+		quizIDs := map[uuid.UUID]bool{}
+		for _, quizQuestion := range linked {
+			question.ApplyTo(quizQuestion)
+			quizIDs[quizQuestion.QuizID] = true
+		}
+
+		for quizID := range quizIDs {
+			if err := syncLinkedQuestions(tx, quizID, linked); err != nil {
+				return err
+			}
+		}
+
+		return syncDrafts(tx, question)
+	})
+}
+
+// syncLinkedQuestions saves the linked questions of the quiz in place and gives the quiz a new
+// version and revision, so new games get the change and running games keep the old one
+func syncLinkedQuestions(tx *gorm.DB, quizID uuid.UUID, linked []*domain.MultipleChoiceQuestion) error {
+	var quiz *domain.Quiz
+	if err := tx.Preload("MultipleChoiceQuestions.Options", orderByPosition).Preload("QuestionPools").Preload("Tags").Where("id = ?", quizID).First(&quiz).Error; err != nil {
+		logrus.WithError(err).Error("Failed to get linked quiz")
+		return err
+	}
+
+	for _, question := range linked {
+		if question.QuizID != quizID {
+			continue
+		}
+
+		for index, existing := range quiz.MultipleChoiceQuestions {
+			if existing.ID == question.ID {
+				quiz.MultipleChoiceQuestions[index] = question
+			}
+		}
+	}
+
+	previous := quiz.Version
+	if err := (&DBQuizService{Database: tx}).UpdateQuestions(quiz, previous); err != nil {
+		return err
+	}
+
+	// The draft gets the same change in syncDrafts, so it may still be published on top of this version
+	if err := tx.Model(new(domain.QuizDraft)).Where("quiz_id = ? AND quiz_version = ?", quizID, previous).Update("quiz_version", quiz.Version).Error; err != nil {
+		logrus.WithError(err).Error("Failed to move draft along")
+		return err
+	}
+
+	return nil
+}
+
+// syncDrafts applies the bank question to the questions that are linked to it in drafts, so publishing
+// a draft doesn't undo the change
+func syncDrafts(tx *gorm.DB, bank *domain.BankQuestion) error {
+	var drafts []*domain.QuizDraft
+	if err := tx.Find(&drafts).Error; err != nil {
+		logrus.WithError(err).Error("Failed to get drafts")
+		return err
+	}
+
+	for _, draft := range drafts {
+		var content *inputs.Quiz
+		if err := json.Unmarshal(draft.Content, &content); err != nil || content == nil {
+			logrus.WithError(err).Errorf("Failed to parse draft of quiz %s", draft.QuizID)
+			continue
+		}
+
+		var changed bool
+		for index, question := range content.MultipleChoiceQuestions {
+			if !question.Linked || question.BankQuestionID == nil || *question.BankQuestionID != bank.ID {
+				continue
+			}
+
+			synced := question.ToDomain()
+			bank.ApplyTo(synced)
+			content.MultipleChoiceQuestions[index] = inputs.NewMultipleChoiceQuestion(synced)
+			changed = true
+		}
+
+		if !changed {
+			continue
+		}
+
+		synced, _ := json.Marshal(content)
+		if err := tx.Model(draft).Updates(map[string]any{"content": synced, "version": gorm.Expr("version + 1")}).Error; err != nil {
+			logrus.WithError(err).Error("Failed to sync draft")
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *DBBankQuestionService) Delete(id uuid.UUID) error {
+	if err := d.Database.Delete(new(domain.BankQuestion), id).Error; err != nil {
+		logrus.WithError(err).Error("Failed to delete bank question")
+		return err
+	}
+
+	return nil
+}
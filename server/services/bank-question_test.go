@@ -0,0 +1,327 @@
+package services
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"github.com/survivorbat/qq.maarten.dev/server/inputs"
+	"testing"
+)
+
+func TestDBBankQuestionService_GetAccessible_ReturnsOwnAndOrganizationQuestions(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	autoMigrate(t, database)
+
+	service := &DBBankQuestionService{Database: database}
+
+	creator := &domain.Creator{Nickname: "abc", AuthID: "abc"}
+	other := &domain.Creator{Nickname: "def", AuthID: "def"}
+	database.Create(creator)
+	database.Create(other)
+
+	organization := &domain.Organization{Name: "school", Members: []*domain.Member{{CreatorID: creator.ID, Role: domain.OrganizationMember}}}
+	database.Create(organization)
+
+	database.Create(&domain.BankQuestion{CreatorID: creator.ID, Title: "Capital of France", Category: "Geography", Tags: domain.NewBankQuestionTags([]string{"Capitals"})})
+	database.Create(&domain.BankQuestion{CreatorID: creator.ID, Title: "Longest river", Category: "Geography"})
+	database.Create(&domain.BankQuestion{CreatorID: other.ID, OrganizationID: &organization.ID, Title: "Capital of Spain", Category: "Geography"})
+	database.Create(&domain.BankQuestion{CreatorID: other.ID, Title: "Capital of Italy", Category: "Geography"})
+	database.Create(&domain.BankQuestion{CreatorID: creator.ID, Title: "Year of the moon landing", Category: "History"})
+
+	tests := map[string]struct {
+		filter   *BankQuestionFilter
+		expected []string
+	}{
+		"everything": {
+			filter:   &BankQuestionFilter{},
+			expected: []string{"Capital of France", "Capital of Spain", "Longest river", "Year of the moon landing"},
+		},
+		"query": {
+			filter:   &BankQuestionFilter{Query: "CAPITAL"},
+			expected: []string{"Capital of France", "Capital of Spain"},
+		},
+		"category": {
+			filter:   &BankQuestionFilter{Category: "history"},
+			expected: []string{"Year of the moon landing"},
+		},
+		"tag": {
+			filter:   &BankQuestionFilter{Tag: "capitals"},
+			expected: []string{"Capital of France"},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			// Act
+			result, err := service.GetAccessible(creator.ID, testData.filter)
+
+			// Assert
+			assert.NoError(t, err)
+
+			titles := make([]string, len(result))
+			for index, question := range result {
+				titles[index] = question.Title
+			}
+
+			assert.Equal(t, testData.expected, titles)
+		})
+	}
+}
+
+func TestDBBankQuestionService_Update_UpdatesLinkedQuestions(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	autoMigrate(t, database)
+
+	service := &DBBankQuestionService{Database: database}
+	quizService := &DBQuizService{Database: database}
+
+	creator := &domain.Creator{Nickname: "abc", AuthID: "abc"}
+	database.Create(creator)
+
+	answerID := uuid.New()
+	bank := &domain.BankQuestion{
+		CreatorID: creator.ID,
+		Title:     "Capital of France?",
+		Category:  "Geography",
+		AnswerID:  answerID,
+		Options:   []*domain.BankOption{{ID: uuid.New(), TextOption: "Lyon"}, {ID: answerID, TextOption: "Paris"}},
+	}
+	if err := service.Create(bank); err != nil {
+		t.Fatal(err)
+	}
+
+	linked := bank.NewQuestion(true)
+	copied := bank.NewQuestion(false)
+	copied.Order = 1
+
+	quiz := &domain.Quiz{Name: "quiz", CreatorID: creator.ID, MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{linked, copied}}
+	if err := quizService.CreateOrUpdate(quiz); err != nil {
+		t.Fatal(err)
+	}
+
+	keptOption := linked.Options[0].ID
+
+	update := &domain.BankQuestion{
+		BaseObject: bank.BaseObject,
+		CreatorID:  creator.ID,
+		Title:      "Capital of Germany?",
+		Category:   "Geography",
+		AnswerID:   answerID,
+		Options:    []*domain.BankOption{{ID: uuid.New(), TextOption: "Munich"}, {ID: uuid.New(), TextOption: "Hamburg"}, {ID: answerID, TextOption: "Berlin"}},
+		Tags:       domain.NewBankQuestionTags([]string{"capitals"}),
+	}
+
+	// Act
+	err := service.Update(update)
+
+	// Assert
+	assert.NoError(t, err)
+
+	result, err := quizService.GetByID(quiz.ID)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	assert.Equal(t, uint(2), result.Version)
+
+	linkedResult, _ := result.Question(linked.ID)
+	assert.Equal(t, "Capital of Germany?", linkedResult.Title)
+	if assert.Len(t, linkedResult.Options, 3) {
+		assert.Equal(t, keptOption, linkedResult.Options[0].ID)
+		assert.Equal(t, "Munich", linkedResult.Options[0].TextOption)
+		assert.Equal(t, "Hamburg", linkedResult.Options[1].TextOption)
+		assert.Equal(t, "Berlin", linkedResult.Options[2].TextOption)
+
+		answer, _ := linkedResult.Option(linkedResult.AnswerID)
+		assert.Equal(t, "Berlin", answer.TextOption)
+	}
+
+	copiedResult, _ := result.Question(copied.ID)
+	assert.Equal(t, "Capital of France?", copiedResult.Title)
+	assert.Len(t, copiedResult.Options, 2)
+
+	var revisions int64
+	database.Model(new(domain.QuizRevision)).Where("quiz_id = ?", quiz.ID).Count(&revisions)
+	assert.Equal(t, int64(2), revisions)
+
+	bankResult, _ := service.GetByID(bank.ID)
+	if assert.Len(t, bankResult.Tags, 1) {
+		assert.Equal(t, "capitals", bankResult.Tags[0].Name)
+	}
+}
+
+func TestDBBankQuestionService_Update_UpdatesLinkedQuestionsInDrafts(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	autoMigrate(t, database)
+
+	service := &DBBankQuestionService{Database: database}
+	quizService := &DBQuizService{Database: database}
+	draftService := &DBQuizDraftService{Database: database}
+
+	creator := &domain.Creator{Nickname: "abc", AuthID: "abc"}
+	database.Create(creator)
+
+	answerID := uuid.New()
+	bank := &domain.BankQuestion{
+		CreatorID: creator.ID,
+		Title:     "Capital of France?",
+		Category:  "Geography",
+		AnswerID:  answerID,
+		Options:   []*domain.BankOption{{ID: uuid.New(), TextOption: "Lyon"}, {ID: answerID, TextOption: "Paris"}},
+	}
+	if err := service.Create(bank); err != nil {
+		t.Fatal(err)
+	}
+
+	linked := bank.NewQuestion(true)
+	quiz := &domain.Quiz{Name: "quiz", CreatorID: creator.ID, MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{linked}}
+	if err := quizService.CreateOrUpdate(quiz); err != nil {
+		t.Fatal(err)
+	}
+
+	// The draft renames the quiz and adds a question, the linked question is still in there
+	edited := inputs.NewQuiz(quiz)
+	edited.Name = "edited quiz"
+	edited.MultipleChoiceQuestions = append(edited.MultipleChoiceQuestions, &inputs.MultipleChoiceQuestion{ID: uuid.New(), Title: "New question", Order: 1})
+	content, _ := json.Marshal(edited)
+
+	if err := draftService.Save(&domain.QuizDraft{QuizID: quiz.ID, Content: content, Version: quiz.Version}); err != nil {
+		t.Fatal(err)
+	}
+
+	update := &domain.BankQuestion{
+		BaseObject: bank.BaseObject,
+		CreatorID:  creator.ID,
+		Title:      "Capital of Germany?",
+		Category:   "Geography",
+		AnswerID:   answerID,
+		Options:    []*domain.BankOption{{ID: uuid.New(), TextOption: "Munich"}, {ID: answerID, TextOption: "Berlin"}},
+	}
+
+	// Act
+	err := service.Update(update)
+
+	// Assert
+	assert.NoError(t, err)
+
+	draft, err := draftService.GetByQuiz(quiz.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, uint(3), draft.Version)
+	assert.Equal(t, uint(2), draft.QuizVersion)
+
+	var result *inputs.Quiz
+	if err := json.Unmarshal(draft.Content, &result); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "edited quiz", result.Name)
+	if assert.Len(t, result.MultipleChoiceQuestions, 2) {
+		synced := result.MultipleChoiceQuestions[0]
+		assert.Equal(t, linked.ID, synced.ID)
+		assert.Equal(t, "Capital of Germany?", synced.Title)
+
+		if assert.Len(t, synced.Options, 2) {
+			assert.Equal(t, linked.Options[0].ID, synced.Options[0].ID)
+			assert.Equal(t, "Munich", synced.Options[0].TextOption)
+			assert.True(t, synced.Options[1].Answer)
+		}
+
+		assert.Equal(t, "New question", result.MultipleChoiceQuestions[1].Title)
+	}
+}
+
+func TestDBBankQuestionService_GetStats_CountsUsage(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	autoMigrate(t, database)
+
+	service := &DBBankQuestionService{Database: database}
+	quizService := &DBQuizService{Database: database}
+
+	creator := &domain.Creator{Nickname: "abc", AuthID: "abc"}
+	database.Create(creator)
+
+	answerID := uuid.New()
+	bank := &domain.BankQuestion{
+		CreatorID: creator.ID,
+		Title:     "Capital of France?",
+		AnswerID:  answerID,
+		Options:   []*domain.BankOption{{ID: uuid.New(), TextOption: "Lyon"}, {ID: answerID, TextOption: "Paris"}},
+	}
+	database.Create(bank)
+
+	linked := bank.NewQuestion(true)
+	copied := bank.NewQuestion(false)
+
+	var quizID uuid.UUID
+	for _, question := range []*domain.MultipleChoiceQuestion{linked, copied} {
+		quiz := &domain.Quiz{Name: "quiz", CreatorID: creator.ID, MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{question}}
+		if err := quizService.CreateOrUpdate(quiz); err != nil {
+			t.Fatal(err)
+		}
+
+		quizID = quiz.ID
+	}
+
+	game := &domain.Game{QuizID: quizID, Players: []*domain.Player{{}, {}}}
+	database.Create(game)
+
+	player, other := game.Players[0], game.Players[1]
+	database.Create(&domain.GameAnswer{GameID: game.ID, PlayerID: player.ID, QuestionID: linked.ID, OptionID: linked.AnswerID})
+	database.Create(&domain.GameAnswer{GameID: game.ID, PlayerID: other.ID, QuestionID: linked.ID, OptionID: linked.Options[0].ID})
+	database.Create(&domain.GameAnswer{GameID: game.ID, PlayerID: player.ID, QuestionID: copied.ID, OptionID: copied.AnswerID})
+
+	// Act
+	result, err := service.GetStats(bank.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.BankQuestionStats{Quizzes: 2, LinkedQuizzes: 1, Answers: 3, CorrectAnswers: 2}, result)
+}
+
+func TestDBBankQuestionService_Delete_KeepsQuizQuestions(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	autoMigrate(t, database)
+
+	service := &DBBankQuestionService{Database: database}
+	quizService := &DBQuizService{Database: database}
+
+	creator := &domain.Creator{Nickname: "abc", AuthID: "abc"}
+	database.Create(creator)
+
+	bank := &domain.BankQuestion{CreatorID: creator.ID, Title: "Capital of France?", Options: []*domain.BankOption{{ID: uuid.New()}, {ID: uuid.New()}}}
+	database.Create(bank)
+
+	question := bank.NewQuestion(true)
+	quiz := &domain.Quiz{Name: "quiz", CreatorID: creator.ID, MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{question}}
+	if err := quizService.CreateOrUpdate(quiz); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	err := service.Delete(bank.ID)
+
+	// Assert
+	assert.NoError(t, err)
+
+	_, err = service.GetByID(bank.ID)
+	assert.Error(t, err)
+
+	result, _ := quizService.GetByID(quiz.ID)
+	assert.Len(t, result.MultipleChoiceQuestions, 1)
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Compile-time interface checks
+var _ CollaboratorService = new(DBCollaboratorService)
+
+type CollaboratorService interface {
+	GetByQuiz(quizID uuid.UUID) ([]*domain.Collaborator, error)
+
+	// Save adds the collaborator to the quiz, or changes their role if they already are one. Returns
+	// gorm.ErrRecordNotFound if the creator doesn't exist.
+	Save(collaborator *domain.Collaborator) error
+	Delete(quizID uuid.UUID, creatorID uuid.UUID) error
+}
+
+type DBCollaboratorService struct {
+	Database *gorm.DB
+}
+
+func (d *DBCollaboratorService) GetByQuiz(quizID uuid.UUID) ([]*domain.Collaborator, error) {
+	var result []*domain.Collaborator
+	if err := d.Database.Preload("Creator").Where("quiz_id = ?", quizID).Find(&result).Error; err != nil {
+		logrus.WithError(err).Error("Failed to get by quiz")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (d *DBCollaboratorService) Save(collaborator *domain.Collaborator) error {
+	var creators int64
+	if err := d.Database.Model(new(domain.Creator)).Where("id = ?", collaborator.CreatorID).Count(&creators).Error; err != nil {
+		logrus.WithError(err).Error("Failed to find creator")
+		return err
+	}
+
+	if creators == 0 {
+		logrus.Errorf("Creator %s not found", collaborator.CreatorID)
+		return gorm.ErrRecordNotFound
+	}
+
+	onConflict := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "quiz_id"}, {Name: "creator_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role", "updated_at"}),
+	}
+
+	if err := d.Database.Clauses(onConflict).Create(collaborator).Error; err != nil {
+		logrus.WithError(err).Error("Failed to save")
+		return err
+	}
+
+	return nil
+}
+
+func (d *DBCollaboratorService) Delete(quizID uuid.UUID, creatorID uuid.UUID) error {
+	query := d.Database.Where("quiz_id = ? AND creator_id = ?", quizID, creatorID).Delete(new(domain.Collaborator))
+	if err := query.Error; err != nil {
+		logrus.WithError(err).Error("Failed to delete")
+		return err
+	}
+
+	if query.RowsAffected == 0 {
+		logrus.Error("Collaborator not found")
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
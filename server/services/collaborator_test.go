@@ -0,0 +1,155 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestDBCollaboratorService_GetByQuiz_ReturnsExpected(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBCollaboratorService{Database: database}
+
+	creators := []*domain.Creator{
+		{BaseObject: domain.BaseObject{ID: uuid.MustParse("3c97f06b-1078-46ef-a2c3-71fc4d9a3d3d")}, Nickname: "a", AuthID: "a"},
+		{BaseObject: domain.BaseObject{ID: uuid.MustParse("5dff22a7-afc7-4e4b-a63d-9903dedd66bf")}, Nickname: "b", AuthID: "b"},
+	}
+
+	quizzes := []*domain.Quiz{
+		{Creator: creators[0], Collaborators: []*domain.Collaborator{{Creator: creators[1], Role: domain.RoleHost}}},
+		{Creator: creators[1]},
+	}
+
+	database.CreateInBatches(quizzes, 10)
+
+	// Act
+	result, err := service.GetByQuiz(quizzes[0].ID)
+
+	// Assert
+	assert.NoError(t, err)
+
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, domain.RoleHost, result[0].Role)
+		assert.Equal(t, creators[1].Nickname, result[0].Creator.Nickname)
+	}
+}
+
+func TestDBCollaboratorService_GetByQuiz_ReturnsDatabaseError(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+
+	// By not running this, we're sure it will return an error
+	// autoMigrate(t, database)
+
+	service := &DBCollaboratorService{Database: database}
+
+	// Act
+	result, err := service.GetByQuiz(uuid.MustParse("3c97f06b-1078-46ef-a2c3-71fc4d9a3d3d"))
+
+	// Assert
+	assert.Empty(t, result)
+	assert.ErrorContains(t, err, "no such table")
+}
+
+func TestDBCollaboratorService_Save_UpdatesExistingRole(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBCollaboratorService{Database: database}
+
+	creators := []*domain.Creator{
+		{Nickname: "a", AuthID: "a"},
+		{Nickname: "b", AuthID: "b"},
+	}
+	quiz := &domain.Quiz{Creator: creators[0]}
+	database.Create(quiz)
+	database.Create(creators[1])
+
+	// Act
+	errA := service.Save(&domain.Collaborator{QuizID: quiz.ID, CreatorID: creators[1].ID, Role: domain.RoleViewer})
+	errB := service.Save(&domain.Collaborator{QuizID: quiz.ID, CreatorID: creators[1].ID, Role: domain.RoleEditor})
+
+	// Assert
+	assert.NoError(t, errA)
+	assert.NoError(t, errB)
+
+	var result []*domain.Collaborator
+	database.Find(&result)
+
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, domain.RoleEditor, result[0].Role)
+	}
+}
+
+func TestDBCollaboratorService_Save_ReturnsErrorOnUnknownCreator(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBCollaboratorService{Database: database}
+
+	quiz := &domain.Quiz{Creator: &domain.Creator{Nickname: "a", AuthID: "a"}}
+	database.Create(quiz)
+
+	// Act
+	err := service.Save(&domain.Collaborator{QuizID: quiz.ID, CreatorID: uuid.MustParse("5dff22a7-afc7-4e4b-a63d-9903dedd66bf"), Role: domain.RoleViewer})
+
+	// Assert
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	var result []*domain.Collaborator
+	database.Find(&result)
+	assert.Empty(t, result)
+}
+
+func TestDBCollaboratorService_Delete_DeletesCollaborator(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBCollaboratorService{Database: database}
+
+	host := &domain.Creator{Nickname: "b", AuthID: "b"}
+	quiz := &domain.Quiz{
+		Creator:       &domain.Creator{Nickname: "a", AuthID: "a"},
+		Collaborators: []*domain.Collaborator{{Creator: host, Role: domain.RoleHost}},
+	}
+	database.Create(quiz)
+
+	// Act
+	err := service.Delete(quiz.ID, host.ID)
+
+	// Assert
+	assert.NoError(t, err)
+
+	var result []*domain.Collaborator
+	database.Find(&result)
+	assert.Empty(t, result)
+}
+
+func TestDBCollaboratorService_Delete_ReturnsErrorOnNotFound(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBCollaboratorService{Database: database}
+
+	// Act
+	err := service.Delete(uuid.MustParse("3c97f06b-1078-46ef-a2c3-71fc4d9a3d3d"), uuid.MustParse("5dff22a7-afc7-4e4b-a63d-9903dedd66bf"))
+
+	// Assert
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
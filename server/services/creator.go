@@ -13,15 +13,18 @@ var _ CreatorService = new(DBCreatorService)
 type CreatorService interface {
 	GetOrCreate(authID string) (*domain.Creator, error)
 	GetByID(id uuid.UUID) (*domain.Creator, error)
+
+	// UpdateAvatar sets the creator's built-in avatar, an empty avatar resets it to a generated one
+	UpdateAvatar(creator *domain.Creator, avatar string) error
 }
 
 type DBCreatorService struct {
-	Database *gorm.DB
+	Database  *gorm.DB
+	Nicknames NicknameGenerator
 }
 
 func (c *DBCreatorService) GetOrCreate(authID string) (*domain.Creator, error) {
-	result := &domain.Creator{AuthID: authID}
-	result.GenerateNickname()
+	result := &domain.Creator{AuthID: authID, Nickname: c.Nicknames.Generate()}
 	result.GenerateColors()
 
 	if err := c.Database.FirstOrCreate(&result, map[string]any{"auth_id": authID}).Error; err != nil {
@@ -41,3 +44,12 @@ func (c *DBCreatorService) GetByID(id uuid.UUID) (*domain.Creator, error) {
 
 	return result, nil
 }
+
+func (c *DBCreatorService) UpdateAvatar(creator *domain.Creator, avatar string) error {
+	if err := c.Database.Model(creator).Update("avatar", avatar).Error; err != nil {
+		logrus.WithError(err).Error("Failed to update avatar")
+		return err
+	}
+
+	return nil
+}
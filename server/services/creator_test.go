@@ -14,7 +14,7 @@ func TestDBCreatorService_GetOrCreate_CreatesNewUser(t *testing.T) {
 	database := gormtestutil.NewMemoryDatabase(t)
 	autoMigrate(t, database)
 
-	service := &DBCreatorService{Database: database}
+	service := &DBCreatorService{Database: database, Nicknames: &staticNicknames{nickname: "Brave Fox"}}
 
 	authID := "23902349"
 
@@ -38,7 +38,7 @@ func TestDBCreatorService_GetOrCreate_ReturnsExistingUser(t *testing.T) {
 	database := gormtestutil.NewMemoryDatabase(t)
 	autoMigrate(t, database)
 
-	service := &DBCreatorService{Database: database}
+	service := &DBCreatorService{Database: database, Nicknames: &staticNicknames{nickname: "Brave Fox"}}
 
 	authID := "23902349"
 
@@ -73,7 +73,7 @@ func TestDBCreatorService_GetOrCreate_ReturnsDatabaseError(t *testing.T) {
 	// By not running this, we're sure it will return an error
 	//autoMigrate(t, database
 
-	service := &DBCreatorService{Database: database}
+	service := &DBCreatorService{Database: database, Nicknames: &staticNicknames{nickname: "Brave Fox"}}
 
 	authID := "23902349"
 
@@ -124,3 +124,26 @@ func TestDBCreatorService_GetByID_ReturnsDatabaseError(t *testing.T) {
 	assert.Empty(t, result)
 	assert.ErrorContains(t, err, "no such table")
 }
+
+func TestDBCreatorService_UpdateAvatar_SavesAvatar(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBCreatorService{Database: database}
+
+	creator := &domain.Creator{BaseObject: domain.BaseObject{ID: uuid.MustParse("6aacfb41-e478-46ec-857e-11221f2a97fc")}}
+
+	database.Create(creator)
+
+	// Act
+	err := service.UpdateAvatar(creator, "moon")
+
+	// Assert
+	assert.NoError(t, err)
+
+	var result *domain.Creator
+	database.First(&result, creator.ID)
+	assert.Equal(t, "moon", result.Avatar)
+}
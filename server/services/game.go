@@ -2,10 +2,13 @@ package services
 
 import (
 	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/survivorbat/qq.maarten.dev/server/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
 )
 
 // Compile-time interface checks
@@ -16,22 +19,46 @@ type GameService interface {
 	GetByID(gameID uuid.UUID) (*domain.Game, error)
 	GetByCode(code string) (*domain.Game, error)
 
+	// GetActiveByQuiz returns the games of the quiz that started and did not finish yet
+	GetActiveByQuiz(quizID uuid.UUID) ([]*domain.Game, error)
+
 	Create(game *domain.Game) error
 	Start(game *domain.Game) error
 	Next(game *domain.Game) error
 	Finish(game *domain.Game) error
 	AnswerQuestion(game *domain.Game, questionID uuid.UUID, playerID uuid.UUID, optionID uuid.UUID) error
 	Delete(game *domain.Game) error
+
+	// NextForPlayer moves a player of a self-paced game to their next question, nil means the player is done
+	NextForPlayer(game *domain.Game, player *domain.Player) (domain.Question, error)
+
+	// GetScheduled returns the games that still have to start or finish by themselves
+	GetScheduled() ([]*domain.Game, error)
 }
 
+var (
+	// ErrTooManyGamesInProgress is returned when starting a game would exceed the max amount of games in progress
+	ErrTooManyGamesInProgress = errors.New("quiz has too many games in progress")
+
+	// ErrAnswerRejected is returned when the game does not accept the answer, like after the deadline
+	ErrAnswerRejected = errors.New("answer was rejected")
+
+	// ErrAlreadyAnswered is returned when the player already answered the question
+	ErrAlreadyAnswered = errors.New("player has already answered this question")
+)
+
+// maxJoinCodeAttempts is how often a new code is generated if it turns out to be taken
+const maxJoinCodeAttempts = 10
+
 type DBGameService struct {
-	Database *gorm.DB
+	Database  *gorm.DB
+	JoinCodes JoinCodeGenerator
 }
 
 func (g *DBGameService) GetByQuiz(quizId uuid.UUID) ([]*domain.Game, error) {
 	var result []*domain.Game
 
-	if err := g.Database.Preload("Answers").Preload("Quiz").Preload("Players").Where("quiz_id = ?", quizId).Find(&result).Error; err != nil {
+	if err := g.Database.Preload("Answers").Preload("Quiz").Preload("Players").Preload("Teams", orderByName).Where("quiz_id = ?", quizId).Find(&result).Error; err != nil {
 		logrus.WithError(err).Error("Failed to fetch by quiz")
 		return nil, err
 	}
@@ -39,21 +66,38 @@ func (g *DBGameService) GetByQuiz(quizId uuid.UUID) ([]*domain.Game, error) {
 	return result, nil
 }
 
+func (g *DBGameService) GetActiveByQuiz(quizID uuid.UUID) ([]*domain.Game, error) {
+	var result []*domain.Game
+
+	if err := g.Database.Preload("Players").Where("quiz_id = ? AND start_time > ? AND finish_time = ?", quizID, time.Time{}, time.Time{}).Order("start_time").Find(&result).Error; err != nil {
+		logrus.WithError(err).Error("Failed to fetch active games")
+		return nil, err
+	}
+
+	return result, nil
+}
+
 func (g *DBGameService) GetByID(gameID uuid.UUID) (*domain.Game, error) {
 	var result *domain.Game
 
-	if err := g.Database.Preload("Answers").Preload("Quiz.Games").Preload("Quiz.MultipleChoiceQuestions.Options").Preload("Players").First(&result, gameID).Error; err != nil {
+	if err := g.Database.Preload("Answers").Preload("Quiz.Games").Preload("Quiz.Collaborators").Preload("Quiz.Organization.Members").Preload("Quiz.MultipleChoiceQuestions.Options", orderByPosition).Preload("Quiz.QuestionPools").Preload("QuizRevision").Preload("DrawnQuestions").Preload("Players").Preload("Teams", orderByName).First(&result, gameID).Error; err != nil {
 		logrus.WithError(err).Error("Failed to fetch by id")
 		return nil, err
 	}
 
+	// Games that started use the questions of their revision, even if the quiz changed since
+	if result.QuizRevision != nil {
+		result.QuizRevision.Apply(result.Quiz)
+	}
+
 	return result, nil
 }
 
+// GetByCode only returns games that have not finished yet, since codes are reused
 func (g *DBGameService) GetByCode(code string) (*domain.Game, error) {
 	var result *domain.Game
 
-	if err := g.Database.Where("code = ?", code).First(&result).Error; err != nil {
+	if err := g.Database.Where("code = ? AND finish_time = ?", code, time.Time{}).First(&result).Error; err != nil {
 		logrus.WithError(err).Error("Failed to fetch by id")
 		return nil, err
 	}
@@ -84,27 +128,110 @@ func (g *DBGameService) Next(game *domain.Game) error {
 	return nil
 }
 
+// Start picks a code that is not used by any other open game, the unique index on the code
+// catches the case where another game claims it at the same time. The game is pinned to the
+// latest revision of its quiz. The quiz is locked while starting, so concurrent starts can not
+// exceed its max amount of games in progress.
 func (g *DBGameService) Start(game *domain.Game) error {
-	if err := game.Start(); err != nil {
-		logrus.WithError(err).Error("Failed to start")
+	for attempt := 0; attempt < maxJoinCodeAttempts; attempt++ {
+		code, err := g.JoinCodes.Generate()
+		if err != nil {
+			logrus.WithError(err).Error("Failed to generate code")
+			return err
+		}
+
+		taken, err := g.isCodeTaken(code)
+		if err != nil {
+			return err
+		}
+
+		if taken {
+			continue
+		}
+
+		started := *game
+		if err := started.Start(code); err != nil {
+			logrus.WithError(err).Error("Failed to start")
+			return err
+		}
+
+		err = g.Database.Transaction(func(tx *gorm.DB) error {
+			if err := canStart(tx, &started); err != nil {
+				return err
+			}
+
+			revision, err := latestRevision(tx, game.Quiz)
+			if err != nil {
+				return err
+			}
+
+			started.QuizRevisionID = &revision.ID
+
+			return tx.Updates(&started).Error
+		})
+
+		if errors.Is(err, ErrTooManyGamesInProgress) {
+			logrus.WithError(err).Errorf("Quiz %s can not start game %s", game.QuizID, game.ID)
+			return err
+		}
+
+		if err != nil {
+			if taken, _ := g.isCodeTaken(code); taken {
+				logrus.WithError(err).Warn("Code was claimed in the meantime, retrying")
+				continue
+			}
+
+			logrus.WithError(err).Error("Failed to create")
+			return err
+		}
+
+		*game = started
+		return nil
+	}
+
+	err := errors.New("failed to find an available code")
+	logrus.WithError(err).Error("Failed to start")
+	return err
+}
+
+// canStart locks the quiz of the game until the transaction ends and checks whether it may start another game
+func canStart(tx *gorm.DB, game *domain.Game) error {
+	var quiz *domain.Quiz
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&quiz, "id = ?", game.QuizID).Error; err != nil {
+		logrus.WithError(err).Error("Failed to lock quiz")
 		return err
 	}
 
-	if err := g.Database.Updates(game).Error; err != nil {
-		logrus.WithError(err).Error("Failed to create")
+	if err := tx.Where("quiz_id = ? AND id <> ?", quiz.ID, game.ID).Find(&quiz.Games).Error; err != nil {
+		logrus.WithError(err).Error("Failed to get games")
 		return err
 	}
 
+	if !quiz.CanStart(game) {
+		return ErrTooManyGamesInProgress
+	}
+
 	return nil
 }
 
+func (g *DBGameService) isCodeTaken(code string) (bool, error) {
+	var count int64
+	if err := g.Database.Model(new(domain.Game)).Where("code = ?", code).Count(&count).Error; err != nil {
+		logrus.WithError(err).Error("Failed to check code")
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
 func (g *DBGameService) Finish(game *domain.Game) error {
 	if err := game.Finish(); err != nil {
 		logrus.WithError(err).Error("Failed to finish")
 		return err
 	}
 
-	if err := g.Database.Updates(game).Error; err != nil {
+	// The code has to be selected explicitly, since it's cleared
+	if err := g.Database.Select("finish_time", "code").Updates(game).Error; err != nil {
 		logrus.WithError(err).Error("Failed to create")
 		return err
 	}
@@ -115,17 +242,53 @@ func (g *DBGameService) AnswerQuestion(game *domain.Game, questionID uuid.UUID,
 	answer, err := game.AnswerQuestion(playerID, questionID, optionID)
 	if err != nil {
 		logrus.WithError(err).Error("Failed to answer")
-		return err
+		return fmt.Errorf("%w: %v", ErrAnswerRejected, err)
 	}
 
 	if err := g.Database.Create(answer).Error; err != nil {
 		logrus.WithError(err).Error("Failed to create")
+
+		// Two answers of the same player may pass validation at once, the unique index only lets one in
+		var count int64
+		if countErr := g.Database.Model(new(domain.GameAnswer)).Where("game_id = ? AND question_id = ? AND player_id = ?", game.ID, questionID, playerID).Count(&count).Error; countErr == nil && count > 0 {
+			return ErrAlreadyAnswered
+		}
+
 		return err
 	}
 
 	return nil
 }
 
+func (g *DBGameService) NextForPlayer(game *domain.Game, player *domain.Player) (domain.Question, error) {
+	question, err := game.NextForPlayer(player)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to move to the next question")
+		return nil, err
+	}
+
+	if err := g.Database.Select("current_question", "current_deadline", "finish_time").Updates(player).Error; err != nil {
+		logrus.WithError(err).Error("Failed to update player")
+		return nil, err
+	}
+
+	return question, nil
+}
+
+func (g *DBGameService) GetScheduled() ([]*domain.Game, error) {
+	var result []*domain.Game
+
+	notStarted := g.Database.Where("start_time = ? AND scheduled_start > ?", time.Time{}, time.Time{})
+	inProgress := g.Database.Where("start_time > ? AND finish_time = ?", time.Time{}, time.Time{}).Where(g.Database.Where("scheduled_finish > ?", time.Time{}).Or("mode = ?", domain.GameModeSelfPaced))
+
+	if err := g.Database.Where(notStarted).Or(inProgress).Find(&result).Error; err != nil {
+		logrus.WithError(err).Error("Failed to fetch scheduled games")
+		return nil, err
+	}
+
+	return result, nil
+}
+
 func (g *DBGameService) Delete(game *domain.Game) error {
 	if ok := game.IsInProgress(); ok {
 		err := errors.New("game is in progress")
@@ -140,3 +303,8 @@ func (g *DBGameService) Delete(game *domain.Game) error {
 
 	return nil
 }
+
+// orderByName keeps preloaded teams in a predictable order
+func orderByName(db *gorm.DB) *gorm.DB {
+	return db.Order("name")
+}
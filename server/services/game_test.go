@@ -5,6 +5,7 @@ import (
 	"github.com/ing-bank/gormtestutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"gorm.io/gorm"
 	"testing"
 	"time"
 )
@@ -99,6 +100,33 @@ func TestDBGameService_GetByID_ReturnsExpected(t *testing.T) {
 	assert.Equal(t, quiz.Name, result.Quiz.Name)
 }
 
+func TestDBGameService_GetByID_ReturnsTeamsByName(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBGameService{Database: database}
+
+	game := &domain.Game{
+		Quiz:  &domain.Quiz{Name: "test", Creator: &domain.Creator{}},
+		Teams: []*domain.Team{{Name: "Sales"}, {Name: "Marketing"}},
+	}
+
+	database.Create(game)
+
+	// Act
+	result, err := service.GetByID(game.ID)
+
+	// Assert
+	assert.NoError(t, err)
+
+	if assert.Len(t, result.Teams, 2) {
+		assert.Equal(t, "Marketing", result.Teams[0].Name)
+		assert.Equal(t, "Sales", result.Teams[1].Name)
+	}
+}
+
 func TestDBGameService_GetByID_ReturnsDatabaseError(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -148,6 +176,26 @@ func TestDBGameService_GetByCode_ReturnsExpected(t *testing.T) {
 	assert.Equal(t, games[0].Code, result.Code)
 }
 
+func TestDBGameService_GetByCode_IgnoresFinishedGames(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBGameService{Database: database}
+
+	quiz := &domain.Quiz{Name: "test", Creator: &domain.Creator{}}
+	game := &domain.Game{Quiz: quiz, Code: "A2DFGH", StartTime: time.Now(), FinishTime: time.Now()}
+	database.Create(game)
+
+	// Act
+	result, err := service.GetByCode(game.Code)
+
+	// Assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
 func TestDBGameService_GetByCode_ReturnsDatabaseError(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -223,7 +271,8 @@ func TestDBGameService_Start_ReturnsErrorIfStarted(t *testing.T) {
 	autoMigrate(t, database)
 
 	service := &DBGameService{
-		Database: database,
+		Database:  database,
+		JoinCodes: &RandomJoinCodeGenerator{Length: 6},
 	}
 
 	game := &domain.Game{
@@ -249,7 +298,8 @@ func TestDBGameService_Start_StartsGame(t *testing.T) {
 	autoMigrate(t, database)
 
 	service := &DBGameService{
-		Database: database,
+		Database:  database,
+		JoinCodes: &RandomJoinCodeGenerator{Length: 6},
 	}
 
 	game := &domain.Game{
@@ -272,6 +322,107 @@ func TestDBGameService_Start_StartsGame(t *testing.T) {
 		t.Fatal(err)
 	}
 	assert.False(t, result.StartTime.IsZero())
+	assert.Len(t, result.Code, 6)
+	assert.Equal(t, result.Code, game.Code)
+}
+
+func TestDBGameService_Start_RetriesOnTakenCode(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBGameService{
+		Database:  database,
+		JoinCodes: &sequenceJoinCodes{codes: []string{"TAKEN1", "TAKEN1", "FREE23"}},
+	}
+
+	quiz := &domain.Quiz{
+		Creator:                 &domain.Creator{},
+		MaxConcurrentGames:      2,
+		MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{{}, {}},
+	}
+
+	games := []*domain.Game{
+		{Quiz: quiz, Code: "TAKEN1", StartTime: time.Now()},
+		{Quiz: quiz},
+	}
+	database.CreateInBatches(games, 10)
+
+	// Act
+	err := service.Start(games[1])
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "FREE23", games[1].Code)
+}
+
+func TestDBGameService_Start_ReturnsErrorIfNoCodeAvailable(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBGameService{
+		Database:  database,
+		JoinCodes: &sequenceJoinCodes{codes: []string{"TAKEN1"}},
+	}
+
+	quiz := &domain.Quiz{
+		Creator:                 &domain.Creator{},
+		MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{{}, {}},
+	}
+
+	games := []*domain.Game{
+		{Quiz: quiz, Code: "TAKEN1", StartTime: time.Now()},
+		{Quiz: quiz},
+	}
+	database.CreateInBatches(games, 10)
+
+	// Act
+	err := service.Start(games[1])
+
+	// Assert
+	assert.ErrorContains(t, err, "available code")
+	assert.True(t, games[1].StartTime.IsZero())
+	assert.Empty(t, games[1].Code)
+}
+
+func TestDBGameService_Start_ReturnsErrorOnTooManyGamesInProgress(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBGameService{
+		Database:  database,
+		JoinCodes: &RandomJoinCodeGenerator{Length: 6},
+	}
+
+	quiz := &domain.Quiz{
+		Creator:                 &domain.Creator{},
+		MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{{}, {}},
+	}
+
+	games := []*domain.Game{
+		{Quiz: quiz, Code: "ABC234", StartTime: time.Now()},
+		{Quiz: quiz},
+	}
+	database.CreateInBatches(games, 10)
+
+	// Act
+	err := service.Start(games[1])
+
+	// Assert
+	assert.ErrorIs(t, err, ErrTooManyGamesInProgress)
+	assert.True(t, games[1].StartTime.IsZero())
+
+	var result *domain.Game
+	if err := database.First(&result, "id = ?", games[1].ID).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, result.StartTime.IsZero())
 }
 
 func TestDBGameService_Finish_ReturnsErrorIfAlreadyFinished(t *testing.T) {
@@ -314,6 +465,7 @@ func TestDBGameService_Finish_FinishesGame(t *testing.T) {
 	game := &domain.Game{
 		BaseObject: domain.BaseObject{ID: uuid.MustParse("238fe389-dede-4ee0-b26f-d2b1a65befac")},
 		StartTime:  time.Now(),
+		Code:       "ABC234",
 		Quiz: &domain.Quiz{
 			Creator: &domain.Creator{},
 		},
@@ -331,6 +483,7 @@ func TestDBGameService_Finish_FinishesGame(t *testing.T) {
 		t.Fatal(err)
 	}
 	assert.False(t, result.FinishTime.IsZero())
+	assert.Empty(t, result.Code)
 }
 
 func TestDBGameService_Next_StartsNextQuestion(t *testing.T) {
@@ -476,6 +629,7 @@ func TestDBGameService_AnswerQuestion_ReturnsErrorIfNotTheCurrentQuestion(t *tes
 
 	game := &domain.Game{
 		BaseObject: domain.BaseObject{ID: uuid.MustParse("238fe389-dede-4ee0-b26f-d2b1a65befac")},
+		StartTime:  time.Now(),
 		Quiz: &domain.Quiz{
 			Creator: &domain.Creator{},
 		},
@@ -486,9 +640,175 @@ func TestDBGameService_AnswerQuestion_ReturnsErrorIfNotTheCurrentQuestion(t *tes
 	err := service.AnswerQuestion(game, questionId, playerId, optionId)
 
 	// Assert
+	assert.ErrorIs(t, err, ErrAnswerRejected)
 	assert.ErrorContains(t, err, "not the current question")
 }
 
+func TestDBGameService_AnswerQuestion_ReturnsErrorOnConcurrentAnswer(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	questionId := uuid.MustParse("c275bf4e-c839-495d-af9c-4f95d8dc05a5")
+	playerId := uuid.MustParse("62750588-5575-4a31-9cdf-2ffed23c7a15")
+	optionId := uuid.MustParse("ecbffee9-c66a-4d33-9cdc-ac0e15da2982")
+
+	service := &DBGameService{
+		Database: database,
+	}
+
+	game := &domain.Game{
+		BaseObject:      domain.BaseObject{ID: uuid.MustParse("238fe389-dede-4ee0-b26f-d2b1a65befac")},
+		StartTime:       time.Now(),
+		Players:         []*domain.Player{{BaseObject: domain.BaseObject{ID: playerId}}},
+		CurrentQuestion: questionId,
+		CurrentDeadline: time.Now().Add(20 * time.Hour),
+		Quiz: &domain.Quiz{
+			Creator: &domain.Creator{},
+		},
+	}
+	database.Create(game)
+
+	// Another request saved its answer after this game was loaded
+	database.Create(&domain.GameAnswer{GameID: game.ID, PlayerID: playerId, QuestionID: questionId, OptionID: optionId})
+
+	// Act
+	err := service.AnswerQuestion(game, questionId, playerId, optionId)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrAlreadyAnswered)
+
+	var count int64
+	database.Model(new(domain.GameAnswer)).Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestDBGameService_NextForPlayer_SavesProgress(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	questionId := uuid.MustParse("c275bf4e-c839-495d-af9c-4f95d8dc05a5")
+	playerId := uuid.MustParse("62750588-5575-4a31-9cdf-2ffed23c7a15")
+
+	service := &DBGameService{
+		Database: database,
+	}
+
+	game := &domain.Game{
+		BaseObject: domain.BaseObject{ID: uuid.MustParse("238fe389-dede-4ee0-b26f-d2b1a65befac")},
+		Mode:       domain.GameModeSelfPaced,
+		StartTime:  time.Now(),
+		OpenTime:   time.Now().Add(-time.Hour),
+		CloseTime:  time.Now().Add(time.Hour),
+		Players:    []*domain.Player{{BaseObject: domain.BaseObject{ID: playerId}}},
+		Quiz: &domain.Quiz{
+			Creator: &domain.Creator{},
+			MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{
+				{
+					BaseQuestion: domain.BaseQuestion{
+						BaseObject:        domain.BaseObject{ID: questionId},
+						DurationInSeconds: 20,
+					},
+				},
+			},
+		},
+	}
+	database.Create(game)
+
+	// Act
+	question, err := service.NextForPlayer(game, game.Players[0])
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, questionId, question.GetBaseQuestion().ID)
+
+	var result *domain.Player
+	if err := database.First(&result, playerId).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, questionId, result.CurrentQuestion)
+	assert.WithinDuration(t, time.Now().Add(20*time.Second), result.CurrentDeadline, time.Second)
+}
+
+func TestDBGameService_GetScheduled_ReturnsPendingGames(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBGameService{
+		Database: database,
+	}
+
+	quiz := &domain.Quiz{Creator: &domain.Creator{}}
+	database.Create(quiz)
+
+	games := []*domain.Game{
+		{
+			BaseObject:     domain.BaseObject{ID: uuid.MustParse("238fe389-dede-4ee0-b26f-d2b1a65befac")},
+			QuizID:         quiz.ID,
+			ScheduledStart: time.Now().Add(time.Hour),
+		},
+		{
+			BaseObject:      domain.BaseObject{ID: uuid.MustParse("4a1cdf60-cd2c-4c5f-88f5-0b31a5ec7b0e")},
+			QuizID:          quiz.ID,
+			Code:            "LIVE12",
+			StartTime:       time.Now().Add(-time.Hour),
+			ScheduledFinish: time.Now().Add(time.Hour),
+		},
+		{
+			BaseObject: domain.BaseObject{ID: uuid.MustParse("9b1a7d25-6d3b-4b0e-9b8c-0e4b7b5c8f3a")},
+			QuizID:     quiz.ID,
+			Mode:       domain.GameModeSelfPaced,
+			Code:       "SELF12",
+			StartTime:  time.Now().Add(-time.Hour),
+			CloseTime:  time.Now().Add(time.Hour),
+		},
+		// Not scheduled
+		{
+			BaseObject: domain.BaseObject{ID: uuid.MustParse("0f1ae4b8-8a4e-4bd5-9d0a-92cbd4f0a7f1")},
+			QuizID:     quiz.ID,
+		},
+		// Already started
+		{
+			BaseObject:     domain.BaseObject{ID: uuid.MustParse("5c3e8a0e-7a0e-4a4b-8c51-4cf1f0b5e6a2")},
+			QuizID:         quiz.ID,
+			Code:           "START1",
+			ScheduledStart: time.Now().Add(-time.Hour),
+			StartTime:      time.Now().Add(-time.Hour),
+		},
+		// Already finished
+		{
+			BaseObject:      domain.BaseObject{ID: uuid.MustParse("d6a8b5b9-2f7c-4b8e-bb5c-3b0f5a8e1c9d")},
+			QuizID:          quiz.ID,
+			StartTime:       time.Now().Add(-2 * time.Hour),
+			ScheduledFinish: time.Now().Add(-time.Hour),
+			FinishTime:      time.Now().Add(-time.Hour),
+		},
+	}
+	for _, game := range games {
+		if err := database.Create(game).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Act
+	result, err := service.GetScheduled()
+
+	// Assert
+	assert.NoError(t, err)
+
+	var ids []uuid.UUID
+	for _, game := range result {
+		ids = append(ids, game.ID)
+	}
+
+	assert.ElementsMatch(t, []uuid.UUID{games[0].ID, games[1].ID, games[2].ID}, ids)
+}
+
 func TestDBGameService_Delete_ReturnsErrorOnInProgress(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -542,3 +862,110 @@ func TestDBGameService_Delete_DeletesCorrectly(t *testing.T) {
 	var result *domain.Game
 	assert.ErrorContains(t, database.First(&result).Error, "not found")
 }
+
+func TestDBGameService_GetActiveByQuiz_ReturnsGamesInProgress(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBGameService{
+		Database: database,
+	}
+
+	quiz := &domain.Quiz{Creator: &domain.Creator{}}
+	database.Create(quiz)
+
+	otherQuiz := &domain.Quiz{CreatorID: quiz.CreatorID}
+	database.Create(otherQuiz)
+
+	games := []*domain.Game{
+		{
+			BaseObject: domain.BaseObject{ID: uuid.MustParse("238fe389-dede-4ee0-b26f-d2b1a65befac")},
+			QuizID:     quiz.ID,
+			Code:       "FIRST1",
+			StartTime:  time.Now().Add(-2 * time.Hour),
+			Players:    []*domain.Player{{}, {}},
+		},
+		{
+			BaseObject: domain.BaseObject{ID: uuid.MustParse("4a1cdf60-cd2c-4c5f-88f5-0b31a5ec7b0e")},
+			QuizID:     quiz.ID,
+			Code:       "SECND1",
+			StartTime:  time.Now().Add(-time.Hour),
+			Players:    []*domain.Player{{}},
+		},
+		// Not started
+		{
+			BaseObject: domain.BaseObject{ID: uuid.MustParse("0f1ae4b8-8a4e-4bd5-9d0a-92cbd4f0a7f1")},
+			QuizID:     quiz.ID,
+		},
+		// Already finished
+		{
+			BaseObject: domain.BaseObject{ID: uuid.MustParse("d6a8b5b9-2f7c-4b8e-bb5c-3b0f5a8e1c9d")},
+			QuizID:     quiz.ID,
+			StartTime:  time.Now().Add(-2 * time.Hour),
+			FinishTime: time.Now().Add(-time.Hour),
+		},
+		// Other quiz
+		{
+			BaseObject: domain.BaseObject{ID: uuid.MustParse("5c3e8a0e-7a0e-4a4b-8c51-4cf1f0b5e6a2")},
+			QuizID:     otherQuiz.ID,
+			Code:       "OTHER1",
+			StartTime:  time.Now().Add(-time.Hour),
+		},
+	}
+	for _, game := range games {
+		if err := database.Create(game).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Act
+	result, err := service.GetActiveByQuiz(quiz.ID)
+
+	// Assert
+	assert.NoError(t, err)
+
+	if assert.Len(t, result, 2) {
+		assert.Equal(t, games[0].ID, result[0].ID)
+		assert.Len(t, result[0].Players, 2)
+		assert.Equal(t, games[1].ID, result[1].ID)
+		assert.Len(t, result[1].Players, 1)
+	}
+}
+
+func TestDBGameService_Start_SavesDrawnQuestions(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBGameService{
+		Database:  database,
+		JoinCodes: &RandomJoinCodeGenerator{Length: 6},
+	}
+
+	game := &domain.Game{
+		BaseObject: domain.BaseObject{ID: uuid.MustParse("238fe389-dede-4ee0-b26f-d2b1a65befac")},
+		Quiz: &domain.Quiz{
+			Creator:                 &domain.Creator{},
+			MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{{}, {}, {}, {}},
+			QuestionPools:           []*domain.QuestionPool{{DrawCount: 2}},
+		},
+	}
+	database.Create(game)
+
+	// Act
+	err := service.Start(game)
+
+	// Assert
+	assert.NoError(t, err)
+
+	result, err := service.GetByID(game.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, result.DrawnQuestions, 2)
+	assert.Len(t, result.Questions(), 2)
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+)
+
+// Compile-time interface checks
+var _ JoinCodeGenerator = new(RandomJoinCodeGenerator)
+
+const (
+	// joinCodeChars leaves out 0, O, 1 and I, since they are easily confused
+	joinCodeChars = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+	// joinCodeDigits are not ambiguous without letters around
+	joinCodeDigits = "0123456789"
+)
+
+type JoinCodeGenerator interface {
+	// Generate returns a new code, uniqueness is guaranteed by the GameService
+	Generate() (string, error)
+}
+
+type RandomJoinCodeGenerator struct {
+	// Length is at least 4 to keep codes hard to guess and at most 12 to keep them easy to type
+	Length int `binding:"min=4,max=12"`
+
+	// Numeric codes are easier to type on phones, but need to be longer to be as hard to guess
+	Numeric bool
+}
+
+func (r *RandomJoinCodeGenerator) Generate() (string, error) {
+	chars := joinCodeChars
+	if r.Numeric {
+		chars = joinCodeDigits
+	}
+
+	max := big.NewInt(int64(len(chars)))
+
+	var result strings.Builder
+	for i := 0; i < r.Length; i++ {
+		index, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+
+		result.WriteByte(chars[index.Int64()])
+	}
+
+	return result.String(), nil
+}
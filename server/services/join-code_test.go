@@ -0,0 +1,52 @@
+package services
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestRandomJoinCodeGenerator_Generate_ReturnsExpectedCode(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		generator *RandomJoinCodeGenerator
+		allowed   string
+	}{
+		"alphanumeric": {
+			generator: &RandomJoinCodeGenerator{Length: 6},
+			allowed:   joinCodeChars,
+		},
+		"numeric": {
+			generator: &RandomJoinCodeGenerator{Length: 8, Numeric: true},
+			allowed:   joinCodeDigits,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			for i := 0; i < 100; i++ {
+				// Act
+				result, err := testData.generator.Generate()
+
+				// Assert
+				assert.NoError(t, err)
+				assert.Len(t, result, testData.generator.Length)
+
+				for _, char := range result {
+					assert.True(t, strings.ContainsRune(testData.allowed, char))
+				}
+			}
+		})
+	}
+}
+
+func TestRandomJoinCodeGenerator_Generate_ExcludesAmbiguousCharacters(t *testing.T) {
+	t.Parallel()
+	// Assert
+	assert.NotContains(t, joinCodeChars, "0")
+	assert.NotContains(t, joinCodeChars, "O")
+	assert.NotContains(t, joinCodeChars, "1")
+	assert.NotContains(t, joinCodeChars, "I")
+}
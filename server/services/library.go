@@ -0,0 +1,169 @@
+package services
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+	"time"
+)
+
+// Compile-time interface checks
+var _ LibraryService = new(DBLibraryService)
+
+type LibrarySort string
+
+const (
+	LibrarySortPopular LibrarySort = "popular"
+	LibrarySortNewest  LibrarySort = "newest"
+	LibrarySortName    LibrarySort = "name"
+)
+
+// LibrarySearch narrows down the public quizzes, empty fields are ignored
+type LibrarySearch struct {
+	Query    string
+	Tag      string
+	Language string
+	Sort     LibrarySort
+
+	Offset int
+	Limit  int
+}
+
+type LibraryService interface {
+	// Search returns a page of public quizzes and the total amount of quizzes that match
+	Search(search *LibrarySearch) ([]*domain.Quiz, int64, error)
+}
+
+type DBLibraryService struct {
+	Database *gorm.DB
+}
+
+func (d *DBLibraryService) Search(search *LibrarySearch) ([]*domain.Quiz, int64, error) {
+	query := d.Database.Model(new(domain.Quiz)).Where("public = ?", true)
+
+	if search.Query != "" {
+		query = query.Where(d.matches(search.Query))
+	}
+
+	if search.Tag != "" {
+		tagged := d.Database.Model(new(domain.QuizTag)).Select("quiz_id").Where("name = ?", domain.NormalizeTag(search.Tag))
+		query = query.Where("id IN (?)", tagged)
+	}
+
+	if search.Language != "" {
+		query = query.Where("language = ?", search.Language)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		logrus.WithError(err).Error("Failed to count quizzes")
+		return nil, 0, err
+	}
+
+	switch search.Sort {
+	case LibrarySortNewest:
+		query = query.Order("created_at DESC").Order("name").Order("id")
+	case LibrarySortName:
+		query = query.Order("name").Order("id")
+	default:
+		// Order only takes columns, so the amount of games played goes in as a clause with its own tie-breakers
+		played := "(SELECT COUNT(*) FROM games WHERE games.quiz_id = quizzes.id AND games.start_time > ?) DESC, name, id"
+		query = query.Clauses(clause.OrderBy{Expression: clause.Expr{SQL: played, Vars: []any{time.Time{}}}})
+	}
+
+	var result []*domain.Quiz
+	err := query.Offset(search.Offset).Limit(search.Limit).
+		Preload("Creator").Preload("Tags").Preload("MultipleChoiceQuestions").
+		Preload("Games", "start_time > ?", time.Time{}).
+		Find(&result).Error
+
+	if err != nil {
+		logrus.WithError(err).Error("Failed to search quizzes")
+		return nil, 0, err
+	}
+
+	return result, total, nil
+}
+
+// matches searches the names, descriptions, question titles and categories of quizzes
+func (d *DBLibraryService) matches(text string) clause.Expression {
+	return searcherFor(d.Database).matches(d.Database, text)
+}
+
+// librarySearcher matches search text in the way a database supports best
+type librarySearcher interface {
+	matches(database *gorm.DB, text string) clause.Expression
+
+	// indexes returns the statements that create the indexes the search relies on
+	indexes() []string
+}
+
+// searcherFor gives postgres its full-text search, other databases fall back to a simple substring match
+func searcherFor(database *gorm.DB) librarySearcher {
+	if database.Dialector.Name() == "postgres" {
+		return fullTextSearcher{}
+	}
+
+	return substringSearcher{}
+}
+
+// CreateSearchIndexes creates the indexes the search of the library needs, if the database has any
+func CreateSearchIndexes(database *gorm.DB) error {
+	for _, statement := range searcherFor(database).indexes() {
+		if err := database.Exec(statement).Error; err != nil {
+			logrus.WithError(err).Error("Failed to create search index")
+			return err
+		}
+	}
+
+	return nil
+}
+
+// The indexes are only used if the queries use the exact same expressions
+const (
+	quizSearchVector     = "to_tsvector('simple', name || ' ' || description)"
+	questionSearchVector = "to_tsvector('simple', title || ' ' || category)"
+)
+
+type fullTextSearcher struct{}
+
+func (fullTextSearcher) matches(database *gorm.DB, text string) clause.Expression {
+	questions := database.Model(new(domain.MultipleChoiceQuestion)).Select("quiz_id").
+		Where(questionSearchVector+" @@ plainto_tsquery('simple', ?)", text)
+
+	return clause.Or(
+		clause.Expr{SQL: quizSearchVector + " @@ plainto_tsquery('simple', ?)", Vars: []any{text}},
+		clause.Expr{SQL: "id IN (?)", Vars: []any{questions}},
+	)
+}
+
+func (fullTextSearcher) indexes() []string {
+	return []string{
+		"CREATE INDEX IF NOT EXISTS idx_quiz_search ON quizzes USING GIN (" + quizSearchVector + ")",
+		"CREATE INDEX IF NOT EXISTS idx_question_search ON multiple_choice_questions USING GIN (" + questionSearchVector + ")",
+	}
+}
+
+type substringSearcher struct{}
+
+func (substringSearcher) matches(database *gorm.DB, text string) clause.Expression {
+	pattern := "%" + likeEscaper.Replace(strings.ToLower(text)) + "%"
+
+	questions := database.Model(new(domain.MultipleChoiceQuestion)).Select("quiz_id").
+		Where(`LOWER(title) LIKE ? ESCAPE '\' OR LOWER(category) LIKE ? ESCAPE '\'`, pattern, pattern)
+
+	return clause.Or(
+		clause.Expr{SQL: `LOWER(name) LIKE ? ESCAPE '\'`, Vars: []any{pattern}},
+		clause.Expr{SQL: `LOWER(description) LIKE ? ESCAPE '\'`, Vars: []any{pattern}},
+		clause.Expr{SQL: "id IN (?)", Vars: []any{questions}},
+	)
+}
+
+func (substringSearcher) indexes() []string {
+	return nil
+}
+
+// likeEscaper makes sure wildcards in search queries are matched literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func libraryQuizzes(t *testing.T) *DBLibraryService {
+	t.Helper()
+
+	database := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	autoMigrate(t, database)
+
+	creator := &domain.Creator{Nickname: "abc", AuthID: "def"}
+	database.Create(creator)
+
+	quizzes := []*domain.Quiz{
+		{
+			Name:      "European capitals",
+			CreatorID: creator.ID,
+			Public:    true,
+			Language:  "en",
+			Tags:      []*domain.QuizTag{{Name: "geography"}},
+			Games:     []*domain.Game{{Code: "a", StartTime: time.Now()}},
+		},
+		{
+			Name:        "Rivers",
+			Description: "Long and wet",
+			CreatorID:   creator.ID,
+			Public:      true,
+			Language:    "nl",
+			Tags:        []*domain.QuizTag{{Name: "geography"}, {Name: "nature"}},
+			Games:       []*domain.Game{{Code: "b", StartTime: time.Now()}, {Code: "c", StartTime: time.Now()}, {Code: "d"}},
+			MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{
+				{BaseQuestion: domain.BaseQuestion{Title: "Which river flows through Paris?", Category: "France"}},
+			},
+		},
+		{
+			Name:      "100% math",
+			CreatorID: creator.ID,
+			Public:    true,
+			Language:  "en",
+		},
+		{
+			Name:      "Private capitals",
+			CreatorID: creator.ID,
+			Tags:      []*domain.QuizTag{{Name: "geography"}},
+		},
+	}
+
+	for _, quiz := range quizzes {
+		if err := database.Create(quiz).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return &DBLibraryService{Database: database}
+}
+
+func TestDBLibraryService_Search_ReturnsMatchingPublicQuizzes(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		search        *LibrarySearch
+		expected      []string
+		expectedTotal int64
+	}{
+		"everything by popularity": {
+			search:        &LibrarySearch{Limit: 10},
+			expected:      []string{"Rivers", "European capitals", "100% math"},
+			expectedTotal: 3,
+		},
+		"by name": {
+			search:        &LibrarySearch{Sort: LibrarySortName, Limit: 10},
+			expected:      []string{"100% math", "European capitals", "Rivers"},
+			expectedTotal: 3,
+		},
+		"name": {
+			search:        &LibrarySearch{Query: "CAPITALS", Limit: 10},
+			expected:      []string{"European capitals"},
+			expectedTotal: 1,
+		},
+		"description": {
+			search:        &LibrarySearch{Query: "wet", Limit: 10},
+			expected:      []string{"Rivers"},
+			expectedTotal: 1,
+		},
+		"question title": {
+			search:        &LibrarySearch{Query: "paris", Limit: 10},
+			expected:      []string{"Rivers"},
+			expectedTotal: 1,
+		},
+		"category": {
+			search:        &LibrarySearch{Query: "france", Limit: 10},
+			expected:      []string{"Rivers"},
+			expectedTotal: 1,
+		},
+		"wildcards are literal": {
+			search:        &LibrarySearch{Query: "0%", Limit: 10},
+			expected:      []string{"100% math"},
+			expectedTotal: 1,
+		},
+		"tag": {
+			search:        &LibrarySearch{Tag: " Geography", Limit: 10},
+			expected:      []string{"Rivers", "European capitals"},
+			expectedTotal: 2,
+		},
+		"language": {
+			search:        &LibrarySearch{Language: "en", Limit: 10},
+			expected:      []string{"European capitals", "100% math"},
+			expectedTotal: 2,
+		},
+		"second page": {
+			search:        &LibrarySearch{Offset: 2, Limit: 2},
+			expected:      []string{"100% math"},
+			expectedTotal: 3,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			service := libraryQuizzes(t)
+
+			// Act
+			result, total, err := service.Search(testData.search)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, testData.expectedTotal, total)
+
+			names := []string{}
+			for _, quiz := range result {
+				names = append(names, quiz.Name)
+			}
+
+			assert.Equal(t, testData.expected, names)
+		})
+	}
+}
+
+func TestDBLibraryService_Search_CountsPlayedGames(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	service := libraryQuizzes(t)
+
+	// Act
+	result, _, err := service.Search(&LibrarySearch{Query: "rivers", Limit: 10})
+
+	// Assert
+	assert.NoError(t, err)
+
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, uint(2), result[0].CountGamesPlayed())
+		assert.Equal(t, []string{"geography", "nature"}, result[0].TagNames())
+		assert.Equal(t, "abc", result[0].Creator.Nickname)
+		assert.NotEqual(t, uuid.Nil, result[0].MultipleChoiceQuestions[0].ID)
+	}
+}
+
+func TestFullTextSearcher_Matches_UsesIndexedExpressions(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	searcher := fullTextSearcher{}
+
+	// Act
+	statement := database.Session(&gorm.Session{DryRun: true}).Model(new(domain.Quiz)).
+		Where(searcher.matches(database, "rome")).Find(&[]*domain.Quiz{}).Statement
+
+	// Assert
+	query := statement.SQL.String()
+	assert.Contains(t, query, quizSearchVector+" @@ plainto_tsquery('simple', ?)")
+	assert.Contains(t, query, "FROM `multiple_choice_questions` WHERE "+questionSearchVector+" @@ plainto_tsquery('simple', ?)")
+	assert.Equal(t, []any{"rome", "rome"}, statement.Vars)
+
+	indexes := searcher.indexes()
+	if assert.Len(t, indexes, 2) {
+		assert.Contains(t, indexes[0], "ON quizzes USING GIN ("+quizSearchVector+")")
+		assert.Contains(t, indexes[1], "ON multiple_choice_questions USING GIN ("+questionSearchVector+")")
+	}
+}
+
+func TestCreateSearchIndexes_SkipsOtherDatabases(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	autoMigrate(t, database)
+
+	// Act
+	err := CreateSearchIndexes(database)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, database.Migrator().HasIndex(new(domain.Quiz), "idx_quiz_search"))
+}
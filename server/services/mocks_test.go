@@ -8,8 +8,44 @@ import (
 
 func autoMigrate(t *testing.T, db *gorm.DB) {
 	err := db.AutoMigrate(&domain.Quiz{}, &domain.Creator{}, &domain.MultipleChoiceQuestion{}, &domain.QuestionOption{},
-		&domain.Game{}, &domain.Player{}, &domain.GameAnswer{})
+		&domain.Game{}, &domain.Player{}, &domain.GameAnswer{}, &domain.APIKey{}, &domain.Collaborator{},
+		&domain.Organization{}, &domain.Member{}, &domain.Invitation{}, &domain.Team{}, &domain.PresenterToken{},
+		&domain.QuestionPool{}, &domain.GameQuestion{}, &domain.QuizRevision{}, &domain.QuizDraft{}, &domain.QuizTag{},
+		&domain.BankQuestion{}, &domain.BankQuestionTag{})
 	if err != nil {
 		t.Fatal(err.Error())
 	}
 }
+
+// sequenceJoinCodes hands out the codes in order, to simulate collisions
+type sequenceJoinCodes struct {
+	codes []string
+	index int
+}
+
+func (s *sequenceJoinCodes) Generate() (string, error) {
+	result := s.codes[s.index%len(s.codes)]
+	s.index++
+	return result, nil
+}
+
+// staticNicknames always returns the same nickname, to simulate running out of names
+type staticNicknames struct {
+	nickname string
+}
+
+func (s *staticNicknames) Generate(...string) string {
+	return s.nickname
+}
+
+// sequenceNicknames hands out the nicknames in order
+type sequenceNicknames struct {
+	nicknames []string
+	index     int
+}
+
+func (s *sequenceNicknames) Generate(...string) string {
+	result := s.nicknames[s.index%len(s.nicknames)]
+	s.index++
+	return result
+}
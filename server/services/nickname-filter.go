@@ -0,0 +1,106 @@
+package services
+
+import (
+	_ "embed"
+	"strings"
+	"unicode"
+)
+
+// Compile-time interface checks
+var _ NicknameFilter = new(BlocklistNicknameFilter)
+
+//go:embed wordlists/blocklist.txt
+var defaultBlocklist string
+
+//go:embed wordlists/allowlist.txt
+var defaultAllowlist string
+
+// DefaultBlocklist returns the words that are blocked if no other list is configured
+func DefaultBlocklist() []string {
+	return ParseWordList(defaultBlocklist)
+}
+
+// DefaultAllowlist returns the words that are allowed despite containing a blocked word, if no other
+// list is configured
+func DefaultAllowlist() []string {
+	return ParseWordList(defaultAllowlist)
+}
+
+// ParseWordList returns the lines of the list, skipping empty lines and comments starting with #
+func ParseWordList(list string) []string {
+	var result []string
+	for _, line := range strings.Split(list, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		result = append(result, line)
+	}
+
+	return result
+}
+
+type NicknameFilter interface {
+	// IsAllowed returns whether players may use this nickname
+	IsAllowed(nickname string) bool
+}
+
+// leetspeak maps characters commonly used to dodge filters to the letters they resemble
+var leetspeak = map[rune]rune{
+	'0': 'o',
+	'1': 'i',
+	'3': 'e',
+	'4': 'a',
+	'5': 's',
+	'7': 't',
+	'8': 'b',
+	'9': 'g',
+	'@': 'a',
+	'$': 's',
+	'!': 'i',
+	'|': 'l',
+}
+
+// BlocklistNicknameFilter rejects nicknames that contain any of the words, regardless of
+// casing, spacing, punctuation or leetspeak. Allowed words are taken out first, so names
+// like Scunthorpe are not rejected for the word they happen to contain.
+type BlocklistNicknameFilter struct {
+	Words   []string
+	Allowed []string
+}
+
+func (b *BlocklistNicknameFilter) IsAllowed(nickname string) bool {
+	normalized := normalizeNickname(nickname)
+
+	for _, word := range b.Allowed {
+		if allowed := normalizeNickname(word); allowed != "" {
+			normalized = strings.ReplaceAll(normalized, allowed, " ")
+		}
+	}
+
+	for _, word := range b.Words {
+		blocked := normalizeNickname(word)
+		if blocked != "" && strings.Contains(normalized, blocked) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// normalizeNickname lowercases the nickname, replaces leetspeak and drops anything that is not a letter
+func normalizeNickname(nickname string) string {
+	var result strings.Builder
+	for _, char := range strings.ToLower(nickname) {
+		if replacement, ok := leetspeak[char]; ok {
+			char = replacement
+		}
+
+		if unicode.IsLetter(char) {
+			result.WriteRune(char)
+		}
+	}
+
+	return result.String()
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBlocklistNicknameFilter_IsAllowed_ReturnsExpectedValue(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		nickname string
+		expected bool
+	}{
+		"clean": {
+			nickname: "Quiz Wizard",
+			expected: true,
+		},
+		"plain": {
+			nickname: "shit",
+		},
+		"casing": {
+			nickname: "ShIt",
+		},
+		"leetspeak": {
+			nickname: "$h1t",
+		},
+		"spacing": {
+			nickname: "s h.i_t",
+		},
+		"inside other words": {
+			nickname: "bullshitter",
+		},
+		"allowed name": {
+			nickname: "Scunthorpe",
+			expected: true,
+		},
+		"allowed name with leetspeak": {
+			nickname: "H1tchc0ck",
+			expected: true,
+		},
+		"allowed name next to blocked word": {
+			nickname: "Scunthorpe $h1t",
+		},
+		"blocked word between allowed names": {
+			nickname: "HitchcockcuntScunthorpe",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			filter := &BlocklistNicknameFilter{
+				Words:   []string{"shit", "cunt", "cock", ""},
+				Allowed: []string{"scunthorpe", "hitchcock", ""},
+			}
+
+			// Act
+			result := filter.IsAllowed(testData.nickname)
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestDefaultBlocklist_ReturnsWords(t *testing.T) {
+	t.Parallel()
+	// Act
+	result := DefaultBlocklist()
+
+	// Assert
+	assert.Contains(t, result, "fuck")
+	assert.NotContains(t, result, "")
+	for _, word := range result {
+		assert.NotContains(t, word, "#")
+	}
+}
+
+func TestDefaultAllowlist_AllowsNamesWithBlockedWords(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	filter := &BlocklistNicknameFilter{Words: DefaultBlocklist(), Allowed: DefaultAllowlist()}
+
+	// Act
+	result := DefaultAllowlist()
+
+	// Assert
+	assert.Contains(t, result, "scunthorpe")
+	assert.Contains(t, result, "hitchcock")
+	for _, word := range result {
+		assert.NotContains(t, word, "#")
+		assert.True(t, filter.IsAllowed(word), word)
+	}
+
+	assert.True(t, filter.IsAllowed("Alfred Hitchcock"))
+	assert.False(t, filter.IsAllowed("Scunthorpe Wanker"))
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"golang.org/x/text/language"
+	"io/fs"
+	"math/rand"
+	"path"
+	"strings"
+)
+
+// Compile-time interface checks
+var _ NicknameGenerator = new(WordListNicknameGenerator)
+
+// DefaultLocale is used if none of the requested locales have a word list
+const DefaultLocale = "en"
+
+// nicknameFiles contains a <locale>.json word list per locale, German prefixes are inflected
+// for masculine nouns, so all German suffixes have to be masculine as well
+//
+//go:embed wordlists/nicknames/*.json
+var nicknameFiles embed.FS
+
+// WordList contains the words a nickname is made of, a prefix followed by a suffix
+type WordList struct {
+	Prefixes []string `json:"prefixes" binding:"min=1"`
+	Suffixes []string `json:"suffixes" binding:"min=1"`
+}
+
+// DefaultWordLists returns the embedded word lists
+func DefaultWordLists() map[string]*WordList {
+	subtree, _ := fs.Sub(nicknameFiles, "wordlists/nicknames")
+
+	result, err := LoadWordLists(subtree)
+	if err != nil {
+		// The embedded files are covered by tests
+		panic(err)
+	}
+
+	return result
+}
+
+// LoadWordLists reads every <locale>.json file in the root of the file system, duplicate words are removed
+func LoadWordLists(fsys fs.FS) (map[string]*WordList, error) {
+	files, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]*WordList{}
+	for _, file := range files {
+		contents, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		var list *WordList
+		if err := json.Unmarshal(contents, &list); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+
+		list.Prefixes = dedupeWords(list.Prefixes)
+		list.Suffixes = dedupeWords(list.Suffixes)
+
+		if len(list.Prefixes) == 0 || len(list.Suffixes) == 0 {
+			return nil, fmt.Errorf("word list %s needs at least one prefix and suffix", file)
+		}
+
+		result[strings.TrimSuffix(path.Base(file), ".json")] = list
+	}
+
+	if len(result) == 0 {
+		return nil, errors.New("no word lists found")
+	}
+
+	return result, nil
+}
+
+// dedupeWords removes empty and duplicate words, while keeping the order
+func dedupeWords(words []string) []string {
+	seen := map[string]bool{}
+
+	var result []string
+	for _, word := range words {
+		word = strings.TrimSpace(word)
+		key := strings.ToLower(word)
+		if word == "" || seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		result = append(result, word)
+	}
+
+	return result
+}
+
+type NicknameGenerator interface {
+	// Generate returns a random nickname using the first locale that has a word list,
+	// locales may be BCP 47 tags like nl-BE or de
+	Generate(locales ...string) string
+}
+
+type WordListNicknameGenerator struct {
+	// Lists needs at least one word list, with at least one prefix and suffix each
+	Lists map[string]*WordList `binding:"min=1,dive,required"`
+
+	// DefaultLocale is used if none of the locales match, falls back to DefaultLocale if empty
+	DefaultLocale string
+}
+
+func (w *WordListNicknameGenerator) Generate(locales ...string) string {
+	list := w.getList(locales)
+
+	prefix := list.Prefixes[rand.Intn(len(list.Prefixes))]
+	suffix := list.Suffixes[rand.Intn(len(list.Suffixes))]
+	return fmt.Sprintf("%s %s", prefix, suffix)
+}
+
+func (w *WordListNicknameGenerator) getList(locales []string) *WordList {
+	for _, locale := range locales {
+		if list, ok := w.Lists[locale]; ok {
+			return list
+		}
+
+		// Try the language without the region, nl-BE should use the nl list
+		tag, err := language.Parse(locale)
+		if err != nil {
+			continue
+		}
+
+		base, _ := tag.Base()
+		if list, ok := w.Lists[base.String()]; ok {
+			return list
+		}
+	}
+
+	if list, ok := w.Lists[w.DefaultLocale]; ok {
+		return list
+	}
+
+	return w.Lists[DefaultLocale]
+}
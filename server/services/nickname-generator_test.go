@@ -0,0 +1,122 @@
+package services
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestDefaultWordLists_ContainsSupportedLocales(t *testing.T) {
+	t.Parallel()
+	// Act
+	result := DefaultWordLists()
+
+	// Assert
+	for _, locale := range []string{"en", "nl", "de"} {
+		if assert.Contains(t, result, locale) {
+			assert.NotEmpty(t, result[locale].Prefixes)
+			assert.NotEmpty(t, result[locale].Suffixes)
+		}
+	}
+}
+
+func TestLoadWordLists_RemovesDuplicates(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	fsys := fstest.MapFS{
+		"fr.json":   {Data: []byte(`{"prefixes": ["Brave", "brave", " "], "suffixes": ["Renard", "Pomme", "Renard"]}`)},
+		"notes.txt": {Data: []byte(`ignored`)},
+	}
+
+	// Act
+	result, err := LoadWordLists(fsys)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, &WordList{Prefixes: []string{"Brave"}, Suffixes: []string{"Renard", "Pomme"}}, result["fr"])
+}
+
+func TestLoadWordLists_ReturnsErrorOnInvalidList(t *testing.T) {
+	t.Parallel()
+	tests := map[string]fstest.MapFS{
+		"empty":       {},
+		"invalid":     {"fr.json": {Data: []byte(`{`)}},
+		"no suffixes": {"fr.json": {Data: []byte(`{"prefixes": ["Brave"]}`)}},
+	}
+
+	for name, fsys := range tests {
+		fsys := fsys
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, err := LoadWordLists(fsys)
+
+			// Assert
+			assert.Nil(t, result)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestWordListNicknameGenerator_Generate_UsesFirstSupportedLocale(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		locales  []string
+		expected string
+	}{
+		"none": {
+			expected: "Brave Fox",
+		},
+		"exact": {
+			locales:  []string{"nl"},
+			expected: "Dappere Vos",
+		},
+		"region": {
+			locales:  []string{"nl-BE"},
+			expected: "Dappere Vos",
+		},
+		"first supported": {
+			locales:  []string{"", "fr", "de-AT", "nl"},
+			expected: "Mutiger Fuchs",
+		},
+		"unsupported": {
+			locales:  []string{"fr"},
+			expected: "Brave Fox",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			generator := &WordListNicknameGenerator{Lists: map[string]*WordList{
+				"en": {Prefixes: []string{"Brave"}, Suffixes: []string{"Fox"}},
+				"nl": {Prefixes: []string{"Dappere"}, Suffixes: []string{"Vos"}},
+				"de": {Prefixes: []string{"Mutiger"}, Suffixes: []string{"Fuchs"}},
+			}}
+
+			// Act
+			result := generator.Generate(testData.locales...)
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestWordListNicknameGenerator_Generate_UsesWordsFromList(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	lists := DefaultWordLists()
+	generator := &WordListNicknameGenerator{Lists: lists}
+
+	// Act
+	result := generator.Generate("nl")
+
+	// Assert
+	split := strings.SplitN(result, " ", 2)
+	assert.Contains(t, lists["nl"].Prefixes, split[0])
+	assert.Contains(t, lists["nl"].Suffixes, split[1])
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// Compile-time interface checks
+var _ OrganizationService = new(DBOrganizationService)
+
+type OrganizationService interface {
+	GetByID(id uuid.UUID) (*domain.Organization, error)
+	GetByCreator(creatorID uuid.UUID) ([]*domain.Organization, error)
+
+	// Create saves the organization and makes the creator its first admin
+	Create(organization *domain.Organization, creatorID uuid.UUID) error
+	Delete(organization *domain.Organization) error
+
+	// SaveMember changes the role of a member
+	SaveMember(member *domain.Member) error
+	RemoveMember(organizationID uuid.UUID, creatorID uuid.UUID) error
+
+	// Invite creates an invitation, or renews it if the creator was already invited. Returns
+	// gorm.ErrRecordNotFound if the creator doesn't exist.
+	Invite(invitation *domain.Invitation) error
+	GetInvitationByID(id uuid.UUID) (*domain.Invitation, error)
+
+	// GetInvitationsByCreator returns the invitations of the creator that have not expired yet
+	GetInvitationsByCreator(creatorID uuid.UUID) ([]*domain.Invitation, error)
+
+	// AcceptInvitation turns the invitation into a membership
+	AcceptInvitation(invitation *domain.Invitation) (*domain.Member, error)
+	DeleteInvitation(invitation *domain.Invitation) error
+}
+
+type DBOrganizationService struct {
+	Database *gorm.DB
+}
+
+func (o *DBOrganizationService) GetByID(id uuid.UUID) (*domain.Organization, error) {
+	var result *domain.Organization
+	if err := o.Database.Preload("Members.Creator").First(&result, id).Error; err != nil {
+		logrus.WithError(err).Error("Failed to get by id")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (o *DBOrganizationService) GetByCreator(creatorID uuid.UUID) ([]*domain.Organization, error) {
+	memberships := o.Database.Model(new(domain.Member)).Select("organization_id").Where("creator_id = ?", creatorID)
+
+	var result []*domain.Organization
+	if err := o.Database.Preload("Members").Where("id IN (?)", memberships).Find(&result).Error; err != nil {
+		logrus.WithError(err).Error("Failed to get by creator")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (o *DBOrganizationService) Create(organization *domain.Organization, creatorID uuid.UUID) error {
+	organization.Members = []*domain.Member{{CreatorID: creatorID, Role: domain.OrganizationAdmin}}
+
+	if err := o.Database.Create(organization).Error; err != nil {
+		logrus.WithError(err).Error("Failed to create")
+		return err
+	}
+
+	return nil
+}
+
+func (o *DBOrganizationService) Delete(organization *domain.Organization) error {
+	if err := o.Database.Delete(organization).Error; err != nil {
+		logrus.WithError(err).Error("Failed to delete")
+		return err
+	}
+
+	return nil
+}
+
+func (o *DBOrganizationService) SaveMember(member *domain.Member) error {
+	query := o.Database.Model(new(domain.Member)).
+		Where("organization_id = ? AND creator_id = ?", member.OrganizationID, member.CreatorID).
+		Update("role", member.Role)
+
+	if err := query.Error; err != nil {
+		logrus.WithError(err).Error("Failed to save member")
+		return err
+	}
+
+	if query.RowsAffected == 0 {
+		logrus.Error("Member not found")
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (o *DBOrganizationService) RemoveMember(organizationID uuid.UUID, creatorID uuid.UUID) error {
+	query := o.Database.Where("organization_id = ? AND creator_id = ?", organizationID, creatorID).Delete(new(domain.Member))
+	if err := query.Error; err != nil {
+		logrus.WithError(err).Error("Failed to remove member")
+		return err
+	}
+
+	if query.RowsAffected == 0 {
+		logrus.Error("Member not found")
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (o *DBOrganizationService) Invite(invitation *domain.Invitation) error {
+	onConflict := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "organization_id"}, {Name: "creator_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role", "invited_by_id", "expires_at", "updated_at"}),
+	}
+
+	return o.Database.Transaction(func(tx *gorm.DB) error {
+		var creators int64
+		if err := tx.Model(new(domain.Creator)).Where("id = ?", invitation.CreatorID).Count(&creators).Error; err != nil {
+			logrus.WithError(err).Error("Failed to find creator")
+			return err
+		}
+
+		if creators == 0 {
+			logrus.Errorf("Creator %s not found", invitation.CreatorID)
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Clauses(onConflict).Create(invitation).Error; err != nil {
+			logrus.WithError(err).Error("Failed to invite")
+			return err
+		}
+
+		// A renewed invitation keeps the ID and creation time of the existing row
+		var saved *domain.Invitation
+		if err := tx.Where("organization_id = ? AND creator_id = ?", invitation.OrganizationID, invitation.CreatorID).First(&saved).Error; err != nil {
+			logrus.WithError(err).Error("Failed to get invitation")
+			return err
+		}
+
+		*invitation = *saved
+		return nil
+	})
+}
+
+func (o *DBOrganizationService) GetInvitationByID(id uuid.UUID) (*domain.Invitation, error) {
+	var result *domain.Invitation
+	if err := o.Database.Preload("Organization").First(&result, id).Error; err != nil {
+		logrus.WithError(err).Error("Failed to get invitation by id")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (o *DBOrganizationService) GetInvitationsByCreator(creatorID uuid.UUID) ([]*domain.Invitation, error) {
+	var result []*domain.Invitation
+	if err := o.Database.Preload("Organization").Where("creator_id = ? AND expires_at > ?", creatorID, time.Now()).Find(&result).Error; err != nil {
+		logrus.WithError(err).Error("Failed to get invitations by creator")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (o *DBOrganizationService) AcceptInvitation(invitation *domain.Invitation) (*domain.Member, error) {
+	member := &domain.Member{
+		OrganizationID: invitation.OrganizationID,
+		CreatorID:      invitation.CreatorID,
+		Role:           invitation.Role,
+	}
+
+	err := o.Database.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(member).Error; err != nil {
+			logrus.WithError(err).Error("Failed to create member")
+			return err
+		}
+
+		if err := tx.Delete(invitation).Error; err != nil {
+			logrus.WithError(err).Error("Failed to delete invitation")
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (o *DBOrganizationService) DeleteInvitation(invitation *domain.Invitation) error {
+	if err := o.Database.Delete(invitation).Error; err != nil {
+		logrus.WithError(err).Error("Failed to delete invitation")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestDBOrganizationService_Create_AddsCreatorAsAdmin(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBOrganizationService{Database: database}
+
+	creator := &domain.Creator{Nickname: "a", AuthID: "a"}
+	database.Create(creator)
+
+	organization := &domain.Organization{Name: "Math department"}
+
+	// Act
+	err := service.Create(organization, creator.ID)
+
+	// Assert
+	assert.NoError(t, err)
+
+	result, _ := service.GetByID(organization.ID)
+	if assert.Len(t, result.Members, 1) {
+		assert.Equal(t, creator.ID, result.Members[0].CreatorID)
+		assert.Equal(t, domain.OrganizationAdmin, result.Members[0].Role)
+		assert.Equal(t, creator.Nickname, result.Members[0].Creator.Nickname)
+	}
+}
+
+func TestDBOrganizationService_GetByCreator_ReturnsMemberships(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBOrganizationService{Database: database}
+
+	creators := []*domain.Creator{
+		{Nickname: "a", AuthID: "a"},
+		{Nickname: "b", AuthID: "b"},
+	}
+	database.CreateInBatches(creators, 10)
+
+	_ = service.Create(&domain.Organization{Name: "A"}, creators[0].ID)
+	_ = service.Create(&domain.Organization{Name: "B"}, creators[1].ID)
+
+	// Act
+	result, err := service.GetByCreator(creators[0].ID)
+
+	// Assert
+	assert.NoError(t, err)
+
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, "A", result[0].Name)
+	}
+}
+
+func TestDBOrganizationService_GetByCreator_ReturnsDatabaseError(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+
+	// By not running this, we're sure it will return an error
+	// autoMigrate(t, database)
+
+	service := &DBOrganizationService{Database: database}
+
+	// Act
+	result, err := service.GetByCreator(uuid.MustParse("3c97f06b-1078-46ef-a2c3-71fc4d9a3d3d"))
+
+	// Assert
+	assert.Empty(t, result)
+	assert.ErrorContains(t, err, "no such table")
+}
+
+func TestDBOrganizationService_SaveMember_ChangesRole(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBOrganizationService{Database: database}
+
+	creator := &domain.Creator{Nickname: "a", AuthID: "a"}
+	database.Create(creator)
+
+	organization := &domain.Organization{Name: "A"}
+	_ = service.Create(organization, creator.ID)
+
+	// Act
+	err := service.SaveMember(&domain.Member{OrganizationID: organization.ID, CreatorID: creator.ID, Role: domain.OrganizationMember})
+
+	// Assert
+	assert.NoError(t, err)
+
+	result, _ := service.GetByID(organization.ID)
+	assert.Equal(t, domain.OrganizationMember, result.Members[0].Role)
+}
+
+func TestDBOrganizationService_RemoveMember_ReturnsNotFound(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBOrganizationService{Database: database}
+
+	// Act
+	err := service.RemoveMember(uuid.MustParse("3c97f06b-1078-46ef-a2c3-71fc4d9a3d3d"), uuid.MustParse("5dff22a7-afc7-4e4b-a63d-9903dedd66bf"))
+
+	// Assert
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestDBOrganizationService_Invite_RenewsExistingInvitation(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBOrganizationService{Database: database}
+
+	creators := []*domain.Creator{
+		{Nickname: "a", AuthID: "a"},
+		{Nickname: "b", AuthID: "b"},
+	}
+	database.CreateInBatches(creators, 10)
+
+	organization := &domain.Organization{Name: "A"}
+	_ = service.Create(organization, creators[0].ID)
+
+	first := &domain.Invitation{OrganizationID: organization.ID, CreatorID: creators[1].ID, InvitedByID: creators[0].ID, Role: domain.OrganizationMember, ExpiresAt: time.Now().Add(time.Hour)}
+	_ = service.Invite(first)
+
+	renewed := &domain.Invitation{OrganizationID: organization.ID, CreatorID: creators[1].ID, InvitedByID: creators[0].ID, Role: domain.OrganizationAdmin, ExpiresAt: time.Now().Add(2 * time.Hour)}
+
+	// Act
+	err := service.Invite(renewed)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, first.ID, renewed.ID)
+	assert.Equal(t, domain.OrganizationAdmin, renewed.Role)
+
+	var result []*domain.Invitation
+	database.Find(&result)
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, domain.OrganizationAdmin, result[0].Role)
+	}
+}
+
+func TestDBOrganizationService_Invite_ReturnsErrorOnUnknownCreator(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBOrganizationService{Database: database}
+
+	creator := &domain.Creator{Nickname: "a", AuthID: "a"}
+	database.Create(creator)
+
+	organization := &domain.Organization{Name: "A"}
+	_ = service.Create(organization, creator.ID)
+
+	// Act
+	err := service.Invite(&domain.Invitation{OrganizationID: organization.ID, CreatorID: uuid.MustParse("5dff22a7-afc7-4e4b-a63d-9903dedd66bf"), InvitedByID: creator.ID, Role: domain.OrganizationMember, ExpiresAt: time.Now().Add(time.Hour)})
+
+	// Assert
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	var result []*domain.Invitation
+	database.Find(&result)
+	assert.Empty(t, result)
+}
+
+func TestDBOrganizationService_GetInvitationsByCreator_IgnoresExpired(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBOrganizationService{Database: database}
+
+	creators := []*domain.Creator{
+		{Nickname: "a", AuthID: "a"},
+		{Nickname: "b", AuthID: "b"},
+	}
+	database.CreateInBatches(creators, 10)
+
+	organizations := []*domain.Organization{{Name: "A"}, {Name: "B"}}
+	_ = service.Create(organizations[0], creators[0].ID)
+	_ = service.Create(organizations[1], creators[0].ID)
+
+	_ = service.Invite(&domain.Invitation{OrganizationID: organizations[0].ID, CreatorID: creators[1].ID, InvitedByID: creators[0].ID, Role: domain.OrganizationMember, ExpiresAt: time.Now().Add(time.Hour)})
+	_ = service.Invite(&domain.Invitation{OrganizationID: organizations[1].ID, CreatorID: creators[1].ID, InvitedByID: creators[0].ID, Role: domain.OrganizationMember, ExpiresAt: time.Now().Add(-time.Hour)})
+
+	// Act
+	result, err := service.GetInvitationsByCreator(creators[1].ID)
+
+	// Assert
+	assert.NoError(t, err)
+
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, "A", result[0].Organization.Name)
+	}
+}
+
+func TestDBOrganizationService_AcceptInvitation_CreatesMember(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBOrganizationService{Database: database}
+
+	creators := []*domain.Creator{
+		{Nickname: "a", AuthID: "a"},
+		{Nickname: "b", AuthID: "b"},
+	}
+	database.CreateInBatches(creators, 10)
+
+	organization := &domain.Organization{Name: "A"}
+	_ = service.Create(organization, creators[0].ID)
+
+	invitation := &domain.Invitation{OrganizationID: organization.ID, CreatorID: creators[1].ID, InvitedByID: creators[0].ID, Role: domain.OrganizationAdmin, ExpiresAt: time.Now().Add(time.Hour)}
+	_ = service.Invite(invitation)
+
+	// Act
+	member, err := service.AcceptInvitation(invitation)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.OrganizationAdmin, member.Role)
+
+	result, _ := service.GetByID(organization.ID)
+	assert.True(t, result.IsAdmin(creators[1].ID))
+
+	_, err = service.GetInvitationByID(invitation.ID)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
@@ -1,21 +1,42 @@
 package services
 
 import (
+	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/survivorbat/qq.maarten.dev/server/domain"
 	"gorm.io/gorm"
 )
 
+// Compile-time interface checks
+var _ PlayerService = new(DBPlayerService)
+
+var (
+	ErrNicknameNotAllowed = errors.New("nickname is not allowed")
+	ErrNicknameTaken      = errors.New("nickname is already taken in this game")
+)
+
+// maxNicknameAttempts is how often a random nickname is generated before falling back to a numbered one
+const maxNicknameAttempts = 10
+
 type PlayerService interface {
 	GetByID(playerID uuid.UUID) (*domain.Player, error)
 	GetByGame(gameID uuid.UUID) ([]*domain.Player, error)
-	Create(player *domain.Player) error
+
+	// Create generates a nickname in the first supported locale if the player has not chosen one,
+	// chosen nicknames are checked against the filter and have to be unique within the game
+	Create(player *domain.Player, locales ...string) error
+
+	// Rename changes the nickname of the player, an empty nickname generates a new one
+	Rename(player *domain.Player, nickname string, locales ...string) error
 	Delete(player *domain.Player) error
 }
 
 type DBPlayerService struct {
-	Database *gorm.DB
+	Database       *gorm.DB
+	NicknameFilter NicknameFilter
+	Nicknames      NicknameGenerator
 }
 
 func (g *DBPlayerService) GetByID(playerID uuid.UUID) (*domain.Player, error) {
@@ -40,16 +61,149 @@ func (g *DBPlayerService) GetByGame(gameID uuid.UUID) ([]*domain.Player, error)
 	return result, nil
 }
 
-func (g *DBPlayerService) Create(player *domain.Player) error {
-	player.GenerateNickname()
+// Create checks the nickname before inserting the player, the unique index on nicknames catches the case
+// where another player takes it at the same time
+func (g *DBPlayerService) Create(player *domain.Player, locales ...string) error {
+	gameID := player.GameID
+	if player.Game != nil {
+		gameID = player.Game.ID
+	}
+
+	chosen := player.Nickname
 	player.GenerateColors()
 
-	if err := g.Database.Create(&player).Error; err != nil {
+	for attempt := 0; ; attempt++ {
+		if err := g.assignNickname(player, gameID, chosen, locales); err != nil {
+			return err
+		}
+
+		err := g.Database.Create(&player).Error
+		if err == nil {
+			return nil
+		}
+
+		retry, nicknameErr := g.retryNickname(gameID, player, chosen, attempt)
+		if retry {
+			continue
+		}
+
+		if nicknameErr != nil {
+			return nicknameErr
+		}
+
 		logrus.WithError(err).Error("Failed to create")
 		return err
 	}
+}
 
-	return nil
+func (g *DBPlayerService) Rename(player *domain.Player, nickname string, locales ...string) error {
+	for attempt := 0; ; attempt++ {
+		if err := g.assignNickname(player, player.GameID, nickname, locales); err != nil {
+			return err
+		}
+
+		err := g.Database.Model(player).Update("nickname", player.Nickname).Error
+		if err == nil {
+			return nil
+		}
+
+		retry, nicknameErr := g.retryNickname(player.GameID, player, nickname, attempt)
+		if retry {
+			continue
+		}
+
+		if nicknameErr != nil {
+			return nicknameErr
+		}
+
+		logrus.WithError(err).Error("Failed to rename")
+		return err
+	}
+}
+
+// retryNickname decides what to do after saving a nickname failed. If another player claimed it in the
+// meantime, generated nicknames are generated again and chosen ones are reported as taken. Otherwise
+// the error of saving should be returned.
+func (g *DBPlayerService) retryNickname(gameID uuid.UUID, player *domain.Player, chosen string, attempt int) (bool, error) {
+	if taken, err := g.isNicknameTaken(gameID, player.ID, player.Nickname); err != nil || !taken {
+		return false, nil
+	}
+
+	if chosen != "" {
+		logrus.Errorf("Nickname %s was claimed in the meantime", chosen)
+		return false, ErrNicknameTaken
+	}
+
+	if attempt >= maxNicknameAttempts {
+		return false, nil
+	}
+
+	logrus.Warnf("Nickname %s was claimed in the meantime, retrying", player.Nickname)
+	return true, nil
+}
+
+// assignNickname validates the chosen nickname, or generates one that is not in use yet
+func (g *DBPlayerService) assignNickname(player *domain.Player, gameID uuid.UUID, nickname string, locales []string) error {
+	if nickname != "" {
+		if !g.NicknameFilter.IsAllowed(nickname) {
+			logrus.Errorf("Nickname %s is not allowed", nickname)
+			return ErrNicknameNotAllowed
+		}
+
+		taken, err := g.isNicknameTaken(gameID, player.ID, nickname)
+		if err != nil {
+			return err
+		}
+
+		if taken {
+			logrus.Errorf("Nickname %s is already taken", nickname)
+			return ErrNicknameTaken
+		}
+
+		player.Nickname = nickname
+		return nil
+	}
+
+	var generated string
+	for attempt := 0; attempt < maxNicknameAttempts; attempt++ {
+		generated = g.Nicknames.Generate(locales...)
+
+		taken, err := g.isNicknameTaken(gameID, player.ID, generated)
+		if err != nil {
+			return err
+		}
+
+		if !taken {
+			player.Nickname = generated
+			return nil
+		}
+	}
+
+	// Large games may run out of combinations, a number keeps them apart
+	for number := 2; ; number++ {
+		numbered := fmt.Sprintf("%s %d", generated, number)
+
+		taken, err := g.isNicknameTaken(gameID, player.ID, numbered)
+		if err != nil {
+			return err
+		}
+
+		if !taken {
+			player.Nickname = numbered
+			return nil
+		}
+	}
+}
+
+func (g *DBPlayerService) isNicknameTaken(gameID uuid.UUID, playerID uuid.UUID, nickname string) (bool, error) {
+	var count int64
+	query := g.Database.Model(new(domain.Player)).Where("game_id = ? AND id <> ? AND LOWER(nickname) = LOWER(?)", gameID, playerID, nickname)
+	if err := query.Count(&count).Error; err != nil {
+		logrus.WithError(err).Error("Failed to check nickname")
+		return false, err
+	}
+
+	return count > 0, nil
 }
 
 func (g *DBPlayerService) Delete(player *domain.Player) error {
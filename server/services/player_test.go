@@ -5,6 +5,7 @@ import (
 	"github.com/ing-bank/gormtestutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"gorm.io/gorm"
 	"testing"
 )
 
@@ -65,7 +66,8 @@ func TestDBPlayerService_GetByGame_ReturnsAnyError(t *testing.T) {
 	// autoMigrate(t, database)
 
 	service := &DBPlayerService{
-		Database: database,
+		Database:  database,
+		Nicknames: &WordListNicknameGenerator{Lists: DefaultWordLists()},
 	}
 
 	quizId := uuid.MustParse("238fe389-dede-4ee0-b26f-d2b1a65befac")
@@ -85,7 +87,8 @@ func TestDBPlayerService_GetByGame_ReturnsExpectedGames(t *testing.T) {
 	autoMigrate(t, database)
 
 	service := &DBPlayerService{
-		Database: database,
+		Database:  database,
+		Nicknames: &WordListNicknameGenerator{Lists: DefaultWordLists()},
 	}
 
 	quizId := uuid.MustParse("238fe389-dede-4ee0-b26f-d2b1a65befac")
@@ -119,7 +122,8 @@ func TestDBPlayerService_Create_CreatesItem(t *testing.T) {
 	autoMigrate(t, database)
 
 	service := &DBPlayerService{
-		Database: database,
+		Database:  database,
+		Nicknames: &WordListNicknameGenerator{Lists: DefaultWordLists()},
 	}
 
 	quizId := uuid.MustParse("238fe389-dede-4ee0-b26f-d2b1a65befac")
@@ -151,6 +155,92 @@ func TestDBPlayerService_Create_CreatesItem(t *testing.T) {
 	assert.NotEmpty(t, result.BackgroundColor)
 }
 
+func TestDBPlayerService_Create_ValidatesChosenNickname(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		nickname    string
+		expectedErr error
+	}{
+		"allowed": {
+			nickname: "Quiz Wizard",
+		},
+		"blocked": {
+			nickname:    "5h1t",
+			expectedErr: ErrNicknameNotAllowed,
+		},
+		"taken": {
+			nickname:    "quiz master",
+			expectedErr: ErrNicknameTaken,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			database := gormtestutil.NewMemoryDatabase(t)
+			autoMigrate(t, database)
+
+			service := &DBPlayerService{
+				Database:       database,
+				NicknameFilter: &BlocklistNicknameFilter{Words: []string{"shit"}},
+				Nicknames:      &WordListNicknameGenerator{Lists: DefaultWordLists()},
+			}
+
+			game := &domain.Game{
+				Quiz:    &domain.Quiz{Creator: &domain.Creator{}},
+				Players: []*domain.Player{{Nickname: "Quiz Master"}},
+			}
+			database.Create(game)
+
+			player := &domain.Player{GameID: game.ID, Nickname: testData.nickname}
+
+			// Act
+			err := service.Create(player)
+
+			// Assert
+			assert.ErrorIs(t, err, testData.expectedErr)
+		})
+	}
+}
+
+func TestDBPlayerService_Rename_ChangesNickname(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBPlayerService{
+		Database:       database,
+		NicknameFilter: &BlocklistNicknameFilter{},
+		Nicknames:      &WordListNicknameGenerator{Lists: DefaultWordLists()},
+	}
+
+	game := &domain.Game{
+		Quiz:    &domain.Quiz{Creator: &domain.Creator{}},
+		Players: []*domain.Player{{Nickname: "Quiz Master"}, {Nickname: "Other"}},
+	}
+	database.Create(game)
+
+	// Act
+	errSame := service.Rename(game.Players[0], "quiz master")
+	errTaken := service.Rename(game.Players[0], "other")
+	errGenerated := service.Rename(game.Players[1], "")
+
+	// Assert
+	assert.NoError(t, errSame)
+	assert.ErrorIs(t, errTaken, ErrNicknameTaken)
+	assert.NoError(t, errGenerated)
+
+	var result *domain.Player
+	if err := database.First(&result, game.Players[1].ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEqual(t, "Other", result.Nickname)
+	assert.Equal(t, game.Players[1].Nickname, result.Nickname)
+}
+
 func TestDBPlayerService_Create_ReturnsAnyError(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -160,7 +250,8 @@ func TestDBPlayerService_Create_ReturnsAnyError(t *testing.T) {
 	// autoMigrate(t, database)
 
 	service := &DBPlayerService{
-		Database: database,
+		Database:  database,
+		Nicknames: &WordListNicknameGenerator{Lists: DefaultWordLists()},
 	}
 
 	// Act
@@ -177,7 +268,8 @@ func TestDBPlayerService_Delete_DeletesCorrectly(t *testing.T) {
 	autoMigrate(t, database)
 
 	service := &DBPlayerService{
-		Database: database,
+		Database:  database,
+		Nicknames: &WordListNicknameGenerator{Lists: DefaultWordLists()},
 	}
 
 	player := &domain.Player{
@@ -208,7 +300,8 @@ func TestDBPlayerService_Delete_ReturnsAnyError(t *testing.T) {
 	// autoMigrate(t, database)
 
 	service := &DBPlayerService{
-		Database: database,
+		Database:  database,
+		Nicknames: &WordListNicknameGenerator{Lists: DefaultWordLists()},
 	}
 
 	// Act
@@ -217,3 +310,98 @@ func TestDBPlayerService_Delete_ReturnsAnyError(t *testing.T) {
 	// Assert
 	assert.ErrorContains(t, err, "no such table")
 }
+
+func TestDBPlayerService_Create_NumbersNicknamesWhenRunningOut(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBPlayerService{
+		Database:  database,
+		Nicknames: &staticNicknames{nickname: "Brave Fox"},
+	}
+
+	game := &domain.Game{Quiz: &domain.Quiz{Creator: &domain.Creator{}}}
+	database.Create(game)
+
+	players := []*domain.Player{{GameID: game.ID}, {GameID: game.ID}, {GameID: game.ID}}
+
+	// Act
+	for _, player := range players {
+		if err := service.Create(player); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Assert
+	assert.Equal(t, "Brave Fox", players[0].Nickname)
+	assert.Equal(t, "Brave Fox 2", players[1].Nickname)
+	assert.Equal(t, "Brave Fox 3", players[2].Nickname)
+}
+
+func TestDBPlayerService_Create_HandlesNicknameClaimedInTheMeantime(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		nickname      string
+		expected      string
+		expectedError error
+		expectedCount int64
+	}{
+		"generated nickname is generated again": {
+			expected:      "Calm Owl",
+			expectedCount: 2,
+		},
+		"chosen nickname is taken": {
+			nickname:      "Brave Fox",
+			expectedError: ErrNicknameTaken,
+			expectedCount: 1,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			database := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			autoMigrate(t, database)
+
+			service := &DBPlayerService{
+				Database:       database,
+				NicknameFilter: &BlocklistNicknameFilter{},
+				Nicknames:      &sequenceNicknames{nicknames: []string{"Brave Fox", "Calm Owl"}},
+			}
+
+			game := &domain.Game{Quiz: &domain.Quiz{Creator: &domain.Creator{}}}
+			database.Create(game)
+
+			// Another player joins right after the nickname was checked
+			claimed := false
+			_ = database.Callback().Query().After("gorm:query").Register("claim_nickname", func(tx *gorm.DB) {
+				if claimed || tx.Statement.Table != "players" {
+					return
+				}
+
+				claimed = true
+				database.Exec("INSERT INTO players (id, game_id, nickname) VALUES (?, ?, ?)", uuid.New(), game.ID, "BRAVE FOX")
+			})
+
+			player := &domain.Player{GameID: game.ID, Nickname: testData.nickname}
+
+			// Act
+			err := service.Create(player)
+
+			// Assert
+			assert.Equal(t, testData.expectedError, err)
+
+			if testData.expectedError == nil {
+				assert.Equal(t, testData.expected, player.Nickname)
+			}
+
+			var count int64
+			database.Model(new(domain.Player)).Where("game_id = ?", game.ID).Count(&count)
+			assert.Equal(t, testData.expectedCount, count)
+		})
+	}
+}
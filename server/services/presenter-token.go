@@ -0,0 +1,87 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"gorm.io/gorm"
+)
+
+// Compile-time interface checks
+var _ PresenterTokenService = new(DBPresenterTokenService)
+
+type PresenterTokenService interface {
+	GetByID(id uuid.UUID) (*domain.PresenterToken, error)
+	GetByGame(gameID uuid.UUID) ([]*domain.PresenterToken, error)
+
+	// Create saves the token and returns the plain token, which can not be retrieved afterwards
+	Create(token *domain.PresenterToken) (string, error)
+	Delete(token *domain.PresenterToken) error
+
+	// Authenticate looks up the token
+	Authenticate(token string) (*domain.PresenterToken, error)
+}
+
+type DBPresenterTokenService struct {
+	Database *gorm.DB
+}
+
+func (p *DBPresenterTokenService) GetByID(id uuid.UUID) (*domain.PresenterToken, error) {
+	var result *domain.PresenterToken
+	if err := p.Database.First(&result, id).Error; err != nil {
+		logrus.WithError(err).Error("Failed to get by id")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (p *DBPresenterTokenService) GetByGame(gameID uuid.UUID) ([]*domain.PresenterToken, error) {
+	var result []*domain.PresenterToken
+	if err := p.Database.Where("game_id = ?", gameID).Find(&result).Error; err != nil {
+		logrus.WithError(err).Error("Failed to get by game")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (p *DBPresenterTokenService) Create(token *domain.PresenterToken) (string, error) {
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		logrus.WithError(err).Error("Failed to generate token")
+		return "", err
+	}
+
+	plain := domain.PresenterTokenPrefix + hex.EncodeToString(secret)
+	token.Hash = domain.HashPresenterToken(plain)
+	token.Hint = plain[:len(domain.PresenterTokenPrefix)+4]
+
+	if err := p.Database.Create(token).Error; err != nil {
+		logrus.WithError(err).Error("Failed to create")
+		return "", err
+	}
+
+	return plain, nil
+}
+
+func (p *DBPresenterTokenService) Delete(token *domain.PresenterToken) error {
+	if err := p.Database.Delete(token).Error; err != nil {
+		logrus.WithError(err).Error("Failed to delete")
+		return err
+	}
+
+	return nil
+}
+
+func (p *DBPresenterTokenService) Authenticate(token string) (*domain.PresenterToken, error) {
+	var result *domain.PresenterToken
+	if err := p.Database.Where("hash = ?", domain.HashPresenterToken(token)).First(&result).Error; err != nil {
+		logrus.WithError(err).Error("Failed to find token")
+		return nil, err
+	}
+
+	return result, nil
+}
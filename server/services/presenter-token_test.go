@@ -0,0 +1,87 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"strings"
+	"testing"
+)
+
+func TestDBPresenterTokenService_Create_AuthenticatesToken(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBPresenterTokenService{Database: database}
+
+	creator := &domain.Creator{BaseObject: domain.BaseObject{ID: uuid.MustParse("3c97f06b-1078-46ef-a2c3-71fc4d9a3d3d")}}
+	game := &domain.Game{Quiz: &domain.Quiz{Creator: creator}}
+	database.Create(game)
+
+	token := &domain.PresenterToken{Name: "Big screen", GameID: game.ID, CreatorID: creator.ID}
+
+	// Act
+	plain, err := service.Create(token)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(plain, domain.PresenterTokenPrefix))
+	assert.NotContains(t, token.Hash, plain)
+	assert.True(t, strings.HasPrefix(plain, token.Hint))
+
+	result, err := service.Authenticate(plain)
+	if assert.NoError(t, err) {
+		assert.Equal(t, token.ID, result.ID)
+		assert.Equal(t, game.ID, result.GameID)
+	}
+
+	_, err = service.Authenticate(plain + "a")
+	assert.Error(t, err)
+}
+
+func TestDBPresenterTokenService_GetByGame_ReturnsExpected(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBPresenterTokenService{Database: database}
+
+	creator := &domain.Creator{BaseObject: domain.BaseObject{ID: uuid.MustParse("3c97f06b-1078-46ef-a2c3-71fc4d9a3d3d")}}
+	games := []*domain.Game{
+		{Quiz: &domain.Quiz{Creator: creator}, PresenterTokens: []*domain.PresenterToken{{Hash: "a", CreatorID: creator.ID}}},
+		{Quiz: &domain.Quiz{Creator: creator}, PresenterTokens: []*domain.PresenterToken{{Hash: "b", CreatorID: creator.ID}}},
+	}
+	database.CreateInBatches(games, 10)
+
+	// Act
+	result, err := service.GetByGame(games[0].ID)
+
+	// Assert
+	assert.NoError(t, err)
+
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, games[0].PresenterTokens[0].ID, result[0].ID)
+	}
+}
+
+func TestDBPresenterTokenService_GetByGame_ReturnsDatabaseError(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+
+	// By not running this, we're sure it will return an error
+	// autoMigrate(t, database)
+
+	service := &DBPresenterTokenService{Database: database}
+
+	// Act
+	result, err := service.GetByGame(uuid.MustParse("3c97f06b-1078-46ef-a2c3-71fc4d9a3d3d"))
+
+	// Assert
+	assert.Empty(t, result)
+	assert.ErrorContains(t, err, "no such table")
+}
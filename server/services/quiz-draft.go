@@ -0,0 +1,116 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Compile-time interface checks
+var _ QuizDraftService = new(DBQuizDraftService)
+
+type QuizDraftService interface {
+	GetByQuiz(quizID uuid.UUID) (*domain.QuizDraft, error)
+
+	// Save replaces the draft of the quiz, if it has one, and raises its version. A new draft should
+	// have the version of the quiz, it's remembered as the draft's quiz version and raised the same way.
+	Save(draft *domain.QuizDraft) error
+
+	// Update replaces the draft only if it's still at the version, a quiz without a draft is at the
+	// version of the quiz. It returns ErrVersionConflict if the draft was changed in the meantime.
+	Update(draft *domain.QuizDraft, version uint) error
+	Delete(quizID uuid.UUID) error
+}
+
+type DBQuizDraftService struct {
+	Database *gorm.DB
+}
+
+func (d *DBQuizDraftService) GetByQuiz(quizID uuid.UUID) (*domain.QuizDraft, error) {
+	var result *domain.QuizDraft
+	if err := d.Database.Where("quiz_id = ?", quizID).First(&result).Error; err != nil {
+		logrus.WithError(err).Error("Failed to get by quiz")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (d *DBQuizDraftService) Save(draft *domain.QuizDraft) error {
+	return d.Database.Transaction(func(tx *gorm.DB) error {
+		saved := tx.Model(new(domain.QuizDraft)).Where("quiz_id = ?", draft.QuizID).Updates(map[string]any{
+			"updated_by_id": draft.UpdatedByID,
+			"content":       draft.Content,
+			"version":       gorm.Expr("version + 1"),
+		})
+
+		if saved.Error != nil {
+			logrus.WithError(saved.Error).Error("Failed to save draft")
+			return saved.Error
+		}
+
+		if saved.RowsAffected == 0 {
+			draft.QuizVersion = draft.Version
+			draft.Version++
+			if err := tx.Create(draft).Error; err != nil {
+				logrus.WithError(err).Error("Failed to create draft")
+				return err
+			}
+
+			return nil
+		}
+
+		if err := tx.Where("quiz_id = ?", draft.QuizID).First(draft).Error; err != nil {
+			logrus.WithError(err).Error("Failed to get saved draft")
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (d *DBQuizDraftService) Update(draft *domain.QuizDraft, version uint) error {
+	return d.Database.Transaction(func(tx *gorm.DB) error {
+		updated := tx.Model(new(domain.QuizDraft)).Where("quiz_id = ? AND version = ?", draft.QuizID, version).Updates(map[string]any{
+			"updated_by_id": draft.UpdatedByID,
+			"content":       draft.Content,
+			"version":       version + 1,
+		})
+
+		if updated.Error != nil {
+			logrus.WithError(updated.Error).Error("Failed to update draft")
+			return updated.Error
+		}
+
+		draft.Version = version + 1
+		if updated.RowsAffected > 0 {
+			return nil
+		}
+
+		draft.QuizVersion = version
+
+		// Nothing is created if the draft is at another version, or if another first edit created it just now
+		created := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "quiz_id"}}, DoNothing: true}).Create(draft)
+		if created.Error != nil {
+			logrus.WithError(created.Error).Error("Failed to create draft")
+			return created.Error
+		}
+
+		if created.RowsAffected == 0 {
+			return ErrVersionConflict
+		}
+
+		return nil
+	})
+}
+
+func (d *DBQuizDraftService) Delete(quizID uuid.UUID) error {
+	if err := d.Database.Where("quiz_id = ?", quizID).Delete(new(domain.QuizDraft)).Error; err != nil {
+		logrus.WithError(err).Error("Failed to delete draft")
+		return err
+	}
+
+	return nil
+}
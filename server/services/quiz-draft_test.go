@@ -0,0 +1,162 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"testing"
+)
+
+func TestDBQuizDraftService_Save_ReplacesExistingDraft(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	autoMigrate(t, database)
+
+	service := &DBQuizDraftService{Database: database}
+
+	quiz := &domain.Quiz{Creator: &domain.Creator{}}
+	database.Create(quiz)
+
+	editor := uuid.MustParse("2f80947c-e724-4b38-8c8d-3823864fef58")
+
+	// Act
+	err := service.Save(&domain.QuizDraft{QuizID: quiz.ID, Content: []byte(`{"name":"first"}`), Version: 2})
+	secondErr := service.Save(&domain.QuizDraft{QuizID: quiz.ID, UpdatedByID: editor, Content: []byte(`{"name":"second"}`)})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, secondErr)
+
+	var count int64
+	database.Model(new(domain.QuizDraft)).Count(&count)
+	assert.Equal(t, int64(1), count)
+
+	result, err := service.GetByQuiz(quiz.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, editor, result.UpdatedByID)
+	assert.Equal(t, uint(4), result.Version)
+	assert.Equal(t, uint(2), result.QuizVersion)
+	assert.JSONEq(t, `{"name":"second"}`, string(result.Content))
+}
+
+func TestDBQuizDraftService_Update_ChecksVersion(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		existing        *domain.QuizDraft
+		version         uint
+		expectedError   error
+		expectedVersion uint
+		expectedContent string
+
+		expectedQuizVersion uint
+	}{
+		"no draft yet": {
+			version:             2,
+			expectedVersion:     3,
+			expectedContent:     `{"name":"new"}`,
+			expectedQuizVersion: 2,
+		},
+		"same version": {
+			existing:            &domain.QuizDraft{Content: []byte(`{"name":"old"}`), Version: 5, QuizVersion: 1},
+			version:             5,
+			expectedVersion:     6,
+			expectedContent:     `{"name":"new"}`,
+			expectedQuizVersion: 1,
+		},
+		"changed in the meantime": {
+			existing:            &domain.QuizDraft{Content: []byte(`{"name":"old"}`), Version: 5, QuizVersion: 1},
+			version:             4,
+			expectedError:       ErrVersionConflict,
+			expectedVersion:     5,
+			expectedContent:     `{"name":"old"}`,
+			expectedQuizVersion: 1,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			database := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			autoMigrate(t, database)
+
+			service := &DBQuizDraftService{Database: database}
+
+			quiz := &domain.Quiz{Creator: &domain.Creator{}}
+			database.Create(quiz)
+
+			if testData.existing != nil {
+				testData.existing.QuizID = quiz.ID
+				database.Create(testData.existing)
+			}
+
+			// Act
+			err := service.Update(&domain.QuizDraft{QuizID: quiz.ID, Content: []byte(`{"name":"new"}`)}, testData.version)
+
+			// Assert
+			assert.Equal(t, testData.expectedError, err)
+
+			result, err := service.GetByQuiz(quiz.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, testData.expectedVersion, result.Version)
+			assert.JSONEq(t, testData.expectedContent, string(result.Content))
+			assert.Equal(t, testData.expectedQuizVersion, result.QuizVersion)
+		})
+	}
+}
+
+func TestDBQuizDraftService_Update_OnlyOneFirstEditCreatesTheDraft(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	autoMigrate(t, database)
+
+	service := &DBQuizDraftService{Database: database}
+
+	quiz := &domain.Quiz{Creator: &domain.Creator{}}
+	database.Create(quiz)
+
+	_ = service.Update(&domain.QuizDraft{QuizID: quiz.ID, Content: []byte(`{"name":"first"}`)}, 1)
+
+	// Act
+	err := service.Update(&domain.QuizDraft{QuizID: quiz.ID, Content: []byte(`{"name":"second"}`)}, 1)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrVersionConflict)
+
+	result, err := service.GetByQuiz(quiz.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, uint(2), result.Version)
+	assert.JSONEq(t, `{"name":"first"}`, string(result.Content))
+}
+
+func TestDBQuizDraftService_Delete_DeletesDraft(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	autoMigrate(t, database)
+
+	service := &DBQuizDraftService{Database: database}
+
+	quiz := &domain.Quiz{Creator: &domain.Creator{}}
+	database.Create(quiz)
+	database.Create(&domain.QuizDraft{QuizID: quiz.ID, Content: []byte(`{}`)})
+
+	// Act
+	err := service.Delete(quiz.ID)
+
+	// Assert
+	assert.NoError(t, err)
+
+	_, err = service.GetByQuiz(quiz.ID)
+	assert.Error(t, err)
+}
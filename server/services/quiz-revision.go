@@ -0,0 +1,97 @@
+package services
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"gorm.io/gorm"
+)
+
+// Compile-time interface checks
+var _ QuizRevisionService = new(DBQuizRevisionService)
+
+type QuizRevisionService interface {
+	// GetByQuiz returns the revisions of the quiz, the latest first
+	GetByQuiz(quizID uuid.UUID) ([]*domain.QuizRevision, error)
+	GetByNumber(quizID uuid.UUID, number uint) (*domain.QuizRevision, error)
+}
+
+type DBQuizRevisionService struct {
+	Database *gorm.DB
+}
+
+func (d *DBQuizRevisionService) GetByQuiz(quizID uuid.UUID) ([]*domain.QuizRevision, error) {
+	var result []*domain.QuizRevision
+	if err := d.Database.Where("quiz_id = ?", quizID).Order("number desc").Find(&result).Error; err != nil {
+		logrus.WithError(err).Error("Failed to get by quiz")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (d *DBQuizRevisionService) GetByNumber(quizID uuid.UUID, number uint) (*domain.QuizRevision, error) {
+	var result *domain.QuizRevision
+	if err := d.Database.Where("quiz_id = ? AND number = ?", quizID, number).First(&result).Error; err != nil {
+		logrus.WithError(err).Error("Failed to get by number")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// createRevision saves the current contents of the quiz as its next revision
+func createRevision(database *gorm.DB, quiz *domain.Quiz) (*domain.QuizRevision, error) {
+	revision, err := nextRevision(database, quiz)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := database.Create(revision).Error; err != nil {
+		logrus.WithError(err).Error("Failed to create revision")
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+// nextRevision prepares the next revision of the quiz without saving it
+func nextRevision(database *gorm.DB, quiz *domain.Quiz) (*domain.QuizRevision, error) {
+	var latest uint
+	if err := database.Model(new(domain.QuizRevision)).Select("COALESCE(MAX(number), 0)").Where("quiz_id = ?", quiz.ID).Scan(&latest).Error; err != nil {
+		logrus.WithError(err).Error("Failed to get latest revision number")
+		return nil, err
+	}
+
+	return &domain.QuizRevision{QuizID: quiz.ID, Number: latest + 1, Snapshot: quiz.Snapshot()}, nil
+}
+
+// isRevisionTaken returns whether the quiz already has a revision with this number
+func isRevisionTaken(database *gorm.DB, revision *domain.QuizRevision) (bool, error) {
+	var count int64
+	if err := database.Model(new(domain.QuizRevision)).Where("quiz_id = ? AND number = ?", revision.QuizID, revision.Number).Count(&count).Error; err != nil {
+		logrus.WithError(err).Error("Failed to check revision")
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// latestRevision returns the quiz's latest revision, quizzes that were saved before revisions
+// existed get their first one
+func latestRevision(database *gorm.DB, quiz *domain.Quiz) (*domain.QuizRevision, error) {
+	var result *domain.QuizRevision
+
+	err := database.Where("quiz_id = ?", quiz.ID).Order("number desc").First(&result).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return createRevision(database, quiz)
+	}
+
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get latest revision")
+		return nil, err
+	}
+
+	return result, nil
+}
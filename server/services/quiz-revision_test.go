@@ -0,0 +1,124 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/survivorbat/qq.maarten.dev/server/domain"
+	"testing"
+)
+
+func TestDBQuizService_CreateOrUpdate_CreatesRevisions(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	autoMigrate(t, database)
+
+	quizService := &DBQuizService{Database: database}
+	service := &DBQuizRevisionService{Database: database}
+
+	creator := &domain.Creator{Nickname: "abc", AuthID: "def"}
+	database.Create(creator)
+
+	quiz := &domain.Quiz{Name: "first", CreatorID: creator.ID}
+
+	// Act
+	err := quizService.CreateOrUpdate(quiz)
+	secondErr := quizService.CreateOrUpdate(&domain.Quiz{BaseObject: domain.BaseObject{ID: quiz.ID}, Name: "second", CreatorID: creator.ID})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, secondErr)
+
+	result, err := service.GetByQuiz(quiz.ID)
+	assert.NoError(t, err)
+
+	if assert.Len(t, result, 2) {
+		assert.Equal(t, uint(2), result[0].Number)
+		assert.Equal(t, "second", result[0].Snapshot.Name)
+		assert.Equal(t, uint(1), result[1].Number)
+		assert.Equal(t, "first", result[1].Snapshot.Name)
+	}
+}
+
+func TestDBQuizRevisionService_GetByNumber_ReturnsRevision(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBQuizRevisionService{Database: database}
+
+	quiz := &domain.Quiz{Creator: &domain.Creator{}}
+	database.Create(quiz)
+
+	revisions := []*domain.QuizRevision{
+		{QuizID: quiz.ID, Number: 1, Snapshot: &domain.QuizSnapshot{Name: "first"}},
+		{QuizID: quiz.ID, Number: 2, Snapshot: &domain.QuizSnapshot{Name: "second"}},
+	}
+	database.Create(revisions)
+
+	// Act
+	result, err := service.GetByNumber(quiz.ID, 2)
+	_, missingErr := service.GetByNumber(quiz.ID, 3)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "second", result.Snapshot.Name)
+	assert.Error(t, missingErr)
+}
+
+func TestDBGameService_GetByID_UsesRevisionOfStartedGame(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	autoMigrate(t, database)
+
+	quizService := &DBQuizService{Database: database}
+	service := &DBGameService{Database: database, JoinCodes: &RandomJoinCodeGenerator{Length: 6}}
+
+	creator := &domain.Creator{Nickname: "abc", AuthID: "def"}
+	database.Create(creator)
+
+	quiz := &domain.Quiz{
+		Name:      "quiz",
+		CreatorID: creator.ID,
+		MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{
+			{BaseQuestion: domain.BaseQuestion{Title: "old"}},
+		},
+	}
+	if err := quizService.CreateOrUpdate(quiz); err != nil {
+		t.Fatal(err)
+	}
+
+	game := &domain.Game{BaseObject: domain.BaseObject{ID: uuid.MustParse("238fe389-dede-4ee0-b26f-d2b1a65befac")}, QuizID: quiz.ID}
+	database.Create(game)
+
+	game, _ = service.GetByID(game.ID)
+	if err := service.Start(game); err != nil {
+		t.Fatal(err)
+	}
+
+	update := &domain.Quiz{
+		BaseObject: domain.BaseObject{ID: quiz.ID},
+		Name:       "quiz",
+		CreatorID:  creator.ID,
+		MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{
+			{BaseQuestion: domain.BaseQuestion{Title: "new"}},
+		},
+	}
+	if err := quizService.CreateOrUpdate(update); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	result, err := service.GetByID(game.ID)
+
+	// Assert
+	assert.NoError(t, err)
+
+	if assert.NotNil(t, result.QuizRevisionID) && assert.Len(t, result.Quiz.MultipleChoiceQuestions, 1) {
+		assert.Equal(t, "old", result.Quiz.MultipleChoiceQuestions[0].Title)
+		assert.Equal(t, quiz.MultipleChoiceQuestions[0].ID, result.Quiz.MultipleChoiceQuestions[0].ID)
+	}
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"errors"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/survivorbat/qq.maarten.dev/server/domain"
@@ -11,10 +12,25 @@ import (
 // Compile-time interface checks
 var _ QuizService = new(DBQuizService)
 
+var ErrVersionConflict = errors.New("quiz was changed in the meantime")
+
+// maxRevisionAttempts is how often saving a quiz is retried if another save took its revision number
+const maxRevisionAttempts = 3
+
 type QuizService interface {
 	GetByID(id uuid.UUID) (*domain.Quiz, error)
 	GetByCreator(id uuid.UUID) ([]*domain.Quiz, error)
+
+	// GetByOrganizations returns the quizzes of every organization the creator is a member of
+	GetByOrganizations(creatorID uuid.UUID) ([]*domain.Quiz, error)
+
+	// GetTemplates returns the public templates and the templates the creator has access to
+	GetTemplates(creatorID uuid.UUID) ([]*domain.Quiz, error)
 	CreateOrUpdate(quiz *domain.Quiz) error
+
+	// UpdateQuestions saves the questions of the quiz in place, keeping their IDs. It returns
+	// ErrVersionConflict if the quiz is no longer at the given version.
+	UpdateQuestions(quiz *domain.Quiz, version uint) error
 	Delete(id uuid.UUID) error
 }
 
@@ -24,16 +40,20 @@ type DBQuizService struct {
 
 func (c *DBQuizService) GetByID(id uuid.UUID) (*domain.Quiz, error) {
 	var result *domain.Quiz
-	if err := c.Database.Preload("Games").Where("id = ?", id).First(&result).Error; err != nil {
+	if err := c.Database.Preload("Games").Preload("Collaborators").Preload("Organization.Members").Preload("MultipleChoiceQuestions.Options", orderByPosition).Preload("QuestionPools").Preload("Tags").Where("id = ?", id).First(&result).Error; err != nil {
 		logrus.WithError(err).Error("Failed to get by id")
 		return nil, err
 	}
 
 	return result, nil
 }
+
+// GetByCreator returns the quizzes of this creator and the ones that have been shared with them
 func (c *DBQuizService) GetByCreator(id uuid.UUID) ([]*domain.Quiz, error) {
+	shared := c.Database.Model(new(domain.Collaborator)).Select("quiz_id").Where("creator_id = ?", id)
+
 	var result []*domain.Quiz
-	if err := c.Database.Preload("MultipleChoiceQuestions.Options").Preload("Games").Where("creator_id = ?", id).Find(&result).Error; err != nil {
+	if err := c.Database.Preload("MultipleChoiceQuestions.Options", orderByPosition).Preload("QuestionPools").Preload("Tags").Preload("Games").Preload("Collaborators").Where("creator_id = ?", id).Or("id IN (?)", shared).Find(&result).Error; err != nil {
 		logrus.WithError(err).Error("Failed to get by creator")
 		return nil, err
 	}
@@ -41,14 +61,114 @@ func (c *DBQuizService) GetByCreator(id uuid.UUID) ([]*domain.Quiz, error) {
 	return result, nil
 }
 
+func (c *DBQuizService) GetByOrganizations(creatorID uuid.UUID) ([]*domain.Quiz, error) {
+	organizations := c.Database.Model(new(domain.Member)).Select("organization_id").Where("creator_id = ?", creatorID)
+
+	var result []*domain.Quiz
+	if err := c.Database.Preload("MultipleChoiceQuestions.Options", orderByPosition).Preload("QuestionPools").Preload("Tags").Preload("Games").Preload("Collaborators").Where("organization_id IN (?)", organizations).Find(&result).Error; err != nil {
+		logrus.WithError(err).Error("Failed to get by organizations")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *DBQuizService) GetTemplates(creatorID uuid.UUID) ([]*domain.Quiz, error) {
+	shared := c.Database.Model(new(domain.Collaborator)).Select("quiz_id").Where("creator_id = ?", creatorID)
+	organizations := c.Database.Model(new(domain.Member)).Select("organization_id").Where("creator_id = ?", creatorID)
+
+	accessible := c.Database.Where("public = ?", true).Or("creator_id = ?", creatorID).Or("id IN (?)", shared).Or("organization_id IN (?)", organizations)
+
+	var result []*domain.Quiz
+	if err := c.Database.Preload("Creator").Preload("MultipleChoiceQuestions").Preload("Tags").Preload("Games").Where("template = ?", true).Where(accessible).Order("name").Find(&result).Error; err != nil {
+		logrus.WithError(err).Error("Failed to get templates")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreateOrUpdate saves the quiz as a new revision, games that already started keep using the revision
+// they were started with. The unique index on revision numbers catches the case where the quiz is saved
+// twice at the same time, after which it's saved again.
 func (c *DBQuizService) CreateOrUpdate(quiz *domain.Quiz) error {
+	for attempt := 0; ; attempt++ {
+		var revision *domain.QuizRevision
+
+		err := c.Database.Transaction(func(tx *gorm.DB) error {
+			var version uint
+			if err := tx.Model(new(domain.Quiz)).Select("version").Where("id = ?", quiz.ID).Scan(&version).Error; err != nil {
+				logrus.WithError(err).Error("Failed to get version")
+				return err
+			}
+
+			quiz.Version = version + 1
+
+			// Tags are identified by their name, so the old ones make way before the quiz saves the new ones
+			if err := tx.Where("quiz_id = ?", quiz.ID).Delete(new(domain.QuizTag)).Error; err != nil {
+				logrus.WithError(err).Error("Failed to delete tags")
+				return err
+			}
+
+			if err := tx.Omit("MultipleChoiceQuestions").Clauses(clause.OnConflict{UpdateAll: true}).Create(quiz).Error; err != nil {
+				logrus.WithError(err).Error("Failed to create quiz")
+				return err
+			}
+
+			if err := saveQuestions(tx, quiz); err != nil {
+				return err
+			}
+
+			if err := tx.Model(quiz).Association("QuestionPools").Replace(quiz.QuestionPools); err != nil {
+				return err
+			}
+
+			next, err := nextRevision(tx, quiz)
+			if err != nil {
+				return err
+			}
+
+			revision = next
+
+			return tx.Create(revision).Error
+		})
+
+		if err == nil {
+			return nil
+		}
+
+		if revision != nil && attempt < maxRevisionAttempts {
+			if taken, _ := isRevisionTaken(c.Database, revision); taken {
+				logrus.WithError(err).Warn("Revision was claimed in the meantime, retrying")
+				continue
+			}
+		}
+
+		logrus.WithError(err).Error("Failed to save quiz")
+		return err
+	}
+}
+
+func (c *DBQuizService) UpdateQuestions(quiz *domain.Quiz, version uint) error {
 	return c.Database.Transaction(func(tx *gorm.DB) error {
-		if err := c.Database.Clauses(clause.OnConflict{UpdateAll: true}).Create(quiz).Error; err != nil {
-			logrus.WithError(err).Error("Failed to create quiz")
+		// Bumping the version first makes sure that only one of two simultaneous updates succeeds
+		bump := tx.Model(new(domain.Quiz)).Where("id = ? AND version = ?", quiz.ID, version).UpdateColumn("version", gorm.Expr("version + 1"))
+		if bump.Error != nil {
+			logrus.WithError(bump.Error).Error("Failed to bump version")
+			return bump.Error
+		}
+
+		if bump.RowsAffected == 0 {
+			return ErrVersionConflict
+		}
+
+		if err := saveQuestions(tx, quiz); err != nil {
 			return err
 		}
 
-		if err := c.Database.Model(quiz).Association("MultipleChoiceQuestions").Replace(quiz.MultipleChoiceQuestions); err != nil {
+		quiz.Version = version + 1
+
+		if _, err := createRevision(tx, quiz); err != nil {
 			return err
 		}
 
@@ -56,6 +176,60 @@ func (c *DBQuizService) CreateOrUpdate(quiz *domain.Quiz) error {
 	})
 }
 
+// saveQuestions saves the questions of the quiz in place so they keep their IDs, questions and options
+// that are no longer part of the quiz are removed
+func saveQuestions(tx *gorm.DB, quiz *domain.Quiz) error {
+	questionIDs := make([]uuid.UUID, len(quiz.MultipleChoiceQuestions))
+	for index, question := range quiz.MultipleChoiceQuestions {
+		if question.ID == uuid.Nil {
+			question.ID = uuid.New()
+		}
+
+		question.QuizID = quiz.ID
+		questionIDs[index] = question.ID
+	}
+
+	removed := tx.Where("quiz_id = ?", quiz.ID)
+	if len(questionIDs) > 0 {
+		removed = removed.Where("id NOT IN ?", questionIDs)
+	}
+
+	if err := removed.Delete(new(domain.MultipleChoiceQuestion)).Error; err != nil {
+		logrus.WithError(err).Error("Failed to delete questions")
+		return err
+	}
+
+	for _, question := range quiz.MultipleChoiceQuestions {
+		optionIDs := make([]uuid.UUID, len(question.Options))
+		for index, option := range question.Options {
+			optionIDs[index] = option.ID
+			option.Position = uint(index)
+		}
+
+		removed := tx.Where("multiple_choice_question_id = ?", question.ID)
+		if len(optionIDs) > 0 {
+			removed = removed.Where("id NOT IN ?", optionIDs)
+		}
+
+		if err := removed.Delete(new(domain.QuestionOption)).Error; err != nil {
+			logrus.WithError(err).Error("Failed to delete options")
+			return err
+		}
+
+		if err := tx.Session(&gorm.Session{FullSaveAssociations: true}).Clauses(clause.OnConflict{UpdateAll: true}).Create(question).Error; err != nil {
+			logrus.WithError(err).Error("Failed to save question")
+			return err
+		}
+	}
+
+	return nil
+}
+
+// orderByPosition loads options in the order they were saved in
+func orderByPosition(db *gorm.DB) *gorm.DB {
+	return db.Order("position")
+}
+
 func (c *DBQuizService) Delete(id uuid.UUID) error {
 	if err := c.Database.Delete(new(domain.Quiz), id).Error; err != nil {
 		logrus.WithError(err).Error("Failed to delete quiz")
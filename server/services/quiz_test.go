@@ -90,6 +90,90 @@ func TestDBQuizService_GetByCreator_ReturnsUser(t *testing.T) {
 	}
 }
 
+func TestDBQuizService_GetByCreator_ReturnsSharedQuizzes(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBQuizService{Database: database}
+
+	creators := []*domain.Creator{
+		{BaseObject: domain.BaseObject{ID: uuid.MustParse("3c97f06b-1078-46ef-a2c3-71fc4d9a3d3d")}, Nickname: "a", AuthID: "a"},
+		{BaseObject: domain.BaseObject{ID: uuid.MustParse("5dff22a7-afc7-4e4b-a63d-9903dedd66bf")}, Nickname: "b", AuthID: "b"},
+	}
+
+	quizzes := []*domain.Quiz{
+		{BaseObject: domain.BaseObject{ID: uuid.MustParse("6aacfb41-e478-46ec-857e-11221f2a97fc")}, Creator: creators[0]},
+		{
+			BaseObject:    domain.BaseObject{ID: uuid.MustParse("6b362ab3-f164-4073-82a4-f7c3d2010947")},
+			Creator:       creators[1],
+			Collaborators: []*domain.Collaborator{{CreatorID: creators[0].ID, Role: domain.RoleHost}},
+		},
+		{BaseObject: domain.BaseObject{ID: uuid.MustParse("e7c3d165-0419-4e4d-beb7-45ea4e0e908e")}, Creator: creators[1]},
+	}
+
+	database.CreateInBatches(quizzes, 10)
+
+	// Act
+	result, err := service.GetByCreator(creators[0].ID)
+
+	// Assert
+	assert.NoError(t, err)
+
+	if assert.Len(t, result, 2) {
+		assert.Equal(t, quizzes[0].ID, result[0].ID)
+		assert.Equal(t, quizzes[1].ID, result[1].ID)
+		assert.Len(t, result[1].Collaborators, 1)
+	}
+}
+
+func TestDBQuizService_GetByOrganizations_ReturnsOrganizationQuizzes(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t)
+	autoMigrate(t, database)
+
+	service := &DBQuizService{Database: database}
+
+	creators := []*domain.Creator{
+		{BaseObject: domain.BaseObject{ID: uuid.MustParse("3c97f06b-1078-46ef-a2c3-71fc4d9a3d3d")}, Nickname: "a", AuthID: "a"},
+		{BaseObject: domain.BaseObject{ID: uuid.MustParse("5dff22a7-afc7-4e4b-a63d-9903dedd66bf")}, Nickname: "b", AuthID: "b"},
+	}
+	database.CreateInBatches(creators, 10)
+
+	organizations := []*domain.Organization{
+		{
+			BaseObject: domain.BaseObject{ID: uuid.MustParse("a1f5b8c2-3f0e-4f0a-9a62-1c4cbe7b4f3d")},
+			Name:       "Mine",
+			Members:    []*domain.Member{{CreatorID: creators[0].ID, Role: domain.OrganizationMember}},
+		},
+		{
+			BaseObject: domain.BaseObject{ID: uuid.MustParse("0d3c2e4b-8a5d-4e1f-b0c7-8f2d7e9a6b1c")},
+			Name:       "Other",
+			Members:    []*domain.Member{{CreatorID: creators[1].ID, Role: domain.OrganizationAdmin}},
+		},
+	}
+	database.CreateInBatches(organizations, 10)
+
+	quizzes := []*domain.Quiz{
+		{BaseObject: domain.BaseObject{ID: uuid.MustParse("6aacfb41-e478-46ec-857e-11221f2a97fc")}, CreatorID: creators[1].ID, OrganizationID: &organizations[0].ID},
+		{BaseObject: domain.BaseObject{ID: uuid.MustParse("6b362ab3-f164-4073-82a4-f7c3d2010947")}, CreatorID: creators[1].ID, OrganizationID: &organizations[1].ID},
+		{BaseObject: domain.BaseObject{ID: uuid.MustParse("e7c3d165-0419-4e4d-beb7-45ea4e0e908e")}, CreatorID: creators[0].ID},
+	}
+	database.CreateInBatches(quizzes, 10)
+
+	// Act
+	result, err := service.GetByOrganizations(creators[0].ID)
+
+	// Assert
+	assert.NoError(t, err)
+
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, quizzes[0].ID, result[0].ID)
+	}
+}
+
 func TestDBQuizService_GetByCreator_ReturnsDatabaseError(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -173,7 +257,7 @@ func TestDBQuizService_CreateOrUpdate_UpdatesExisting(t *testing.T) {
 	update := &domain.Quiz{
 		BaseObject: domain.BaseObject{ID: existing.ID},
 		Name:       "new",
-		Creator:    &domain.Creator{Nickname: "abc", AuthID: "def"},
+		CreatorID:  existing.CreatorID,
 		MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{
 			{
 				BaseQuestion: domain.BaseQuestion{
@@ -202,6 +286,129 @@ func TestDBQuizService_CreateOrUpdate_UpdatesExisting(t *testing.T) {
 	assert.Equal(t, "abc", result.MultipleChoiceQuestions[0].Options[0].TextOption)
 }
 
+func TestDBQuizService_CreateOrUpdate_ReplacesQuestionPools(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	autoMigrate(t, database)
+
+	service := &DBQuizService{Database: database}
+
+	existing := &domain.Quiz{
+		Name:          "old",
+		Creator:       &domain.Creator{Nickname: "abc", AuthID: "def"},
+		QuestionPools: []*domain.QuestionPool{{Category: "Math", DrawCount: 2}},
+	}
+	if err := database.Create(existing).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	update := &domain.Quiz{
+		BaseObject:    domain.BaseObject{ID: existing.ID},
+		Name:          "new",
+		CreatorID:     existing.CreatorID,
+		QuestionPools: []*domain.QuestionPool{{Category: "Geography", DrawCount: 3}},
+	}
+
+	// Act
+	err := service.CreateOrUpdate(update)
+
+	// Assert
+	assert.NoError(t, err)
+
+	result, err := service.GetByID(existing.ID)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if assert.Len(t, result.QuestionPools, 1) {
+		assert.Equal(t, "Geography", result.QuestionPools[0].Category)
+		assert.Equal(t, uint(3), result.QuestionPools[0].DrawCount)
+	}
+}
+
+func TestDBQuizService_CreateOrUpdate_ReplacesTags(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	autoMigrate(t, database)
+
+	service := &DBQuizService{Database: database}
+
+	existing := &domain.Quiz{
+		Name:    "old",
+		Creator: &domain.Creator{Nickname: "abc", AuthID: "def"},
+		Tags:    []*domain.QuizTag{{Name: "math"}, {Name: "kids"}},
+	}
+	if err := service.CreateOrUpdate(existing); err != nil {
+		t.Fatal(err)
+	}
+
+	update := &domain.Quiz{
+		BaseObject: domain.BaseObject{ID: existing.ID},
+		Name:       "new",
+		CreatorID:  existing.CreatorID,
+		Tags:       []*domain.QuizTag{{Name: "math"}, {Name: "history"}},
+	}
+
+	// Act
+	err := service.CreateOrUpdate(update)
+
+	// Assert
+	assert.NoError(t, err)
+
+	result, err := service.GetByID(existing.ID)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	assert.ElementsMatch(t, []string{"math", "history"}, result.TagNames())
+}
+
+func TestDBQuizService_CreateOrUpdate_RetriesOnTakenRevision(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	autoMigrate(t, database)
+
+	service := &DBQuizService{Database: database}
+
+	quiz := &domain.Quiz{Name: "old", Creator: &domain.Creator{}}
+	if err := service.CreateOrUpdate(quiz); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another save takes the revision number right after it was picked
+	claimed := false
+	_ = database.Callback().Create().Before("gorm:create").Register("claim_revision", func(tx *gorm.DB) {
+		revision, ok := tx.Statement.Dest.(*domain.QuizRevision)
+		if claimed || !ok {
+			return
+		}
+
+		claimed = true
+		revision.Number--
+	})
+
+	quiz.Name = "new"
+
+	// Act
+	err := service.CreateOrUpdate(quiz)
+
+	// Assert
+	assert.NoError(t, err)
+
+	revisions, err := (&DBQuizRevisionService{Database: database}).GetByQuiz(quiz.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, revisions, 2) {
+		assert.Equal(t, uint(2), revisions[0].Number)
+		assert.Equal(t, "new", revisions[0].Snapshot.Name)
+	}
+}
+
 func TestDBQuizService_CreateOrUpdate_ReturnsDatabaseError(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -219,6 +426,197 @@ func TestDBQuizService_CreateOrUpdate_ReturnsDatabaseError(t *testing.T) {
 	assert.ErrorContains(t, err, "no such table")
 }
 
+func TestDBQuizService_CreateOrUpdate_KeepsQuestionIDs(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	autoMigrate(t, database)
+
+	service := &DBQuizService{Database: database}
+
+	quiz := &domain.Quiz{
+		Name:    "quiz",
+		Creator: &domain.Creator{Nickname: "abc", AuthID: "def"},
+		MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{
+			{BaseQuestion: domain.BaseQuestion{Title: "first", Order: 0}, Options: []*domain.QuestionOption{{TextOption: "a"}, {TextOption: "b"}, {TextOption: "c"}}},
+			{BaseQuestion: domain.BaseQuestion{Title: "second", Order: 1}, Options: []*domain.QuestionOption{{TextOption: "d"}, {TextOption: "e"}}},
+		},
+	}
+	if err := service.CreateOrUpdate(quiz); err != nil {
+		t.Fatal(err)
+	}
+
+	quiz, _ = service.GetByID(quiz.ID)
+	first, _ := quiz.Question(quiz.MultipleChoiceQuestions[0].ID)
+	removedQuestion := quiz.MultipleChoiceQuestions[1].ID
+	removedOption := first.Options[2].ID
+	keptOption := first.Options[0].ID
+
+	first.Title = "changed"
+	first.Options = first.Options[:2]
+
+	update := &domain.Quiz{
+		BaseObject: domain.BaseObject{ID: quiz.ID},
+		Name:       "quiz",
+		CreatorID:  quiz.CreatorID,
+		MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{
+			first,
+			{BaseQuestion: domain.BaseQuestion{Title: "third", Order: 1}, Options: []*domain.QuestionOption{{TextOption: "f"}, {TextOption: "g"}}},
+		},
+	}
+
+	// Act
+	err := service.CreateOrUpdate(update)
+
+	// Assert
+	assert.NoError(t, err)
+
+	result, err := service.GetByID(quiz.ID)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if assert.Len(t, result.MultipleChoiceQuestions, 2) {
+		question, ok := result.Question(first.ID)
+		if assert.True(t, ok) {
+			assert.Equal(t, "changed", question.Title)
+
+			_, removed := question.Option(removedOption)
+			_, kept := question.Option(keptOption)
+			assert.False(t, removed)
+			assert.True(t, kept)
+		}
+
+		_, removed := result.Question(removedQuestion)
+		assert.False(t, removed)
+	}
+
+	var options int64
+	database.Model(new(domain.QuestionOption)).Where("id = ?", removedOption).Count(&options)
+	assert.Zero(t, options)
+}
+
+func TestDBQuizService_UpdateQuestions_KeepsIDs(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	autoMigrate(t, database)
+
+	service := &DBQuizService{Database: database}
+
+	quiz := &domain.Quiz{
+		Name:    "quiz",
+		Creator: &domain.Creator{Nickname: "abc", AuthID: "def"},
+		MultipleChoiceQuestions: []*domain.MultipleChoiceQuestion{
+			{BaseQuestion: domain.BaseQuestion{Title: "first", Order: 0}, Options: []*domain.QuestionOption{{TextOption: "a"}, {TextOption: "b"}, {TextOption: "c"}}},
+			{BaseQuestion: domain.BaseQuestion{Title: "second", Order: 1}, Options: []*domain.QuestionOption{{TextOption: "d"}, {TextOption: "e"}}},
+		},
+	}
+	if err := service.CreateOrUpdate(quiz); err != nil {
+		t.Fatal(err)
+	}
+
+	quiz, _ = service.GetByID(quiz.ID)
+	first, _ := quiz.Question(quiz.MultipleChoiceQuestions[0].ID)
+	removedOption := first.Options[2].ID
+	keptOption := first.Options[0].ID
+
+	first.Title = "changed"
+	_ = first.RemoveOption(removedOption)
+	_ = quiz.RemoveQuestion(quiz.MultipleChoiceQuestions[1].ID)
+
+	// Act
+	err := service.UpdateQuestions(quiz, 1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), quiz.Version)
+
+	result, err := service.GetByID(quiz.ID)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	assert.Equal(t, uint(2), result.Version)
+	if assert.Len(t, result.MultipleChoiceQuestions, 1) {
+		question := result.MultipleChoiceQuestions[0]
+		assert.Equal(t, first.ID, question.ID)
+		assert.Equal(t, "changed", question.Title)
+
+		_, removed := question.Option(removedOption)
+		_, kept := question.Option(keptOption)
+		assert.False(t, removed)
+		assert.True(t, kept)
+		assert.Len(t, question.Options, 2)
+	}
+
+	var revisions int64
+	database.Model(new(domain.QuizRevision)).Where("quiz_id = ?", quiz.ID).Count(&revisions)
+	assert.Equal(t, int64(2), revisions)
+}
+
+func TestDBQuizService_UpdateQuestions_ReturnsConflictOnOldVersion(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	autoMigrate(t, database)
+
+	service := &DBQuizService{Database: database}
+
+	quiz := &domain.Quiz{Name: "quiz", Creator: &domain.Creator{Nickname: "abc", AuthID: "def"}}
+	if err := service.CreateOrUpdate(quiz); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := service.UpdateQuestions(quiz, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	err := service.UpdateQuestions(quiz, 1)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrVersionConflict)
+}
+
+func TestDBQuizService_GetTemplates_ReturnsAccessibleTemplates(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	database := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	autoMigrate(t, database)
+
+	service := &DBQuizService{Database: database}
+
+	creators := []*domain.Creator{
+		{BaseObject: domain.BaseObject{ID: uuid.MustParse("3c97f06b-1078-46ef-a2c3-71fc4d9a3d3d")}, Nickname: "a", AuthID: "a"},
+		{BaseObject: domain.BaseObject{ID: uuid.MustParse("5dff22a7-afc7-4e4b-a63d-9903dedd66bf")}, Nickname: "b", AuthID: "b"},
+	}
+	database.Create(creators)
+
+	quizzes := []*domain.Quiz{
+		{Name: "public template", CreatorID: creators[1].ID, Template: true, Public: true},
+		{Name: "private template", CreatorID: creators[1].ID, Template: true},
+		{Name: "my template", CreatorID: creators[0].ID, Template: true},
+		{Name: "public quiz", CreatorID: creators[1].ID, Public: true},
+		{Name: "shared template", CreatorID: creators[1].ID, Template: true, Collaborators: []*domain.Collaborator{{CreatorID: creators[0].ID, Role: domain.RoleViewer}}},
+	}
+	database.Create(quizzes)
+
+	// Act
+	result, err := service.GetTemplates(creators[0].ID)
+
+	// Assert
+	assert.NoError(t, err)
+
+	var names []string
+	for _, quiz := range result {
+		names = append(names, quiz.Name)
+	}
+
+	assert.Equal(t, []string{"my template", "public template", "shared template"}, names)
+	assert.Equal(t, "a", result[0].Creator.Nickname)
+}
+
 func TestDBQuizService_Delete_DeletesQuiz(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"github.com/survivorbat/go-tsyncmap"
+	"math"
+	"sync"
+	"time"
+)
+
+// Compile-time interface checks
+var _ RateLimiter = new(MemoryRateLimiter)
+var _ Lockout = new(MemoryLockout)
+
+// RateLimiter throttles requests per key, implementations backed by a shared store
+// can be used when running multiple instances
+type RateLimiter interface {
+	// Take consumes a token for the key, if none are left it returns false and how long to wait
+	Take(key string) (bool, time.Duration)
+}
+
+// Lockout blocks keys after too many failed attempts, failures are only forgotten over time so
+// successful attempts in between don't help
+type Lockout interface {
+	// LockedFor returns how long the key is still locked out, zero if it isn't
+	LockedFor(key string) time.Duration
+	Fail(key string)
+}
+
+// sweepInterval is how often entries that no longer matter are removed from memory
+const sweepInterval = time.Minute
+
+// sweeper keeps track of when memory was last cleaned up
+type sweeper struct {
+	sync.Mutex
+
+	last time.Time
+}
+
+// due returns true at most once every sweepInterval
+func (s *sweeper) due(now time.Time) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	if now.Sub(s.last) < sweepInterval {
+		return false
+	}
+
+	s.last = now
+	return true
+}
+
+// MemoryRateLimiter is a token bucket rate limiter that lives in memory
+type MemoryRateLimiter struct {
+	// Rate is the amount of tokens added to a bucket per second
+	Rate float64
+
+	// Burst is the size of a bucket
+	Burst int
+
+	buckets tsyncmap.Map[string, *bucket]
+	sweeper sweeper
+
+	// now may be overwritten in tests
+	now func() time.Time
+}
+
+type bucket struct {
+	sync.Mutex
+
+	tokens  float64
+	updated time.Time
+}
+
+func (m *MemoryRateLimiter) Take(key string) (bool, time.Duration) {
+	now := m.getNow()
+
+	if m.sweeper.due(now) {
+		m.sweep(now)
+	}
+
+	b, _ := m.buckets.LoadOrStore(key, &bucket{tokens: float64(m.Burst), updated: now})
+
+	b.Lock()
+	defer b.Unlock()
+
+	b.tokens = math.Min(float64(m.Burst), b.tokens+now.Sub(b.updated).Seconds()*m.Rate)
+	b.updated = now
+
+	if b.tokens < 1 {
+		wait := (1 - b.tokens) / m.Rate
+		return false, time.Duration(wait * float64(time.Second))
+	}
+
+	b.tokens--
+	return true, 0
+}
+
+// sweep removes buckets that have filled up again, they're no different from new ones
+func (m *MemoryRateLimiter) sweep(now time.Time) {
+	m.buckets.Range(func(key string, b *bucket) bool {
+		b.Lock()
+		defer b.Unlock()
+
+		if b.tokens+now.Sub(b.updated).Seconds()*m.Rate >= float64(m.Burst) {
+			m.buckets.Delete(key)
+		}
+
+		return true
+	})
+}
+
+func (m *MemoryRateLimiter) getNow() time.Time {
+	if m.now == nil {
+		return time.Now()
+	}
+
+	return m.now()
+}
+
+// MemoryLockout keeps track of failed attempts in memory
+type MemoryLockout struct {
+	// MaxAttempts is the amount of failures within Duration that are allowed before locking the key
+	MaxAttempts int
+
+	// Duration is how long a key remains locked, and how long failures are remembered
+	Duration time.Duration
+
+	attempts tsyncmap.Map[string, *attempts]
+	sweeper  sweeper
+
+	// now may be overwritten in tests
+	now func() time.Time
+}
+
+type attempts struct {
+	sync.Mutex
+
+	failures    int
+	since       time.Time
+	lockedUntil time.Time
+}
+
+func (m *MemoryLockout) LockedFor(key string) time.Duration {
+	a, ok := m.attempts.Load(key)
+	if !ok {
+		return 0
+	}
+
+	a.Lock()
+	defer a.Unlock()
+
+	remaining := a.lockedUntil.Sub(m.getNow())
+	if remaining < 0 {
+		return 0
+	}
+
+	return remaining
+}
+
+func (m *MemoryLockout) Fail(key string) {
+	now := m.getNow()
+
+	if m.sweeper.due(now) {
+		m.sweep(now)
+	}
+
+	a, _ := m.attempts.LoadOrStore(key, &attempts{since: now})
+
+	a.Lock()
+	defer a.Unlock()
+
+	// Failures decay by starting over once they're older than the duration
+	if now.Sub(a.since) >= m.Duration {
+		a.failures = 0
+		a.since = now
+	}
+
+	a.failures++
+	if a.failures >= m.MaxAttempts {
+		a.failures = 0
+		a.since = now
+		a.lockedUntil = now.Add(m.Duration)
+	}
+}
+
+// sweep removes keys that are not locked and whose failures have decayed
+func (m *MemoryLockout) sweep(now time.Time) {
+	m.attempts.Range(func(key string, a *attempts) bool {
+		a.Lock()
+		defer a.Unlock()
+
+		if !now.Before(a.lockedUntil) && now.Sub(a.since) >= m.Duration {
+			m.attempts.Delete(key)
+		}
+
+		return true
+	})
+}
+
+func (m *MemoryLockout) getNow() time.Time {
+	if m.now == nil {
+		return time.Now()
+	}
+
+	return m.now()
+}
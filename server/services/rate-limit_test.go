@@ -0,0 +1,132 @@
+package services
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMemoryRateLimiter_Take_AllowsBurst(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	now := time.Now()
+	limiter := &MemoryRateLimiter{Rate: 1, Burst: 3, now: func() time.Time { return now }}
+
+	// Act
+	var results []bool
+	for i := 0; i < 4; i++ {
+		ok, _ := limiter.Take("a")
+		results = append(results, ok)
+	}
+
+	otherResult, _ := limiter.Take("b")
+
+	// Assert
+	assert.Equal(t, []bool{true, true, true, false}, results)
+	assert.True(t, otherResult)
+}
+
+func TestMemoryRateLimiter_Take_ReturnsWaitAndRefills(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	now := time.Now()
+	limiter := &MemoryRateLimiter{Rate: 2, Burst: 1, now: func() time.Time { return now }}
+	_, _ = limiter.Take("a")
+
+	// Act
+	blocked, wait := limiter.Take("a")
+
+	now = now.Add(500 * time.Millisecond)
+	refilled, _ := limiter.Take("a")
+
+	// Assert
+	assert.False(t, blocked)
+	assert.Equal(t, 500*time.Millisecond, wait)
+	assert.True(t, refilled)
+}
+
+func TestMemoryLockout_Fail_LocksAfterMaxAttempts(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	now := time.Now()
+	lockout := &MemoryLockout{MaxAttempts: 2, Duration: time.Minute, now: func() time.Time { return now }}
+
+	// Act
+	lockout.Fail("a")
+	afterOne := lockout.LockedFor("a")
+
+	lockout.Fail("a")
+	afterTwo := lockout.LockedFor("a")
+
+	now = now.Add(2 * time.Minute)
+	afterExpiry := lockout.LockedFor("a")
+
+	// Assert
+	assert.Zero(t, afterOne)
+	assert.Equal(t, time.Minute, afterTwo)
+	assert.Zero(t, afterExpiry)
+}
+
+func TestMemoryLockout_Fail_ForgetsFailuresAfterDuration(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	now := time.Now()
+	lockout := &MemoryLockout{MaxAttempts: 2, Duration: time.Minute, now: func() time.Time { return now }}
+	lockout.Fail("a")
+
+	// Act
+	now = now.Add(time.Minute)
+	lockout.Fail("a")
+
+	// Assert
+	assert.Zero(t, lockout.LockedFor("a"))
+}
+
+func TestMemoryLockout_Fail_RemovesStaleKeys(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	now := time.Now()
+	lockout := &MemoryLockout{MaxAttempts: 2, Duration: time.Minute, now: func() time.Time { return now }}
+	lockout.Fail("a")
+
+	now = now.Add(30 * time.Second)
+	lockout.Fail("b")
+	lockout.Fail("b")
+
+	// Act
+	now = now.Add(30 * time.Second)
+	lockout.Fail("c")
+
+	// Assert
+	var keys []string
+	lockout.attempts.Range(func(key string, _ *attempts) bool {
+		keys = append(keys, key)
+		return true
+	})
+
+	assert.ElementsMatch(t, []string{"b", "c"}, keys)
+}
+
+func TestMemoryRateLimiter_Take_RemovesFullBuckets(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	now := time.Now()
+	limiter := &MemoryRateLimiter{Rate: 0.01, Burst: 2, now: func() time.Time { return now }}
+	_, _ = limiter.Take("a")
+
+	now = now.Add(sweepInterval)
+	_, _ = limiter.Take("b")
+
+	// Act
+	now = now.Add(sweepInterval)
+	_, _ = limiter.Take("c")
+
+	// Assert
+	var keys []string
+	limiter.buckets.Range(func(key string, _ *bucket) bool {
+		keys = append(keys, key)
+		return true
+	})
+
+	assert.ElementsMatch(t, []string{"b", "c"}, keys)
+}
@@ -0,0 +1,312 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Compile-time interface checks
+var _ SpreadsheetReader = new(FileSpreadsheetReader)
+
+type SpreadsheetFormat string
+
+const (
+	SpreadsheetCSV  SpreadsheetFormat = "csv"
+	SpreadsheetXLSX SpreadsheetFormat = "xlsx"
+)
+
+// ErrUnknownSpreadsheetFormat is returned for formats other than CSV and XLSX
+var ErrUnknownSpreadsheetFormat = errors.New("unknown spreadsheet format")
+
+// Limits well past what a quiz needs, so a small file can't make the reader build a huge table
+const (
+	maxSpreadsheetRows     = 1000
+	maxSpreadsheetColumns  = 26
+	maxSpreadsheetFileSize = 10 << 20
+)
+
+type SpreadsheetReader interface {
+	// Read returns the cells of the first sheet, rows are as long as the longest row and
+	// empty rows are kept so row numbers match the ones in the spreadsheet
+	Read(content []byte, format SpreadsheetFormat) ([][]string, error)
+}
+
+// FileSpreadsheetReader reads CSV files and the first sheet of XLSX workbooks
+type FileSpreadsheetReader struct{}
+
+func (f *FileSpreadsheetReader) Read(content []byte, format SpreadsheetFormat) ([][]string, error) {
+	var rows [][]string
+	var err error
+
+	switch format {
+	case SpreadsheetCSV:
+		rows, err = readCSV(content)
+	case SpreadsheetXLSX:
+		rows, err = readXLSX(content)
+	default:
+		return nil, ErrUnknownSpreadsheetFormat
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return padRows(rows), nil
+}
+
+func readCSV(content []byte) ([][]string, error) {
+	// Spreadsheet programs like to start their CSV files with a byte order mark
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+
+	// Semicolons are common in locales that use a comma as decimal separator
+	if firstLine, _, _ := bytes.Cut(content, []byte("\n")); bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	// Records are read one by one, so a large file is refused before it's all in memory
+	var rows [][]string
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(rows) == maxSpreadsheetRows {
+			return nil, fmt.Errorf("the file has more than %d rows", maxSpreadsheetRows)
+		}
+
+		if len(record) > maxSpreadsheetColumns {
+			return nil, fmt.Errorf("row %d is wider than %d columns", len(rows)+1, maxSpreadsheetColumns)
+		}
+
+		rows = append(rows, record)
+	}
+}
+
+// padRows makes every row as long as the longest one
+func padRows(rows [][]string) [][]string {
+	var width int
+	for _, row := range rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+
+	for index, row := range rows {
+		if len(row) < width {
+			rows[index] = append(row, make([]string, width-len(row))...)
+		}
+	}
+
+	return rows
+}
+
+// The parts of the XLSX format that are needed to read the values of a sheet
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+// xlsxText is either plain text or rich text made up of runs
+type xlsxText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (x xlsxText) String() string {
+	var builder strings.Builder
+	builder.WriteString(x.Text)
+
+	for _, run := range x.Runs {
+		builder.WriteString(run.Text)
+	}
+
+	return builder.String()
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		Number int `xml:"r,attr"`
+		Cells  []struct {
+			Reference string   `xml:"r,attr"`
+			Type      string   `xml:"t,attr"`
+			Value     string   `xml:"v"`
+			Inline    xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func readXLSX(content []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, err
+	}
+
+	sheetPath, err := firstSheetPath(archive)
+	if err != nil {
+		return nil, err
+	}
+
+	var sharedStrings xlsxSharedStrings
+	if err := readXML(archive, "xl/sharedStrings.xml", &sharedStrings); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	var sheet xlsxSheet
+	if err := readXML(archive, sheetPath, &sheet); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, row := range sheet.Rows {
+		number := row.Number
+		if number == 0 {
+			number = len(rows) + 1
+		}
+
+		if number < 1 || number > maxSpreadsheetRows {
+			return nil, fmt.Errorf("row %d is outside of the first %d rows", number, maxSpreadsheetRows)
+		}
+
+		// Rows without any values are left out of the sheet
+		for len(rows) < number {
+			rows = append(rows, nil)
+		}
+
+		for index, cell := range row.Cells {
+			column := index
+			if cell.Reference != "" {
+				column = columnIndex(cell.Reference)
+			}
+
+			if column < 0 || column >= maxSpreadsheetColumns {
+				return nil, fmt.Errorf("cell %q is outside of the first %d columns", cell.Reference, maxSpreadsheetColumns)
+			}
+
+			value := cell.Value
+			switch cell.Type {
+			case "s":
+				sharedIndex, err := strconv.Atoi(cell.Value)
+				if err != nil || sharedIndex < 0 || sharedIndex >= len(sharedStrings.Items) {
+					return nil, fmt.Errorf("cell %s refers to unknown shared string %q", cell.Reference, cell.Value)
+				}
+
+				value = sharedStrings.Items[sharedIndex].String()
+			case "inlineStr":
+				value = cell.Inline.String()
+			}
+
+			cells := rows[number-1]
+			for len(cells) <= column {
+				cells = append(cells, "")
+			}
+
+			cells[column] = value
+			rows[number-1] = cells
+		}
+	}
+
+	return rows, nil
+}
+
+// firstSheetPath looks up the file of the first sheet through the workbook's relationships
+func firstSheetPath(archive *zip.Reader) (string, error) {
+	var workbook xlsxWorkbook
+	if err := readXML(archive, "xl/workbook.xml", &workbook); err != nil {
+		return "", err
+	}
+
+	if len(workbook.Sheets) == 0 {
+		return "", errors.New("workbook has no sheets")
+	}
+
+	var relationships xlsxRelationships
+	if err := readXML(archive, "xl/_rels/workbook.xml.rels", &relationships); err != nil {
+		return "", err
+	}
+
+	for _, relationship := range relationships.Relationships {
+		if relationship.ID != workbook.Sheets[0].ID {
+			continue
+		}
+
+		// Targets are relative to the workbook, but some programs write absolute ones
+		if strings.HasPrefix(relationship.Target, "/") {
+			return strings.TrimPrefix(relationship.Target, "/"), nil
+		}
+
+		return path.Join("xl", relationship.Target), nil
+	}
+
+	return "", errors.New("first sheet not found")
+}
+
+func readXML(archive *zip.Reader, name string, target any) error {
+	file, err := archive.Open(name)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxSpreadsheetFileSize+1))
+	if err != nil {
+		return err
+	}
+
+	if len(content) > maxSpreadsheetFileSize {
+		return fmt.Errorf("%s is larger than %d bytes", name, maxSpreadsheetFileSize)
+	}
+
+	return xml.Unmarshal(content, target)
+}
+
+// columnIndex turns the letters of a cell reference like AB12 into a zero-based column index, it's -1 if
+// the reference has no letters or more than the three spreadsheet programs use
+func columnIndex(reference string) int {
+	var result int
+	for index, character := range reference {
+		if character < 'A' || character > 'Z' {
+			break
+		}
+
+		if index == 3 {
+			return -1
+		}
+
+		result = result*26 + int(character-'A'+1)
+	}
+
+	return result - 1
+}
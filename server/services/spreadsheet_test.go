@@ -0,0 +1,248 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+// newWorkbook zips the files into an XLSX workbook
+func newWorkbook(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+
+	for name, content := range files {
+		file, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		_, _ = file.Write([]byte(content))
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	return buffer.Bytes()
+}
+
+const testWorkbook = `<?xml version="1.0" encoding="UTF-8"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+	<sheets><sheet name="Questions" sheetId="1" r:id="rId3"/><sheet name="Other" sheetId="2" r:id="rId4"/></sheets>
+</workbook>`
+
+const testRelationships = `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+	<Relationship Id="rId4" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+	<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet2.xml"/>
+</Relationships>`
+
+const testSharedStrings = `<?xml version="1.0" encoding="UTF-8"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+	<si><t>title</t></si>
+	<si><t>duration</t></si>
+	<si><r><t>Capital of </t></r><r><t>France</t></r></si>
+</sst>`
+
+const testSheet = `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+	<sheetData>
+		<row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="s"><v>1</v></c></row>
+		<row r="3"><c r="A3" t="s"><v>2</v></c><c r="B3" t="inlineStr"><is><t>Paris</t></is></c><c r="C3"><v>15</v></c></row>
+	</sheetData>
+</worksheet>`
+
+// sheetWorkbook returns a workbook with the rows as its first sheet
+func sheetWorkbook(t *testing.T, rows string) []byte {
+	t.Helper()
+
+	return newWorkbook(t, map[string]string{
+		"xl/workbook.xml":            testWorkbook,
+		"xl/_rels/workbook.xml.rels": testRelationships,
+		"xl/worksheets/sheet2.xml":   `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` + rows + `</sheetData></worksheet>`,
+	})
+}
+
+func TestFileSpreadsheetReader_Read_ReadsCSV(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		content  string
+		expected [][]string
+	}{
+		"comma": {
+			content:  "title,duration\n\"Capital of France, the country\",15\n",
+			expected: [][]string{{"title", "duration"}, {"Capital of France, the country", "15"}},
+		},
+		"semicolon": {
+			content:  "title;duration\nCapital of France;15\n",
+			expected: [][]string{{"title", "duration"}, {"Capital of France", "15"}},
+		},
+		"byte order mark": {
+			content:  "\xef\xbb\xbftitle,duration\n",
+			expected: [][]string{{"title", "duration"}},
+		},
+		"short rows": {
+			content:  "title,duration,category\nCapital of France\n",
+			expected: [][]string{{"title", "duration", "category"}, {"Capital of France", "", ""}},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			reader := &FileSpreadsheetReader{}
+
+			// Act
+			result, err := reader.Read([]byte(testData.content), SpreadsheetCSV)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestFileSpreadsheetReader_Read_ReadsFirstSheetOfXLSX(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	reader := &FileSpreadsheetReader{}
+
+	content := newWorkbook(t, map[string]string{
+		"xl/workbook.xml":            testWorkbook,
+		"xl/_rels/workbook.xml.rels": testRelationships,
+		"xl/sharedStrings.xml":       testSharedStrings,
+		"xl/worksheets/sheet1.xml":   `<worksheet><sheetData><row r="1"><c r="A1" t="inlineStr"><is><t>wrong sheet</t></is></c></row></sheetData></worksheet>`,
+		"xl/worksheets/sheet2.xml":   testSheet,
+	})
+
+	// Act
+	result, err := reader.Read(content, SpreadsheetXLSX)
+
+	// Assert
+	assert.NoError(t, err)
+
+	expected := [][]string{
+		{"title", "", "duration"},
+		{"", "", ""},
+		{"Capital of France", "Paris", "15"},
+	}
+	assert.Equal(t, expected, result)
+}
+
+func TestFileSpreadsheetReader_Read_ReturnsError(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		content []byte
+		format  SpreadsheetFormat
+	}{
+		"unknown format": {
+			content: []byte("title"),
+			format:  "ods",
+		},
+		"not a zip": {
+			content: []byte("title"),
+			format:  SpreadsheetXLSX,
+		},
+		"no workbook": {
+			content: newWorkbook(t, map[string]string{"xl/worksheets/sheet1.xml": testSheet}),
+			format:  SpreadsheetXLSX,
+		},
+		"unknown shared string": {
+			content: newWorkbook(t, map[string]string{
+				"xl/workbook.xml":            testWorkbook,
+				"xl/_rels/workbook.xml.rels": testRelationships,
+				"xl/worksheets/sheet2.xml":   testSheet,
+			}),
+			format: SpreadsheetXLSX,
+		},
+		"negative row": {
+			content: sheetWorkbook(t, `<row r="-1"><c r="A1"><v>1</v></c></row>`),
+			format:  SpreadsheetXLSX,
+		},
+		"row too far down": {
+			content: sheetWorkbook(t, `<row r="2000000000"><c r="A2000000000"><v>1</v></c></row>`),
+			format:  SpreadsheetXLSX,
+		},
+		"column too far right": {
+			content: sheetWorkbook(t, `<row r="1"><c r="XFD1"><v>1</v></c></row>`),
+			format:  SpreadsheetXLSX,
+		},
+		"reference without column": {
+			content: sheetWorkbook(t, `<row r="1"><c r="1"><v>1</v></c></row>`),
+			format:  SpreadsheetXLSX,
+		},
+		"too many cells without reference": {
+			content: sheetWorkbook(t, `<row r="1">`+strings.Repeat(`<c><v>1</v></c>`, 27)+`</row>`),
+			format:  SpreadsheetXLSX,
+		},
+		"sheet too large": {
+			content: newWorkbook(t, map[string]string{
+				"xl/workbook.xml":            testWorkbook,
+				"xl/_rels/workbook.xml.rels": testRelationships,
+				"xl/worksheets/sheet2.xml":   strings.Repeat(" ", maxSpreadsheetFileSize+1),
+			}),
+			format: SpreadsheetXLSX,
+		},
+		"too many csv rows": {
+			content: []byte(strings.Repeat("title\n", maxSpreadsheetRows+1)),
+			format:  SpreadsheetCSV,
+		},
+		"csv row too wide": {
+			content: []byte("title\n" + strings.Repeat(",", 1000000) + "\n"),
+			format:  SpreadsheetCSV,
+		},
+		"unbalanced quotes": {
+			content: []byte("title\n\"Capital"),
+			format:  SpreadsheetCSV,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			reader := &FileSpreadsheetReader{}
+
+			// Act
+			result, err := reader.Read(testData.content, testData.format)
+
+			// Assert
+			assert.Error(t, err)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func TestColumnIndex_ReturnsExpectedIndex(t *testing.T) {
+	t.Parallel()
+	tests := map[string]int{
+		"A1":    0,
+		"C12":   2,
+		"Z3":    25,
+		"AA1":   26,
+		"AB100": 27,
+		"XFD1":  16383,
+		"AAAA1": -1,
+		"1":     -1,
+	}
+
+	for reference, expected := range tests {
+		reference, expected := reference, expected
+		t.Run(reference, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result := columnIndex(reference)
+
+			// Assert
+			assert.Equal(t, expected, result)
+		})
+	}
+}